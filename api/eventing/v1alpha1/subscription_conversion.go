@@ -9,11 +9,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/conversion"
 
 	"github.com/kyma-project/eventing-manager/api/eventing/v1alpha2"
+	"github.com/kyma-project/eventing-manager/api/eventing/v1alpha3"
 	"github.com/kyma-project/eventing-manager/pkg/backend/eventtype"
 )
 
 const (
-	ErrorHubVersionMsg     = "hub version is not the expected v1alpha2 version"
+	ErrorHubVersionMsg     = "hub version is not the expected v1alpha3 version"
 	ErrorMultipleSourceMsg = "subscription contains more than 1 eventSource"
 )
 
@@ -24,13 +25,18 @@ func InitializeEventTypeCleaner(cleaner eventtype.Cleaner) {
 	v1alpha1TypeCleaner = cleaner
 }
 
-// ConvertTo converts this Subscription in version v1 to the Hub version v2.
+// ConvertTo converts this Subscription in version v1 to the Hub version v3.
+// The conversion is done through the intermediate version v2.
 func (s *Subscription) ConvertTo(dstRaw conversion.Hub) error {
-	dst, ok := dstRaw.(*v1alpha2.Subscription)
+	dst, ok := dstRaw.(*v1alpha3.Subscription)
 	if !ok {
 		return errors.Errorf(ErrorHubVersionMsg)
 	}
-	return V1ToV2(s, dst)
+	v2 := &v1alpha2.Subscription{}
+	if err := V1ToV2(s, v2); err != nil {
+		return err
+	}
+	return v1alpha2.V2ToV3(v2, dst)
 }
 
 // V1ToV2 copies the v1alpha1-type field values into v1alpha2-type field values.
@@ -60,13 +66,18 @@ func V1ToV2(src *Subscription, dst *v1alpha2.Subscription) error {
 	return nil
 }
 
-// ConvertFrom converts this Subscription from the Hub version (v2) to v1.
+// ConvertFrom converts this Subscription from the Hub version (v3) to v1.
+// The conversion is done through the intermediate version v2.
 func (s *Subscription) ConvertFrom(srcRaw conversion.Hub) error {
-	src, ok := srcRaw.(*v1alpha2.Subscription)
+	src, ok := srcRaw.(*v1alpha3.Subscription)
 	if !ok {
 		return errors.Errorf(ErrorHubVersionMsg)
 	}
-	return V2ToV1(s, src)
+	v2 := &v1alpha2.Subscription{}
+	if err := v1alpha2.V3ToV2(v2, src); err != nil {
+		return err
+	}
+	return V2ToV1(s, v2)
 }
 
 // V2ToV1 copies the v1alpha2-type field values into v1alpha1-type field values.
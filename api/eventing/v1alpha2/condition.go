@@ -14,6 +14,7 @@ const (
 	ConditionSubscriptionActive ConditionType = "Subscription active"
 	ConditionAPIRuleStatus      ConditionType = "APIRule status"
 	ConditionWebhookCallStatus  ConditionType = "Webhook call status"
+	ConditionDeliveryHealthy    ConditionType = "DeliveryHealthy"
 
 	ConditionPublisherProxyReady ConditionType = "Publisher Proxy Ready"
 	ConditionControllerReady     ConditionType = "Subscription Controller Ready"
@@ -40,6 +41,8 @@ const (
 	// JetStream Conditions.
 	ConditionReasonNATSSubscriptionActive    ConditionReason = "NATS Subscription active"
 	ConditionReasonNATSSubscriptionNotActive ConditionReason = "NATS Subscription not active"
+	ConditionReasonDeliveryHealthy           ConditionReason = "Events delivered to the sink"
+	ConditionReasonDeliveryFailing           ConditionReason = "Events not delivered to the sink"
 
 	// EventMesh Conditions.
 	ConditionReasonSubscriptionCreated        ConditionReason = "EventMesh Subscription created"
@@ -285,3 +288,24 @@ func GetSubscriptionActiveCondition(sub *Subscription, err error) []Condition {
 
 	return []Condition{subscriptionActiveCondition}
 }
+
+// GetDeliveryHealthyCondition returns the ConditionDeliveryHealthy condition based on the health of the deliveries.
+// It keeps the last transition time of the current condition, if the condition did not change.
+func GetDeliveryHealthyCondition(sub *Subscription, healthy bool, message string) Condition {
+	deliveryHealthyCondition := Condition{
+		Type:               ConditionDeliveryHealthy,
+		LastTransitionTime: kmetav1.Now(),
+		Status:             kcorev1.ConditionTrue,
+		Reason:             ConditionReasonDeliveryHealthy,
+		Message:            message,
+	}
+	if !healthy {
+		deliveryHealthyCondition.Status = kcorev1.ConditionFalse
+		deliveryHealthyCondition.Reason = ConditionReasonDeliveryFailing
+	}
+	if currentCondition := sub.Status.FindCondition(ConditionDeliveryHealthy); currentCondition != nil &&
+		ConditionEquals(*currentCondition, deliveryHealthyCondition) {
+		return *currentCondition
+	}
+	return deliveryHealthyCondition
+}
@@ -550,3 +550,64 @@ func Test_SetConditionSubscriptionActive(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetDeliveryHealthyCondition(t *testing.T) {
+	message := "Most of the latest deliveries to the sink failed, the last one with status code 503"
+	conditionHealthy := v1alpha2.MakeCondition(
+		v1alpha2.ConditionDeliveryHealthy,
+		v1alpha2.ConditionReasonDeliveryHealthy,
+		kcorev1.ConditionTrue, "")
+	conditionHealthy.LastTransitionTime = kmetav1.NewTime(time.Now().AddDate(0, 0, -1))
+	conditionFailing := v1alpha2.MakeCondition(
+		v1alpha2.ConditionDeliveryHealthy,
+		v1alpha2.ConditionReasonDeliveryFailing,
+		kcorev1.ConditionFalse, message)
+	conditionFailing.LastTransitionTime = kmetav1.NewTime(time.Now().AddDate(0, 0, -2))
+	sub := eventingtesting.NewSubscription("test", "test")
+
+	testCases := []struct {
+		name                   string
+		givenConditions        []v1alpha2.Condition
+		givenHealthy           bool
+		givenMessage           string
+		wantCondition          v1alpha2.Condition
+		wantLastTransitionTime *kmetav1.Time
+	}{
+		{
+			name:            "healthy deliveries should set the condition to true",
+			givenConditions: []v1alpha2.Condition{conditionFailing},
+			givenHealthy:    true,
+			wantCondition:   conditionHealthy,
+		},
+		{
+			name:          "failing deliveries should set the condition to false",
+			givenHealthy:  false,
+			givenMessage:  message,
+			wantCondition: conditionFailing,
+		},
+		{
+			name:                   "the same condition should not change the lastTransitionTime",
+			givenConditions:        []v1alpha2.Condition{conditionFailing},
+			givenHealthy:           false,
+			givenMessage:           message,
+			wantCondition:          conditionFailing,
+			wantLastTransitionTime: &conditionFailing.LastTransitionTime,
+		},
+	}
+	for _, testCase := range testCases {
+		tc := testCase
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			sub.Status.Conditions = tc.givenConditions
+
+			// when
+			condition := v1alpha2.GetDeliveryHealthyCondition(sub, tc.givenHealthy, tc.givenMessage)
+
+			// then
+			require.True(t, v1alpha2.ConditionEquals(condition, tc.wantCondition))
+			if tc.wantLastTransitionTime != nil {
+				require.Equal(t, *tc.wantLastTransitionTime, condition.LastTransitionTime)
+			}
+		})
+	}
+}
@@ -3,13 +3,20 @@ package v1alpha2
 const (
 	TypeMatchingStandard TypeMatching = "standard"
 	TypeMatchingExact    TypeMatching = "exact"
+	TypeMatchingWildcard TypeMatching = "wildcard"
+
+	// wildcards of the event types with the wildcard type matching.
+	WildcardSegment          = "*"
+	WildcardTrailingSegments = ">"
 
 	// config fields.
 	MaxInFlightMessages = "maxInFlightMessages"
+	ReplyEnabled        = "replyEnabled"
 
 	// protocol settings.
 	Protocol                        = "protocol"
 	ProtocolSettingsContentMode     = "contentMode"
+	ProtocolSettingsFormat          = "format"
 	ProtocolSettingsExemptHandshake = "exemptHandshake"
 	ProtocolSettingsQos             = "qos"
 
@@ -20,4 +27,7 @@ const (
 	WebhookAuthClientSecret = "clientSecret"
 	WebhookAuthTokenURL     = "tokenUrl"
 	WebhookAuthScope        = "scope"
+	// WebhookAuthSecretName is the name of the Secret holding the credentials of the auth types other than oauth2,
+	// in the namespace of the Subscription.
+	WebhookAuthSecretName = "secretName"
 )
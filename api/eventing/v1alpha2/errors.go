@@ -15,7 +15,9 @@ var (
 	TypesPath  = field.NewPath("spec").Child("types")
 	ConfigPath = field.NewPath("spec").Child("config")
 	SinkPath   = field.NewPath("spec").Child("sink")
-	NSPath     = field.NewPath("metadata").Child("namespace")
+	// TransformationPath is the path of the transformation of the events.
+	TransformationPath = field.NewPath("spec").Child("transformation")
+	NSPath             = field.NewPath("metadata").Child("namespace")
 
 	EmptyErrDetail          = "must not be empty"
 	InvalidURIErrDetail     = "must be valid as per RFC 3986"
@@ -24,11 +26,31 @@ var (
 	MinSegmentErrDetail     = fmt.Sprintf("must have minimum %s segments", strconv.Itoa(minEventTypeSegments))
 	InvalidPrefixErrDetail  = fmt.Sprintf("must not have %s as type prefix", InvalidPrefix)
 	StringIntErrDetail      = fmt.Sprintf("%s must be a stringified int value", MaxInFlightMessages)
+	StringBoolErrDetail     = fmt.Sprintf("%s must be a stringified bool value", ReplyEnabled)
+
+	WildcardSegmentErrDetail  = "must only have the wildcards * and > as whole segments"
+	WildcardPositionErrDetail = "must not have a wildcard as the first segment, or > before the last segment"
+	OverlappingTypesErrDetail = "must not have overlapping wildcard types"
+
+	InvalidProjectionErrDetail = "must have valid JSONPath expressions, invalid field: "
+	InvalidExtensionErrDetail  = "must have extension names of lowercase letters and digits, " +
+		"which are not CloudEvent context attributes: "
+	InvalidTransformedCEErrDetail = "must have a source which is valid for a CloudEvent"
 
 	InvalidQosErrDetail = fmt.Sprintf("must be a valid QoS value %s or %s",
 		types.QosAtLeastOnce, types.QosAtMostOnce)
-	InvalidAuthTypeErrDetail  = fmt.Sprintf("must be a valid Auth Type value %s", types.AuthTypeClientCredentials)
-	InvalidGrantTypeErrDetail = fmt.Sprintf("must be a valid Grant Type value %s", types.GrantTypeClientCredentials)
+	InvalidContentModeErrDetail = fmt.Sprintf("must be a valid Content Mode value %s, %s or %s",
+		types.ContentModeBinary, types.ContentModeStructured, types.ContentModeRaw)
+	InvalidEventFormatErrDetail = fmt.Sprintf("must be a valid Event Format value %s or %s",
+		types.EventFormatJSON, types.EventFormatProtobuf)
+	FormatContentModeErrDetail = fmt.Sprintf("%s must be used with the Content Mode %s",
+		ProtocolSettingsFormat, types.ContentModeStructured)
+	InvalidAuthTypeErrDetail = fmt.Sprintf("must be a valid Auth Type value %s, %s, %s, %s or %s",
+		types.AuthTypeClientCredentials, types.AuthTypeBearer, types.AuthTypeBasic, types.AuthTypeMTLS,
+		types.AuthTypeHMAC)
+	InvalidGrantTypeErrDetail   = fmt.Sprintf("must be a valid Grant Type value %s", types.GrantTypeClientCredentials)
+	MissingWebhookAuthErrDetail = "must have the webhook auth fields of the Auth Type: "
+	InvalidTokenURLErrDetail    = fmt.Sprintf("%s must be a valid URL", WebhookAuthTokenURL)
 
 	MissingSchemeErrDetail = "must have URL scheme 'http' or 'https'"
 	SuffixMissingErrDetail = fmt.Sprintf("must have valid sink URL suffix %s", ClusterLocalURLSuffix)
@@ -0,0 +1,81 @@
+//nolint:lll // this is annotation
+package v1alpha2
+
+import (
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EventingConfigName is the name of the EventingConfig, which the Subscriptions of its namespace are defaulted with.
+const EventingConfigName = "default"
+
+// EventingConfigSpec defines the Eventing configuration of a namespace.
+type EventingConfigSpec struct {
+	// Defines the defaults of the config of the Subscriptions of the namespace.
+	// +optional
+	SubscriptionDefaults *SubscriptionDefaults `json:"subscriptionDefaults,omitempty"`
+}
+
+// SubscriptionDefaults defines the config values of the Subscriptions, which are set if a Subscription does not set
+// them. They take precedence over the built-in defaults of Eventing.
+type SubscriptionDefaults struct {
+	// Defines how many not-ACKed messages can be in flight simultaneously. It is capped by the
+	// `subscriptionLimits.maxInFlightMessages` of the Eventing CR owning the namespace.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxInFlightMessages *int `json:"maxInFlightMessages,omitempty"`
+
+	// Defines the quality of service for the delivery. Used only with EventMesh as the backend.
+	// +optional
+	// +kubebuilder:validation:Enum=AT_LEAST_ONCE;AT_MOST_ONCE
+	Qos string `json:"qos,omitempty"`
+
+	// Defines the content mode of the delivered events. The value is either `BINARY`, `STRUCTURED`, or `RAW`.
+	// `RAW` is supported with NATS as the backend only.
+	// +optional
+	// +kubebuilder:validation:Enum=BINARY;STRUCTURED;RAW
+	ContentMode string `json:"contentMode,omitempty"`
+
+	// Defines the authentication used by the backend when calling the sink. It is set only if a Subscription sets
+	// no authentication type, so that the authentication of a Subscription is never mixed with the defaults.
+	// +optional
+	WebhookAuth *DefaultWebhookAuth `json:"webhookAuth,omitempty"`
+}
+
+// DefaultWebhookAuth defines the default authentication used by the backend when calling the sink.
+// The credentials are read from a Secret only, so that no credentials are copied into the Subscriptions.
+type DefaultWebhookAuth struct {
+	// Defines the authentication type. The types are supported with NATS as the backend only. The oauth2 type
+	// cannot be defaulted, since its client credentials are set in the config of the Subscription.
+	// +kubebuilder:validation:Enum=bearer;basic;mtls;hmac
+	Type string `json:"type"`
+
+	// Defines the name of the Secret in the namespace of the Subscription, which holds the credentials.
+	// +kubebuilder:validation:MinLength=1
+	SecretName string `json:"secretName"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:validation:XValidation:rule="self.metadata.name == 'default'", message="the name of the EventingConfig must be default"
+
+// EventingConfig is the Schema for the eventingconfigs API.
+// It configures the defaults of the Subscriptions of its namespace.
+type EventingConfig struct {
+	kmetav1.TypeMeta   `json:",inline"`
+	kmetav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec EventingConfigSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// EventingConfigList contains a list of EventingConfig.
+type EventingConfigList struct {
+	kmetav1.TypeMeta `json:",inline"`
+	kmetav1.ListMeta `json:"metadata,omitempty"`
+	Items            []EventingConfig `json:"items"`
+}
+
+func init() { //nolint:gochecknoinits
+	SchemeBuilder.Register(&EventingConfig{}, &EventingConfigList{})
+}
@@ -0,0 +1,342 @@
+package v1alpha2
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/kyma-project/eventing-manager/api/eventing/v1alpha3"
+)
+
+const (
+	ErrorHubVersionMsg = "hub version is not the expected v1alpha3 version"
+
+	// UnconvertedConfigAnnotation holds the v1alpha2 config entries which have no typed counterpart in v1alpha3.
+	// It makes the v1alpha2 -> v1alpha3 -> v1alpha2 round trip lossless.
+	UnconvertedConfigAnnotation = "eventing.kyma-project.io/v1alpha2-config"
+)
+
+// ConvertTo converts this Subscription in version v1alpha2 to the Hub version v1alpha3.
+func (s *Subscription) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1alpha3.Subscription)
+	if !ok {
+		return errors.Errorf(ErrorHubVersionMsg)
+	}
+	return V2ToV3(s, dst)
+}
+
+// ConvertFrom converts this Subscription from the Hub version v1alpha3 to v1alpha2.
+func (s *Subscription) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1alpha3.Subscription)
+	if !ok {
+		return errors.Errorf(ErrorHubVersionMsg)
+	}
+	return V3ToV2(s, src)
+}
+
+// V2ToV3 copies the v1alpha2-type field values into v1alpha3-type field values.
+func V2ToV3(src *Subscription, dst *v1alpha3.Subscription) error {
+	// ObjectMeta
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+
+	// SPEC fields
+	dst.Spec.ID = src.Spec.ID
+	dst.Spec.Sink = src.Spec.Sink
+	dst.Spec.TypeMatching = v1alpha3.TypeMatching(src.Spec.TypeMatching)
+	dst.Spec.Source = src.Spec.Source
+	dst.Spec.Types = append([]string(nil), src.Spec.Types...)
+	if src.Spec.Transformation != nil {
+		// the transformation has the same fields in both versions.
+		transformation := v1alpha3.Transformation(*src.Spec.Transformation.DeepCopy())
+		dst.Spec.Transformation = &transformation
+	}
+
+	// Config
+	unconverted := src.configToV3(dst)
+	if len(unconverted) > 0 {
+		raw, err := json.Marshal(unconverted)
+		if err != nil {
+			return err
+		}
+		if dst.Annotations == nil {
+			dst.Annotations = map[string]string{}
+		}
+		dst.Annotations[UnconvertedConfigAnnotation] = string(raw)
+	}
+
+	// STATUS fields
+	dst.Status = src.Status.toV3()
+
+	return nil
+}
+
+// V3ToV2 copies the v1alpha3-type field values into v1alpha2-type field values.
+func V3ToV2(dst *Subscription, src *v1alpha3.Subscription) error {
+	// ObjectMeta
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+
+	// SPEC fields
+	dst.Spec.ID = src.Spec.ID
+	dst.Spec.Sink = src.Spec.Sink
+	dst.Spec.TypeMatching = TypeMatching(src.Spec.TypeMatching)
+	dst.Spec.Source = src.Spec.Source
+	dst.Spec.Types = append([]string(nil), src.Spec.Types...)
+	if src.Spec.Transformation != nil {
+		// the transformation has the same fields in both versions.
+		transformation := Transformation(*src.Spec.Transformation.DeepCopy())
+		dst.Spec.Transformation = &transformation
+	}
+
+	// Config
+	dst.configFromV3(src)
+	if raw, ok := dst.Annotations[UnconvertedConfigAnnotation]; ok {
+		unconverted := map[string]string{}
+		if err := json.Unmarshal([]byte(raw), &unconverted); err != nil {
+			return err
+		}
+		for key, value := range unconverted {
+			if _, exists := dst.Spec.Config[key]; !exists {
+				dst.setConfig(key, value)
+			}
+		}
+		delete(dst.Annotations, UnconvertedConfigAnnotation)
+		if len(dst.Annotations) == 0 {
+			dst.Annotations = nil
+		}
+	}
+
+	// STATUS fields
+	dst.Status = statusFromV3(src.Status)
+
+	return nil
+}
+
+// configToV3 converts the generic v1alpha2 Spec config to the typed v1alpha3 Spec fields.
+// It returns the config entries which could not be converted.
+func (s *Subscription) configToV3(dst *v1alpha3.Subscription) map[string]string {
+	unconverted := map[string]string{}
+	for key, value := range s.Spec.Config {
+		switch key {
+		case MaxInFlightMessages:
+			intVal, err := strconv.Atoi(value)
+			if err != nil {
+				unconverted[key] = value
+				continue
+			}
+			initializeDeliveryIfNil(dst)
+			dst.Spec.Delivery.MaxInFlightMessages = &intVal
+		case ProtocolSettingsQos:
+			initializeDeliveryIfNil(dst)
+			dst.Spec.Delivery.Qos = value
+		case ReplyEnabled:
+			enabled, err := strconv.ParseBool(value)
+			if err != nil {
+				unconverted[key] = value
+				continue
+			}
+			initializeDeliveryIfNil(dst)
+			dst.Spec.Delivery.ReplyEnabled = &enabled
+		case Protocol:
+			initializeProtocolIfNil(dst)
+			dst.Spec.Protocol.Name = value
+		case ProtocolSettingsContentMode:
+			initializeProtocolIfNil(dst)
+			dst.Spec.Protocol.ContentMode = value
+		case ProtocolSettingsFormat:
+			initializeProtocolIfNil(dst)
+			dst.Spec.Protocol.Format = value
+		case ProtocolSettingsExemptHandshake:
+			handshake, err := strconv.ParseBool(value)
+			if err != nil {
+				unconverted[key] = value
+				continue
+			}
+			initializeProtocolIfNil(dst)
+			dst.Spec.Protocol.ExemptHandshake = &handshake
+		case WebhookAuthType:
+			initializeWebhookAuthIfNil(dst)
+			dst.Spec.WebhookAuth.Type = value
+		case WebhookAuthGrantType:
+			initializeWebhookAuthIfNil(dst)
+			dst.Spec.WebhookAuth.GrantType = value
+		case WebhookAuthClientID:
+			initializeWebhookAuthIfNil(dst)
+			dst.Spec.WebhookAuth.ClientID = value
+		case WebhookAuthClientSecret:
+			initializeWebhookAuthIfNil(dst)
+			dst.Spec.WebhookAuth.ClientSecret = value
+		case WebhookAuthTokenURL:
+			initializeWebhookAuthIfNil(dst)
+			dst.Spec.WebhookAuth.TokenURL = value
+		case WebhookAuthScope:
+			initializeWebhookAuthIfNil(dst)
+			dst.Spec.WebhookAuth.Scope = strings.Split(value, ",")
+		case WebhookAuthSecretName:
+			initializeWebhookAuthIfNil(dst)
+			dst.Spec.WebhookAuth.SecretName = value
+		default:
+			unconverted[key] = value
+		}
+	}
+	return unconverted
+}
+
+// configFromV3 converts the typed v1alpha3 Spec fields to the generic v1alpha2 Spec config.
+func (s *Subscription) configFromV3(src *v1alpha3.Subscription) {
+	if delivery := src.Spec.Delivery; delivery != nil {
+		if delivery.MaxInFlightMessages != nil {
+			s.setConfig(MaxInFlightMessages, strconv.Itoa(*delivery.MaxInFlightMessages))
+		}
+		if delivery.Qos != "" {
+			s.setConfig(ProtocolSettingsQos, delivery.Qos)
+		}
+		if delivery.ReplyEnabled != nil {
+			s.setConfig(ReplyEnabled, strconv.FormatBool(*delivery.ReplyEnabled))
+		}
+	}
+
+	if protocol := src.Spec.Protocol; protocol != nil {
+		if protocol.Name != "" {
+			s.setConfig(Protocol, protocol.Name)
+		}
+		if protocol.ContentMode != "" {
+			s.setConfig(ProtocolSettingsContentMode, protocol.ContentMode)
+		}
+		if protocol.Format != "" {
+			s.setConfig(ProtocolSettingsFormat, protocol.Format)
+		}
+		if protocol.ExemptHandshake != nil {
+			s.setConfig(ProtocolSettingsExemptHandshake, strconv.FormatBool(*protocol.ExemptHandshake))
+		}
+	}
+
+	if auth := src.Spec.WebhookAuth; auth != nil {
+		for key, value := range map[string]string{
+			WebhookAuthType:         auth.Type,
+			WebhookAuthGrantType:    auth.GrantType,
+			WebhookAuthClientID:     auth.ClientID,
+			WebhookAuthClientSecret: auth.ClientSecret,
+			WebhookAuthTokenURL:     auth.TokenURL,
+			WebhookAuthSecretName:   auth.SecretName,
+		} {
+			if value != "" {
+				s.setConfig(key, value)
+			}
+		}
+		if auth.Scope != nil {
+			s.setConfig(WebhookAuthScope, strings.Join(auth.Scope, ","))
+		}
+	}
+}
+
+func (s *Subscription) setConfig(key, value string) {
+	if s.Spec.Config == nil {
+		s.Spec.Config = map[string]string{}
+	}
+	s.Spec.Config[key] = value
+}
+
+func initializeDeliveryIfNil(dst *v1alpha3.Subscription) {
+	if dst.Spec.Delivery == nil {
+		dst.Spec.Delivery = &v1alpha3.DeliverySettings{}
+	}
+}
+
+func initializeProtocolIfNil(dst *v1alpha3.Subscription) {
+	if dst.Spec.Protocol == nil {
+		dst.Spec.Protocol = &v1alpha3.ProtocolSettings{}
+	}
+}
+
+func initializeWebhookAuthIfNil(dst *v1alpha3.Subscription) {
+	if dst.Spec.WebhookAuth == nil {
+		dst.Spec.WebhookAuth = &v1alpha3.WebhookAuth{}
+	}
+}
+
+// toV3 converts the v1alpha2 Subscription status to the v1alpha3 version.
+func (s SubscriptionStatus) toV3() v1alpha3.SubscriptionStatus {
+	status := v1alpha3.SubscriptionStatus{
+		Ready: s.Ready,
+		Backend: v1alpha3.Backend{
+			Ev2hash:            s.Backend.Ev2hash,
+			EventMeshHash:      s.Backend.EventMeshHash,
+			EventMeshLocalHash: s.Backend.EventMeshLocalHash,
+			WebhookAuthHash:    s.Backend.WebhookAuthHash,
+			ExternalSink:       s.Backend.ExternalSink,
+			FailedActivation:   s.Backend.FailedActivation,
+			APIRuleName:        s.Backend.APIRuleName,
+		},
+	}
+	for _, condition := range s.Conditions {
+		status.Conditions = append(status.Conditions, v1alpha3.Condition{
+			Type:               v1alpha3.ConditionType(condition.Type),
+			Status:             condition.Status,
+			LastTransitionTime: condition.LastTransitionTime,
+			Reason:             v1alpha3.ConditionReason(condition.Reason),
+			Message:            condition.Message,
+		})
+	}
+	if s.Types != nil {
+		status.Types = make([]v1alpha3.EventType, 0, len(s.Types))
+		for _, eventType := range s.Types {
+			status.Types = append(status.Types, v1alpha3.EventType(eventType))
+		}
+	}
+	if s.Backend.EventMeshSubscriptionStatus != nil {
+		emsStatus := v1alpha3.EventMeshSubscriptionStatus(*s.Backend.EventMeshSubscriptionStatus)
+		status.Backend.EventMeshSubscriptionStatus = &emsStatus
+	}
+	for _, jsType := range s.Backend.Types {
+		status.Backend.Types = append(status.Backend.Types, v1alpha3.JetStreamTypes(jsType))
+	}
+	for _, emsType := range s.Backend.EmsTypes {
+		status.Backend.EmsTypes = append(status.Backend.EmsTypes, v1alpha3.EventMeshTypes(emsType))
+	}
+	return status
+}
+
+// statusFromV3 converts the v1alpha3 Subscription status to the v1alpha2 version.
+func statusFromV3(s v1alpha3.SubscriptionStatus) SubscriptionStatus {
+	status := SubscriptionStatus{
+		Ready: s.Ready,
+		Backend: Backend{
+			Ev2hash:            s.Backend.Ev2hash,
+			EventMeshHash:      s.Backend.EventMeshHash,
+			EventMeshLocalHash: s.Backend.EventMeshLocalHash,
+			WebhookAuthHash:    s.Backend.WebhookAuthHash,
+			ExternalSink:       s.Backend.ExternalSink,
+			FailedActivation:   s.Backend.FailedActivation,
+			APIRuleName:        s.Backend.APIRuleName,
+		},
+	}
+	for _, condition := range s.Conditions {
+		status.Conditions = append(status.Conditions, Condition{
+			Type:               ConditionType(condition.Type),
+			Status:             condition.Status,
+			LastTransitionTime: condition.LastTransitionTime,
+			Reason:             ConditionReason(condition.Reason),
+			Message:            condition.Message,
+		})
+	}
+	if s.Types != nil {
+		status.Types = make([]EventType, 0, len(s.Types))
+		for _, eventType := range s.Types {
+			status.Types = append(status.Types, EventType(eventType))
+		}
+	}
+	if s.Backend.EventMeshSubscriptionStatus != nil {
+		emsStatus := EventMeshSubscriptionStatus(*s.Backend.EventMeshSubscriptionStatus)
+		status.Backend.EventMeshSubscriptionStatus = &emsStatus
+	}
+	for _, jsType := range s.Backend.Types {
+		status.Backend.Types = append(status.Backend.Types, JetStreamTypes(jsType))
+	}
+	for _, emsType := range s.Backend.EmsTypes {
+		status.Backend.EmsTypes = append(status.Backend.EmsTypes, EventMeshTypes(emsType))
+	}
+	return status
+}
@@ -0,0 +1,228 @@
+package v1alpha2_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	kcorev1 "k8s.io/api/core/v1"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/kyma-project/eventing-manager/api/eventing/v1alpha2"
+	"github.com/kyma-project/eventing-manager/api/eventing/v1alpha3"
+)
+
+func Test_V2ToV3(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		givenSub   *v1alpha2.Subscription
+		wantSpec   v1alpha3.SubscriptionSpec
+		wantAnnots map[string]string
+	}{
+		{
+			name: "should convert a subscription without config",
+			givenSub: &v1alpha2.Subscription{
+				Spec: v1alpha2.SubscriptionSpec{
+					Sink:         "http://sink.test.svc.cluster.local",
+					TypeMatching: v1alpha2.TypeMatchingStandard,
+					Source:       "source",
+					Types:        []string{"order.created.v1"},
+				},
+			},
+			wantSpec: v1alpha3.SubscriptionSpec{
+				Sink:         "http://sink.test.svc.cluster.local",
+				TypeMatching: v1alpha3.TypeMatchingStandard,
+				Source:       "source",
+				Types:        []string{"order.created.v1"},
+			},
+		},
+		{
+			name: "should convert the config to typed fields",
+			givenSub: &v1alpha2.Subscription{
+				Spec: v1alpha2.SubscriptionSpec{
+					Types: []string{"order.created.v1"},
+					Config: map[string]string{
+						v1alpha2.MaxInFlightMessages:             "20",
+						v1alpha2.ProtocolSettingsQos:             "AT_MOST_ONCE",
+						v1alpha2.ReplyEnabled:                    "true",
+						v1alpha2.Protocol:                        "BEB",
+						v1alpha2.ProtocolSettingsContentMode:     "BINARY",
+						v1alpha2.ProtocolSettingsExemptHandshake: "true",
+						v1alpha2.WebhookAuthType:                 "oauth2",
+						v1alpha2.WebhookAuthGrantType:            "client_credentials",
+						v1alpha2.WebhookAuthClientID:             "id",
+						v1alpha2.WebhookAuthClientSecret:         "secret",
+						v1alpha2.WebhookAuthTokenURL:             "https://token.local",
+						v1alpha2.WebhookAuthScope:                "a,b",
+					},
+				},
+			},
+			wantSpec: v1alpha3.SubscriptionSpec{
+				Types: []string{"order.created.v1"},
+				Delivery: &v1alpha3.DeliverySettings{
+					MaxInFlightMessages: ptr.To(20),
+					Qos:                 "AT_MOST_ONCE",
+					ReplyEnabled:        ptr.To(true),
+				},
+				Protocol: &v1alpha3.ProtocolSettings{
+					Name:            "BEB",
+					ContentMode:     "BINARY",
+					ExemptHandshake: ptr.To(true),
+				},
+				WebhookAuth: &v1alpha3.WebhookAuth{
+					Type:         "oauth2",
+					GrantType:    "client_credentials",
+					ClientID:     "id",
+					ClientSecret: "secret",
+					TokenURL:     "https://token.local",
+					Scope:        []string{"a", "b"},
+				},
+			},
+		},
+		{
+			name: "should convert the webhook auth with a Secret",
+			givenSub: &v1alpha2.Subscription{
+				Spec: v1alpha2.SubscriptionSpec{
+					Types: []string{"order.created.v1"},
+					Config: map[string]string{
+						v1alpha2.WebhookAuthType:       "mtls",
+						v1alpha2.WebhookAuthSecretName: "sink-credentials",
+					},
+				},
+			},
+			wantSpec: v1alpha3.SubscriptionSpec{
+				Types: []string{"order.created.v1"},
+				WebhookAuth: &v1alpha3.WebhookAuth{
+					Type:       "mtls",
+					SecretName: "sink-credentials",
+				},
+			},
+		},
+		{
+			name: "should convert the content mode with an event format",
+			givenSub: &v1alpha2.Subscription{
+				Spec: v1alpha2.SubscriptionSpec{
+					Types: []string{"order.created.v1"},
+					Config: map[string]string{
+						v1alpha2.ProtocolSettingsContentMode: "STRUCTURED",
+						v1alpha2.ProtocolSettingsFormat:      "PROTOBUF",
+					},
+				},
+			},
+			wantSpec: v1alpha3.SubscriptionSpec{
+				Types: []string{"order.created.v1"},
+				Protocol: &v1alpha3.ProtocolSettings{
+					ContentMode: "STRUCTURED",
+					Format:      "PROTOBUF",
+				},
+			},
+		},
+		{
+			name: "should keep the non-convertible config in an annotation",
+			givenSub: &v1alpha2.Subscription{
+				ObjectMeta: kmetav1.ObjectMeta{
+					Annotations: map[string]string{"foo": "bar"},
+				},
+				Spec: v1alpha2.SubscriptionSpec{
+					Types: []string{"order.created.v1"},
+					Config: map[string]string{
+						v1alpha2.MaxInFlightMessages: "not-an-int",
+						"unknown":                    "value",
+					},
+				},
+			},
+			wantSpec: v1alpha3.SubscriptionSpec{
+				Types: []string{"order.created.v1"},
+			},
+			wantAnnots: map[string]string{
+				"foo":                                "bar",
+				v1alpha2.UnconvertedConfigAnnotation: `{"maxInFlightMessages":"not-an-int","unknown":"value"}`,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			gotSub := &v1alpha3.Subscription{}
+			err := tc.givenSub.ConvertTo(gotSub)
+
+			// then
+			require.NoError(t, err)
+			require.Equal(t, tc.wantSpec, gotSub.Spec)
+			require.Equal(t, tc.wantAnnots, gotSub.Annotations)
+		})
+	}
+}
+
+func Test_RoundTripConversion(t *testing.T) {
+	t.Parallel()
+
+	// given
+	givenSub := &v1alpha2.Subscription{
+		ObjectMeta: kmetav1.ObjectMeta{
+			Name:        "test",
+			Namespace:   "test",
+			Annotations: map[string]string{"foo": "bar"},
+		},
+		Spec: v1alpha2.SubscriptionSpec{
+			ID:           "id",
+			Sink:         "http://sink.test.svc.cluster.local",
+			TypeMatching: v1alpha2.TypeMatchingExact,
+			Source:       "source",
+			Types:        []string{"order.created.v1", "order.deleted.v1"},
+			Config: map[string]string{
+				v1alpha2.MaxInFlightMessages:             "10",
+				v1alpha2.ProtocolSettingsExemptHandshake: "invalid",
+				v1alpha2.WebhookAuthClientID:             "id",
+				"unknown":                                "value",
+			},
+			Transformation: &v1alpha2.Transformation{
+				Projection:       map[string]string{"orderId": ".order.id"},
+				SetExtensions:    map[string]string{"tenant": "acme"},
+				RemoveExtensions: []string{"traceparent"},
+				Type:             "order.received.v1",
+				Source:           "shop",
+			},
+		},
+		Status: v1alpha2.SubscriptionStatus{
+			Ready: true,
+			Conditions: []v1alpha2.Condition{
+				{
+					Type:   v1alpha2.ConditionSubscriptionActive,
+					Status: kcorev1.ConditionTrue,
+					Reason: v1alpha2.ConditionReasonNATSSubscriptionActive,
+				},
+			},
+			Types: []v1alpha2.EventType{
+				{OriginalType: "order.created.v1", CleanType: "order.created.v1"},
+			},
+			Backend: v1alpha2.Backend{
+				Ev2hash: 123,
+				EventMeshSubscriptionStatus: &v1alpha2.EventMeshSubscriptionStatus{
+					Status: "active",
+				},
+				Types: []v1alpha2.JetStreamTypes{
+					{OriginalType: "order.created.v1", ConsumerName: "consumer"},
+				},
+				EmsTypes: []v1alpha2.EventMeshTypes{
+					{OriginalType: "order.created.v1", EventMeshType: "order.created.v1"},
+				},
+			},
+		},
+	}
+
+	// when
+	hub := &v1alpha3.Subscription{}
+	require.NoError(t, givenSub.DeepCopy().ConvertTo(hub))
+	gotSub := &v1alpha2.Subscription{}
+	require.NoError(t, gotSub.ConvertFrom(hub))
+
+	// then
+	require.Equal(t, givenSub, gotSub)
+}
@@ -29,7 +29,8 @@ type SubscriptionSpec struct {
 
 	// Defines how types should be handled.<br />
 	// - `standard`: backend-specific logic will be applied to the configured source and types.<br />
-	// - `exact`: no further processing will be applied to the configured source and types.
+	// - `exact`: no further processing will be applied to the configured source and types.<br />
+	// - `wildcard`: like `standard`, but the types may contain the `*` (one segment) and `>` (trailing segments) wildcards.
 	TypeMatching TypeMatching `json:"typeMatching,omitempty"`
 
 	// Defines the origin of the event.
@@ -41,6 +42,10 @@ type SubscriptionSpec struct {
 	// Map of configuration options that will be applied on the backend.
 	// +optional
 	Config map[string]string `json:"config,omitempty"`
+
+	// Transformation of the events before they are dispatched to the sink. Used only with NATS as the backend.
+	// +optional
+	Transformation *Transformation `json:"transformation,omitempty"`
 }
 
 // SubscriptionStatus defines the observed state of Subscription.
@@ -97,6 +102,12 @@ func (s *Subscription) GetMaxInFlightMessages(defaults *env.DefaultSubscriptionC
 	return val
 }
 
+// IsReplyEnabled returns true if the reply events of the sink should be published back to the backend.
+func (s *Subscription) IsReplyEnabled() bool {
+	enabled, err := strconv.ParseBool(s.Spec.Config[ReplyEnabled])
+	return err == nil && enabled
+}
+
 // InitializeEventTypes initializes the SubscriptionStatus.Types with an empty slice of EventType.
 func (s *SubscriptionStatus) InitializeEventTypes() {
 	s.Types = []EventType{}
@@ -140,6 +151,3 @@ type SubscriptionList struct {
 func init() { //nolint:gochecknoinits
 	SchemeBuilder.Register(&Subscription{}, &SubscriptionList{})
 }
-
-// Hub marks this type as a conversion hub.
-func (*Subscription) Hub() {}
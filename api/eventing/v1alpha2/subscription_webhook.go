@@ -1,6 +1,8 @@
 package v1alpha2
 
 import (
+	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -24,9 +26,12 @@ const (
 	ValidSource                = "source"
 )
 
-func (s *Subscription) SetupWebhookWithManager(mgr kctrl.Manager) error {
+// SetupWebhookWithManager sets up the webhooks of the Subscription. The Subscriptions are defaulted by the given
+// defaulter, or by Default if it is nil.
+func (s *Subscription) SetupWebhookWithManager(mgr kctrl.Manager, defaulter admission.CustomDefaulter) error {
 	return kctrl.NewWebhookManagedBy(mgr).
 		For(s).
+		WithDefaulter(defaulter).
 		Complete()
 }
 
@@ -48,6 +53,35 @@ func (s *Subscription) Default() {
 	}
 }
 
+// DefaultWith sets the config values of the given defaults, which are not set by the Subscription.
+// The webhook auth is set only if the Subscription sets no auth type, so that the auth fields are never mixed,
+// and only with the auth types reading their credentials from a Secret.
+func (s *Subscription) DefaultWith(defaults *SubscriptionDefaults) {
+	if defaults == nil {
+		return
+	}
+	if defaults.MaxInFlightMessages != nil {
+		s.setDefaultConfigValue(MaxInFlightMessages, strconv.Itoa(*defaults.MaxInFlightMessages))
+	}
+	s.setDefaultConfigValue(ProtocolSettingsQos, defaults.Qos)
+	s.setDefaultConfigValue(ProtocolSettingsContentMode, defaults.ContentMode)
+	if auth := defaults.WebhookAuth; auth != nil && s.Spec.Config[WebhookAuthType] == "" &&
+		types.IsSecretAuthType(auth.Type) {
+		s.setDefaultConfigValue(WebhookAuthType, auth.Type)
+		s.setDefaultConfigValue(WebhookAuthSecretName, auth.SecretName)
+	}
+}
+
+func (s *Subscription) setDefaultConfigValue(key, value string) {
+	if value == "" || s.Spec.Config[key] != "" {
+		return
+	}
+	if s.Spec.Config == nil {
+		s.Spec.Config = map[string]string{}
+	}
+	s.Spec.Config[key] = value
+}
+
 //nolint: lll
 //+kubebuilder:webhook:path=/validate-eventing-kyma-project-io-v1alpha2-subscription,mutating=false,failurePolicy=fail,sideEffects=None,groups=eventing.kyma-project.io,resources=subscriptions,verbs=create;update,versions=v1alpha2,name=vsubscription.kb.io,admissionReviewVersions=v1beta1
 
@@ -83,6 +117,9 @@ func (s *Subscription) ValidateSubscription() (admission.Warnings, error) {
 	if err := s.validateSubscriptionSink(); err != nil {
 		allErrs = append(allErrs, err)
 	}
+	if err := s.validateSubscriptionTransformation(); err != nil {
+		allErrs = append(allErrs, err...)
+	}
 	if len(allErrs) == 0 {
 		return nil, nil
 	}
@@ -118,24 +155,87 @@ func (s *Subscription) validateSubscriptionTypes() *field.Error {
 		if s.Spec.TypeMatching != TypeMatchingExact && strings.HasPrefix(etype, InvalidPrefix) {
 			return MakeInvalidFieldError(TypesPath, s.Name, InvalidPrefixErrDetail)
 		}
+		if s.Spec.TypeMatching == TypeMatchingWildcard {
+			if detail := validateWildcardType(etype); detail != "" {
+				return MakeInvalidFieldError(TypesPath, s.Name, detail)
+			}
+		}
 		// Check only is the event type is valid for the cloud event, with a valid source.
 		if IsInvalidCE(ValidSource, etype) {
 			return MakeInvalidFieldError(TypesPath, s.Name, InvalidURIErrDetail)
 		}
 	}
+	if s.Spec.TypeMatching == TypeMatchingWildcard && hasOverlappingTypes(s.Spec.Types) {
+		return MakeInvalidFieldError(TypesPath, s.Name, OverlappingTypesErrDetail)
+	}
 	return nil
 }
 
+// validateWildcardType returns the error detail if the wildcards of the event type are not in a safe position.
+// The wildcards must be whole segments, the first segment must not be a wildcard, and > must be the last segment.
+func validateWildcardType(eventType string) string {
+	segments := strings.Split(eventType, ".")
+	for i, segment := range segments {
+		isWildcard := segment == WildcardSegment || segment == WildcardTrailingSegments
+		if !isWildcard && strings.ContainsAny(segment, WildcardSegment+WildcardTrailingSegments) {
+			return WildcardSegmentErrDetail
+		}
+		if isWildcard && i == 0 {
+			return WildcardPositionErrDetail
+		}
+		if segment == WildcardTrailingSegments && i != len(segments)-1 {
+			return WildcardPositionErrDetail
+		}
+	}
+	return ""
+}
+
+// hasOverlappingTypes returns true if an event could match more than one of the wildcard types.
+func hasOverlappingTypes(eventTypes []string) bool {
+	for i := range eventTypes {
+		for j := i + 1; j < len(eventTypes); j++ {
+			if typesOverlap(strings.Split(eventTypes[i], "."), strings.Split(eventTypes[j], ".")) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func typesOverlap(first, second []string) bool {
+	for i := 0; ; i++ {
+		if i == len(first) || i == len(second) {
+			return len(first) == len(second)
+		}
+		if first[i] == WildcardTrailingSegments || second[i] == WildcardTrailingSegments {
+			return true
+		}
+		if first[i] != second[i] && first[i] != WildcardSegment && second[i] != WildcardSegment {
+			return false
+		}
+	}
+}
+
 func (s *Subscription) validateSubscriptionConfig() field.ErrorList {
 	var allErrs field.ErrorList
 	if isNotInt(s.Spec.Config[MaxInFlightMessages]) {
 		allErrs = append(allErrs, MakeInvalidFieldError(ConfigPath, s.Name, StringIntErrDetail))
 	}
+	if s.ifKeyExistsInConfig(ReplyEnabled) && isNotBool(s.Spec.Config[ReplyEnabled]) {
+		allErrs = append(allErrs, MakeInvalidFieldError(ConfigPath, s.Name, StringBoolErrDetail))
+	}
 	if s.ifKeyExistsInConfig(ProtocolSettingsQos) && types.IsInvalidQoS(s.Spec.Config[ProtocolSettingsQos]) {
 		allErrs = append(allErrs, MakeInvalidFieldError(ConfigPath, s.Name, InvalidQosErrDetail))
 	}
-	if s.ifKeyExistsInConfig(WebhookAuthType) && types.IsInvalidAuthType(s.Spec.Config[WebhookAuthType]) {
-		allErrs = append(allErrs, MakeInvalidFieldError(ConfigPath, s.Name, InvalidAuthTypeErrDetail))
+	if s.ifKeyExistsInConfig(ProtocolSettingsContentMode) &&
+		types.IsInvalidContentMode(s.Spec.Config[ProtocolSettingsContentMode]) {
+		allErrs = append(allErrs, MakeInvalidFieldError(ConfigPath, s.Name, InvalidContentModeErrDetail))
+	}
+	if s.ifKeyExistsInConfig(ProtocolSettingsFormat) {
+		allErrs = append(allErrs, s.validateEventFormat()...)
+	}
+	if s.ifKeyExistsInConfig(WebhookAuthType) {
+		allErrs = append(allErrs, s.validateWebhookAuth()...)
 	}
 	if s.ifKeyExistsInConfig(WebhookAuthGrantType) && types.IsInvalidGrantType(s.Spec.Config[WebhookAuthGrantType]) {
 		allErrs = append(allErrs, MakeInvalidFieldError(ConfigPath, s.Name, InvalidGrantTypeErrDetail))
@@ -143,6 +243,99 @@ func (s *Subscription) validateSubscriptionConfig() field.ErrorList {
 	return allErrs
 }
 
+// validateEventFormat validates that the event format is used with the structured content mode.
+func (s *Subscription) validateEventFormat() field.ErrorList {
+	var allErrs field.ErrorList
+	if types.IsInvalidEventFormat(s.Spec.Config[ProtocolSettingsFormat]) {
+		allErrs = append(allErrs, MakeInvalidFieldError(ConfigPath, s.Name, InvalidEventFormatErrDetail))
+	}
+	if s.Spec.Config[ProtocolSettingsContentMode] != types.ContentModeStructured {
+		allErrs = append(allErrs, MakeInvalidFieldError(ConfigPath, s.Name, FormatContentModeErrDetail))
+	}
+	return allErrs
+}
+
+// validateWebhookAuth validates that the config has the webhook auth fields required by the auth type.
+func (s *Subscription) validateWebhookAuth() field.ErrorList {
+	authType := s.Spec.Config[WebhookAuthType]
+	if types.IsInvalidAuthType(authType) {
+		return field.ErrorList{MakeInvalidFieldError(ConfigPath, s.Name, InvalidAuthTypeErrDetail)}
+	}
+
+	requiredKeys := []string{WebhookAuthSecretName}
+	if !types.IsSecretAuthType(authType) {
+		requiredKeys = []string{WebhookAuthClientID, WebhookAuthClientSecret, WebhookAuthTokenURL}
+	}
+	var missingKeys []string
+	for _, key := range requiredKeys {
+		if s.Spec.Config[key] == "" {
+			missingKeys = append(missingKeys, key)
+		}
+	}
+	var allErrs field.ErrorList
+	if len(missingKeys) > 0 {
+		allErrs = append(allErrs, MakeInvalidFieldError(ConfigPath, s.Name,
+			MissingWebhookAuthErrDetail+strings.Join(missingKeys, ", ")))
+	}
+	if tokenURL := s.Spec.Config[WebhookAuthTokenURL]; tokenURL != "" && !types.IsSecretAuthType(authType) {
+		if parsedURL, err := url.ParseRequestURI(tokenURL); err != nil || parsedURL.Host == "" {
+			allErrs = append(allErrs, MakeInvalidFieldError(ConfigPath, s.Name, InvalidTokenURLErrDetail))
+		}
+	}
+	return allErrs
+}
+
+// validateSubscriptionTransformation validates the projected fields, the extension names, and the remapped
+// type and source of the transformation.
+func (s *Subscription) validateSubscriptionTransformation() field.ErrorList {
+	transformation := s.Spec.Transformation
+	if transformation == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	for _, name := range sortedKeys(transformation.Projection) {
+		if _, err := ParseProjectionField(name, transformation.Projection[name]); err != nil {
+			allErrs = append(allErrs, MakeInvalidFieldError(TransformationPath, s.Name, InvalidProjectionErrDetail+name))
+		}
+	}
+	extensions := append(sortedKeys(transformation.SetExtensions), transformation.RemoveExtensions...)
+	for _, extension := range extensions {
+		if isInvalidExtensionName(extension) {
+			allErrs = append(allErrs, MakeInvalidFieldError(TransformationPath, s.Name,
+				InvalidExtensionErrDetail+extension))
+		}
+	}
+	if IsInvalidCE(transformation.Source, transformation.Type) {
+		allErrs = append(allErrs, MakeInvalidFieldError(TransformationPath, s.Name, InvalidTransformedCEErrDetail))
+	}
+	return allErrs
+}
+
+// isInvalidExtensionName returns true if the name is not a valid CloudEvent extension attribute name,
+// or if it is the name of a context attribute.
+func isInvalidExtensionName(name string) bool {
+	switch name {
+	case "", "specversion", "id", "source", "type", "datacontenttype", "dataschema", "subject", "time", "data":
+		return true
+	}
+	for _, char := range name {
+		if (char < 'a' || char > 'z') && (char < '0' || char > '9') {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func (s *Subscription) validateSubscriptionSink() *field.Error {
 	if s.Spec.Sink == "" {
 		return MakeInvalidFieldError(SinkPath, s.Name, EmptyErrDetail)
@@ -188,6 +381,11 @@ func isNotInt(value string) bool {
 	return false
 }
 
+func isNotBool(value string) bool {
+	_, err := strconv.ParseBool(value)
+	return err != nil
+}
+
 func IsInvalidCE(source, eventType string) bool {
 	if source == "" {
 		return false
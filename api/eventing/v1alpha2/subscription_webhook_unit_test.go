@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/require"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 
 	"github.com/kyma-project/eventing-manager/api/eventing/v1alpha2"
 	eventingtesting "github.com/kyma-project/eventing-manager/testing"
@@ -79,6 +80,83 @@ func Test_Default(t *testing.T) {
 	}
 }
 
+func Test_DefaultWith(t *testing.T) {
+	t.Parallel()
+
+	secretDefaults := &v1alpha2.SubscriptionDefaults{
+		WebhookAuth: &v1alpha2.DefaultWebhookAuth{
+			Type:       "bearer",
+			SecretName: "default-credentials",
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		givenSub      *v1alpha2.Subscription
+		givenDefaults *v1alpha2.SubscriptionDefaults
+		wantConfig    map[string]string
+	}{
+		{
+			name:          "should not change the Subscription without defaults",
+			givenSub:      eventingtesting.NewSubscription(subName, subNamespace),
+			givenDefaults: nil,
+			wantConfig:    map[string]string{},
+		},
+		{
+			name:     "should set the defaults not set by the Subscription",
+			givenSub: eventingtesting.NewSubscription(subName, subNamespace, eventingtesting.WithMaxInFlightMessages("5")),
+			givenDefaults: &v1alpha2.SubscriptionDefaults{
+				MaxInFlightMessages: ptr.To(20),
+				Qos:                 "AT_MOST_ONCE",
+				ContentMode:         "BINARY",
+			},
+			wantConfig: map[string]string{
+				v1alpha2.MaxInFlightMessages:         "5",
+				v1alpha2.ProtocolSettingsQos:         "AT_MOST_ONCE",
+				v1alpha2.ProtocolSettingsContentMode: "BINARY",
+			},
+		},
+		{
+			name:          "should set the default webhook auth",
+			givenSub:      eventingtesting.NewSubscription(subName, subNamespace),
+			givenDefaults: secretDefaults,
+			wantConfig: map[string]string{
+				v1alpha2.WebhookAuthType:       "bearer",
+				v1alpha2.WebhookAuthSecretName: "default-credentials",
+			},
+		},
+		{
+			name:     "should not set the default webhook auth of an auth type without a Secret",
+			givenSub: eventingtesting.NewSubscription(subName, subNamespace),
+			givenDefaults: &v1alpha2.SubscriptionDefaults{
+				WebhookAuth: &v1alpha2.DefaultWebhookAuth{Type: "oauth2", SecretName: "default-credentials"},
+			},
+			wantConfig: map[string]string{},
+		},
+		{
+			name: "should not mix the webhook auth of the Subscription with the defaults",
+			givenSub: eventingtesting.NewSubscription(subName, subNamespace,
+				eventingtesting.WithConfigValue(v1alpha2.WebhookAuthType, "basic"),
+				eventingtesting.WithConfigValue(v1alpha2.WebhookAuthSecretName, "sink-credentials"),
+			),
+			givenDefaults: secretDefaults,
+			wantConfig: map[string]string{
+				v1alpha2.WebhookAuthType:       "basic",
+				v1alpha2.WebhookAuthSecretName: "sink-credentials",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tc.givenSub.DefaultWith(tc.givenDefaults)
+			require.Equal(t, tc.wantConfig, tc.givenSub.Spec.Config)
+		})
+	}
+}
+
 func Test_validateSubscription(t *testing.T) {
 	t.Parallel()
 	type TestCase struct {
@@ -244,6 +322,84 @@ func Test_validateSubscription(t *testing.T) {
 			),
 			wantErr: nil,
 		},
+		{
+			name: "wildcard types in safe positions should not return error",
+			givenSub: eventingtesting.NewSubscription(subName, subNamespace,
+				eventingtesting.WithTypeMatching(v1alpha2.TypeMatchingWildcard),
+				eventingtesting.WithSource(eventingtesting.EventSourceClean),
+				eventingtesting.WithTypes([]string{"order.*.v1", "customer.>"}),
+				eventingtesting.WithMaxInFlightMessages(v1alpha2.DefaultMaxInFlightMessages),
+				eventingtesting.WithSink(sink),
+			),
+			wantErr: nil,
+		},
+		{
+			name: "wildcard within a segment should return error",
+			givenSub: eventingtesting.NewSubscription(subName, subNamespace,
+				eventingtesting.WithTypeMatching(v1alpha2.TypeMatchingWildcard),
+				eventingtesting.WithSource(eventingtesting.EventSourceClean),
+				eventingtesting.WithTypes([]string{"order.created*.v1"}),
+				eventingtesting.WithMaxInFlightMessages(v1alpha2.DefaultMaxInFlightMessages),
+				eventingtesting.WithSink(sink),
+			),
+			wantErr: kerrors.NewInvalid(
+				v1alpha2.GroupKind, subName,
+				field.ErrorList{v1alpha2.MakeInvalidFieldError(v1alpha2.TypesPath,
+					subName, v1alpha2.WildcardSegmentErrDetail)}),
+		},
+		{
+			name: "wildcard as the first segment should return error",
+			givenSub: eventingtesting.NewSubscription(subName, subNamespace,
+				eventingtesting.WithTypeMatching(v1alpha2.TypeMatchingWildcard),
+				eventingtesting.WithSource(eventingtesting.EventSourceClean),
+				eventingtesting.WithTypes([]string{"*.created.v1"}),
+				eventingtesting.WithMaxInFlightMessages(v1alpha2.DefaultMaxInFlightMessages),
+				eventingtesting.WithSink(sink),
+			),
+			wantErr: kerrors.NewInvalid(
+				v1alpha2.GroupKind, subName,
+				field.ErrorList{v1alpha2.MakeInvalidFieldError(v1alpha2.TypesPath,
+					subName, v1alpha2.WildcardPositionErrDetail)}),
+		},
+		{
+			name: "trailing wildcard before the last segment should return error",
+			givenSub: eventingtesting.NewSubscription(subName, subNamespace,
+				eventingtesting.WithTypeMatching(v1alpha2.TypeMatchingWildcard),
+				eventingtesting.WithSource(eventingtesting.EventSourceClean),
+				eventingtesting.WithTypes([]string{"order.>.v1"}),
+				eventingtesting.WithMaxInFlightMessages(v1alpha2.DefaultMaxInFlightMessages),
+				eventingtesting.WithSink(sink),
+			),
+			wantErr: kerrors.NewInvalid(
+				v1alpha2.GroupKind, subName,
+				field.ErrorList{v1alpha2.MakeInvalidFieldError(v1alpha2.TypesPath,
+					subName, v1alpha2.WildcardPositionErrDetail)}),
+		},
+		{
+			name: "overlapping wildcard types should return error",
+			givenSub: eventingtesting.NewSubscription(subName, subNamespace,
+				eventingtesting.WithTypeMatching(v1alpha2.TypeMatchingWildcard),
+				eventingtesting.WithSource(eventingtesting.EventSourceClean),
+				eventingtesting.WithTypes([]string{"order.*.v1", "order.created.>"}),
+				eventingtesting.WithMaxInFlightMessages(v1alpha2.DefaultMaxInFlightMessages),
+				eventingtesting.WithSink(sink),
+			),
+			wantErr: kerrors.NewInvalid(
+				v1alpha2.GroupKind, subName,
+				field.ErrorList{v1alpha2.MakeInvalidFieldError(v1alpha2.TypesPath,
+					subName, v1alpha2.OverlappingTypesErrDetail)}),
+		},
+		{
+			name: "wildcard types of different lengths should not return error",
+			givenSub: eventingtesting.NewSubscription(subName, subNamespace,
+				eventingtesting.WithTypeMatching(v1alpha2.TypeMatchingWildcard),
+				eventingtesting.WithSource(eventingtesting.EventSourceClean),
+				eventingtesting.WithTypes([]string{"order.*.v1", "order.created.v1.beta"}),
+				eventingtesting.WithMaxInFlightMessages(v1alpha2.DefaultMaxInFlightMessages),
+				eventingtesting.WithSink(sink),
+			),
+			wantErr: nil,
+		},
 		{
 			name: "invalid maxInFlight value should return error",
 			givenSub: eventingtesting.NewSubscription(subName, subNamespace,
@@ -258,6 +414,21 @@ func Test_validateSubscription(t *testing.T) {
 				field.ErrorList{v1alpha2.MakeInvalidFieldError(v1alpha2.ConfigPath,
 					subName, v1alpha2.StringIntErrDetail)}),
 		},
+		{
+			name: "invalid replyEnabled value should return error",
+			givenSub: eventingtesting.NewSubscription(subName, subNamespace,
+				eventingtesting.WithTypeMatchingStandard(),
+				eventingtesting.WithSource(eventingtesting.EventSourceClean),
+				eventingtesting.WithEventType(eventingtesting.OrderCreatedV1Event),
+				eventingtesting.WithMaxInFlightMessages(v1alpha2.DefaultMaxInFlightMessages),
+				eventingtesting.WithConfigValue(v1alpha2.ReplyEnabled, "invalid"),
+				eventingtesting.WithSink(sink),
+			),
+			wantErr: kerrors.NewInvalid(
+				v1alpha2.GroupKind, subName,
+				field.ErrorList{v1alpha2.MakeInvalidFieldError(v1alpha2.ConfigPath,
+					subName, v1alpha2.StringBoolErrDetail)}),
+		},
 		{
 			name: "invalid QoS value should return error",
 			givenSub: eventingtesting.NewSubscription(subName, subNamespace,
@@ -273,6 +444,52 @@ func Test_validateSubscription(t *testing.T) {
 				field.ErrorList{v1alpha2.MakeInvalidFieldError(v1alpha2.ConfigPath,
 					subName, v1alpha2.InvalidQosErrDetail)}),
 		},
+		{
+			name: "structured content mode with an event format should not return error",
+			givenSub: eventingtesting.NewSubscription(subName, subNamespace,
+				eventingtesting.WithTypeMatchingStandard(),
+				eventingtesting.WithSource(eventingtesting.EventSourceClean),
+				eventingtesting.WithEventType(eventingtesting.OrderCreatedV1Event),
+				eventingtesting.WithMaxInFlightMessages(v1alpha2.DefaultMaxInFlightMessages),
+				eventingtesting.WithConfigValue(v1alpha2.ProtocolSettingsContentMode, "STRUCTURED"),
+				eventingtesting.WithConfigValue(v1alpha2.ProtocolSettingsFormat, "PROTOBUF"),
+				eventingtesting.WithSink(sink),
+			),
+			wantErr: nil,
+		},
+		{
+			name: "invalid content mode value should return error",
+			givenSub: eventingtesting.NewSubscription(subName, subNamespace,
+				eventingtesting.WithTypeMatchingStandard(),
+				eventingtesting.WithSource(eventingtesting.EventSourceClean),
+				eventingtesting.WithEventType(eventingtesting.OrderCreatedV1Event),
+				eventingtesting.WithMaxInFlightMessages(v1alpha2.DefaultMaxInFlightMessages),
+				eventingtesting.WithConfigValue(v1alpha2.ProtocolSettingsContentMode, "invalid"),
+				eventingtesting.WithSink(sink),
+			),
+			wantErr: kerrors.NewInvalid(
+				v1alpha2.GroupKind, subName,
+				field.ErrorList{v1alpha2.MakeInvalidFieldError(v1alpha2.ConfigPath,
+					subName, v1alpha2.InvalidContentModeErrDetail)}),
+		},
+		{
+			name: "event format without the structured content mode should return error",
+			givenSub: eventingtesting.NewSubscription(subName, subNamespace,
+				eventingtesting.WithTypeMatchingStandard(),
+				eventingtesting.WithSource(eventingtesting.EventSourceClean),
+				eventingtesting.WithEventType(eventingtesting.OrderCreatedV1Event),
+				eventingtesting.WithMaxInFlightMessages(v1alpha2.DefaultMaxInFlightMessages),
+				eventingtesting.WithConfigValue(v1alpha2.ProtocolSettingsContentMode, "BINARY"),
+				eventingtesting.WithConfigValue(v1alpha2.ProtocolSettingsFormat, "XML"),
+				eventingtesting.WithSink(sink),
+			),
+			wantErr: kerrors.NewInvalid(
+				v1alpha2.GroupKind, subName,
+				field.ErrorList{
+					v1alpha2.MakeInvalidFieldError(v1alpha2.ConfigPath, subName, v1alpha2.InvalidEventFormatErrDetail),
+					v1alpha2.MakeInvalidFieldError(v1alpha2.ConfigPath, subName, v1alpha2.FormatContentModeErrDetail),
+				}),
+		},
 		{
 			name: "invalid webhook auth type value should return error",
 			givenSub: eventingtesting.NewSubscription(subName, subNamespace,
@@ -288,6 +505,101 @@ func Test_validateSubscription(t *testing.T) {
 				field.ErrorList{v1alpha2.MakeInvalidFieldError(v1alpha2.ConfigPath,
 					subName, v1alpha2.InvalidAuthTypeErrDetail)}),
 		},
+		{
+			name: "oauth2 webhook auth without credentials should return error",
+			givenSub: eventingtesting.NewSubscription(subName, subNamespace,
+				eventingtesting.WithTypeMatchingStandard(),
+				eventingtesting.WithSource(eventingtesting.EventSourceClean),
+				eventingtesting.WithEventType(eventingtesting.OrderCreatedV1Event),
+				eventingtesting.WithMaxInFlightMessages(v1alpha2.DefaultMaxInFlightMessages),
+				eventingtesting.WithConfigValue(v1alpha2.WebhookAuthType, "oauth2"),
+				eventingtesting.WithConfigValue(v1alpha2.WebhookAuthClientID, "id"),
+				eventingtesting.WithConfigValue(v1alpha2.WebhookAuthTokenURL, "not-a-url"),
+				eventingtesting.WithSink(sink),
+			),
+			wantErr: kerrors.NewInvalid(
+				v1alpha2.GroupKind, subName,
+				field.ErrorList{
+					v1alpha2.MakeInvalidFieldError(v1alpha2.ConfigPath,
+						subName, v1alpha2.MissingWebhookAuthErrDetail+v1alpha2.WebhookAuthClientSecret),
+					v1alpha2.MakeInvalidFieldError(v1alpha2.ConfigPath,
+						subName, v1alpha2.InvalidTokenURLErrDetail),
+				}),
+		},
+		{
+			name: "webhook auth with a Secret should not return error",
+			givenSub: eventingtesting.NewSubscription(subName, subNamespace,
+				eventingtesting.WithTypeMatchingStandard(),
+				eventingtesting.WithSource(eventingtesting.EventSourceClean),
+				eventingtesting.WithEventType(eventingtesting.OrderCreatedV1Event),
+				eventingtesting.WithMaxInFlightMessages(v1alpha2.DefaultMaxInFlightMessages),
+				eventingtesting.WithConfigValue(v1alpha2.WebhookAuthType, "hmac"),
+				eventingtesting.WithConfigValue(v1alpha2.WebhookAuthSecretName, "sink-credentials"),
+				eventingtesting.WithSink(sink),
+			),
+			wantErr: nil,
+		},
+		{
+			name: "webhook auth with a Secret without the Secret name should return error",
+			givenSub: eventingtesting.NewSubscription(subName, subNamespace,
+				eventingtesting.WithTypeMatchingStandard(),
+				eventingtesting.WithSource(eventingtesting.EventSourceClean),
+				eventingtesting.WithEventType(eventingtesting.OrderCreatedV1Event),
+				eventingtesting.WithMaxInFlightMessages(v1alpha2.DefaultMaxInFlightMessages),
+				eventingtesting.WithConfigValue(v1alpha2.WebhookAuthType, "bearer"),
+				eventingtesting.WithSink(sink),
+			),
+			wantErr: kerrors.NewInvalid(
+				v1alpha2.GroupKind, subName,
+				field.ErrorList{v1alpha2.MakeInvalidFieldError(v1alpha2.ConfigPath,
+					subName, v1alpha2.MissingWebhookAuthErrDetail+v1alpha2.WebhookAuthSecretName)}),
+		},
+		{
+			name: "valid transformation should not return error",
+			givenSub: eventingtesting.NewSubscription(subName, subNamespace,
+				eventingtesting.WithTypeMatchingStandard(),
+				eventingtesting.WithSource(eventingtesting.EventSourceClean),
+				eventingtesting.WithEventType(eventingtesting.OrderCreatedV1Event),
+				eventingtesting.WithMaxInFlightMessages(v1alpha2.DefaultMaxInFlightMessages),
+				eventingtesting.WithTransformation(&v1alpha2.Transformation{
+					Projection:       map[string]string{"orderId": ".order.id", "total": "{.amount.total}"},
+					SetExtensions:    map[string]string{"tenant": "acme"},
+					RemoveExtensions: []string{"traceparent"},
+					Type:             "order.received.v1",
+					Source:           "shop",
+				}),
+				eventingtesting.WithSink(sink),
+			),
+			wantErr: nil,
+		},
+		{
+			name: "invalid transformation should return error",
+			givenSub: eventingtesting.NewSubscription(subName, subNamespace,
+				eventingtesting.WithTypeMatchingStandard(),
+				eventingtesting.WithSource(eventingtesting.EventSourceClean),
+				eventingtesting.WithEventType(eventingtesting.OrderCreatedV1Event),
+				eventingtesting.WithMaxInFlightMessages(v1alpha2.DefaultMaxInFlightMessages),
+				eventingtesting.WithTransformation(&v1alpha2.Transformation{
+					Projection:       map[string]string{"orderId": ".order[", "total": ".amount.total"},
+					SetExtensions:    map[string]string{"Tenant": "acme"},
+					RemoveExtensions: []string{"source"},
+					Source:           "%",
+				}),
+				eventingtesting.WithSink(sink),
+			),
+			wantErr: kerrors.NewInvalid(
+				v1alpha2.GroupKind, subName,
+				field.ErrorList{
+					v1alpha2.MakeInvalidFieldError(v1alpha2.TransformationPath,
+						subName, v1alpha2.InvalidProjectionErrDetail+"orderId"),
+					v1alpha2.MakeInvalidFieldError(v1alpha2.TransformationPath,
+						subName, v1alpha2.InvalidExtensionErrDetail+"Tenant"),
+					v1alpha2.MakeInvalidFieldError(v1alpha2.TransformationPath,
+						subName, v1alpha2.InvalidExtensionErrDetail+"source"),
+					v1alpha2.MakeInvalidFieldError(v1alpha2.TransformationPath,
+						subName, v1alpha2.InvalidTransformedCEErrDetail),
+				}),
+		},
 		{
 			name: "invalid webhook grant type value should return error",
 			givenSub: eventingtesting.NewSubscription(subName, subNamespace,
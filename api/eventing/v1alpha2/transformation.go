@@ -0,0 +1,45 @@
+package v1alpha2
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// Transformation defines how the events are transformed before they are dispatched to the sink.
+type Transformation struct {
+	// Fields of the data to dispatch, by name, as JSONPath expressions on the event data, e.g. `.order.id`.
+	// The event data must be JSON, and it is replaced with an object of the projected fields.
+	// +optional
+	Projection map[string]string `json:"projection,omitempty"`
+
+	// CloudEvent extension attributes to set, by name.
+	// +optional
+	SetExtensions map[string]string `json:"setExtensions,omitempty"`
+
+	// CloudEvent extension attributes to remove.
+	// +optional
+	RemoveExtensions []string `json:"removeExtensions,omitempty"`
+
+	// Type to dispatch the events with, instead of their own type.
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Source to dispatch the events with, instead of their own source.
+	// +optional
+	Source string `json:"source,omitempty"`
+}
+
+// ParseProjectionField parses the JSONPath expression of a projected field, which may omit the enclosing braces.
+func ParseProjectionField(name, expression string) (*jsonpath.JSONPath, error) {
+	expression = strings.TrimSpace(expression)
+	if !strings.HasPrefix(expression, "{") {
+		expression = fmt.Sprintf("{%s}", expression)
+	}
+	parser := jsonpath.New(name).AllowMissingKeys(true)
+	if err := parser.Parse(expression); err != nil {
+		return nil, err
+	}
+	return parser, nil
+}
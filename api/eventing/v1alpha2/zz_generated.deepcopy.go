@@ -71,6 +71,21 @@ func (in *Condition) DeepCopy() *Condition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefaultWebhookAuth) DeepCopyInto(out *DefaultWebhookAuth) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultWebhookAuth.
+func (in *DefaultWebhookAuth) DeepCopy() *DefaultWebhookAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(DefaultWebhookAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EventMeshSubscriptionStatus) DeepCopyInto(out *EventMeshSubscriptionStatus) {
 	*out = *in
@@ -116,6 +131,84 @@ func (in *EventType) DeepCopy() *EventType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventingConfig) DeepCopyInto(out *EventingConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventingConfig.
+func (in *EventingConfig) DeepCopy() *EventingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EventingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EventingConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventingConfigList) DeepCopyInto(out *EventingConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EventingConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventingConfigList.
+func (in *EventingConfigList) DeepCopy() *EventingConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(EventingConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EventingConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventingConfigSpec) DeepCopyInto(out *EventingConfigSpec) {
+	*out = *in
+	if in.SubscriptionDefaults != nil {
+		in, out := &in.SubscriptionDefaults, &out.SubscriptionDefaults
+		*out = new(SubscriptionDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventingConfigSpec.
+func (in *EventingConfigSpec) DeepCopy() *EventingConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EventingConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JetStreamTypes) DeepCopyInto(out *JetStreamTypes) {
 	*out = *in
@@ -158,6 +251,31 @@ func (in *Subscription) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubscriptionDefaults) DeepCopyInto(out *SubscriptionDefaults) {
+	*out = *in
+	if in.MaxInFlightMessages != nil {
+		in, out := &in.MaxInFlightMessages, &out.MaxInFlightMessages
+		*out = new(int)
+		**out = **in
+	}
+	if in.WebhookAuth != nil {
+		in, out := &in.WebhookAuth, &out.WebhookAuth
+		*out = new(DefaultWebhookAuth)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubscriptionDefaults.
+func (in *SubscriptionDefaults) DeepCopy() *SubscriptionDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(SubscriptionDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SubscriptionList) DeepCopyInto(out *SubscriptionList) {
 	*out = *in
@@ -205,6 +323,11 @@ func (in *SubscriptionSpec) DeepCopyInto(out *SubscriptionSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Transformation != nil {
+		in, out := &in.Transformation, &out.Transformation
+		*out = new(Transformation)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubscriptionSpec.
@@ -244,3 +367,37 @@ func (in *SubscriptionStatus) DeepCopy() *SubscriptionStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Transformation) DeepCopyInto(out *Transformation) {
+	*out = *in
+	if in.Projection != nil {
+		in, out := &in.Projection, &out.Projection
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SetExtensions != nil {
+		in, out := &in.SetExtensions, &out.SetExtensions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RemoveExtensions != nil {
+		in, out := &in.RemoveExtensions, &out.RemoveExtensions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Transformation.
+func (in *Transformation) DeepCopy() *Transformation {
+	if in == nil {
+		return nil
+	}
+	out := new(Transformation)
+	in.DeepCopyInto(out)
+	return out
+}
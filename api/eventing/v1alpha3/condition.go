@@ -0,0 +1,25 @@
+package v1alpha3
+
+import (
+	kcorev1 "k8s.io/api/core/v1"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ConditionType string
+
+type ConditionReason string
+
+type Condition struct {
+	// Short description of the condition.
+	Type ConditionType `json:"type,omitempty"`
+
+	// Status of the condition. The value is either `True`, `False`, or `Unknown`.
+	Status kcorev1.ConditionStatus `json:"status"`
+
+	// Defines the date of the last condition status change.
+	LastTransitionTime kmetav1.Time `json:"lastTransitionTime,omitempty"`
+	// Defines the reason for the condition status change.
+	Reason ConditionReason `json:"reason,omitempty"`
+	// Provides more details about the condition status change.
+	Message string `json:"message,omitempty"`
+}
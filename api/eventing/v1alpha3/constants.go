@@ -0,0 +1,24 @@
+package v1alpha3
+
+const (
+	TypeMatchingStandard TypeMatching = "standard"
+	TypeMatchingExact    TypeMatching = "exact"
+	TypeMatchingWildcard TypeMatching = "wildcard"
+
+	// quality of service values.
+	QosAtLeastOnce = "AT_LEAST_ONCE"
+	QosAtMostOnce  = "AT_MOST_ONCE"
+
+	// content mode values.
+	ContentModeBinary     = "BINARY"
+	ContentModeStructured = "STRUCTURED"
+	ContentModeRaw        = "RAW"
+
+	// event format values of the structured content mode.
+	EventFormatJSON     = "JSON"
+	EventFormatProtobuf = "PROTOBUF"
+
+	// webhook auth values.
+	WebhookAuthTypeOAuth2                = "oauth2"
+	WebhookAuthGrantTypeClientCredential = "client_credentials"
+)
@@ -0,0 +1,230 @@
+//nolint:lll // this is annotation
+package v1alpha3
+
+import (
+	"encoding/json"
+
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kyma-project/eventing-manager/pkg/env"
+)
+
+type TypeMatching string
+
+// Defines the desired state of the Subscription.
+// +kubebuilder:validation:XValidation:rule="(has(self.typeMatching) && self.typeMatching == 'exact') || size(self.source) > 0", message="source must not be empty"
+type SubscriptionSpec struct {
+	// Unique identifier of the Subscription, read-only.
+	// +optional
+	ID string `json:"id,omitempty"`
+
+	// Kubernetes Service that should be used as a target for the events that match the Subscription.
+	// Must exist in the same Namespace as the Subscription.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="isURL(self)", message="sink must be a valid URL"
+	Sink string `json:"sink"`
+
+	// Defines how types should be handled.<br />
+	// - `standard`: backend-specific logic will be applied to the configured source and types.<br />
+	// - `exact`: no further processing will be applied to the configured source and types.<br />
+	// - `wildcard`: like `standard`, but the types may contain the `*` (one segment) and `>` (trailing segments) wildcards.
+	// +kubebuilder:validation:Enum=standard;exact;wildcard
+	TypeMatching TypeMatching `json:"typeMatching,omitempty"`
+
+	// Defines the origin of the event.
+	Source string `json:"source"`
+
+	// List of event types that will be used for subscribing on the backend.
+	// +kubebuilder:validation:MinItems=1
+	Types []string `json:"types"`
+
+	// Settings for the delivery of events to the sink.
+	// +optional
+	Delivery *DeliverySettings `json:"delivery,omitempty"`
+
+	// Settings for the CloudEvents protocol used by the backend.
+	// +optional
+	Protocol *ProtocolSettings `json:"protocol,omitempty"`
+
+	// Authentication used by the backend when calling the sink.
+	// +optional
+	WebhookAuth *WebhookAuth `json:"webhookAuth,omitempty"`
+
+	// Transformation of the events before they are dispatched to the sink. Used only with NATS as the backend.
+	// +optional
+	Transformation *Transformation `json:"transformation,omitempty"`
+}
+
+// Transformation defines how the events are transformed before they are dispatched to the sink.
+type Transformation struct {
+	// Fields of the data to dispatch, by name, as JSONPath expressions on the event data, e.g. `.order.id`.
+	// The event data must be JSON, and it is replaced with an object of the projected fields.
+	// +optional
+	Projection map[string]string `json:"projection,omitempty"`
+
+	// CloudEvent extension attributes to set, by name.
+	// +optional
+	SetExtensions map[string]string `json:"setExtensions,omitempty"`
+
+	// CloudEvent extension attributes to remove.
+	// +optional
+	RemoveExtensions []string `json:"removeExtensions,omitempty"`
+
+	// Type to dispatch the events with, instead of their own type.
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Source to dispatch the events with, instead of their own source.
+	// +optional
+	Source string `json:"source,omitempty"`
+}
+
+// DeliverySettings defines how events are delivered to the sink.
+type DeliverySettings struct {
+	// Defines how many not-ACKed messages can be in flight simultaneously.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxInFlightMessages *int `json:"maxInFlightMessages,omitempty"`
+
+	// Defines the quality of service for the delivery. Used only with EventMesh as the backend.
+	// +optional
+	// +kubebuilder:validation:Enum=AT_LEAST_ONCE;AT_MOST_ONCE
+	Qos string `json:"qos,omitempty"`
+
+	// Defines if a CloudEvent replied by the sink is published back to the backend. Used only with NATS as the backend.
+	// +optional
+	ReplyEnabled *bool `json:"replyEnabled,omitempty"`
+}
+
+// ProtocolSettings defines the CloudEvents protocol settings.
+// +kubebuilder:validation:XValidation:rule="!has(self.format) || has(self.contentMode) && self.contentMode == 'STRUCTURED'", message="format must be used with the contentMode STRUCTURED"
+type ProtocolSettings struct {
+	// Name of the CloudEvents protocol specification implementation.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Defines the content mode of the delivered events. The value is either `BINARY`, `STRUCTURED`, or `RAW`.
+	// With `RAW`, only the data of the events is delivered, without the CloudEvent attributes.
+	// `RAW` is supported with NATS as the backend only.
+	// +optional
+	// +kubebuilder:validation:Enum=BINARY;STRUCTURED;RAW
+	ContentMode string `json:"contentMode,omitempty"`
+
+	// Defines the event format of the `STRUCTURED` content mode. The value is either `JSON` or `PROTOBUF`.
+	// The `PROTOBUF` format is supported with NATS as the backend only.
+	// +optional
+	// +kubebuilder:validation:Enum=JSON;PROTOBUF
+	Format string `json:"format,omitempty"`
+
+	// Defines if the exempt handshake is used. Used only with EventMesh as the backend.
+	// +optional
+	ExemptHandshake *bool `json:"exemptHandshake,omitempty"`
+}
+
+// WebhookAuth defines the authentication used by the backend when calling the sink.
+// +kubebuilder:validation:XValidation:rule="has(self.type) && self.type != 'oauth2' || has(self.grantType) && has(self.clientId) && has(self.clientSecret) && has(self.tokenUrl)", message="grantType, clientId, clientSecret and tokenUrl are required for oauth2"
+// +kubebuilder:validation:XValidation:rule="!has(self.type) || self.type == 'oauth2' || has(self.secretName)", message="secretName is required for the auth types other than oauth2"
+type WebhookAuth struct {
+	// Defines the authentication type. The types other than oauth2 are supported with NATS as the backend only.
+	// +optional
+	// +kubebuilder:validation:Enum=oauth2;bearer;basic;mtls;hmac
+	Type string `json:"type,omitempty"`
+
+	// Defines the grant type for OAuth2.
+	// +optional
+	// +kubebuilder:validation:Enum=client_credentials
+	GrantType string `json:"grantType,omitempty"`
+
+	// Defines the clientID for OAuth2.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	ClientID string `json:"clientId,omitempty"`
+
+	// Defines the Client Secret for OAuth2.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	ClientSecret string `json:"clientSecret,omitempty"`
+
+	// Defines the token URL for OAuth2.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="isURL(self)", message="tokenUrl must be a valid URL"
+	TokenURL string `json:"tokenUrl,omitempty"`
+
+	// Defines the scope for OAuth2.
+	// +optional
+	Scope []string `json:"scope,omitempty"`
+
+	// Defines the name of the Secret in the namespace of the Subscription, which holds the credentials of the
+	// auth types other than oauth2.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	SecretName string `json:"secretName,omitempty"`
+}
+
+// SubscriptionStatus defines the observed state of Subscription.
+// +kubebuilder:subresource:status
+type SubscriptionStatus struct {
+	// Current state of the Subscription.
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+
+	// Overall readiness of the Subscription.
+	Ready bool `json:"ready"`
+
+	// List of event types after cleanup for use with the configured backend.
+	Types []EventType `json:"types"`
+
+	// Backend-specific status which is applicable to the active backend only.
+	Backend Backend `json:"backend,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Subscription is the Schema for the subscriptions API.
+type Subscription struct {
+	kmetav1.TypeMeta   `json:",inline"`
+	kmetav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SubscriptionSpec   `json:"spec,omitempty"`
+	Status SubscriptionStatus `json:"status,omitempty"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// If the SubscriptionStatus.Types is nil, it will be initialized to an empty slice of EventType.
+// It is needed because the Kubernetes APIServer will reject requests containing null in the JSON payload.
+func (s Subscription) MarshalJSON() ([]byte, error) {
+	// Use type alias to copy the subscription without causing an infinite recursion when calling json.Marshal.
+	type Alias Subscription
+	a := Alias(s)
+	if a.Status.Types == nil {
+		a.Status.Types = []EventType{}
+	}
+	return json.Marshal(a)
+}
+
+// GetMaxInFlightMessages returns the configured maxInFlightMessages or the default value if it is not set.
+func (s *Subscription) GetMaxInFlightMessages(defaults *env.DefaultSubscriptionConfig) int {
+	if s.Spec.Delivery == nil || s.Spec.Delivery.MaxInFlightMessages == nil {
+		return defaults.MaxInFlightMessages
+	}
+	return *s.Spec.Delivery.MaxInFlightMessages
+}
+
+// +kubebuilder:object:root=true
+
+// SubscriptionList contains a list of Subscription.
+type SubscriptionList struct {
+	kmetav1.TypeMeta `json:",inline"`
+	kmetav1.ListMeta `json:"metadata,omitempty"`
+	Items            []Subscription `json:"items"`
+}
+
+func init() { //nolint:gochecknoinits
+	SchemeBuilder.Register(&Subscription{}, &SubscriptionList{})
+}
+
+// Hub marks this type as a conversion hub.
+func (*Subscription) Hub() {}
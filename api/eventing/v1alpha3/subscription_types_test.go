@@ -0,0 +1,52 @@
+package v1alpha3_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+
+	"github.com/kyma-project/eventing-manager/api/eventing/v1alpha3"
+	"github.com/kyma-project/eventing-manager/pkg/env"
+)
+
+func TestGetMaxInFlightMessages(t *testing.T) {
+	defaultSubConfig := env.DefaultSubscriptionConfig{MaxInFlightMessages: 5}
+	testCases := []struct {
+		name              string
+		givenSubscription *v1alpha3.Subscription
+		wantResult        int
+	}{
+		{
+			name:              "function should give the default MaxInFlight if the delivery settings are missing",
+			givenSubscription: &v1alpha3.Subscription{},
+			wantResult:        defaultSubConfig.MaxInFlightMessages,
+		},
+		{
+			name: "function should give the default MaxInFlight if it is missing in the delivery settings",
+			givenSubscription: &v1alpha3.Subscription{
+				Spec: v1alpha3.SubscriptionSpec{
+					Delivery: &v1alpha3.DeliverySettings{Qos: v1alpha3.QosAtLeastOnce},
+				},
+			},
+			wantResult: defaultSubConfig.MaxInFlightMessages,
+		},
+		{
+			name: "function should give the expected MaxInFlight",
+			givenSubscription: &v1alpha3.Subscription{
+				Spec: v1alpha3.SubscriptionSpec{
+					Delivery: &v1alpha3.DeliverySettings{MaxInFlightMessages: ptr.To(20)},
+				},
+			},
+			wantResult: 20,
+		},
+	}
+
+	for _, tc := range testCases {
+		testcase := tc
+		t.Run(testcase.name, func(t *testing.T) {
+			result := testcase.givenSubscription.GetMaxInFlightMessages(&defaultSubConfig)
+			assert.Equal(t, testcase.wantResult, result)
+		})
+	}
+}
@@ -38,6 +38,15 @@ const (
 	ConditionWebhookReady             ConditionType = "WebhookReady"
 	ConditionSubscriptionManagerReady ConditionType = "SubscriptionManagerReady"
 	ConditionDeleted                  ConditionType = "Deleted"
+	ConditionCredentialsRotated       ConditionType = "CredentialsRotated"
+
+	// backend health check conditions.
+	ConditionJetStreamAccountAvailable ConditionType = "JetStreamAccountAvailable"
+	ConditionJetStreamStreamAvailable  ConditionType = "JetStreamStreamAvailable"
+	ConditionEventMeshTokenAvailable   ConditionType = "EventMeshTokenAvailable"
+	ConditionEventMeshAPIAvailable     ConditionType = "EventMeshAPIAvailable"
+
+	ConditionDeliveryProbeSucceeded ConditionType = "DeliveryProbeSucceeded"
 
 	// common reasons.
 	ConditionReasonProcessing ConditionReason = "Processing"
@@ -52,6 +61,7 @@ const (
 	ConditionReasonNATSNotAvailable           ConditionReason = "NATSUnavailable"
 	ConditionReasonBackendNotSpecified        ConditionReason = "BackendNotSpecified"
 	ConditionReasonForbidden                  ConditionReason = "Forbidden"
+	ConditionReasonInstanceConflict           ConditionReason = "InstanceConflict"
 	ConditionReasonWebhookFailed              ConditionReason = "WebhookFailed"
 	ConditionReasonWebhookReady               ConditionReason = "Ready"
 	ConditionReasonDeletionError              ConditionReason = "DeletionError"
@@ -65,21 +75,42 @@ const (
 	ConditionSubscriptionManagerReadyMessage   = "Subscription manager is ready"
 	ConditionSubscriptionManagerStoppedMessage = "Subscription manager is stopped"
 	ConditionBackendNotSpecifiedMessage        = "Backend config is not provided. Please specify a backend."
+	ConditionCredentialsRotatedMessage         = "EventMesh credentials are rotated without recreating the subscriptions"
+	ConditionDeliveryProbePendingMessage       = "Waiting for the first canary event to be delivered"
 
 	// subscription manager reasons.
 	ConditionReasonEventMeshSubManagerReady      ConditionReason = "EventMeshSubscriptionManagerReady"
 	ConditionReasonEventMeshSubManagerFailed     ConditionReason = "EventMeshSubscriptionManagerFailed"
 	ConditionReasonEventMeshSubManagerStopFailed ConditionReason = "EventMeshSubscriptionManagerStopFailed"
+
+	// credentials rotation reasons.
+	ConditionReasonCredentialsRotated        ConditionReason = "CredentialsRotated"
+	ConditionReasonCredentialsRotationFailed ConditionReason = "CredentialsRotationFailed"
+
+	// backend health check reasons.
+	ConditionReasonHealthCheckSucceeded ConditionReason = "HealthCheckSucceeded"
+	ConditionReasonHealthCheckFailed    ConditionReason = "HealthCheckFailed"
+
+	// delivery probe reasons.
+	ConditionReasonDeliveryProbeSucceeded ConditionReason = "DeliveryProbeSucceeded"
+	ConditionReasonDeliveryProbeFailed    ConditionReason = "DeliveryProbeFailed"
+	ConditionReasonDeliveryProbePending   ConditionReason = "DeliveryProbePending"
 )
 
 // getSupportedConditionsTypes returns a map of supported condition types.
 func getSupportedConditionsTypes() map[ConditionType]interface{} {
 	return map[ConditionType]interface{}{
-		ConditionBackendAvailable:         nil,
-		ConditionPublisherProxyReady:      nil,
-		ConditionWebhookReady:             nil,
-		ConditionSubscriptionManagerReady: nil,
-		ConditionDeleted:                  nil,
+		ConditionBackendAvailable:          nil,
+		ConditionPublisherProxyReady:       nil,
+		ConditionWebhookReady:              nil,
+		ConditionSubscriptionManagerReady:  nil,
+		ConditionDeleted:                   nil,
+		ConditionCredentialsRotated:        nil,
+		ConditionJetStreamAccountAvailable: nil,
+		ConditionJetStreamStreamAvailable:  nil,
+		ConditionEventMeshTokenAvailable:   nil,
+		ConditionEventMeshAPIAvailable:     nil,
+		ConditionDeliveryProbeSucceeded:    nil,
 	}
 }
 
@@ -112,6 +143,8 @@ type EventingStatus struct {
 	State            string              `json:"state"`
 	PublisherService string              `json:"publisherService,omitempty"`
 	Conditions       []kmetav1.Condition `json:"conditions,omitempty"`
+	// Domain is the cluster public domain used by the EventMesh backend, either configured or discovered.
+	Domain string `json:"domain,omitempty"`
 }
 
 // EventingSpec defines the desired state of Eventing.
@@ -133,6 +166,27 @@ type EventingSpec struct {
 
 	// Labels allows to add Labels to resources.
 	Labels map[string]string `json:"labels,omitempty"`
+
+	// NamespaceSelector selects the namespaces whose Subscriptions are owned by this Eventing instance.
+	// It must be set for every Eventing CR except the default one, which owns the Subscriptions of all the namespaces
+	// not selected by another instance. The default Eventing CR owns only the selected namespaces if it is set.
+	// +optional
+	NamespaceSelector *kmetav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// DeliveryProbe enables the periodic delivery of canary events through eventing-publisher-proxy and the active
+	// backend to a Subscription served by eventing-manager. It is only supported for the default Eventing CR.
+	// +optional
+	DeliveryProbe *DeliveryProbe `json:"deliveryProbe,omitempty"`
+
+	// Tracing enables the export of the spans of the event dispatching to an OpenTelemetry collector. It is only
+	// supported for the default Eventing CR.
+	// +optional
+	Tracing *Tracing `json:"tracing,omitempty"`
+
+	// SubscriptionLimits caps the Subscription defaults set by the EventingConfigs of the namespaces owned by this
+	// Eventing instance.
+	// +optional
+	SubscriptionLimits *SubscriptionLimits `json:"subscriptionLimits,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -182,6 +236,29 @@ type BackendConfig struct {
 	// +kubebuilder:default:=1000000
 	NATSMaxMsgsPerTopic int `json:"natsMaxMsgsPerTopic,omitempty"`
 
+	// NATSStreamDuplicatesWindow defines the time window in which the stream drops messages with an already stored
+	// Nats-Msg-Id header. The events are published with the header set to their CloudEvent id.
+	// +kubebuilder:default:="2m"
+	NATSStreamDuplicatesWindow kmetav1.Duration `json:"natsStreamDuplicatesWindow,omitempty"`
+
+	// NATSIdempotencyCacheSize defines how many IDs of successfully dispatched events are cached per Subscription
+	// to skip their redelivery. The cache is disabled if set to 0.
+	// +kubebuilder:default:=0
+	// +kubebuilder:validation:Minimum=0
+	NATSIdempotencyCacheSize int `json:"natsIdempotencyCacheSize,omitempty"`
+
+	// NATSIdempotencyCacheTTL defines how long the ID of a successfully dispatched event is cached.
+	// +kubebuilder:default:="10m"
+	NATSIdempotencyCacheTTL kmetav1.Duration `json:"natsIdempotencyCacheTTL,omitempty"`
+
+	// NATSCredentialsSecret defines the name of the K8s Secret containing the NATS user credentials of Eventing.
+	// The Secret must be in the namespace of the Eventing CR. Eventing connects anonymously to NATS if it is not set.
+	// The keys "manager.creds" and "publisher.creds" contain a NATS credentials file with the user JWT and nkey seed.
+	// Alternatively, the keys "manager.user", "manager.password", "publisher.user", and "publisher.password"
+	// contain the user and password.
+	// +kubebuilder:validation:Pattern:="^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$"
+	NATSCredentialsSecret string `json:"natsCredentialsSecret,omitempty"`
+
 	// EventMeshSecret defines the namespaced name of K8s Secret containing EventMesh credentials. The format of name is "namespace/name".
 	// +kubebuilder:validation:Pattern:="^[a-zA-Z0-9_-]+/[a-zA-Z0-9_-]+$"
 	EventMeshSecret string `json:"eventMeshSecret,omitempty"`
@@ -194,6 +271,64 @@ type BackendConfig struct {
 	// and their corresponding ApiRules.
 	// +kubebuilder:validation:Pattern:="^(?:([a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9\\-]{0,61}[a-zA-Z0-9])(\\.([a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9\\-]{0,61}[a-zA-Z0-9]))*)?$"
 	Domain string `json:"domain,omitempty"`
+
+	// DomainDiscovery defines how the cluster public domain is discovered if it is not configured in Domain.
+	// The domain is read from the Gardener ConfigMap "kube-system/shoot-info" by default.
+	// +optional
+	DomainDiscovery *DomainDiscovery `json:"domainDiscovery,omitempty"`
+
+	// EventTypeRewrites defines the rules to rewrite the event types of the Subscriptions before they are cleaned,
+	// e.g. to alias the event types of a renamed application. The first matching rule is applied.
+	// +optional
+	EventTypeRewrites []EventTypeRewrite `json:"eventTypeRewrites,omitempty"`
+}
+
+type DomainDiscoveryStrategy string
+
+const (
+	DomainDiscoveryStrategyShootInfo    DomainDiscoveryStrategy = "ShootInfo"
+	DomainDiscoveryStrategyIstioGateway DomainDiscoveryStrategy = "IstioGateway"
+	DomainDiscoveryStrategyGateway      DomainDiscoveryStrategy = "Gateway"
+	DomainDiscoveryStrategyIngress      DomainDiscoveryStrategy = "Ingress"
+	DomainDiscoveryStrategyService      DomainDiscoveryStrategy = "Service"
+)
+
+// DomainDiscovery defines the resource the cluster public domain is discovered from.
+// +kubebuilder:validation:XValidation:rule="self.strategy == 'ShootInfo' || has(self.resource)", message="resource must be set for the strategy"
+// +kubebuilder:validation:XValidation:rule="self.strategy != 'Service' || has(self.key)", message="key must be set for the Service strategy"
+type DomainDiscovery struct {
+	// Strategy defines the kind of resource the domain is discovered from. The value is one of:
+	// ShootInfo for the Gardener ConfigMap "kube-system/shoot-info",
+	// IstioGateway for the hosts of an Istio Gateway,
+	// Gateway for the listener hostnames of a Kubernetes Gateway API Gateway,
+	// Ingress for the rule hosts of an Ingress,
+	// Service for the DNS name in a label or annotation of a Service.
+	// +kubebuilder:default:="ShootInfo"
+	// +kubebuilder:validation:Enum=ShootInfo;IstioGateway;Gateway;Ingress;Service
+	Strategy DomainDiscoveryStrategy `json:"strategy,omitempty"`
+
+	// Resource defines the namespaced name of the resource the domain is discovered from. The format of name is "namespace/name".
+	// +kubebuilder:validation:Pattern:="^[a-z0-9]([-a-z0-9]*[a-z0-9])?/[a-z0-9]([-a-z0-9.]*[a-z0-9])?$"
+	// +optional
+	Resource string `json:"resource,omitempty"`
+
+	// Key defines the key of the label or annotation of the Service containing the DNS name, e.g.
+	// "external-dns.alpha.kubernetes.io/hostname". It is only used by the Service strategy.
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// EventTypeRewrite defines a rule to rewrite an event type, e.g. "sap.kyma.custom.oldapp.*" to "sap.kyma.custom.newapp.*".
+// +kubebuilder:validation:XValidation:rule="self.from.endsWith('*') == self.to.endsWith('*')", message="from and to must both end with '*' or none of them"
+type EventTypeRewrite struct {
+	// From defines the event type to rewrite, or its prefix if it ends with "*".
+	// +kubebuilder:validation:Pattern:=`^[^*]+\*?$`
+	From string `json:"from"`
+
+	// To defines the event type to rewrite to. If it ends with "*", the remainder of the event type matched
+	// by From is appended to its prefix.
+	// +kubebuilder:validation:Pattern:=`^[^*]+\*?$`
+	To string `json:"to"`
 }
 
 // Publisher defines the configurations for eventing-publisher-proxy.
@@ -220,6 +355,34 @@ type Replicas struct {
 	Max int `json:"max,omitempty"`
 }
 
+// DeliveryProbe defines the configurations of the synthetic probe of the end-to-end event delivery.
+type DeliveryProbe struct {
+	// Interval defines the interval at which the canary events are published.
+	// +kubebuilder:default:="1m"
+	Interval kmetav1.Duration `json:"interval,omitempty"`
+
+	// Timeout defines the duration within which a canary event must be delivered.
+	// +kubebuilder:default:="10s"
+	Timeout kmetav1.Duration `json:"timeout,omitempty"`
+}
+
+// Tracing defines the export of the spans of the event dispatching.
+type Tracing struct {
+	// Endpoint defines the URL of the OTLP/HTTP endpoint the spans are exported to, for example,
+	// `http://telemetry-otlp-traces.kyma-system:4318`. The path defaults to `/v1/traces`.
+	// +kubebuilder:validation:XValidation:rule="self.startsWith('http://') || self.startsWith('https://')", message="endpoint must be an http or https URL"
+	Endpoint string `json:"endpoint"`
+}
+
+// SubscriptionLimits defines the limits of the Subscription defaults of the namespaces.
+type SubscriptionLimits struct {
+	// MaxInFlightMessages defines the maximum of the default `maxInFlightMessages` of a namespace. It does not cap
+	// the value set by a Subscription itself.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxInFlightMessages *int `json:"maxInFlightMessages,omitempty"`
+}
+
 type Logging struct {
 	// LogLevel defines the log level.
 	// +kubebuilder:default:=Info
@@ -100,11 +100,17 @@ func TestIsSpecBackendTypeChanged(t *testing.T) {
 
 func Test_getSupportedConditionsTypes(t *testing.T) {
 	want := map[ConditionType]interface{}{
-		ConditionBackendAvailable:         nil,
-		ConditionPublisherProxyReady:      nil,
-		ConditionWebhookReady:             nil,
-		ConditionSubscriptionManagerReady: nil,
-		ConditionDeleted:                  nil,
+		ConditionBackendAvailable:          nil,
+		ConditionPublisherProxyReady:       nil,
+		ConditionWebhookReady:              nil,
+		ConditionSubscriptionManagerReady:  nil,
+		ConditionDeleted:                   nil,
+		ConditionCredentialsRotated:        nil,
+		ConditionJetStreamAccountAvailable: nil,
+		ConditionJetStreamStreamAvailable:  nil,
+		ConditionEventMeshTokenAvailable:   nil,
+		ConditionEventMeshAPIAvailable:     nil,
+		ConditionDeliveryProbeSucceeded:    nil,
 	}
 	got := getSupportedConditionsTypes()
 	require.Equal(t, want, got)
@@ -74,6 +74,54 @@ func (es *EventingStatus) UpdateConditionDeletion(status kmetav1.ConditionStatus
 	meta.SetStatusCondition(&es.Conditions, condition)
 }
 
+func (es *EventingStatus) UpdateConditionCredentialsRotated(status kmetav1.ConditionStatus, reason ConditionReason,
+	message string,
+) {
+	condition := kmetav1.Condition{
+		Type:               string(ConditionCredentialsRotated),
+		Status:             status,
+		LastTransitionTime: kmetav1.Now(),
+		Reason:             string(reason),
+		Message:            message,
+	}
+	meta.SetStatusCondition(&es.Conditions, condition)
+}
+
+// UpdateConditionHealthCheck sets the condition of a backend health check, it is false if the check failed.
+func (es *EventingStatus) UpdateConditionHealthCheck(conditionType ConditionType, checkName string, err error) {
+	condition := kmetav1.Condition{
+		Type:               string(conditionType),
+		Status:             kmetav1.ConditionTrue,
+		LastTransitionTime: kmetav1.Now(),
+		Reason:             string(ConditionReasonHealthCheckSucceeded),
+		Message:            fmt.Sprintf("Health check %s succeeded", checkName),
+	}
+	if err != nil {
+		condition.Status = kmetav1.ConditionFalse
+		condition.Reason = string(ConditionReasonHealthCheckFailed)
+		condition.Message = fmt.Sprintf("Health check %s failed: %v", checkName, err)
+	}
+	meta.SetStatusCondition(&es.Conditions, condition)
+}
+
+func (es *EventingStatus) UpdateConditionDeliveryProbeSucceeded(status kmetav1.ConditionStatus, reason ConditionReason,
+	message string,
+) {
+	condition := kmetav1.Condition{
+		Type:               string(ConditionDeliveryProbeSucceeded),
+		Status:             status,
+		LastTransitionTime: kmetav1.Now(),
+		Reason:             string(reason),
+		Message:            message,
+	}
+	meta.SetStatusCondition(&es.Conditions, condition)
+}
+
+// RemoveConditionDeliveryProbeSucceeded removes the condition of the delivery probe, e.g. once it is disabled.
+func (es *EventingStatus) RemoveConditionDeliveryProbeSucceeded() {
+	meta.RemoveStatusCondition(&es.Conditions, string(ConditionDeliveryProbeSucceeded))
+}
+
 func (es *EventingStatus) SetSubscriptionManagerReadyConditionToTrue() {
 	es.UpdateConditionSubscriptionManagerReady(kmetav1.ConditionTrue, ConditionReasonEventMeshSubManagerReady,
 		ConditionSubscriptionManagerReadyMessage)
@@ -98,6 +146,15 @@ func (es *EventingStatus) SetSubscriptionManagerReadyConditionToFalse(reason Con
 		message)
 }
 
+func (es *EventingStatus) SetCredentialsRotatedConditionToTrue() {
+	es.UpdateConditionCredentialsRotated(kmetav1.ConditionTrue, ConditionReasonCredentialsRotated,
+		ConditionCredentialsRotatedMessage)
+}
+
+func (es *EventingStatus) SetCredentialsRotatedConditionToFalse(message string) {
+	es.UpdateConditionCredentialsRotated(kmetav1.ConditionFalse, ConditionReasonCredentialsRotationFailed, message)
+}
+
 func (es *EventingStatus) SetPublisherProxyConditionToFalse(reason ConditionReason, message string) {
 	es.UpdateConditionPublisherProxyReady(kmetav1.ConditionFalse, reason,
 		message)
@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -283,3 +284,23 @@ func TestRemoveUnsupportedConditions(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateConditionHealthCheck(t *testing.T) {
+	t.Parallel()
+
+	// given
+	givenEventingStatus := &EventingStatus{}
+
+	// when
+	givenEventingStatus.UpdateConditionHealthCheck(ConditionJetStreamAccountAvailable, "jetstream-account", nil)
+	givenEventingStatus.UpdateConditionHealthCheck(ConditionJetStreamStreamAvailable, "jetstream-stream",
+		errors.New("stream not found"))
+
+	// then
+	require.Len(t, givenEventingStatus.Conditions, 2)
+	require.Equal(t, kmetav1.ConditionTrue, givenEventingStatus.Conditions[0].Status)
+	require.Equal(t, string(ConditionReasonHealthCheckSucceeded), givenEventingStatus.Conditions[0].Reason)
+	require.Equal(t, kmetav1.ConditionFalse, givenEventingStatus.Conditions[1].Status)
+	require.Equal(t, string(ConditionReasonHealthCheckFailed), givenEventingStatus.Conditions[1].Reason)
+	require.Equal(t, "Health check jetstream-stream failed: stream not found", givenEventingStatus.Conditions[1].Message)
+}
@@ -46,6 +46,18 @@ func (in *Backend) DeepCopy() *Backend {
 func (in *BackendConfig) DeepCopyInto(out *BackendConfig) {
 	*out = *in
 	out.NATSStreamMaxSize = in.NATSStreamMaxSize.DeepCopy()
+	out.NATSStreamDuplicatesWindow = in.NATSStreamDuplicatesWindow
+	out.NATSIdempotencyCacheTTL = in.NATSIdempotencyCacheTTL
+	if in.DomainDiscovery != nil {
+		in, out := &in.DomainDiscovery, &out.DomainDiscovery
+		*out = new(DomainDiscovery)
+		**out = **in
+	}
+	if in.EventTypeRewrites != nil {
+		in, out := &in.EventTypeRewrites, &out.EventTypeRewrites
+		*out = make([]EventTypeRewrite, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendConfig.
@@ -58,6 +70,53 @@ func (in *BackendConfig) DeepCopy() *BackendConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeliveryProbe) DeepCopyInto(out *DeliveryProbe) {
+	*out = *in
+	out.Interval = in.Interval
+	out.Timeout = in.Timeout
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeliveryProbe.
+func (in *DeliveryProbe) DeepCopy() *DeliveryProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(DeliveryProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainDiscovery) DeepCopyInto(out *DomainDiscovery) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainDiscovery.
+func (in *DomainDiscovery) DeepCopy() *DomainDiscovery {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainDiscovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventTypeRewrite) DeepCopyInto(out *EventTypeRewrite) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventTypeRewrite.
+func (in *EventTypeRewrite) DeepCopy() *EventTypeRewrite {
+	if in == nil {
+		return nil
+	}
+	out := new(EventTypeRewrite)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Eventing) DeepCopyInto(out *Eventing) {
 	*out = *in
@@ -141,6 +200,26 @@ func (in *EventingSpec) DeepCopyInto(out *EventingSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeliveryProbe != nil {
+		in, out := &in.DeliveryProbe, &out.DeliveryProbe
+		*out = new(DeliveryProbe)
+		**out = **in
+	}
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(Tracing)
+		**out = **in
+	}
+	if in.SubscriptionLimits != nil {
+		in, out := &in.SubscriptionLimits, &out.SubscriptionLimits
+		*out = new(SubscriptionLimits)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventingSpec.
@@ -221,3 +300,38 @@ func (in *Replicas) DeepCopy() *Replicas {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubscriptionLimits) DeepCopyInto(out *SubscriptionLimits) {
+	*out = *in
+	if in.MaxInFlightMessages != nil {
+		in, out := &in.MaxInFlightMessages, &out.MaxInFlightMessages
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubscriptionLimits.
+func (in *SubscriptionLimits) DeepCopy() *SubscriptionLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(SubscriptionLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Tracing) DeepCopyInto(out *Tracing) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tracing.
+func (in *Tracing) DeepCopy() *Tracing {
+	if in == nil {
+		return nil
+	}
+	out := new(Tracing)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,105 @@
+//nolint:lll // this is annotation
+package v1alpha1
+
+import (
+	kapiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ValidationPolicy string
+
+const (
+	// ValidationPolicyDrop acknowledges and drops the non-conforming events.
+	ValidationPolicyDrop ValidationPolicy = "Drop"
+	// ValidationPolicyDeadLetter republishes the non-conforming events to the dead-letter subject.
+	ValidationPolicyDeadLetter ValidationPolicy = "DeadLetter"
+	// ValidationPolicyDeliverWithWarning delivers the non-conforming events with a warning extension.
+	ValidationPolicyDeliverWithWarning ValidationPolicy = "DeliverWithWarning"
+
+	ConditionReady = "Ready"
+
+	ConditionReasonSchemasRegistered = "SchemasRegistered"
+	ConditionReasonInvalidSchema     = "InvalidSchema"
+)
+
+// EventTypeSpec defines the desired state of the EventType.
+type EventTypeSpec struct {
+	// Event type without the version suffix, for example, `order.created`.
+	// +kubebuilder:validation:MinLength=1
+	Type string `json:"type"`
+
+	// Defines the origin of the event. If empty, the schemas apply to events from any source.
+	// +optional
+	Source string `json:"source,omitempty"`
+
+	// List of versions of the event type, each with the JSON Schema of the event data.
+	// +kubebuilder:validation:MinItems=1
+	Versions []EventTypeVersion `json:"versions"`
+
+	// Defines how events that do not conform to the schema are handled.<br />
+	// - `Drop`: the event is acknowledged and not delivered.<br />
+	// - `DeadLetter`: the event is published to the dead-letter subject and not delivered.<br />
+	// - `DeliverWithWarning`: the event is delivered with the `schemaviolation` extension.
+	// +optional
+	// +kubebuilder:default=DeliverWithWarning
+	// +kubebuilder:validation:Enum=Drop;DeadLetter;DeliverWithWarning
+	ValidationPolicy ValidationPolicy `json:"validationPolicy,omitempty"`
+}
+
+// EventTypeVersion defines the JSON Schema of one version of the event type.
+type EventTypeVersion struct {
+	// Version of the event type, for example, `v1`.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// JSON Schema of the event data.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Schema kapiextensionsv1.JSON `json:"schema"`
+}
+
+// EventTypeStatus defines the observed state of the EventType.
+type EventTypeStatus struct {
+	// Current state of the EventType.
+	// +optional
+	Conditions []kmetav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Type",type="string",JSONPath=".spec.type"
+// +kubebuilder:printcolumn:name="Policy",type="string",JSONPath=".spec.validationPolicy"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// EventType is the Schema for the eventtypes API.
+// It registers the JSON Schemas used to validate the data of the dispatched events.
+type EventType struct {
+	kmetav1.TypeMeta   `json:",inline"`
+	kmetav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EventTypeSpec   `json:"spec,omitempty"`
+	Status EventTypeStatus `json:"status,omitempty"`
+}
+
+// GetValidationPolicy returns the configured validation policy or DeliverWithWarning if it is not set.
+func (e *EventType) GetValidationPolicy() ValidationPolicy {
+	if e.Spec.ValidationPolicy == "" {
+		return ValidationPolicyDeliverWithWarning
+	}
+	return e.Spec.ValidationPolicy
+}
+
+// +kubebuilder:object:root=true
+
+// EventTypeList contains a list of EventType.
+type EventTypeList struct {
+	kmetav1.TypeMeta `json:",inline"`
+	kmetav1.ListMeta `json:"metadata,omitempty"`
+	Items            []EventType `json:"items"`
+}
+
+func init() { //nolint:gochecknoinits
+	SchemeBuilder.Register(&EventType{}, &EventTypeList{})
+}
@@ -20,41 +20,57 @@ import (
 	"context"
 	"flag"
 	"log"
+	"net/http"
 	"os"
 	"time"
 
 	"github.com/go-logr/zapr"
 	apigatewayv1beta1 "github.com/kyma-project/api-gateway/apis/gateway/v1beta1"
 	natsio "github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel"
 	kapiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	kapixclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	ktypes "k8s.io/apimachinery/pkg/types"
 	kutilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/dynamic"
 	kkubernetesscheme "k8s.io/client-go/kubernetes/scheme"
 	kctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	eventingv1alpha1 "github.com/kyma-project/eventing-manager/api/eventing/v1alpha1"
 	eventingv1alpha2 "github.com/kyma-project/eventing-manager/api/eventing/v1alpha2"
+	eventingv1alpha3 "github.com/kyma-project/eventing-manager/api/eventing/v1alpha3"
 	operatorv1alpha1 "github.com/kyma-project/eventing-manager/api/operator/v1alpha1"
+	registryv1alpha1 "github.com/kyma-project/eventing-manager/api/registry/v1alpha1"
 	natsconnection "github.com/kyma-project/eventing-manager/internal/connection/nats"
 	controllercache "github.com/kyma-project/eventing-manager/internal/controller/cache"
 	controllerclient "github.com/kyma-project/eventing-manager/internal/controller/client"
 	eventingcontroller "github.com/kyma-project/eventing-manager/internal/controller/operator/eventing"
+	"github.com/kyma-project/eventing-manager/internal/label"
 	"github.com/kyma-project/eventing-manager/options"
 	backendmetrics "github.com/kyma-project/eventing-manager/pkg/backend/metrics"
+	"github.com/kyma-project/eventing-manager/pkg/catalog"
+	"github.com/kyma-project/eventing-manager/pkg/deliveryprobe"
 	"github.com/kyma-project/eventing-manager/pkg/env"
 	"github.com/kyma-project/eventing-manager/pkg/eventing"
+	"github.com/kyma-project/eventing-manager/pkg/eventstore"
+	"github.com/kyma-project/eventing-manager/pkg/health"
 	"github.com/kyma-project/eventing-manager/pkg/istio/peerauthentication"
 	"github.com/kyma-project/eventing-manager/pkg/k8s"
 	"github.com/kyma-project/eventing-manager/pkg/logger"
+	"github.com/kyma-project/eventing-manager/pkg/sharding"
+	"github.com/kyma-project/eventing-manager/pkg/subscriptiondefaults"
 	"github.com/kyma-project/eventing-manager/pkg/subscriptionmanager"
 	"github.com/kyma-project/eventing-manager/pkg/subscriptionmanager/jetstream"
+	"github.com/kyma-project/eventing-manager/pkg/tenancy"
+	"github.com/kyma-project/eventing-manager/pkg/tracing"
+	"github.com/kyma-project/eventing-manager/pkg/webhookcert"
 )
 
 func registerSchemas(scheme *runtime.Scheme) {
@@ -66,6 +82,8 @@ func registerSchemas(scheme *runtime.Scheme) {
 	kutilruntime.Must(jetstream.AddV1Alpha2ToScheme(scheme))
 	kutilruntime.Must(eventingv1alpha1.AddToScheme(scheme))
 	kutilruntime.Must(eventingv1alpha2.AddToScheme(scheme))
+	kutilruntime.Must(eventingv1alpha3.AddToScheme(scheme))
+	kutilruntime.Must(registryv1alpha1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
@@ -110,14 +128,50 @@ func main() { //nolint:funlen // main function needs to initialize many object
 	// setup ctrl manager
 	k8sRestCfg := kctrl.GetConfigOrDie()
 
+	// get backend configs.
+	backendConfig := env.GetBackendConfig()
+
+	// init the client of the components which run before the manager cache is started.
+	directClient, err := client.New(k8sRestCfg, client.Options{Scheme: scheme})
+	if err != nil {
+		setupLog.Error(err, "unable to create the direct client")
+		syncLogger(ctrLogger)
+		os.Exit(1)
+	}
+
+	// init the event store browser, which is served on the webhook server, so that the bearer tokens of the users are
+	// only sent over TLS. It reads the Subscriptions and reviews the access of the users directly, since it is used
+	// for debugging only.
+	eventStore := eventstore.New(directClient, ctrLogger)
+	reviewAuthorizer := eventstore.NewReviewAuthorizer(directClient)
+	eventStoreHandler := eventstore.NewHandler(eventStore, reviewAuthorizer)
+
+	// init the webhook certificate rotator, which provides the serving certificate of the webhook server.
+	webhookCertRotator := webhookcert.NewRotator(directClient, backendConfig, ctrLogger)
+	webhookCertRotator.RegisterMetrics()
+	webhookOptions := webhook.Options{Port: webhookServerPort, TLSOpts: webhookCertRotator.TLSOpts()}
+	webhookServer := webhook.NewServer(webhookOptions)
+	webhookServer.Register(eventstore.PathPrefix, eventStoreHandler)
+
+	// create the registry of the health checks of the active messaging backend. Its results are served on the metrics
+	// server, but not on the readiness endpoint, so that a failing backend does not make the webhooks unavailable.
+	healthRegistry := health.NewRegistry(opts.CheckTimeout, opts.CheckInterval)
+
+	metricsOptions := server.Options{
+		BindAddress: opts.MetricsAddr,
+		ExtraHandlers: map[string]http.Handler{
+			health.PathBackendHealth: healthRegistry,
+		},
+	}
+
 	mgr, err := kctrl.NewManager(k8sRestCfg, kctrl.Options{
 		Scheme:                 scheme,
 		HealthProbeBindAddress: opts.ProbeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       leaderElectionID,
-		WebhookServer:          webhook.NewServer(webhook.Options{Port: webhookServerPort}),
+		WebhookServer:          webhookServer,
 		Cache:                  cache.Options{SyncPeriod: &opts.ReconcilePeriod},
-		Metrics:                server.Options{BindAddress: opts.MetricsAddr},
+		Metrics:                metricsOptions,
 		NewCache:               controllercache.New,
 		NewClient:              controllerclient.New,
 	})
@@ -148,9 +202,6 @@ func main() { //nolint:funlen // main function needs to initialize many object
 	recorder := mgr.GetEventRecorderFor("eventing-manager")
 	ctx := context.Background()
 
-	// get backend configs.
-	backendConfig := env.GetBackendConfig()
-
 	// create eventing manager instance.
 	eventingManager := eventing.NewEventingManager(ctx, k8sClient, kubeClient, backendConfig, ctrLogger, recorder)
 
@@ -158,6 +209,35 @@ func main() { //nolint:funlen // main function needs to initialize many object
 	metricsCollector := backendmetrics.NewCollector()
 	metricsCollector.RegisterMetrics()
 
+	// bootstrap and rotate the webhook certificates.
+	if err = mgr.Add(webhookCertRotator); err != nil {
+		setupLog.Error(err, "unable to set up the webhook certificate rotator")
+		syncLogger(ctrLogger)
+		os.Exit(1)
+	}
+
+	// init the event catalog and serve it.
+	eventCatalog := catalog.New(k8sClient, ctrLogger)
+	if err = mgr.Add(catalog.NewServer(opts.CatalogAddr, eventCatalog, reviewAuthorizer)); err != nil {
+		setupLog.Error(err, "unable to set up the event catalog server")
+		syncLogger(ctrLogger)
+		os.Exit(1)
+	}
+
+	// init the resolver of the Eventing instances owning the Subscriptions of the namespaces.
+	tenancyResolver := tenancy.NewResolver(k8sClient, ktypes.NamespacedName{
+		Name:      backendConfig.EventingCRName,
+		Namespace: backendConfig.EventingCRNamespace,
+	})
+
+	// drain the in-flight deliveries of the JetStream subscription managers when the manager stops.
+	subManagerDrainer := jetstream.NewDrainer()
+	if err = mgr.Add(subManagerDrainer); err != nil {
+		setupLog.Error(err, "unable to set up the drain of the subscription managers")
+		syncLogger(ctrLogger)
+		os.Exit(1)
+	}
+
 	// init subscription manager factory.
 	subManagerFactory := subscriptionmanager.NewFactory(
 		k8sRestCfg,
@@ -165,8 +245,20 @@ func main() { //nolint:funlen // main function needs to initialize many object
 		metricsCollector,
 		opts.ReconcilePeriod,
 		ctrLogger,
+		eventCatalog,
+		eventStore,
+		tenancyResolver,
+		subManagerDrainer,
 	)
 
+	// init the sharded JetStream dispatcher, which runs on all the replicas.
+	if err = addShardedDispatcher(mgr, kubeClient, opts, backendConfig, metricsCollector, tenancyResolver,
+		ctrLogger); err != nil {
+		setupLog.Error(err, "unable to set up the sharded dispatcher")
+		syncLogger(ctrLogger)
+		os.Exit(1)
+	}
+
 	// init NATS connection builder
 	natsConnectionBuilder, err := initNATSConnectionBuilder()
 	if err != nil {
@@ -175,6 +267,44 @@ func main() { //nolint:funlen // main function needs to initialize many object
 		os.Exit(1)
 	}
 
+	// run the health checks of the active messaging backend in the background.
+	if err = mgr.Add(healthRegistry); err != nil {
+		setupLog.Error(err, "unable to set up the backend health checks")
+		syncLogger(ctrLogger)
+		os.Exit(1)
+	}
+
+	// init the delivery prober, which is configured by the Eventing reconciler.
+	deliveryProber, err := deliveryprobe.NewProber(opts.ProbeSinkAddr, ctrLogger)
+	if err != nil {
+		setupLog.Error(err, "unable to create the delivery prober")
+		syncLogger(ctrLogger)
+		os.Exit(1)
+	}
+	deliveryProber.RegisterMetrics()
+	if err = mgr.Add(deliveryProber); err != nil {
+		setupLog.Error(err, "unable to set up the delivery prober")
+		syncLogger(ctrLogger)
+		os.Exit(1)
+	}
+
+	// init the provider of the spans of the event dispatching, which all the replicas configure with the tracing of
+	// the default Eventing CR.
+	tracingProvider := tracing.NewProvider()
+	tracingProvider.SetEndpointSource(eventingcontroller.NewTracingEndpointSource(mgr.GetClient(), client.ObjectKey{
+		Name:      backendConfig.EventingCRName,
+		Namespace: backendConfig.EventingCRNamespace,
+	}))
+	otel.SetTracerProvider(tracingProvider)
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		ctrLogger.WithContext().Named("tracing").Errorw("Failed to trace the event dispatching", "error", err)
+	}))
+	if err = mgr.Add(tracingProvider); err != nil {
+		setupLog.Error(err, "unable to set up the tracing provider")
+		syncLogger(ctrLogger)
+		os.Exit(1)
+	}
+
 	// create Eventing reconciler instance
 	eventingReconciler := eventingcontroller.NewReconciler(
 		k8sClient,
@@ -194,6 +324,8 @@ func main() { //nolint:funlen // main function needs to initialize many object
 			},
 		},
 		natsConnectionBuilder,
+		healthRegistry,
+		deliveryProber,
 	)
 
 	if err = (eventingReconciler).SetupWithManager(mgr); err != nil {
@@ -209,7 +341,8 @@ func main() { //nolint:funlen // main function needs to initialize many object
 		os.Exit(1)
 	}
 
-	if err = (&eventingv1alpha2.Subscription{}).SetupWebhookWithManager(mgr); err != nil {
+	subscriptionDefaulter := subscriptiondefaults.NewDefaulter(k8sClient, tenancyResolver)
+	if err = (&eventingv1alpha2.Subscription{}).SetupWebhookWithManager(mgr, subscriptionDefaulter); err != nil {
 		setupLog.Error(err, "Failed to create webhook")
 		syncLogger(ctrLogger)
 		os.Exit(1)
@@ -243,6 +376,55 @@ func main() { //nolint:funlen // main function needs to initialize many object
 	syncLogger(ctrLogger)
 }
 
+// addShardedDispatcher adds the dispatcher and its shard membership to the manager, if the sharded dispatch is enabled.
+func addShardedDispatcher(mgr kctrl.Manager, kubeClient k8s.Client, opts *options.Options,
+	backendConfig env.BackendConfig, metricsCollector *backendmetrics.Collector, tenancyResolver *tenancy.Resolver,
+	ctrLogger *logger.Logger,
+) error {
+	natsConfig, err := env.GetNATSConfig(opts.MaxReconnects, opts.ReconnectWait)
+	if err != nil {
+		return err
+	}
+	if !natsConfig.JSShardedDispatch {
+		return nil
+	}
+
+	identity := os.Getenv("POD_NAME")
+	if identity == "" {
+		if identity, err = os.Hostname(); err != nil {
+			return err
+		}
+	}
+	membership := sharding.NewLeaseMembership(mgr.GetClient(), mgr.GetAPIReader(),
+		backendConfig.Namespace, label.ValueDispatcher, identity, ctrLogger)
+	if err = mgr.Add(membership); err != nil {
+		return err
+	}
+
+	natsConfigHandler := eventingcontroller.NewNatsConfigHandler(kubeClient, opts)
+	eventingCRKey := client.ObjectKey{Name: backendConfig.EventingCRName, Namespace: backendConfig.EventingCRNamespace}
+	configProvider := func(ctx context.Context) (*env.NATSConfig, error) {
+		eventingCR := &operatorv1alpha1.Eventing{}
+		if err := mgr.GetClient().Get(ctx, eventingCRKey, eventingCR); err != nil {
+			return nil, client.IgnoreNotFound(err)
+		}
+		if eventingCR.Spec.Backend == nil || eventingCR.Spec.Backend.Type != operatorv1alpha1.NatsBackendType {
+			return nil, nil //nolint:nilnil // NATS is not the active backend.
+		}
+		return natsConfigHandler.GetNatsConfig(ctx, *eventingCR)
+	}
+
+	// the Secrets of the sink authentication are read directly, to not cache all the Secrets of the cluster.
+	dispatcher := jetstream.NewDispatcher(mgr.GetClient(), mgr.GetAPIReader(),
+		mgr.GetEventRecorderFor("eventing-controller-jetstream"), membership, configProvider,
+		backendConfig.DefaultSubscriptionConfig, metricsCollector, ctrLogger)
+	// like the subscription manager, the dispatcher dispatches the Subscriptions of its Eventing CR only.
+	dispatcher.SetFilter(tenancyResolver.Filter(&operatorv1alpha1.Eventing{
+		ObjectMeta: kmetav1.ObjectMeta{Name: eventingCRKey.Name, Namespace: eventingCRKey.Namespace},
+	}))
+	return mgr.Add(dispatcher)
+}
+
 func initNATSConnectionBuilder() (natsconnection.Builder, error) {
 	const (
 		// connectionURL is the NATS connection URL.
@@ -25,7 +25,6 @@ const (
 	ManagerContainerName        = "manager"
 	PublisherContainerName      = "eventing-publisher-proxy"
 	WebhookServerCertSecretName = "eventing-manager-webhook-server-cert"
-	WebhookServerCertJobName    = "eventing-manager-cert-handler"
 	EventMeshSecretNamespace    = "kyma-system"
 	EventMeshSecretName         = "eventing-backend"
 	EventOriginalTypeHeader     = "originaltype"
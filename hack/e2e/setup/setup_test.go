@@ -70,62 +70,21 @@ func TestMain(m *testing.M) {
 	os.Exit(code)
 }
 
-// Test_WebhookServerCertSecret tests if the Secret exists.
+// Test_WebhookServerCertSecret tests if the Secret exists and contains the webhook certificates.
 func Test_WebhookServerCertSecret(t *testing.T) {
 	t.Parallel()
 	ctx := context.TODO()
 	err := Retry(testenvironment.Attempts, testenvironment.Interval, func() error {
-		_, getErr := testEnvironment.K8sClientset.CoreV1().Secrets(NamespaceName).Get(ctx, WebhookServerCertSecretName, metav1.GetOptions{})
-		return getErr
-	})
-	require.NoError(t, err)
-}
-
-// Test_WebhookServerCertJob tests if the Job exists.
-func Test_WebhookServerCertJob(t *testing.T) {
-	t.Parallel()
-	ctx := context.TODO()
-	err := Retry(testenvironment.Attempts, testenvironment.Interval, func() error {
-		job, getErr := testEnvironment.K8sClientset.BatchV1().Jobs(NamespaceName).Get(ctx, WebhookServerCertJobName, metav1.GetOptions{})
-		if getErr != nil {
-			return getErr
-		}
-
-		// Check if the PriorityClassName was set correctly.
-		if job.Spec.Template.Spec.PriorityClassName != eventing.PriorityClassName {
-			return fmt.Errorf("Job '%s' was expected to have PriorityClassName '%s' but has '%s'",
-				job.GetName(),
-				eventing.PriorityClassName,
-				job.Spec.Template.Spec.PriorityClassName,
-			)
-		}
-
-		return nil
-	})
-	require.NoError(t, err)
-}
-
-// Test_WebhookServerCertCronJob tests if the CronJob exists.
-func Test_WebhookServerCertCronJob(t *testing.T) {
-	t.Parallel()
-	ctx := context.TODO()
-	err := Retry(testenvironment.Attempts, testenvironment.Interval, func() error {
-		job, getErr := testEnvironment.K8sClientset.BatchV1().CronJobs(NamespaceName).Get(
-			ctx,
-			WebhookServerCertJobName,
-			metav1.GetOptions{},
-		)
+		secret, getErr := testEnvironment.K8sClientset.CoreV1().Secrets(NamespaceName).Get(ctx, WebhookServerCertSecretName, metav1.GetOptions{})
 		if getErr != nil {
 			return getErr
 		}
 
-		// Check if the PriorityClassName was set correctly.
-		if job.Spec.JobTemplate.Spec.Template.Spec.PriorityClassName != eventing.PriorityClassName {
-			return fmt.Errorf("ChronJob '%s' was expected to have PriorityClassName '%s' but has '%s'",
-				job.GetName(),
-				eventing.PriorityClassName,
-				job.Spec.JobTemplate.Spec.Template.Spec.PriorityClassName,
-			)
+		// Check if the certificates were bootstrapped by the eventing-manager.
+		for _, field := range []string{"ca.crt", "tls.crt", "tls.key"} {
+			if len(secret.Data[field]) == 0 {
+				return fmt.Errorf("Secret '%s' was expected to contain '%s'", secret.GetName(), field)
+			}
 		}
 
 		return nil
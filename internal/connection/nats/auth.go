@@ -0,0 +1,35 @@
+package nats
+
+import (
+	"fmt"
+
+	natsio "github.com/nats-io/nats.go"
+	"github.com/nats-io/nkeys"
+)
+
+// AuthOptions returns the options to authenticate a NATS connection as the given user.
+// The userCredentials is the content of a NATS credentials file with the user JWT and nkey seed, and takes
+// precedence over the user and password. No options are returned if neither are given, i.e. for anonymous access.
+func AuthOptions(userCredentials, user, password string) ([]natsio.Option, error) {
+	if len(userCredentials) > 0 {
+		userJWT, err := nkeys.ParseDecoratedJWT([]byte(userCredentials))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the user JWT of the NATS credentials: %w", err)
+		}
+		keyPair, err := nkeys.ParseDecoratedNKey([]byte(userCredentials))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the nkey seed of the NATS credentials: %w", err)
+		}
+		seed, err := keyPair.Seed()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the nkey seed of the NATS credentials: %w", err)
+		}
+		return []natsio.Option{natsio.UserJWTAndSeed(userJWT, string(seed))}, nil
+	}
+
+	if len(user) > 0 {
+		return []natsio.Option{natsio.UserInfo(user, password)}, nil
+	}
+
+	return nil, nil
+}
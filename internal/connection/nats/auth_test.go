@@ -0,0 +1,101 @@
+package nats
+
+import (
+	"fmt"
+	"testing"
+
+	natsio "github.com/nats-io/nats.go"
+	"github.com/nats-io/nkeys"
+	"github.com/stretchr/testify/require"
+)
+
+const userJWT = "eyJ0eXAiOiJKV1QiLCJhbGciOiJlZDI1NTE5LW5rZXkifQ.e30.c2lnbmF0dXJl"
+
+func TestAuthOptions(t *testing.T) {
+	t.Parallel()
+
+	// given
+	keyPair, err := nkeys.CreateUser()
+	require.NoError(t, err)
+	seed, err := keyPair.Seed()
+	require.NoError(t, err)
+	userCredentials := fmt.Sprintf(`-----BEGIN NATS USER JWT-----
+%s
+------END NATS USER JWT------
+
+-----BEGIN USER NKEY SEED-----
+%s
+------END USER NKEY SEED------
+`, userJWT, seed)
+
+	testCases := []struct {
+		name                 string
+		givenUserCredentials string
+		givenUser            string
+		givenPassword        string
+		wantOptions          bool
+		wantUser             string
+		wantPassword         string
+		wantJWT              bool
+		wantErr              bool
+	}{
+		{
+			name:        "should return no options for anonymous access",
+			wantOptions: false,
+		},
+		{
+			name:          "should return the user and password",
+			givenUser:     "user",
+			givenPassword: "password",
+			wantOptions:   true,
+			wantUser:      "user",
+			wantPassword:  "password",
+		},
+		{
+			name:                 "should prefer the user credentials over the user and password",
+			givenUserCredentials: userCredentials,
+			givenUser:            "user",
+			givenPassword:        "password",
+			wantOptions:          true,
+			wantJWT:              true,
+		},
+		{
+			name:                 "should return an error if the user credentials are invalid",
+			givenUserCredentials: "invalid",
+			wantErr:              true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			opts, err := AuthOptions(tc.givenUserCredentials, tc.givenUser, tc.givenPassword)
+
+			// then
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if !tc.wantOptions {
+				require.Empty(t, opts)
+				return
+			}
+			natsOpts := &natsio.Options{}
+			for _, opt := range opts {
+				require.NoError(t, opt(natsOpts))
+			}
+			require.Equal(t, tc.wantUser, natsOpts.User)
+			require.Equal(t, tc.wantPassword, natsOpts.Password)
+			if tc.wantJWT {
+				require.NotNil(t, natsOpts.UserJWT)
+				gotJWT, err := natsOpts.UserJWT()
+				require.NoError(t, err)
+				require.Equal(t, userJWT, gotJWT)
+			}
+		})
+	}
+}
@@ -9,7 +9,8 @@ import (
 )
 
 type Builder interface {
-	Build() Interface
+	// Build returns a connection configured with the builder options followed by the given options.
+	Build(opts ...natsio.Option) Interface
 }
 
 type ConnectionBuilder struct {
@@ -30,11 +31,11 @@ func NewBuilder(url, name string, opts ...natsio.Option) (*ConnectionBuilder, er
 	return &ConnectionBuilder{url: url, opts: opts}, nil
 }
 
-func (b *ConnectionBuilder) Build() Interface {
+func (b *ConnectionBuilder) Build(opts ...natsio.Option) Interface {
 	return &connection{
 		url:                            b.url,
 		conn:                           nil,
-		opts:                           b.opts,
+		opts:                           append(append([]natsio.Option{}, b.opts...), opts...),
 		reconnectHandlerRegistered:     false,
 		disconnectErrHandlerRegistered: false,
 	}
@@ -1,6 +1,8 @@
 package mocks
 
 import (
+	natsio "github.com/nats-io/nats.go"
+
 	natsconnection "github.com/kyma-project/eventing-manager/internal/connection/nats"
 )
 
@@ -12,6 +14,6 @@ func NewBuilder(conn *Connection) *Builder {
 	return &Builder{conn: conn}
 }
 
-func (b *Builder) Build() natsconnection.Interface {
+func (b *Builder) Build(...natsio.Option) natsconnection.Interface {
 	return b.conn
 }
@@ -0,0 +1,114 @@
+package eventtype
+
+import (
+	"context"
+	"reflect"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/meta"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	registryv1alpha1 "github.com/kyma-project/eventing-manager/api/registry/v1alpha1"
+	"github.com/kyma-project/eventing-manager/pkg/backend/schema"
+	"github.com/kyma-project/eventing-manager/pkg/logger"
+)
+
+const (
+	reconcilerName = "eventtype-reconciler"
+)
+
+// Reconciler keeps the schema registry in sync with the EventType resources.
+type Reconciler struct {
+	client.Client
+	registry *schema.Registry
+	logger   *logger.Logger
+}
+
+func NewReconciler(client client.Client, registry *schema.Registry, logger *logger.Logger) *Reconciler {
+	return &Reconciler{
+		Client:   client,
+		registry: registry,
+		logger:   logger,
+	}
+}
+
+// SetupUnmanaged creates a controller under the client control.
+func (r *Reconciler) SetupUnmanaged(ctx context.Context, mgr kctrl.Manager) error {
+	ctru, err := controller.NewUnmanaged(reconcilerName, mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		r.namedLogger().Errorw("Failed to create unmanaged controller", "error", err)
+		return err
+	}
+
+	if err := ctru.Watch(source.Kind(mgr.GetCache(), &registryv1alpha1.EventType{}),
+		&handler.EnqueueRequestForObject{}); err != nil {
+		r.namedLogger().Errorw("Failed to setup watch for event types", "error", err)
+		return err
+	}
+
+	go func(r *Reconciler, c controller.Controller) {
+		if err := c.Start(ctx); err != nil {
+			r.namedLogger().Fatalw("Failed to start controller", "error", err)
+		}
+	}(r, ctru)
+
+	return nil
+}
+
+// +kubebuilder:rbac:groups=registry.kyma-project.io,resources=eventtypes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=registry.kyma-project.io,resources=eventtypes/status,verbs=get;update;patch
+
+func (r *Reconciler) Reconcile(ctx context.Context, req kctrl.Request) (kctrl.Result, error) {
+	r.namedLogger().Debugw("Received event type reconciliation request",
+		"namespace", req.Namespace, "name", req.Name)
+
+	eventType := &registryv1alpha1.EventType{}
+	if err := r.Client.Get(ctx, req.NamespacedName, eventType); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			r.registry.Delete(req.NamespacedName)
+		}
+		return kctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !eventType.DeletionTimestamp.IsZero() {
+		r.registry.Delete(req.NamespacedName)
+		return kctrl.Result{}, nil
+	}
+
+	condition := kmetav1.Condition{
+		Type:               registryv1alpha1.ConditionReady,
+		Status:             kmetav1.ConditionTrue,
+		ObservedGeneration: eventType.Generation,
+		Reason:             registryv1alpha1.ConditionReasonSchemasRegistered,
+		Message:            "Schemas are registered for validation",
+	}
+	if err := r.registry.Set(eventType); err != nil {
+		// a malformed schema cannot be fixed by retrying, so it is only reported in the status.
+		r.namedLogger().Errorw("Failed to register the schemas", "namespace", req.Namespace, "name", req.Name, "error", err)
+		condition.Status = kmetav1.ConditionFalse
+		condition.Reason = registryv1alpha1.ConditionReasonInvalidSchema
+		condition.Message = err.Error()
+	}
+
+	return kctrl.Result{}, r.updateStatus(ctx, eventType, condition)
+}
+
+func (r *Reconciler) updateStatus(ctx context.Context, eventType *registryv1alpha1.EventType,
+	condition kmetav1.Condition,
+) error {
+	desiredEventType := eventType.DeepCopy()
+	meta.SetStatusCondition(&desiredEventType.Status.Conditions, condition)
+	if reflect.DeepEqual(eventType.Status, desiredEventType.Status) {
+		return nil
+	}
+	return r.Client.Status().Update(ctx, desiredEventType)
+}
+
+func (r *Reconciler) namedLogger() *zap.SugaredLogger {
+	return r.logger.WithContext().Named(reconcilerName)
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/url"
 	"reflect"
+	"sync"
 	"time"
 
 	apigatewayv1beta1 "github.com/kyma-project/api-gateway/apis/gateway/v1beta1"
@@ -21,6 +22,7 @@ import (
 	kctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
@@ -37,6 +39,7 @@ import (
 	"github.com/kyma-project/eventing-manager/pkg/env"
 	"github.com/kyma-project/eventing-manager/pkg/logger"
 	"github.com/kyma-project/eventing-manager/pkg/object"
+	"github.com/kyma-project/eventing-manager/pkg/tenancy"
 	"github.com/kyma-project/eventing-manager/pkg/utils"
 )
 
@@ -50,12 +53,15 @@ type Reconciler struct {
 	Backend           eventmesh.Backend
 	Domain            string
 	cleaner           cleaner.Cleaner
+	credentialsMutex  sync.RWMutex
 	oauth2credentials *eventmesh.OAuth2ClientCredentials
 	// nameMapper is used to map the Kyma subscription name to a subscription name on EventMesh.
 	nameMapper                     backendutils.NameMapper
 	sinkValidator                  sink.Validator
 	collector                      *metrics.Collector
 	syncConditionWebhookCallStatus syncConditionWebhookCallStatusFunc
+	customEventsChannel            chan event.GenericEvent
+	filter                         *tenancy.Filter
 }
 
 const (
@@ -91,9 +97,16 @@ func NewReconciler(client client.Client, logger *logger.Logger, recorder record.
 		sinkValidator:                  validator,
 		collector:                      collector,
 		syncConditionWebhookCallStatus: syncConditionWebhookCallStatus,
+		customEventsChannel:            make(chan event.GenericEvent),
 	}
 }
 
+// SetFilter sets the filter of the Subscriptions owned by the Eventing instance of the reconciler.
+// The Subscriptions owned by other instances are skipped, all the Subscriptions are reconciled if it is not set.
+func (r *Reconciler) SetFilter(filter *tenancy.Filter) {
+	r.filter = filter
+}
+
 // +kubebuilder:rbac:groups=eventing.kyma-project.io,resources=subscriptions,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=eventing.kyma-project.io,resources=subscriptions/status,verbs=get;update;patch
 // Generate required RBAC to emit kubernetes events in the controller.
@@ -101,6 +114,11 @@ func NewReconciler(client client.Client, logger *logger.Logger, recorder record.
 // +kubebuilder:rbac:groups=gateway.kyma-project.io,resources=apirules,verbs=get;list;watch;create;update;patch;delete
 
 func (r *Reconciler) Reconcile(ctx context.Context, req kctrl.Request) (kctrl.Result, error) {
+	// skip the subscriptions owned by another Eventing instance.
+	if owned, err := r.filter.Owns(ctx, req.Namespace); err != nil || !owned {
+		return kctrl.Result{}, err
+	}
+
 	// fetch current subscription object and ensure the object was not deleted in the meantime
 	currentSubscription := &eventingv1alpha2.Subscription{}
 	if err := r.Client.Get(ctx, req.NamespacedName, currentSubscription); err != nil {
@@ -530,7 +548,7 @@ func (r *Reconciler) handlePreviousAPIRule(ctx context.Context, subscription *ev
 		// update the APIRule OwnerReferences list and Spec Rules
 		object.WithOwnerReference(subscriptions)(previousAPIRule)
 		object.WithRules(
-			r.oauth2credentials.CertsURL,
+			r.getCredentials().CertsURL,
 			subscriptions,
 			*previousAPIRule.Spec.Service,
 			http.MethodPost,
@@ -613,7 +631,7 @@ func (r *Reconciler) makeAPIRule(svcNs, svcName string, labels map[string]string
 		object.WithOwnerReference(subs),
 		object.WithService(hostName, svcName, port),
 		object.WithGateway(constants.ClusterLocalAPIGateway),
-		object.WithRules(r.oauth2credentials.CertsURL, subs, svc, http.MethodPost, http.MethodOptions))
+		object.WithRules(r.getCredentials().CertsURL, subs, svc, http.MethodPost, http.MethodOptions))
 	return apiRule
 }
 
@@ -754,6 +772,11 @@ func (r *Reconciler) SetupUnmanaged(ctx context.Context, mgr kctrl.Manager) erro
 		return fmt.Errorf("failed to watch APIRule: %w", err)
 	}
 
+	if err := ctru.Watch(&source.Channel{Source: r.customEventsChannel},
+		&handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("failed to watch custom channel: %w", err)
+	}
+
 	go func(r *Reconciler, c controller.Controller) {
 		if err := c.Start(ctx); err != nil {
 			r.namedLogger().Fatalw("Failed to start controller",
@@ -829,9 +852,29 @@ func (r *Reconciler) namedLogger() *zap.SugaredLogger {
 	return r.logger.WithContext().Named(reconcilerName)
 }
 
-// SetCredentials sets the WebhookAuth credentials.
-// WARNING: This functions should be used for testing purposes only.
+// SetCredentials sets the WebhookAuth credentials, which are used in the rules of the APIRules.
 func (r *Reconciler) SetCredentials(credentials *eventmesh.OAuth2ClientCredentials) {
-	r.namedLogger().Warn("This logic should be used for testing purposes only")
+	r.credentialsMutex.Lock()
+	defer r.credentialsMutex.Unlock()
 	r.oauth2credentials = credentials
 }
+
+func (r *Reconciler) getCredentials() *eventmesh.OAuth2ClientCredentials {
+	r.credentialsMutex.RLock()
+	defer r.credentialsMutex.RUnlock()
+	return r.oauth2credentials
+}
+
+// EnqueueReconciliationForAllSubscriptions adds all the subscriptions to the customEventsChannel which is being
+// watched by the controller, e.g. to update them on EventMesh after the credentials are rotated.
+func (r *Reconciler) EnqueueReconciliationForAllSubscriptions(ctx context.Context) error {
+	var subs eventingv1alpha2.SubscriptionList
+	if err := r.Client.List(ctx, &subs); err != nil {
+		return err
+	}
+	r.namedLogger().Debug("Enqueuing reconciliation request for all subscriptions")
+	for i := range subs.Items {
+		r.customEventsChannel <- event.GenericEvent{Object: &subs.Items[i]}
+	}
+	return nil
+}
@@ -199,7 +199,7 @@ func setupEventMesh(defaultLogger *logger.Logger) (*backendeventmesh.EventMesh,
 }
 
 func startAndWaitForWebhookServer(k8sManager manager.Manager, webhookInstallOpts *envtest.WebhookInstallOptions) error {
-	if err := (&eventingv1alpha2.Subscription{}).SetupWebhookWithManager(k8sManager); err != nil {
+	if err := (&eventingv1alpha2.Subscription{}).SetupWebhookWithManager(k8sManager, nil); err != nil {
 		return err
 	}
 	dialer := &net.Dialer{Timeout: time.Second}
@@ -183,7 +183,7 @@ func setupManager(cfg *rest.Config) (manager.Manager, *envtest.WebhookInstallOpt
 }
 
 func startAndWaitForWebhookServer(manager manager.Manager, installOpts *envtest.WebhookInstallOptions) error {
-	if err := (&eventingv1alpha2.Subscription{}).SetupWebhookWithManager(manager); err != nil {
+	if err := (&eventingv1alpha2.Subscription{}).SetupWebhookWithManager(manager, nil); err != nil {
 		return err
 	}
 	dialer := &net.Dialer{Timeout: time.Second}
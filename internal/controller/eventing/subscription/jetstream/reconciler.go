@@ -27,6 +27,7 @@ import (
 	"github.com/kyma-project/eventing-manager/pkg/errors"
 	"github.com/kyma-project/eventing-manager/pkg/logger"
 	"github.com/kyma-project/eventing-manager/pkg/object"
+	"github.com/kyma-project/eventing-manager/pkg/tenancy"
 	"github.com/kyma-project/eventing-manager/pkg/utils"
 )
 
@@ -34,6 +35,10 @@ const (
 	reconcilerName  = "jetstream-subscription-reconciler"
 	requeueDuration = 10 * time.Second
 	backendType     = "NATS_Jetstream"
+	// sinkAuthResyncPeriod is the period in which the subscriptions with a webhook auth Secret are reconciled again,
+	// so that a changed Secret is applied. The Secrets are read directly instead of being watched, to not cache all
+	// the Secrets of the cluster.
+	sinkAuthResyncPeriod = 30 * time.Second
 )
 
 type Reconciler struct {
@@ -45,6 +50,8 @@ type Reconciler struct {
 	sinkValidator       sink.Validator
 	customEventsChannel chan event.GenericEvent
 	collector           *metrics.Collector
+	filter              *tenancy.Filter
+	deliveryHealth      *jetstream.DeliveryHealthTracker
 }
 
 func NewReconciler(client client.Client, jsBackend jetstream.Backend,
@@ -64,6 +71,18 @@ func NewReconciler(client client.Client, jsBackend jetstream.Backend,
 	return reconciler
 }
 
+// SetFilter sets the filter of the Subscriptions owned by the Eventing instance of the reconciler.
+// The Subscriptions owned by other instances are skipped, all the Subscriptions are reconciled if it is not set.
+func (r *Reconciler) SetFilter(filter *tenancy.Filter) {
+	r.filter = filter
+}
+
+// SetDeliveryHealthTracker sets the tracker of the delivery results, which the DeliveryHealthy condition of the
+// Subscriptions is computed from. The Subscriptions have no DeliveryHealthy condition without a tracker.
+func (r *Reconciler) SetDeliveryHealthTracker(tracker *jetstream.DeliveryHealthTracker) {
+	r.deliveryHealth = tracker
+}
+
 // SetupUnmanaged creates a controller under the client control.
 func (r *Reconciler) SetupUnmanaged(ctx context.Context, mgr kctrl.Manager) error {
 	ctru, err := controller.NewUnmanaged(reconcilerName, mgr, controller.Options{Reconciler: r})
@@ -103,6 +122,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, req kctrl.Request) (kctrl.Re
 	r.namedLogger().Debugw("Received subscription v1alpha2 reconciliation request",
 		"namespace", req.Namespace, "name", req.Name)
 
+	// skip the subscriptions owned by another Eventing instance.
+	if owned, err := r.filter.Owns(ctx, req.Namespace); err != nil || !owned {
+		return kctrl.Result{}, err
+	}
+
 	// fetch current subscription object and ensure the object was not deleted in the meantime
 	currentSubscription := &eventingv1alpha2.Subscription{}
 	err := r.Client.Get(ctx, req.NamespacedName, currentSubscription)
@@ -174,7 +198,19 @@ func (r *Reconciler) Reconcile(ctx context.Context, req kctrl.Request) (kctrl.Re
 	}
 
 	// Update Subscription status
-	return kctrl.Result{}, r.syncSubscriptionStatus(ctx, desiredSubscription, nil, log)
+	if err := r.syncSubscriptionStatus(ctx, desiredSubscription, nil, log); err != nil {
+		return kctrl.Result{}, err
+	}
+	return sinkAuthResyncResult(desiredSubscription), nil
+}
+
+// sinkAuthResyncResult returns the result which reconciles the subscription again after sinkAuthResyncPeriod if it
+// has a webhook auth Secret, so that the backend recreates its sink client once the credentials of the Secret changed.
+func sinkAuthResyncResult(subscription *eventingv1alpha2.Subscription) kctrl.Result {
+	if subscription.Spec.Config[eventingv1alpha2.WebhookAuthSecretName] == "" {
+		return kctrl.Result{}
+	}
+	return kctrl.Result{RequeueAfter: sinkAuthResyncPeriod}
 }
 
 func (r *Reconciler) updateSubscriptionMetrics(current, desired *eventingv1alpha2.Subscription) {
@@ -220,6 +256,31 @@ func (r *Reconciler) HandleNatsConnClose(_ *nats.Conn) {
 	r.enqueueReconciliationForSubscriptions(subs.Items)
 }
 
+// HandleDeliveryHealth is called by the delivery health tracker when the deliveries of a subscription fail or when
+// their health changes. It records a warning event on the subscription for the failure, and reconciles the
+// subscription to update its DeliveryHealthy condition if the health changed.
+func (r *Reconciler) HandleDeliveryHealth(key ktypes.NamespacedName, failure *jetstream.DeliveryFailure,
+	healthChanged bool,
+) {
+	subscription := &eventingv1alpha2.Subscription{}
+	if err := r.Client.Get(context.Background(), key, subscription); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			r.namedLogger().Errorw("Failed to get the subscription to report the delivery health",
+				"namespace", key.Namespace, "name", key.Name, "error", err)
+		}
+		return
+	}
+
+	if failure != nil {
+		events.Warn(r.recorder, subscription, events.ReasonDeliveryFailed,
+			"Failed to deliver events to the sink %s with status code %d: %s",
+			subscription.Spec.Sink, failure.StatusCode, failure.Error)
+	}
+	if healthChanged {
+		r.enqueueReconciliationForSubscriptions([]eventingv1alpha2.Subscription{*subscription})
+	}
+}
+
 // enqueueReconciliationForSubscriptions adds the subscriptions to the customEventsChannel
 // which is being watched by the controller.
 func (r *Reconciler) enqueueReconciliationForSubscriptions(subs []eventingv1alpha2.Subscription) {
@@ -279,12 +340,29 @@ func (r *Reconciler) syncSubscriptionStatus(ctx context.Context,
 	desiredSubscription.Status.Ready = err == nil
 
 	// compile the desired conditions
-	desiredSubscription.Status.Conditions = eventingv1alpha2.GetSubscriptionActiveCondition(desiredSubscription, err)
+	conditions := eventingv1alpha2.GetSubscriptionActiveCondition(desiredSubscription, err)
+	if condition := r.getDeliveryHealthyCondition(desiredSubscription); condition != nil {
+		conditions = append(conditions, *condition)
+	}
+	desiredSubscription.Status.Conditions = conditions
 
 	// Update the subscription
 	return r.updateSubscriptionStatus(ctx, desiredSubscription, log)
 }
 
+// getDeliveryHealthyCondition returns the DeliveryHealthy condition computed from the latest deliveries of the
+// subscription. The current condition is kept if no deliveries were recorded, e.g. since the restart of the manager.
+// The condition does not affect the readiness of the subscription.
+func (r *Reconciler) getDeliveryHealthyCondition(subscription *eventingv1alpha2.Subscription) *eventingv1alpha2.Condition {
+	health, found := r.deliveryHealth.Get(ktypes.NamespacedName{Name: subscription.Name, Namespace: subscription.Namespace})
+	if !found {
+		return subscription.Status.FindCondition(eventingv1alpha2.ConditionDeliveryHealthy)
+	}
+
+	condition := eventingv1alpha2.GetDeliveryHealthyCondition(subscription, health.Healthy(), health.Message())
+	return &condition
+}
+
 // updateSubscriptionStatus updates the subscription's status changes to k8s.
 func (r *Reconciler) updateSubscriptionStatus(ctx context.Context,
 	sub *eventingv1alpha2.Subscription, logger *zap.SugaredLogger,
@@ -360,7 +438,8 @@ func (r *Reconciler) syncEventTypes(desiredSubscription *eventingv1alpha2.Subscr
 	jsSubjects := r.Backend.GetJetStreamSubjects(desiredSubscription.Spec.Source,
 		jetstream.GetCleanEventTypesFromEventTypes(cleanedTypes),
 		desiredSubscription.Spec.TypeMatching)
-	jsTypes, err := jetstream.GetBackendJetStreamTypes(desiredSubscription, jsSubjects)
+	jsTypes, err := jetstream.GetBackendJetStreamTypes(desiredSubscription, jsSubjects,
+		r.Backend.GetConfig().JSConsolidatedConsumers)
 	if err != nil {
 		return err
 	}
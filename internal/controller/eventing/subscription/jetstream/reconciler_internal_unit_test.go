@@ -2,6 +2,7 @@ package jetstream
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	kymalogger "github.com/kyma-project/kyma/common/logging/logger"
@@ -17,6 +18,7 @@ import (
 	kctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 
 	eventingv1alpha2 "github.com/kyma-project/eventing-manager/api/eventing/v1alpha2"
 	"github.com/kyma-project/eventing-manager/pkg/backend/cleaner"
@@ -472,6 +474,144 @@ func Test_syncSubscriptionStatus(t *testing.T) {
 	}
 }
 
+func Test_syncSubscriptionStatus_DeliveryHealthy(t *testing.T) {
+	trueNatsSubActiveCondition := eventingv1alpha2.MakeCondition(eventingv1alpha2.ConditionSubscriptionActive,
+		eventingv1alpha2.ConditionReasonNATSSubscriptionActive,
+		kcorev1.ConditionTrue, "")
+	trueDeliveryHealthyCondition := eventingv1alpha2.MakeCondition(eventingv1alpha2.ConditionDeliveryHealthy,
+		eventingv1alpha2.ConditionReasonDeliveryHealthy,
+		kcorev1.ConditionTrue, "")
+	falseDeliveryHealthyCondition := eventingv1alpha2.MakeCondition(eventingv1alpha2.ConditionDeliveryHealthy,
+		eventingv1alpha2.ConditionReasonDeliveryFailing,
+		kcorev1.ConditionFalse, "Most of the latest deliveries to the sink failed, the last one with status code 503")
+
+	testCases := []struct {
+		name            string
+		givenConditions []eventingv1alpha2.Condition
+		givenDeliveries func(tracker *jetstream.DeliveryHealthTracker, key types.NamespacedName)
+		givenNoTracker  bool
+		wantConditions  []eventingv1alpha2.Condition
+	}{
+		{
+			name:            "Subscription should have no DeliveryHealthy condition without a tracker",
+			givenConditions: []eventingv1alpha2.Condition{trueNatsSubActiveCondition},
+			givenNoTracker:  true,
+			wantConditions:  []eventingv1alpha2.Condition{trueNatsSubActiveCondition},
+		},
+		{
+			name:            "Subscription should keep its DeliveryHealthy condition if no deliveries were recorded",
+			givenConditions: []eventingv1alpha2.Condition{trueNatsSubActiveCondition, falseDeliveryHealthyCondition},
+			givenDeliveries: func(*jetstream.DeliveryHealthTracker, types.NamespacedName) {},
+			wantConditions:  []eventingv1alpha2.Condition{trueNatsSubActiveCondition, falseDeliveryHealthyCondition},
+		},
+		{
+			name:            "Subscription should become delivery healthy if the deliveries succeed",
+			givenConditions: []eventingv1alpha2.Condition{trueNatsSubActiveCondition, falseDeliveryHealthyCondition},
+			givenDeliveries: func(tracker *jetstream.DeliveryHealthTracker, key types.NamespacedName) {
+				tracker.RecordFailure(key, http.StatusServiceUnavailable, errors.New("unavailable"))
+				tracker.RecordSuccess(key)
+				tracker.RecordSuccess(key)
+			},
+			wantConditions: []eventingv1alpha2.Condition{trueNatsSubActiveCondition, trueDeliveryHealthyCondition},
+		},
+		{
+			name:            "Subscription should stay ready but not delivery healthy if the deliveries fail",
+			givenConditions: []eventingv1alpha2.Condition{trueNatsSubActiveCondition},
+			givenDeliveries: func(tracker *jetstream.DeliveryHealthTracker, key types.NamespacedName) {
+				tracker.RecordSuccess(key)
+				for i := 0; i < 5; i++ {
+					tracker.RecordFailure(key, http.StatusServiceUnavailable, errors.New("unavailable"))
+				}
+			},
+			wantConditions: []eventingv1alpha2.Condition{trueNatsSubActiveCondition, falseDeliveryHealthyCondition},
+		},
+	}
+	for _, tC := range testCases {
+		testCase := tC
+		t.Run(testCase.name, func(t *testing.T) {
+			// given
+			sub := eventingtesting.NewSubscription(subscriptionName, namespaceName,
+				eventingtesting.WithConditions(testCase.givenConditions),
+				eventingtesting.WithStatus(true),
+			)
+
+			testEnvironment := setupTestEnvironment(t, sub)
+			ctx, r := context.Background(), testEnvironment.Reconciler
+			if !testCase.givenNoTracker {
+				tracker := jetstream.NewDeliveryHealthTracker()
+				testCase.givenDeliveries(tracker, types.NamespacedName{Name: sub.Name, Namespace: sub.Namespace})
+				r.SetDeliveryHealthTracker(tracker)
+			}
+
+			// when
+			err := r.syncSubscriptionStatus(ctx, sub, nil, r.namedLogger())
+			require.NoError(t, err)
+
+			// then
+			fetchedSub, err := fetchTestSubscription(ctx, r)
+			require.NoError(t, err)
+			ensureSubscriptionMatchesConditionsAndStatus(t, fetchedSub, testCase.wantConditions, true)
+		})
+	}
+}
+
+func Test_HandleDeliveryHealth(t *testing.T) {
+	t.Parallel()
+
+	// given
+	sub := eventingtesting.NewSubscription(subscriptionName, namespaceName,
+		eventingtesting.WithSink("https://webhook.test"),
+	)
+	testEnvironment := setupTestEnvironment(t, sub)
+	r := testEnvironment.Reconciler
+	recorder := record.NewFakeRecorder(1)
+	r.recorder = recorder
+	r.customEventsChannel = make(chan event.GenericEvent, 1)
+	key := types.NamespacedName{Name: sub.Name, Namespace: sub.Namespace}
+
+	// when the deliveries fail
+	r.HandleDeliveryHealth(key, &jetstream.DeliveryFailure{StatusCode: http.StatusBadGateway, Error: "bad gateway"},
+		true)
+
+	// then a warning event is recorded, and the subscription is reconciled
+	require.Equal(t, "Warning DeliveryFailed Failed to deliver events to the sink https://webhook.test "+
+		"with status code 502: bad gateway", <-recorder.Events)
+	require.Equal(t, sub.Name, (<-r.customEventsChannel).Object.GetName())
+
+	// when the deliveries recovered
+	r.HandleDeliveryHealth(key, nil, true)
+
+	// then no event is recorded, and the subscription is reconciled
+	require.Empty(t, recorder.Events)
+	require.Equal(t, sub.Name, (<-r.customEventsChannel).Object.GetName())
+
+	// when the subscription does not exist
+	r.HandleDeliveryHealth(types.NamespacedName{Name: "unknown", Namespace: namespaceName},
+		&jetstream.DeliveryFailure{StatusCode: http.StatusBadGateway}, true)
+
+	// then it is skipped
+	require.Empty(t, recorder.Events)
+	require.Empty(t, r.customEventsChannel)
+}
+
+func Test_sinkAuthResyncResult(t *testing.T) {
+	t.Parallel()
+
+	// given
+	authenticated := eventingtesting.NewSubscription("authenticated", namespaceName,
+		eventingtesting.WithConfigValue(eventingv1alpha2.WebhookAuthSecretName, "sink-auth"),
+	)
+	unauthenticated := eventingtesting.NewSubscription("unauthenticated", namespaceName)
+
+	// when
+	authenticatedResult := sinkAuthResyncResult(authenticated)
+	unauthenticatedResult := sinkAuthResyncResult(unauthenticated)
+
+	// then only the subscriptions with a webhook auth Secret are resynced
+	require.Equal(t, kctrl.Result{RequeueAfter: sinkAuthResyncPeriod}, authenticatedResult)
+	require.Equal(t, kctrl.Result{}, unauthenticatedResult)
+}
+
 func Test_syncEventTypes(t *testing.T) {
 	testEnvironment := setupTestEnvironment(t)
 	r := testEnvironment.Reconciler
@@ -493,6 +633,7 @@ func Test_syncEventTypes(t *testing.T) {
 		Types: jsTypes,
 	}
 	testEnvironment.Backend.On("GetJetStreamSubjects", mock.Anything, mock.Anything, mock.Anything).Return(jsSubjects)
+	testEnvironment.Backend.On("GetConfig").Return(env.NATSConfig{JSStreamName: "sap"})
 
 	testCases := []struct {
 		name          string
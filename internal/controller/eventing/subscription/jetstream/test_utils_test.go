@@ -557,7 +557,7 @@ func GenerateInvalidSubscriptionError(subName, errType string, path *field.Path)
 }
 
 func StartAndWaitForWebhookServer(k8sManager manager.Manager, webhookInstallOpts *envtest.WebhookInstallOptions) error {
-	if err := (&eventingv1alpha2.Subscription{}).SetupWebhookWithManager(k8sManager); err != nil {
+	if err := (&eventingv1alpha2.Subscription{}).SetupWebhookWithManager(k8sManager, nil); err != nil {
 		return err
 	}
 	// wait for the webhook server to get ready
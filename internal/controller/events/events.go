@@ -20,6 +20,8 @@ const (
 	ReasonUpdateFailed reason = "UpdateFailed"
 	// ReasonValidationFailed is used when an object validation fails.
 	ReasonValidationFailed reason = "ValidationFailed"
+	// ReasonDeliveryFailed is used when the delivery of events to a sink fails.
+	ReasonDeliveryFailed reason = "DeliveryFailed"
 )
 
 // Normal records a normal event for an API object.
@@ -29,7 +29,6 @@ import (
 	kcorev1 "k8s.io/api/core/v1"
 	krbacv1 "k8s.io/api/rbac/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
-	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
@@ -40,6 +39,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	kctrlmanager "sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
@@ -48,13 +48,17 @@ import (
 	natsconnection "github.com/kyma-project/eventing-manager/internal/connection/nats"
 	natsconnectionerrors "github.com/kyma-project/eventing-manager/internal/connection/nats/errors"
 	"github.com/kyma-project/eventing-manager/options"
+	"github.com/kyma-project/eventing-manager/pkg/deliveryprobe"
+	"github.com/kyma-project/eventing-manager/pkg/domain"
 	"github.com/kyma-project/eventing-manager/pkg/env"
 	"github.com/kyma-project/eventing-manager/pkg/eventing"
+	"github.com/kyma-project/eventing-manager/pkg/health"
 	"github.com/kyma-project/eventing-manager/pkg/k8s"
 	"github.com/kyma-project/eventing-manager/pkg/logger"
 	"github.com/kyma-project/eventing-manager/pkg/object"
 	"github.com/kyma-project/eventing-manager/pkg/subscriptionmanager"
 	"github.com/kyma-project/eventing-manager/pkg/subscriptionmanager/manager"
+	"github.com/kyma-project/eventing-manager/pkg/tenancy"
 	"github.com/kyma-project/eventing-manager/pkg/watcher"
 )
 
@@ -96,9 +100,9 @@ type Reconciler struct {
 	scheme                        *runtime.Scheme
 	recorder                      record.EventRecorder
 	subManagerFactory             subscriptionmanager.ManagerFactory
-	natsSubManager                manager.Manager
+	natsSubManagers               map[string]manager.Manager // keyed by the namespace, which resolves the NATS server.
 	eventMeshSubManager           manager.Manager
-	isNATSSubManagerStarted       bool
+	isNATSSubManagerStarted       map[string]bool
 	isEventMeshSubManagerStarted  bool
 	natsConfigHandler             NatsConfigHandler
 	oauth2credentials             oauth2Credentials
@@ -107,9 +111,15 @@ type Reconciler struct {
 	clusterScopedResourcesWatched bool
 	natsCRWatchStarted            bool
 	natsWatchers                  map[string]watcher.Watcher
-	natsConnection                natsconnection.Interface
+	natsConnections               map[string]natsconnection.Interface
+	natsCredentialsVersions       map[string]string               // keyed by the namespace, the credentials of the NATS connections.
+	secretVersions                map[types.NamespacedName]string // the data versions of the Secrets used by the Eventing CRs.
 	genericEvents                 chan event.GenericEvent
 	natsConnectionBuilder         natsconnection.Builder
+	domainWatcher                 watcher.Watcher
+	domainSource                  domain.Source
+	healthRegistry                *health.Registry
+	deliveryProber                *deliveryprobe.Prober
 }
 
 func NewReconciler(
@@ -119,33 +129,39 @@ func NewReconciler(
 	scheme *runtime.Scheme,
 	logger *logger.Logger,
 	recorder record.EventRecorder,
-	manager eventing.Manager,
+	eventingManager eventing.Manager,
 	backendConfig env.BackendConfig,
 	subManagerFactory subscriptionmanager.ManagerFactory,
 	opts *options.Options,
 	allowedEventingCR *operatorv1alpha1.Eventing,
 	natsConnectionBuilder natsconnection.Builder,
+	healthRegistry *health.Registry,
+	deliveryProber *deliveryprobe.Prober,
 ) *Reconciler {
 	return &Reconciler{
 		Client:                  client,
 		logger:                  logger,
 		ctrlManager:             nil, // ctrlManager will be initialized in `SetupWithManager`.
-		eventingManager:         manager,
+		eventingManager:         eventingManager,
 		kubeClient:              kubeClient,
 		dynamicClient:           dynamicClient,
 		scheme:                  scheme,
 		recorder:                recorder,
 		backendConfig:           backendConfig,
 		subManagerFactory:       subManagerFactory,
-		natsSubManager:          nil,
+		natsSubManagers:         make(map[string]manager.Manager),
 		eventMeshSubManager:     nil,
-		isNATSSubManagerStarted: false,
+		isNATSSubManagerStarted: make(map[string]bool),
 		natsConfigHandler:       NewNatsConfigHandler(kubeClient, opts),
 		allowedEventingCR:       allowedEventingCR,
 		natsWatchers:            make(map[string]watcher.Watcher),
-		natsConnection:          nil, // This will be initialized only when reconciling the NATS backend.
+		natsConnections:         make(map[string]natsconnection.Interface),
+		natsCredentialsVersions: make(map[string]string),
+		secretVersions:          make(map[types.NamespacedName]string),
 		genericEvents:           make(chan event.GenericEvent),
 		natsConnectionBuilder:   natsConnectionBuilder,
+		healthRegistry:          healthRegistry,
+		deliveryProber:          deliveryProber,
 	}
 }
 
@@ -156,6 +172,7 @@ func NewReconciler(
 //+kubebuilder:rbac:groups=operator.kyma-project.io,resources=eventings/finalizers,verbs=update
 //+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;delete;patch
 //+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;update;patch;create;delete
+//+kubebuilder:rbac:groups="",resources=endpoints,verbs=get;list;watch;update;patch;create;delete
 //+kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=clusterroles,verbs=get;list;watch;update;patch;create;delete
 //+kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=clusterrolebindings,verbs=get;list;watch;update;patch;create;delete
 //+kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;update;patch;create;delete
@@ -164,6 +181,10 @@ func NewReconciler(
 //+kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=operator.kyma-project.io,resources=nats,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+//+kubebuilder:rbac:groups=networking.istio.io,resources=gateways,verbs=get;list;watch
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways,verbs=get;list;watch
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;update;patch;create;delete
 //+kubebuilder:rbac:groups="admissionregistration.k8s.io",resources=mutatingwebhookconfigurations,verbs=get;list;watch;update;patch;create;delete
 //+kubebuilder:rbac:groups="admissionregistration.k8s.io",resources=validatingwebhookconfigurations,verbs=get;list;watch;update;patch;create;delete
@@ -219,24 +240,66 @@ func (r *Reconciler) Reconcile(ctx context.Context, req kctrl.Request) (kctrl.Re
 }
 
 // handleEventingCRAllowedCheck checks if Eventing CR is allowed to be created or not.
+// Besides the default Eventing CR, the Eventing CRs selecting the namespaces of their Subscriptions are allowed as
+// isolated Eventing instances, unless they conflict with the preceding instances.
 // returns true if the Eventing CR is allowed.
 func (r *Reconciler) handleEventingCRAllowedCheck(ctx context.Context, eventing *operatorv1alpha1.Eventing,
 	log *zap.SugaredLogger,
 ) (bool, error) {
-	// if the name and namespace matches with allowed NATS CR then allow the CR to be reconciled.
-	if eventing.Name == r.allowedEventingCR.Name && eventing.Namespace == r.allowedEventingCR.Namespace {
-		return true, nil
+	instanceResolver := r.getInstanceResolver()
+
+	// only the default Eventing CR is allowed without a namespace selector.
+	if !instanceResolver.IsDefault(eventing) && eventing.Spec.NamespaceSelector == nil {
+		errorMessage := fmt.Sprintf("Only a single Eventing CR with name: %s and namespace: %s "+
+			"is allowed to be created in a Kyma cluster without a namespaceSelector.",
+			r.allowedEventingCR.Name, r.allowedEventingCR.Namespace)
+		return false, r.syncStatusWithNotAllowedErr(ctx, eventing, operatorv1alpha1.ConditionReasonForbidden,
+			errorMessage, log)
+	}
+	if err := tenancy.ValidateNamespaceSelector(eventing); err != nil {
+		errorMessage := fmt.Sprintf("The namespaceSelector is invalid: %v", err)
+		return false, r.syncStatusWithNotAllowedErr(ctx, eventing, operatorv1alpha1.ConditionReasonForbidden,
+			errorMessage, log)
 	}
 
-	// set error state in status.
-	eventing.Status.SetStateError()
-	// update conditions in status.
-	errorMessage := fmt.Sprintf("Only a single Eventing CR with name: %s and namespace: %s "+
-		"is allowed to be created in a Kyma cluster.", r.allowedEventingCR.Name, r.allowedEventingCR.Namespace)
-	eventing.Status.UpdateConditionPublisherProxyReady(kmetav1.ConditionFalse,
-		operatorv1alpha1.ConditionReasonForbidden, errorMessage)
+	conflicts, err := instanceResolver.Conflicts(ctx, eventing)
+	if err != nil {
+		return false, err
+	}
+	if len(conflicts) > 0 {
+		errorMessage := fmt.Sprintf("The Eventing CR conflicts with other Eventing instances: %s.",
+			strings.Join(conflicts, "; "))
+		return false, r.syncStatusWithNotAllowedErr(ctx, eventing, operatorv1alpha1.ConditionReasonInstanceConflict,
+			errorMessage, log)
+	}
 
-	return false, r.syncEventingStatus(ctx, eventing, log)
+	return true, nil
+}
+
+// isIsolatedInstance returns true if the given Eventing CR is an isolated Eventing instance besides the default one.
+func (r *Reconciler) isIsolatedInstance(eventing *operatorv1alpha1.Eventing) bool {
+	return r.allowedEventingCR != nil && !r.getInstanceResolver().IsDefault(eventing)
+}
+
+// ownedSubscriptionExists returns true if the given Eventing instance owns any Subscriptions.
+func (r *Reconciler) ownedSubscriptionExists(ctx context.Context, eventing *operatorv1alpha1.Eventing) (bool, error) {
+	subscriptionList, err := r.kubeClient.GetSubscriptions(ctx)
+	if err != nil {
+		return false, err
+	}
+	owned, err := r.getInstanceResolver().Filter(eventing).OwnedSubscriptions(ctx, subscriptionList.Items)
+	if err != nil {
+		return false, err
+	}
+	return len(owned) > 0, nil
+}
+
+// getInstanceResolver returns the resolver of the Eventing instances owning the Subscriptions.
+func (r *Reconciler) getInstanceResolver() *tenancy.Resolver {
+	return tenancy.NewResolver(r.Client, types.NamespacedName{
+		Namespace: r.allowedEventingCR.Namespace,
+		Name:      r.allowedEventingCR.Name,
+	})
 }
 
 func (r *Reconciler) logEventForResource(name, namespace, resourceType, eventType string, enqueue bool) {
@@ -273,6 +336,12 @@ func (r *Reconciler) SkipEnqueueOnUpdateAfterSemanticCompare(resourceType, nameF
 // SetupWithManager sets up the controller with the Manager.
 func (r *Reconciler) SetupWithManager(mgr kctrl.Manager) error {
 	r.ctrlManager = mgr
+	r.healthRegistry.SetHandler(r.handleBackendHealthChange)
+
+	// the Secrets used by the Eventing CRs are resynced instead of watched, to not cache all the Secrets of the cluster.
+	if err := mgr.Add(kctrlmanager.RunnableFunc(r.resyncSecretsPeriodically)); err != nil {
+		return err
+	}
 
 	var err error
 	r.controller, err = kctrl.NewControllerManagedBy(mgr).
@@ -403,8 +472,17 @@ func (r *Reconciler) handleEventingDeletion(ctx context.Context, eventing *opera
 		return r.removeFinalizer(ctx, eventing)
 	}
 
+	// delete the Subscription of the canary events, so that it does not block the deletion.
+	if err := r.stopDeliveryProbe(ctx, eventing); err != nil {
+		return kctrl.Result{}, r.syncStatusWithDeletionErr(ctx, eventing, err, log)
+	}
+
 	// check if subscription resources exist
 	exists, err := r.eventingManager.SubscriptionExists(ctx)
+	if err == nil && exists && r.isIsolatedInstance(eventing) {
+		// an isolated Eventing instance can be deleted while other instances own Subscriptions.
+		exists, err = r.ownedSubscriptionExists(ctx, eventing)
+	}
 	if err != nil {
 		eventing.Status.SetStateError()
 		return kctrl.Result{}, r.syncStatusWithDeletionErr(ctx, eventing, err, log)
@@ -416,15 +494,19 @@ func (r *Reconciler) handleEventingDeletion(ctx context.Context, eventing *opera
 
 	log.Info("handling Eventing deletion...")
 	if eventing.Spec.Backend.Type == operatorv1alpha1.NatsBackendType {
-		if err := r.stopNATSSubManager(true, log); err != nil {
+		if err := r.stopNATSSubManager(eventing, true, log); err != nil {
 			return kctrl.Result{}, r.syncStatusWithNATSErr(ctx, eventing, err, log)
 		}
+		if r.isIsolatedInstance(eventing) {
+			r.releaseNATS(eventing)
+		}
 	} else {
 		if err := r.stopEventMeshSubManager(true, log); err != nil {
 			return kctrl.Result{}, r.syncStatusWithSubscriptionManagerErrWithReason(ctx,
 				operatorv1alpha1.ConditionReasonEventMeshSubManagerStopFailed,
 				eventing, err, log)
 		}
+		r.stopDomainSourceWatch()
 	}
 	eventing.Status.SetSubscriptionManagerReadyConditionToFalse(
 		operatorv1alpha1.ConditionReasonStopped,
@@ -517,19 +599,21 @@ func (r *Reconciler) handleBackendSwitching(ctx context.Context,
 	// stop the previous active backend.
 	previousBackend := eventingCR.Status.ActiveBackend
 	if previousBackend == operatorv1alpha1.NatsBackendType {
-		log.Info("Stopping the NATS subscription manager because backend is switched")
-		if err := r.stopNATSSubManager(true, log); err != nil {
+		log.Info("Stopping the NATS subscription manager and draining its in-flight deliveries because backend is switched")
+		if err := r.stopNATSSubManager(eventingCR, true, log); err != nil {
 			return err
 		}
 		r.stopNATSCRWatch(eventingCR)
-		if r.natsConnection != nil {
-			r.natsConnection.Disconnect()
+		if natsConnection, found := r.natsConnections[eventingCR.Namespace]; found {
+			natsConnection.Disconnect()
 		}
 	} else {
 		log.Info("Stopping the EventMesh subscription manager because backend is switched")
 		if err := r.stopEventMeshSubManager(true, log); err != nil {
 			return err
 		}
+		r.stopDomainSourceWatch()
+		eventingCR.Status.Domain = ""
 	}
 
 	// update the Eventing CR status.
@@ -572,7 +656,7 @@ func (r *Reconciler) reconcileNATSBackend(ctx context.Context,
 		return kctrl.Result{}, r.syncStatusWithNATSErr(ctx, eventingCR, err, log)
 	}
 
-	if connErr := r.connectToNATS(eventingCR); connErr != nil {
+	if connErr := r.connectToNATS(ctx, eventingCR); connErr != nil {
 		if errors.Is(connErr, natsconnectionerrors.ErrCannotConnect) {
 			return kctrl.Result{}, reconcile.TerminalError(
 				r.syncStatusWithNATSErr(ctx, eventingCR, connErr, log),
@@ -594,15 +678,36 @@ func (r *Reconciler) reconcileNATSBackend(ctx context.Context,
 	if err := r.reconcileNATSSubManager(eventingCR, log); err != nil {
 		return kctrl.Result{}, r.syncStatusWithNATSErr(ctx, eventingCR, err, log)
 	}
+	r.updateBackendHealth(ctx, eventingCR, natsHealthInstance(eventingCR), r.natsSubManagers[eventingCR.Namespace])
+	r.reconcileDeliveryProbe(ctx, eventingCR)
 
 	return r.handleEventingState(ctx, deployment, eventingCR, log)
 }
 
 // connectToNATS connects to NATS and returns an error if it failed.
+// It reconnects if the NATS credentials Secret changed since the connection was built.
 // It also registers handlers for reconnection and disconnection.
-func (r *Reconciler) connectToNATS(eventingCR *operatorv1alpha1.Eventing) error {
-	if r.natsConnection == nil {
-		r.natsConnection = r.natsConnectionBuilder.Build()
+func (r *Reconciler) connectToNATS(ctx context.Context, eventingCR *operatorv1alpha1.Eventing) error {
+	secret, err := getNATSCredentialsSecret(ctx, r.kubeClient, eventingCR)
+	if err != nil {
+		return err
+	}
+	credentialsVersion := natsCredentialsVersion(secret)
+
+	natsConnection, found := r.natsConnections[eventingCR.Namespace]
+	if found && r.natsCredentialsVersions[eventingCR.Namespace] != credentialsVersion {
+		r.namedLogger().Infow("Reconnecting to NATS with the changed credentials", "namespace", eventingCR.Namespace)
+		natsConnection.Disconnect()
+		found = false
+	}
+	if !found {
+		authOptions, err := getNATSAuthOptions(secret)
+		if err != nil {
+			return err
+		}
+		natsConnection = r.natsConnectionBuilder.Build(authOptions...)
+		r.natsConnections[eventingCR.Namespace] = natsConnection
+		r.natsCredentialsVersions[eventingCR.Namespace] = credentialsVersion
 	}
 
 	connHandler := func(_ *natsio.Conn) {
@@ -615,7 +720,7 @@ func (r *Reconciler) connectToNATS(eventingCR *operatorv1alpha1.Eventing) error
 		r.genericEvents <- event.GenericEvent{Object: eventingCR}
 	}
 
-	return r.natsConnection.Connect(connHandler, connErrHandler)
+	return natsConnection.Connect(connHandler, connErrHandler)
 }
 
 func (r *Reconciler) handlePublisherProxy(
@@ -672,11 +777,14 @@ func (r *Reconciler) reconcileEventMeshBackend(ctx context.Context, eventing *op
 		return kctrl.Result{}, r.syncStatusWithSubscriptionManagerErr(ctx, eventing, err, log)
 	}
 	eventing.Status.SetSubscriptionManagerReadyConditionToTrue()
+	r.updateBackendHealth(ctx, eventing, eventMeshHealthInstance, r.eventMeshSubManager)
 
 	deployment, err := r.handlePublisherProxy(ctx, eventing, eventing.Spec.Backend.Type)
 	if err != nil {
 		return kctrl.Result{}, r.syncStatusWithPublisherProxyErr(ctx, eventing, err, log)
 	}
+	r.reconcileDeliveryProbe(ctx, eventing)
+
 	return r.handleEventingState(ctx, deployment, eventing, log)
 }
 
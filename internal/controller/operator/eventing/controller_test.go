@@ -8,10 +8,14 @@ import (
 	natsv1alpha1 "github.com/kyma-project/nats-manager/api/v1alpha1"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	kcorev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 
 	operatorv1alpha1 "github.com/kyma-project/eventing-manager/api/operator/v1alpha1"
+	natsconnectionmocks "github.com/kyma-project/eventing-manager/internal/connection/nats/mocks"
 	eventingmocks "github.com/kyma-project/eventing-manager/pkg/eventing/mocks"
 	submgrmanagermocks "github.com/kyma-project/eventing-manager/pkg/subscriptionmanager/manager/mocks"
 	"github.com/kyma-project/eventing-manager/pkg/watcher"
@@ -100,8 +104,8 @@ func Test_handleEventingCRAllowedCheck(t *testing.T) {
 						LastTransitionTime: kmetav1.Now(),
 						Reason:             string(operatorv1alpha1.ConditionReasonForbidden),
 						Message: fmt.Sprintf("Only a single Eventing CR with name: %s and namespace: %s "+
-							"is allowed to be created in a Kyma cluster.", givenAllowedEventingCR.Name,
-							givenAllowedEventingCR.Namespace),
+							"is allowed to be created in a Kyma cluster without a namespaceSelector.",
+							givenAllowedEventingCR.Name, givenAllowedEventingCR.Namespace),
 					},
 				}
 				require.True(t, natsv1alpha1.ConditionsEquals(wantConditions, gotEventing.Status.Conditions))
@@ -110,6 +114,145 @@ func Test_handleEventingCRAllowedCheck(t *testing.T) {
 	}
 }
 
+func Test_handleEventingCRAllowedCheck_IsolatedInstances(t *testing.T) {
+	t.Parallel()
+
+	givenAllowedEventingCR := testutils.NewEventingCR(
+		testutils.WithEventingCRName("eventing"),
+		testutils.WithEventingCRNamespace("kyma-system"),
+	)
+	givenTenantNamespace := &kcorev1.Namespace{
+		ObjectMeta: kmetav1.ObjectMeta{Name: "tenant-a-apps", Labels: map[string]string{"tenant": "a"}},
+	}
+
+	// define test cases
+	testCases := []struct {
+		name              string
+		givenEventing     *operatorv1alpha1.Eventing
+		givenOtherObjects []client.Object
+		wantCheckResult   bool
+		wantReason        operatorv1alpha1.ConditionReason
+		wantMessage       string
+	}{
+		{
+			name: "should allow Eventing CR with a namespace selector",
+			givenEventing: testutils.NewEventingCR(
+				testutils.WithEventingCRName("tenant-a"),
+				testutils.WithEventingCRNamespace("tenant-a-system"),
+				testutils.WithEventingNamespaceSelector(map[string]string{"tenant": "a"}),
+			),
+			givenOtherObjects: []client.Object{
+				givenTenantNamespace,
+				testutils.NewEventingCR(
+					testutils.WithEventingCRName("eventing"),
+					testutils.WithEventingCRNamespace("kyma-system"),
+				),
+			},
+			wantCheckResult: true,
+		},
+		{
+			name: "should not allow Eventing CR with an invalid namespace selector",
+			givenEventing: testutils.NewEventingCR(
+				testutils.WithEventingCRName("tenant-a"),
+				testutils.WithEventingCRNamespace("tenant-a-system"),
+				testutils.WithEventingNamespaceSelector(map[string]string{"tenant": "a b"}),
+			),
+			wantCheckResult: false,
+			wantReason:      operatorv1alpha1.ConditionReasonForbidden,
+			wantMessage:     "The namespaceSelector is invalid",
+		},
+		{
+			name: "should not allow Eventing CR using the NATS server of the default Eventing CR",
+			givenEventing: testutils.NewEventingCR(
+				testutils.WithEventingCRName("tenant-a"),
+				testutils.WithEventingCRNamespace("kyma-system"),
+				testutils.WithEventingNamespaceSelector(map[string]string{"tenant": "a"}),
+			),
+			givenOtherObjects: []client.Object{
+				testutils.NewEventingCR(
+					testutils.WithEventingCRName("eventing"),
+					testutils.WithEventingCRNamespace("kyma-system"),
+				),
+			},
+			wantCheckResult: false,
+			wantReason:      operatorv1alpha1.ConditionReasonInstanceConflict,
+			wantMessage:     "the NATS server of namespace kyma-system is used by Eventing CR kyma-system/eventing",
+		},
+		{
+			name: "should not allow a second Eventing CR using the EventMesh backend",
+			givenEventing: testutils.NewEventingCR(
+				testutils.WithEventingCRName("tenant-a"),
+				testutils.WithEventingCRNamespace("tenant-a-system"),
+				testutils.WithEventMeshBackend("eventmesh-secret"),
+				testutils.WithEventingNamespaceSelector(map[string]string{"tenant": "a"}),
+			),
+			givenOtherObjects: []client.Object{
+				testutils.NewEventingCR(
+					testutils.WithEventingCRName("eventing"),
+					testutils.WithEventingCRNamespace("kyma-system"),
+					testutils.WithEventMeshBackend("eventmesh-secret"),
+				),
+			},
+			wantCheckResult: false,
+			wantReason:      operatorv1alpha1.ConditionReasonInstanceConflict,
+			wantMessage:     "only one EventMesh instance is supported per cluster",
+		},
+		{
+			name: "should not allow Eventing CR selecting the namespace of a preceding Eventing CR",
+			givenEventing: testutils.NewEventingCR(
+				testutils.WithEventingCRName("tenant-b"),
+				testutils.WithEventingCRNamespace("tenant-b-system"),
+				testutils.WithEventingNamespaceSelector(map[string]string{"tenant": "a"}),
+			),
+			givenOtherObjects: []client.Object{
+				givenTenantNamespace,
+				testutils.NewEventingCR(
+					testutils.WithEventingCRName("eventing"),
+					testutils.WithEventingCRNamespace("kyma-system"),
+					testutils.WithEventingNamespaceSelector(map[string]string{"tenant": "a"}),
+				),
+			},
+			wantCheckResult: false,
+			wantReason:      operatorv1alpha1.ConditionReasonInstanceConflict,
+			wantMessage:     "namespace tenant-a-apps is selected by Eventing CR kyma-system/eventing",
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			testEnv := NewMockedUnitTestEnvironment(t, append(tc.givenOtherObjects, tc.givenEventing)...)
+			testEnv.Reconciler.allowedEventingCR = givenAllowedEventingCR
+			logger := testEnv.Reconciler.logger.WithContext().Named(ControllerName)
+
+			// when
+			result, err := testEnv.Reconciler.handleEventingCRAllowedCheck(context.Background(), tc.givenEventing, logger)
+
+			// then
+			require.NoError(t, err)
+			require.Equal(t, tc.wantCheckResult, result)
+			if tc.wantCheckResult {
+				return
+			}
+
+			// check if the CR status is correctly updated.
+			gotEventing, err := testEnv.GetEventing(tc.givenEventing.Name, tc.givenEventing.Namespace)
+			require.NoError(t, err)
+			require.Equal(t, operatorv1alpha1.StateError, gotEventing.Status.State)
+			gotCondition := meta.FindStatusCondition(gotEventing.Status.Conditions,
+				string(operatorv1alpha1.ConditionPublisherProxyReady))
+			require.NotNil(t, gotCondition)
+			require.Equal(t, kmetav1.ConditionFalse, gotCondition.Status)
+			require.Equal(t, string(tc.wantReason), gotCondition.Reason)
+			require.Contains(t, gotCondition.Message, tc.wantMessage)
+		})
+	}
+}
+
 func Test_handleBackendSwitching(t *testing.T) {
 	t.Parallel()
 
@@ -294,7 +437,7 @@ func Test_handleBackendSwitching(t *testing.T) {
 			// given
 			testEnv := NewMockedUnitTestEnvironment(t)
 			logger := testEnv.Reconciler.logger.WithContext().Named(ControllerName)
-			testEnv.Reconciler.isNATSSubManagerStarted = true
+			testEnv.Reconciler.isNATSSubManagerStarted[tc.givenEventing.Namespace] = true
 			testEnv.Reconciler.isEventMeshSubManagerStarted = true
 
 			mockNatsWatcher := new(watchermocks.Watcher)
@@ -309,7 +452,7 @@ func Test_handleBackendSwitching(t *testing.T) {
 			givenEventingManagerMock := tc.givenEventingManagerMock()
 
 			// connect mocks with reconciler.
-			testEnv.Reconciler.natsSubManager = givenNATSSubManagerMock
+			testEnv.Reconciler.natsSubManagers[tc.givenEventing.Namespace] = givenNATSSubManagerMock
 			testEnv.Reconciler.eventMeshSubManager = givenEventMeshSubManagerMock
 			testEnv.Reconciler.eventingManager = givenEventingManagerMock
 
@@ -334,12 +477,12 @@ func Test_handleBackendSwitching(t *testing.T) {
 
 			// NATS
 			if tc.wantNATSStopped {
-				require.Nil(t, testEnv.Reconciler.natsSubManager)
-				require.False(t, testEnv.Reconciler.isNATSSubManagerStarted)
+				require.Nil(t, testEnv.Reconciler.natsSubManagers[tc.givenEventing.Namespace])
+				require.False(t, testEnv.Reconciler.isNATSSubManagerStarted[tc.givenEventing.Namespace])
 				givenEventingManagerMock.AssertExpectations(t)
 			} else {
-				require.NotNil(t, testEnv.Reconciler.natsSubManager)
-				require.True(t, testEnv.Reconciler.isNATSSubManagerStarted)
+				require.NotNil(t, testEnv.Reconciler.natsSubManagers[tc.givenEventing.Namespace])
+				require.True(t, testEnv.Reconciler.isNATSSubManagerStarted[tc.givenEventing.Namespace])
 			}
 
 			// EventMesh
@@ -356,6 +499,42 @@ func Test_handleBackendSwitching(t *testing.T) {
 	}
 }
 
+func Test_connectToNATS_ReconnectsOnChangedCredentials(t *testing.T) {
+	t.Parallel()
+
+	// given
+	eventing := testutils.NewEventingCR(
+		testutils.WithEventingCRName("test-name"),
+		testutils.WithEventingCRNamespace("test-namespace"),
+		testutils.WithNATSBackend(),
+		testutils.WithEventingNATSCredentialsSecret("nats-credentials"),
+	)
+	secret := newSecret("nats-credentials", "test-namespace")
+	secret.Data = map[string][]byte{"manager.user": []byte("manager"), "manager.password": []byte("old")}
+	testEnv := NewMockedUnitTestEnvironment(t, eventing, secret)
+
+	natsConnection := new(natsconnectionmocks.Connection)
+	natsConnection.On("Connect", mock.Anything, mock.Anything).Return(nil)
+	natsConnection.On("Disconnect").Return()
+	testEnv.Reconciler.natsConnectionBuilder = natsconnectionmocks.NewBuilder(natsConnection)
+
+	// when
+	require.NoError(t, testEnv.Reconciler.connectToNATS(context.Background(), eventing))
+	require.NoError(t, testEnv.Reconciler.connectToNATS(context.Background(), eventing))
+
+	// then the connection is kept while the credentials are unchanged
+	natsConnection.AssertNotCalled(t, "Disconnect")
+
+	// when the credentials change
+	secret.Data["manager.password"] = []byte("new")
+	require.NoError(t, testEnv.Client.Update(context.Background(), secret))
+	require.NoError(t, testEnv.Reconciler.connectToNATS(context.Background(), eventing))
+
+	// then the connection is rebuilt
+	natsConnection.AssertNumberOfCalls(t, "Disconnect", 1)
+	natsConnection.AssertNumberOfCalls(t, "Connect", 3)
+}
+
 func Test_startNatsCRWatch(t *testing.T) {
 	testCases := []struct {
 		name         string
@@ -0,0 +1,145 @@
+package eventing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	operatorv1alpha1 "github.com/kyma-project/eventing-manager/api/operator/v1alpha1"
+	"github.com/kyma-project/eventing-manager/pkg/deliveryprobe"
+	"github.com/kyma-project/eventing-manager/pkg/eventing"
+)
+
+const (
+	defaultDeliveryProbeInterval = time.Minute
+	defaultDeliveryProbeTimeout  = 10 * time.Second
+)
+
+var ErrPodIPMissing = errors.New("the IP of the eventing-manager Pod is not set in the POD_IP environment variable")
+
+// reconcileDeliveryProbe deploys the sink Service and the Subscription of the canary events, and configures the
+// delivery prober, if the delivery probe is enabled for the given Eventing CR. It reports the result of the last
+// probe in the DeliveryProbeSucceeded condition. The condition is informational, so failures do not fail the
+// reconciliation.
+func (r *Reconciler) reconcileDeliveryProbe(ctx context.Context, eventingCR *operatorv1alpha1.Eventing) {
+	if r.deliveryProber == nil {
+		return
+	}
+
+	// the delivery probe is only supported for the default Eventing CR.
+	if eventingCR.Spec.DeliveryProbe == nil || r.isIsolatedInstance(eventingCR) {
+		if err := r.stopDeliveryProbe(ctx, eventingCR); err != nil {
+			r.namedLogger().Errorw("Failed to stop the delivery probe", "error", err)
+		}
+		return
+	}
+
+	if err := r.deployDeliveryProbeResources(ctx, eventingCR); err != nil {
+		eventingCR.Status.UpdateConditionDeliveryProbeSucceeded(kmetav1.ConditionFalse,
+			operatorv1alpha1.ConditionReasonDeliveryProbeFailed,
+			fmt.Sprintf("Failed to deploy the delivery probe resources: %v", err))
+		return
+	}
+
+	key := &operatorv1alpha1.Eventing{
+		ObjectMeta: kmetav1.ObjectMeta{Name: eventingCR.Name, Namespace: eventingCR.Namespace},
+	}
+	r.deliveryProber.Configure(deliveryProbeTarget(eventingCR), func() {
+		r.genericEvents <- event.GenericEvent{Object: key}
+	})
+
+	result, found := r.deliveryProber.LastResult()
+	switch {
+	case !found:
+		eventingCR.Status.UpdateConditionDeliveryProbeSucceeded(kmetav1.ConditionFalse,
+			operatorv1alpha1.ConditionReasonDeliveryProbePending,
+			operatorv1alpha1.ConditionDeliveryProbePendingMessage)
+	case result.Err != nil:
+		eventingCR.Status.UpdateConditionDeliveryProbeSucceeded(kmetav1.ConditionFalse,
+			operatorv1alpha1.ConditionReasonDeliveryProbeFailed,
+			fmt.Sprintf("Delivery probe at %s failed: %v", result.Time.UTC().Format(time.RFC3339), result.Err))
+	default:
+		eventingCR.Status.UpdateConditionDeliveryProbeSucceeded(kmetav1.ConditionTrue,
+			operatorv1alpha1.ConditionReasonDeliveryProbeSucceeded,
+			fmt.Sprintf("Canary event delivered in %v at %s", result.Latency.Round(time.Millisecond),
+				result.Time.UTC().Format(time.RFC3339)))
+	}
+}
+
+// stopDeliveryProbe disables the delivery prober and deletes the resources of the delivery probe. The delivery probe
+// is only stopped if it was started for the given Eventing CR, i.e. if it has the DeliveryProbeSucceeded condition.
+func (r *Reconciler) stopDeliveryProbe(ctx context.Context, eventingCR *operatorv1alpha1.Eventing) error {
+	if r.deliveryProber == nil ||
+		meta.FindStatusCondition(eventingCR.Status.Conditions,
+			string(operatorv1alpha1.ConditionDeliveryProbeSucceeded)) == nil {
+		return nil
+	}
+
+	name := deliveryprobe.ResourceName(eventingCR.Name)
+	for _, obj := range []client.Object{
+		deliveryprobe.NewSubscription(name, eventingCR.Namespace, ""),
+		deliveryprobe.NewEndpoints(name, eventingCR.Namespace, "", 0),
+		deliveryprobe.NewService(name, eventingCR.Namespace, 0),
+	} {
+		if err := r.kubeClient.DeleteResource(ctx, obj); err != nil {
+			return err
+		}
+	}
+
+	r.deliveryProber.Disable()
+	eventingCR.Status.RemoveConditionDeliveryProbeSucceeded()
+	return nil
+}
+
+// deployDeliveryProbeResources deploys the Service serving the canary events by the eventing-manager Pod running the
+// delivery prober, and the Subscription of the canary events.
+func (r *Reconciler) deployDeliveryProbeResources(ctx context.Context, eventingCR *operatorv1alpha1.Eventing) error {
+	if r.backendConfig.PodIP == "" {
+		return ErrPodIPMissing
+	}
+	port, err := r.deliveryProber.Port()
+	if err != nil {
+		return err
+	}
+
+	name := deliveryprobe.ResourceName(eventingCR.Name)
+	for _, obj := range []client.Object{
+		deliveryprobe.NewService(name, eventingCR.Namespace, port),
+		deliveryprobe.NewEndpoints(name, eventingCR.Namespace, r.backendConfig.PodIP, port),
+		deliveryprobe.NewSubscription(name, eventingCR.Namespace, deliveryprobe.SinkURL(name, eventingCR.Namespace)),
+	} {
+		if err := controllerutil.SetControllerReference(eventingCR, obj, r.scheme); err != nil {
+			return err
+		}
+		if err := r.kubeClient.PatchApply(ctx, obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deliveryProbeTarget returns the target of the delivery prober, which publishes the canary events to the
+// eventing-publisher-proxy of the given Eventing CR.
+func deliveryProbeTarget(eventingCR *operatorv1alpha1.Eventing) deliveryprobe.Target {
+	target := deliveryprobe.Target{
+		PublishURL: fmt.Sprintf("http://%s.%s.svc.cluster.local/publish",
+			eventing.GetPublisherPublishServiceName(*eventingCR), eventingCR.Namespace),
+		Backend:  string(eventingCR.Spec.Backend.Type),
+		Interval: eventingCR.Spec.DeliveryProbe.Interval.Duration,
+		Timeout:  eventingCR.Spec.DeliveryProbe.Timeout.Duration,
+	}
+	if target.Interval <= 0 {
+		target.Interval = defaultDeliveryProbeInterval
+	}
+	if target.Timeout <= 0 {
+		target.Timeout = defaultDeliveryProbeTimeout
+	}
+	return target
+}
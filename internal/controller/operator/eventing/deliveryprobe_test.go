@@ -0,0 +1,133 @@
+package eventing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorv1alpha1 "github.com/kyma-project/eventing-manager/api/operator/v1alpha1"
+	"github.com/kyma-project/eventing-manager/pkg/deliveryprobe"
+	k8smocks "github.com/kyma-project/eventing-manager/pkg/k8s/mocks"
+	"github.com/kyma-project/eventing-manager/test/utils"
+)
+
+func Test_reconcileDeliveryProbe(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name                string
+		givenDeliveryProbe  *operatorv1alpha1.DeliveryProbe
+		givenCondition      bool
+		givenPodIP          string
+		givenKubeClient     func() *k8smocks.Client
+		wantConfigured      bool
+		wantConditionReason operatorv1alpha1.ConditionReason
+	}{
+		{
+			name: "it should do nothing because the delivery probe is disabled",
+			givenKubeClient: func() *k8smocks.Client {
+				return new(k8smocks.Client)
+			},
+		},
+		{
+			name:           "it should stop the delivery probe because it got disabled",
+			givenCondition: true,
+			givenKubeClient: func() *k8smocks.Client {
+				kubeClient := new(k8smocks.Client)
+				kubeClient.On("DeleteResource", mock.Anything, mock.Anything).Return(nil).Times(3)
+				return kubeClient
+			},
+		},
+		{
+			name:               "it should report the failure because the Pod IP is missing",
+			givenDeliveryProbe: &operatorv1alpha1.DeliveryProbe{},
+			givenKubeClient: func() *k8smocks.Client {
+				return new(k8smocks.Client)
+			},
+			wantConditionReason: operatorv1alpha1.ConditionReasonDeliveryProbeFailed,
+		},
+		{
+			name:               "it should deploy the resources and configure the prober",
+			givenDeliveryProbe: &operatorv1alpha1.DeliveryProbe{},
+			givenPodIP:         "10.0.0.1",
+			givenKubeClient: func() *k8smocks.Client {
+				kubeClient := new(k8smocks.Client)
+				kubeClient.On("PatchApply", mock.Anything, mock.Anything).Return(nil).Times(3)
+				return kubeClient
+			},
+			wantConfigured:      true,
+			wantConditionReason: operatorv1alpha1.ConditionReasonDeliveryProbePending,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenEventing := utils.NewEventingCR(
+				utils.WithEventingCRName("eventing"),
+				utils.WithEventingCRNamespace("test-namespace"),
+			)
+			givenEventing.Spec.DeliveryProbe = tc.givenDeliveryProbe
+			if tc.givenCondition {
+				givenEventing.Status.UpdateConditionDeliveryProbeSucceeded(kmetav1.ConditionTrue,
+					operatorv1alpha1.ConditionReasonDeliveryProbeSucceeded, "")
+			}
+			testEnv := NewMockedUnitTestEnvironment(t, givenEventing)
+			prober, err := deliveryprobe.NewProber(":8083", testEnv.Logger)
+			require.NoError(t, err)
+			kubeClient := tc.givenKubeClient()
+			testEnv.Reconciler.deliveryProber = prober
+			testEnv.Reconciler.kubeClient = kubeClient
+			testEnv.Reconciler.backendConfig.PodIP = tc.givenPodIP
+
+			// when
+			testEnv.Reconciler.reconcileDeliveryProbe(context.Background(), givenEventing)
+
+			// then
+			kubeClient.AssertExpectations(t)
+			gotCondition := meta.FindStatusCondition(givenEventing.Status.Conditions,
+				string(operatorv1alpha1.ConditionDeliveryProbeSucceeded))
+			if tc.wantConditionReason == "" {
+				require.Nil(t, gotCondition)
+			} else {
+				require.NotNil(t, gotCondition)
+				require.Equal(t, string(tc.wantConditionReason), gotCondition.Reason)
+			}
+			_, gotConfigured := prober.Target()
+			require.Equal(t, tc.wantConfigured, gotConfigured)
+		})
+	}
+}
+
+func Test_deliveryProbeTarget(t *testing.T) {
+	t.Parallel()
+
+	// given
+	givenEventing := utils.NewEventingCR(
+		utils.WithEventingCRName("eventing"),
+		utils.WithEventingCRNamespace("kyma-system"),
+		utils.WithEventMeshBackend("kyma-system/eventing-backend"),
+	)
+	givenEventing.Spec.DeliveryProbe = &operatorv1alpha1.DeliveryProbe{
+		Interval: kmetav1.Duration{Duration: 30 * time.Second},
+	}
+
+	// when
+	target := deliveryProbeTarget(givenEventing)
+
+	// then
+	require.Equal(t, deliveryprobe.Target{
+		PublishURL: "http://eventing-publisher-proxy.kyma-system.svc.cluster.local/publish",
+		Backend:    string(operatorv1alpha1.EventMeshBackendType),
+		Interval:   30 * time.Second,
+		Timeout:    defaultDeliveryProbeTimeout,
+	}, target)
+}
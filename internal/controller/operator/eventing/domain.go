@@ -4,6 +4,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	operatorv1alpha1 "github.com/kyma-project/eventing-manager/api/operator/v1alpha1"
+	"github.com/kyma-project/eventing-manager/pkg/domain"
+	"github.com/kyma-project/eventing-manager/pkg/utils"
+	"github.com/kyma-project/eventing-manager/pkg/watcher"
 )
 
 const (
@@ -13,10 +25,75 @@ const (
 	domainMissingMessageFormat  = `%w. domain must be configured in either the Eventing` +
 		` CustomResource under "Spec.Backend.Config.Domain" or in the ConfigMap "%s/%s" under "data.%s"`
 	domainMissingMessageFormatWithError = domainMissingMessageFormat + `: %w`
+	domainDiscoveryFailedMessageFormat  = `%w. domain must be configured in either the Eventing` +
+		` CustomResource under "Spec.Backend.Config.Domain" or be discovered by the strategy %s from "%s": %w`
 )
 
 var ErrDomainConfigMissing = errors.New("domain configuration missing")
 
+// checkDomain returns the domain configured in the Eventing CR. If it is not configured, the domain is discovered
+// by the strategy of the Eventing CR, which reads the Gardener ConfigMap by default.
+func (r *Reconciler) checkDomain(ctx context.Context, eventing *operatorv1alpha1.Eventing) (string, error) {
+	ret := eventing.Spec.Backend.Config.Domain
+	discovery := eventing.Spec.Backend.Config.DomainDiscovery
+	if !utils.IsEmpty(ret) || !isResourceDiscovery(discovery) {
+		// the configured domain and the Gardener ConfigMap are not watched.
+		r.stopDomainSourceWatch()
+	}
+
+	switch {
+	case !utils.IsEmpty(ret):
+	case isResourceDiscovery(discovery):
+		r.namedLogger().Infof(
+			`Domain is not configured in the Eventing CR, discovering it by the strategy %s from %s`,
+			discovery.Strategy, discovery.Resource,
+		)
+		discoveredDomain, err := r.discoverDomain(ctx, eventing)
+		if err != nil {
+			return "", domainDiscoveryFailedError(discovery, err)
+		}
+		ret = discoveredDomain
+	default:
+		r.namedLogger().Infof(
+			`Domain is not configured in the Eventing CR, reading it from the ConfigMap %s/%s`,
+			shootInfoConfigMapNamespace, shootInfoConfigMapName,
+		)
+		cmDomain, err := r.readDomainFromConfigMap(ctx)
+		if err != nil || utils.IsEmpty(cmDomain) {
+			return "", domainMissingError(err)
+		}
+		ret = cmDomain
+	}
+	r.namedLogger().Infof(`Domain is %s`, ret)
+	return ret, nil
+}
+
+// isResourceDiscovery returns true if the domain is discovered from a resource selected in the Eventing CR.
+func isResourceDiscovery(discovery *operatorv1alpha1.DomainDiscovery) bool {
+	return discovery != nil && discovery.Strategy != "" &&
+		discovery.Strategy != operatorv1alpha1.DomainDiscoveryStrategyShootInfo
+}
+
+// discoverDomain discovers the domain from the resource selected by the DomainDiscovery of the given Eventing CR,
+// and watches the resource to reconcile the Eventing CR if the domain changes.
+func (r *Reconciler) discoverDomain(ctx context.Context, eventing *operatorv1alpha1.Eventing) (string, error) {
+	discovery := eventing.Spec.Backend.Config.DomainDiscovery
+	discoveredDomain, err := domain.NewDiscoverer(r.dynamicClient).Discover(ctx, discovery)
+	if err != nil {
+		return "", err
+	}
+
+	// the resource is watched only once it is discovered, because the watch blocks until its type exists.
+	domainSource, err := domain.SourceOf(discovery)
+	if err != nil {
+		return "", err
+	}
+	if err = r.startDomainSourceWatch(eventing, domainSource); err != nil {
+		return "", err
+	}
+	return discoveredDomain, nil
+}
+
 func (r *Reconciler) readDomainFromConfigMap(ctx context.Context) (string, error) {
 	cm, err := r.kubeClient.GetConfigMap(ctx, shootInfoConfigMapName, shootInfoConfigMapNamespace)
 	if err != nil {
@@ -25,6 +102,47 @@ func (r *Reconciler) readDomainFromConfigMap(ctx context.Context) (string, error
 	return cm.Data[shootInfoConfigMapKeyDomain], nil
 }
 
+// startDomainSourceWatch watches the resources of the given domain source in its namespace to reconcile the given
+// Eventing CR. The watch of the previous domain source is stopped if the source changed.
+func (r *Reconciler) startDomainSourceWatch(eventing *operatorv1alpha1.Eventing, domainSource domain.Source) error {
+	if r.domainWatcher != nil && r.domainWatcher.IsStarted() && r.domainSource == domainSource {
+		return nil
+	}
+	r.stopDomainSourceWatch()
+
+	domainWatcher := watcher.NewResourceWatcher(r.dynamicClient, domainSource.GVR, domainSource.Namespace)
+	if err := r.controller.Watch(&source.Channel{Source: domainWatcher.GetEventsChannel()},
+		handler.EnqueueRequestsFromMapFunc(func(_ context.Context, _ client.Object) []reconcile.Request {
+			return []reconcile.Request{
+				{NamespacedName: types.NamespacedName{
+					Namespace: eventing.Namespace,
+					Name:      eventing.Name,
+				}},
+			}
+		}),
+		predicate.ResourceVersionChangedPredicate{},
+	); err != nil {
+		return err
+	}
+	domainWatcher.Start()
+
+	r.domainWatcher = domainWatcher
+	r.domainSource = domainSource
+	return nil
+}
+
+// stopDomainSourceWatch stops watching the domain source, if it is watched.
+func (r *Reconciler) stopDomainSourceWatch() {
+	if r.domainWatcher == nil {
+		return
+	}
+	if r.domainWatcher.IsStarted() {
+		r.domainWatcher.Stop()
+	}
+	r.domainWatcher = nil
+	r.domainSource = domain.Source{}
+}
+
 func domainMissingError(err error) error {
 	if err != nil {
 		return fmt.Errorf(
@@ -37,3 +155,8 @@ func domainMissingError(err error) error {
 		shootInfoConfigMapNamespace, shootInfoConfigMapName, shootInfoConfigMapKeyDomain,
 	)
 }
+
+func domainDiscoveryFailedError(discovery *operatorv1alpha1.DomainDiscovery, err error) error {
+	return fmt.Errorf(domainDiscoveryFailedMessageFormat, ErrDomainConfigMissing,
+		discovery.Strategy, discovery.Resource, err)
+}
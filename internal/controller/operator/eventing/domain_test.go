@@ -8,8 +8,15 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	kcorev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kdynamicfake "k8s.io/client-go/dynamic/fake"
 
+	operatorv1alpha1 "github.com/kyma-project/eventing-manager/api/operator/v1alpha1"
+	"github.com/kyma-project/eventing-manager/pkg/domain"
 	k8smocks "github.com/kyma-project/eventing-manager/pkg/k8s/mocks"
+	watchermocks "github.com/kyma-project/eventing-manager/pkg/watcher/mocks"
 	"github.com/kyma-project/eventing-manager/test/utils"
 )
 
@@ -59,3 +66,95 @@ func Test_domainMissingError(t *testing.T) {
 	require.NotErrorIs(t, err0, err)
 	require.ErrorIs(t, err1, err)
 }
+
+func Test_checkDomain(t *testing.T) {
+	t.Parallel()
+
+	givenIngress := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "networking.k8s.io/v1",
+		"kind":       "Ingress",
+		"metadata":   map[string]interface{}{"namespace": "ingress-system", "name": "ingress"},
+		"spec": map[string]interface{}{
+			"rules": []interface{}{map[string]interface{}{"host": "*." + utils.Domain}},
+		},
+	}}
+	givenIngressSource := domain.Source{
+		GVR:       schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"},
+		Namespace: "ingress-system",
+		Name:      "ingress",
+	}
+
+	testCases := []struct {
+		name                string
+		givenEventing       *operatorv1alpha1.Eventing
+		givenWatchedSource  *domain.Source
+		wantDomain          string
+		wantError           error
+		wantDomainWatcher   bool
+		wantWatchStopCalled bool
+	}{
+		{
+			name: "should return the configured domain and stop watching the domain source",
+			givenEventing: utils.NewEventingCR(
+				utils.WithEventMeshBackend("test-namespace/test-secret-name"),
+				utils.WithEventingDomain(utils.Domain),
+			),
+			givenWatchedSource:  &givenIngressSource,
+			wantDomain:          utils.Domain,
+			wantDomainWatcher:   false,
+			wantWatchStopCalled: true,
+		},
+		{
+			name: "should discover the domain from the watched Ingress",
+			givenEventing: utils.NewEventingCR(
+				utils.WithEventMeshBackend("test-namespace/test-secret-name"),
+				utils.WithEventingDomain(""),
+				utils.WithEventingDomainDiscovery(operatorv1alpha1.DomainDiscoveryStrategyIngress, "ingress-system/ingress", ""),
+			),
+			givenWatchedSource: &givenIngressSource,
+			wantDomain:         utils.Domain,
+			wantDomainWatcher:  true,
+		},
+		{
+			name: "should return an error if the Ingress does not exist",
+			givenEventing: utils.NewEventingCR(
+				utils.WithEventMeshBackend("test-namespace/test-secret-name"),
+				utils.WithEventingDomain(""),
+				utils.WithEventingDomainDiscovery(operatorv1alpha1.DomainDiscoveryStrategyIngress, "ingress-system/other", ""),
+			),
+			wantError:         ErrDomainConfigMissing,
+			wantDomainWatcher: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			testEnv := NewMockedUnitTestEnvironment(t)
+			scheme := runtime.NewScheme()
+			testEnv.Reconciler.dynamicClient = kdynamicfake.NewSimpleDynamicClient(scheme, givenIngress.DeepCopy())
+
+			domainWatcher := new(watchermocks.Watcher)
+			if tc.givenWatchedSource != nil {
+				domainWatcher.On("IsStarted").Return(true)
+				domainWatcher.On("Stop").Return().Maybe()
+				testEnv.Reconciler.domainWatcher = domainWatcher
+				testEnv.Reconciler.domainSource = *tc.givenWatchedSource
+			}
+
+			// when
+			gotDomain, err := testEnv.Reconciler.checkDomain(context.Background(), tc.givenEventing)
+
+			// then
+			require.ErrorIs(t, err, tc.wantError)
+			require.Equal(t, tc.wantDomain, gotDomain)
+			require.Equal(t, tc.wantDomainWatcher, testEnv.Reconciler.domainWatcher != nil)
+			if tc.wantWatchStopCalled {
+				domainWatcher.AssertCalled(t, "Stop")
+			}
+		})
+	}
+}
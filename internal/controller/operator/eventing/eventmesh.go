@@ -19,7 +19,6 @@ import (
 	"github.com/kyma-project/eventing-manager/pkg/env"
 	"github.com/kyma-project/eventing-manager/pkg/eventing"
 	submgrmanager "github.com/kyma-project/eventing-manager/pkg/subscriptionmanager/manager"
-	"github.com/kyma-project/eventing-manager/pkg/utils"
 )
 
 const (
@@ -45,7 +44,7 @@ type oauth2Credentials struct {
 }
 
 func (r *Reconciler) reconcileEventMeshSubManager(ctx context.Context, eventing *v1alpha1.Eventing, eventMeshSecret *kcorev1.Secret) error {
-	// gets oauth2ClientID and secret and stops the EventMesh subscription manager if changed
+	// gets oauth2ClientID and secret and stops the EventMesh subscription manager if changed and it cannot rotate them
 	err := r.syncOauth2ClientIDAndSecret(ctx, eventing)
 	if err != nil {
 		return fmt.Errorf("failed to sync OAuth secret: %w", err)
@@ -64,21 +63,27 @@ func (r *Reconciler) reconcileEventMeshSubManager(ctx context.Context, eventing
 	}
 
 	// Read the cluster domain from the Eventing CR, or
-	// read it from the configmap managed by gardener
-	domain, err := r.checkDomain(ctx, eventing.Spec.Backend.Config.Domain)
+	// discover it by the strategy configured in the Eventing CR
+	domain, err := r.checkDomain(ctx, eventing)
 	if err != nil {
 		return err
 	}
+	eventing.Status.Domain = domain
 
 	// get the subscription config
 	defaultSubsConfig := r.getDefaultSubscriptionConfig()
 	// get the subManager parameters
 	eventMeshSubMgrParams := r.getEventMeshSubManagerParams()
 	// get the hash of current config
+	eventTypeRewrites, err := env.EventTypeRewrites(eventing.Spec.Backend.Config.EventTypeRewrites).Encode()
+	if err != nil {
+		return err
+	}
 	specHash, err := getEventMeshBackendConfigHash(
 		eventing.Spec.Backend.Config.EventMeshSecret,
 		eventing.Spec.Backend.Config.EventTypePrefix,
 		domain,
+		eventTypeRewrites,
 	)
 	if err != nil {
 		return err
@@ -94,7 +99,7 @@ func (r *Reconciler) reconcileEventMeshSubManager(ctx context.Context, eventing
 
 	if r.eventMeshSubManager == nil {
 		// create instance of EventMesh subscription manager
-		eventMeshSubManager, err := r.subManagerFactory.NewEventMeshManager(domain)
+		eventMeshSubManager, err := r.subManagerFactory.NewEventMeshManager(*eventing, domain)
 		if err != nil {
 			return err
 		}
@@ -111,7 +116,7 @@ func (r *Reconciler) reconcileEventMeshSubManager(ctx context.Context, eventing
 
 	if r.isEventMeshSubManagerStarted {
 		r.namedLogger().Info("EventMesh subscription-manager is already started")
-		return nil
+		return r.rotateEventMeshCredentials(eventing, eventMeshSubMgrParams)
 	}
 
 	err = r.startEventMeshSubManager(defaultSubsConfig, eventMeshSubMgrParams)
@@ -126,23 +131,6 @@ func (r *Reconciler) reconcileEventMeshSubManager(ctx context.Context, eventing
 	return nil
 }
 
-func (r *Reconciler) checkDomain(ctx context.Context, domain string) (string, error) {
-	ret := domain
-	if utils.IsEmpty(ret) {
-		r.namedLogger().Infof(
-			`Domain is not configured in the Eventing CR, reading it from the ConfigMap %s/%s`,
-			shootInfoConfigMapNamespace, shootInfoConfigMapName,
-		)
-		cmDomain, err := r.readDomainFromConfigMap(ctx)
-		if err != nil || utils.IsEmpty(cmDomain) {
-			return "", domainMissingError(err)
-		}
-		ret = cmDomain
-	}
-	r.namedLogger().Infof(`Domain is %s`, ret)
-	return ret, nil
-}
-
 func (r *Reconciler) getEventMeshSubManagerParams() submgrmanager.Params {
 	return submgrmanager.Params{
 		submgrmanager.ParamNameClientID:     r.oauth2credentials.clientID,
@@ -165,6 +153,35 @@ func (r *Reconciler) startEventMeshSubManager(defaultSubsConfig env.DefaultSubsc
 	return nil
 }
 
+// rotateEventMeshCredentials swaps the current credentials into the started EventMesh subscription manager, and
+// reports the rotation in the Eventing CR status.
+func (r *Reconciler) rotateEventMeshCredentials(eventing *v1alpha1.Eventing,
+	eventMeshSubMgrParams submgrmanager.Params,
+) error {
+	credentialsRotator, ok := r.eventMeshSubManager.(submgrmanager.CredentialsRotator)
+	if !ok {
+		return nil
+	}
+
+	rotated, err := credentialsRotator.RotateCredentials(eventMeshSubMgrParams)
+	if err != nil {
+		eventing.Status.SetCredentialsRotatedConditionToFalse(err.Error())
+		return fmt.Errorf("failed to rotate EventMesh credentials: %w", err)
+	}
+	if rotated {
+		r.namedLogger().Info("EventMesh credentials are rotated")
+		eventing.Status.SetCredentialsRotatedConditionToTrue()
+	}
+	return nil
+}
+
+// canRotateEventMeshCredentials returns true if the started EventMesh subscription manager can rotate the
+// credentials in place.
+func (r *Reconciler) canRotateEventMeshCredentials() bool {
+	_, ok := r.eventMeshSubManager.(submgrmanager.CredentialsRotator)
+	return ok && r.isEventMeshSubManagerStarted
+}
+
 func (r *Reconciler) stopEventMeshSubManager(runCleanup bool, log *zap.SugaredLogger) error {
 	log.Debug("stopping EventMesh subscription-manager")
 	if r.eventMeshSubManager == nil || !r.isEventMeshSubManagerStarted {
@@ -181,6 +198,7 @@ func (r *Reconciler) stopEventMeshSubManager(runCleanup bool, log *zap.SugaredLo
 	// update flags so it does not try to stop the manager again.
 	r.isEventMeshSubManagerStarted = false
 	r.eventMeshSubManager = nil
+	r.healthRegistry.Remove(eventMeshHealthInstance)
 
 	return nil
 }
@@ -211,7 +229,10 @@ func (r *Reconciler) syncOauth2ClientIDAndSecret(ctx context.Context, eventing *
 			!bytes.Equal(r.oauth2credentials.tokenURL, credentials.tokenURL) ||
 			!bytes.Equal(r.oauth2credentials.certsURL, credentials.certsURL)
 	}
-	if oauth2CredentialsNotFound || oauth2CredentialsChanged {
+	if oauth2CredentialsChanged && r.canRotateEventMeshCredentials() {
+		// the credentials are rotated in place once the environment is set up
+		r.namedLogger().Info("OAuth2 credentials changed, rotating them in the EventMesh subscription manager")
+	} else if oauth2CredentialsNotFound || oauth2CredentialsChanged {
 		// Stop the controller and mark all subs as not ready
 		message := "Stopping the EventMesh subscription manager due to change in OAuth2 oauth2credentials"
 		r.namedLogger().Info(message)
@@ -397,5 +418,13 @@ func setUpEnvironmentForEventMesh(secret *kcorev1.Secret, eventingCR *v1alpha1.E
 		return fmt.Errorf("set EVENT_TYPE_PREFIX env var failed: %w", err)
 	}
 
+	eventTypeRewrites, err := env.EventTypeRewrites(eventingCR.Spec.Backend.Config.EventTypeRewrites).Encode()
+	if err != nil {
+		return fmt.Errorf("encode the event type rewrites failed: %w", err)
+	}
+	if err := os.Setenv("EVENT_TYPE_REWRITES", eventTypeRewrites); err != nil {
+		return fmt.Errorf("set EVENT_TYPE_REWRITES env var failed: %w", err)
+	}
+
 	return nil
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	kcorev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/kyma-project/eventing-manager/pkg/k8s"
 	k8smocks "github.com/kyma-project/eventing-manager/pkg/k8s/mocks"
 	"github.com/kyma-project/eventing-manager/pkg/logger"
+	submgrmanager "github.com/kyma-project/eventing-manager/pkg/subscriptionmanager/manager"
 	submgrmanagermocks "github.com/kyma-project/eventing-manager/pkg/subscriptionmanager/manager/mocks"
 	submgrmocks "github.com/kyma-project/eventing-manager/pkg/subscriptionmanager/mocks"
 	"github.com/kyma-project/eventing-manager/test/utils"
@@ -39,6 +41,12 @@ var (
 	ErrFailedToSyncPPSecret = errors.New("failed to sync Publisher Proxy secret: failed to apply patch")
 )
 
+// rotatingSubManagerMock is a subscription manager mock, which can rotate the credentials.
+type rotatingSubManagerMock struct {
+	*submgrmanagermocks.Manager
+	*submgrmanagermocks.CredentialsRotator
+}
+
 func Test_reconcileEventMeshSubManager(t *testing.T) {
 	t.Parallel()
 
@@ -175,7 +183,7 @@ func Test_reconcileEventMeshSubManager(t *testing.T) {
 			},
 			givenManagerFactoryMock: func(subManager *submgrmanagermocks.Manager) *submgrmocks.ManagerFactory {
 				subManagerFactoryMock := new(submgrmocks.ManagerFactory)
-				subManagerFactoryMock.On("NewEventMeshManager", mock.Anything).Return(subManager, nil).Once()
+				subManagerFactoryMock.On("NewEventMeshManager", mock.Anything, mock.Anything).Return(subManager, nil).Once()
 				return subManagerFactoryMock
 			},
 			givenKubeClientMock: func() k8s.Client {
@@ -207,7 +215,7 @@ func Test_reconcileEventMeshSubManager(t *testing.T) {
 			},
 			givenManagerFactoryMock: func(subManager *submgrmanagermocks.Manager) *submgrmocks.ManagerFactory {
 				subManagerFactoryMock := new(submgrmocks.ManagerFactory)
-				subManagerFactoryMock.On("NewEventMeshManager", mock.Anything).Return(subManager, nil).Once()
+				subManagerFactoryMock.On("NewEventMeshManager", mock.Anything, mock.Anything).Return(subManager, nil).Once()
 				return subManagerFactoryMock
 			},
 			givenKubeClientMock: func() k8s.Client {
@@ -241,7 +249,7 @@ func Test_reconcileEventMeshSubManager(t *testing.T) {
 			},
 			givenManagerFactoryMock: func(subManager *submgrmanagermocks.Manager) *submgrmocks.ManagerFactory {
 				subManagerFactoryMock := new(submgrmocks.ManagerFactory)
-				subManagerFactoryMock.On("NewEventMeshManager", mock.Anything).Return(subManager, nil).Once()
+				subManagerFactoryMock.On("NewEventMeshManager", mock.Anything, mock.Anything).Return(subManager, nil).Once()
 				return subManagerFactoryMock
 			},
 			givenKubeClientMock: func() k8s.Client {
@@ -373,7 +381,7 @@ func Test_reconcileEventMeshSubManager_ReadClusterDomain(t *testing.T) {
 			},
 			givenManagerFactoryMock: func(subManager *submgrmanagermocks.Manager) *submgrmocks.ManagerFactory {
 				subManagerFactoryMock := new(submgrmocks.ManagerFactory)
-				subManagerFactoryMock.On("NewEventMeshManager", mock.Anything).Return(subManager, nil).Once()
+				subManagerFactoryMock.On("NewEventMeshManager", mock.Anything, mock.Anything).Return(subManager, nil).Once()
 				return subManagerFactoryMock
 			},
 			givenKubeClientMock: func() (k8s.Client, *k8smocks.Client) {
@@ -404,7 +412,7 @@ func Test_reconcileEventMeshSubManager_ReadClusterDomain(t *testing.T) {
 			},
 			givenManagerFactoryMock: func(subManager *submgrmanagermocks.Manager) *submgrmocks.ManagerFactory {
 				subManagerFactoryMock := new(submgrmocks.ManagerFactory)
-				subManagerFactoryMock.On("NewEventMeshManager", mock.Anything).Return(subManager, nil).Once()
+				subManagerFactoryMock.On("NewEventMeshManager", mock.Anything, mock.Anything).Return(subManager, nil).Once()
 				return subManagerFactoryMock
 			},
 			givenKubeClientMock: func() (k8s.Client, *k8smocks.Client) {
@@ -946,6 +954,7 @@ func Test_syncOauth2ClientIDAndSecret(t *testing.T) {
 		givenSecret                    *kcorev1.Secret
 		givenCredentials               *oauth2Credentials
 		givenSubManagerStarted         bool
+		givenCredentialsRotator        bool
 		shouldEventMeshSubManagerExist bool
 		wantErr                        bool
 		wantCredentials                *oauth2Credentials
@@ -1017,6 +1026,44 @@ func Test_syncOauth2ClientIDAndSecret(t *testing.T) {
 			},
 			wantAssertCheck: true,
 		},
+		{
+			name: "oauth2 credentials changed and the subscription manager can rotate them",
+			givenEventing: utils.NewEventingCR(
+				utils.WithEventingCRNamespace("test-namespace"),
+				utils.WithEventMeshBackend("test-namespace/test-secret-name"),
+				utils.WithEventingPublisherData(2, 2, "199m", "99Mi", "399m", "199Mi"),
+				utils.WithEventingEventTypePrefix("test-prefix"),
+			),
+			givenSecret: &kcorev1.Secret{
+				ObjectMeta: kmetav1.ObjectMeta{
+					Namespace: "test-namespace",
+					Name:      defaultEventingWebhookAuthSecretName,
+				},
+				Data: map[string][]byte{
+					secretKeyClientID:     []byte("test-client-id-changed"),
+					secretKeyClientSecret: []byte("test-client-secret-changed"),
+					secretKeyTokenURL:     []byte("test-token-url-changed"),
+					secretKeyCertsURL:     []byte("test-certs-url-changed"),
+				},
+			},
+			givenCredentials: &oauth2Credentials{
+				clientID:     []byte("test-client-id"),
+				clientSecret: []byte("test-client-secret"),
+				tokenURL:     []byte("test-token-url"),
+				certsURL:     []byte("test-certs-url"),
+			},
+			givenCredentialsRotator:        true,
+			givenSubManagerStarted:         true,
+			shouldEventMeshSubManagerExist: true,
+			wantErr:                        false,
+			wantCredentials: &oauth2Credentials{
+				clientID:     []byte("test-client-id-changed"),
+				clientSecret: []byte("test-client-secret-changed"),
+				tokenURL:     []byte("test-token-url-changed"),
+				certsURL:     []byte("test-certs-url-changed"),
+			},
+			wantAssertCheck: true,
+		},
 		{
 			name: "no change in oauth2 credentials",
 			givenEventing: utils.NewEventingCR(
@@ -1076,8 +1123,16 @@ func Test_syncOauth2ClientIDAndSecret(t *testing.T) {
 			testEnv := NewMockedUnitTestEnvironment(t, tc.givenEventing)
 			testEnv.Reconciler.oauth2credentials = oauth2Credentials{}
 			eventMeshSubManagerMock := new(submgrmanagermocks.Manager)
-			eventMeshSubManagerMock.On("Stop", mock.Anything).Return(nil).Once()
 			testEnv.Reconciler.eventMeshSubManager = eventMeshSubManagerMock
+			if tc.givenCredentialsRotator {
+				// the credentials are rotated without stopping the subscription manager
+				testEnv.Reconciler.eventMeshSubManager = &rotatingSubManagerMock{
+					Manager:            eventMeshSubManagerMock,
+					CredentialsRotator: new(submgrmanagermocks.CredentialsRotator),
+				}
+			} else {
+				eventMeshSubManagerMock.On("Stop", mock.Anything).Return(nil).Once()
+			}
 			testEnv.Reconciler.backendConfig = env.BackendConfig{
 				EventingWebhookAuthSecretNamespace: tc.givenEventing.Namespace,
 				EventingWebhookAuthSecretName:      defaultEventingWebhookAuthSecretName,
@@ -1112,3 +1167,97 @@ func Test_syncOauth2ClientIDAndSecret(t *testing.T) {
 		})
 	}
 }
+
+func Test_rotateEventMeshCredentials(t *testing.T) {
+	t.Parallel()
+
+	givenParams := submgrmanager.Params{submgrmanager.ParamNameClientID: []byte("test-client-id")}
+
+	testCases := []struct {
+		name                    string
+		givenCredentialsRotator func() *submgrmanagermocks.CredentialsRotator
+		wantError               error
+		wantCondition           *kmetav1.Condition
+	}{
+		{
+			name:                    "it should do nothing because the subscription manager cannot rotate the credentials",
+			givenCredentialsRotator: func() *submgrmanagermocks.CredentialsRotator { return nil },
+		},
+		{
+			name: "it should not report the rotation because the credentials did not change",
+			givenCredentialsRotator: func() *submgrmanagermocks.CredentialsRotator {
+				rotatorMock := new(submgrmanagermocks.CredentialsRotator)
+				rotatorMock.On("RotateCredentials", givenParams).Return(false, nil).Once()
+				return rotatorMock
+			},
+		},
+		{
+			name: "it should report the rotated credentials",
+			givenCredentialsRotator: func() *submgrmanagermocks.CredentialsRotator {
+				rotatorMock := new(submgrmanagermocks.CredentialsRotator)
+				rotatorMock.On("RotateCredentials", givenParams).Return(true, nil).Once()
+				return rotatorMock
+			},
+			wantCondition: &kmetav1.Condition{
+				Type:    string(v1alpha1.ConditionCredentialsRotated),
+				Status:  kmetav1.ConditionTrue,
+				Reason:  string(v1alpha1.ConditionReasonCredentialsRotated),
+				Message: v1alpha1.ConditionCredentialsRotatedMessage,
+			},
+		},
+		{
+			name: "it should report the failed rotation",
+			givenCredentialsRotator: func() *submgrmanagermocks.CredentialsRotator {
+				rotatorMock := new(submgrmanagermocks.CredentialsRotator)
+				rotatorMock.On("RotateCredentials", givenParams).Return(true, ErrFailedToStart).Once()
+				return rotatorMock
+			},
+			wantError: ErrFailedToStart,
+			wantCondition: &kmetav1.Condition{
+				Type:    string(v1alpha1.ConditionCredentialsRotated),
+				Status:  kmetav1.ConditionFalse,
+				Reason:  string(v1alpha1.ConditionReasonCredentialsRotationFailed),
+				Message: ErrFailedToStart.Error(),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenEventing := utils.NewEventingCR(utils.WithEventMeshBackend("test-namespace/test-secret-name"))
+			testEnv := NewMockedUnitTestEnvironment(t, givenEventing)
+			eventMeshSubManagerMock := new(submgrmanagermocks.Manager)
+			testEnv.Reconciler.eventMeshSubManager = eventMeshSubManagerMock
+			rotatorMock := tc.givenCredentialsRotator()
+			if rotatorMock != nil {
+				testEnv.Reconciler.eventMeshSubManager = &rotatingSubManagerMock{
+					Manager:            eventMeshSubManagerMock,
+					CredentialsRotator: rotatorMock,
+				}
+			}
+
+			// when
+			err := testEnv.Reconciler.rotateEventMeshCredentials(givenEventing, givenParams)
+
+			// then
+			require.ErrorIs(t, err, tc.wantError)
+			gotCondition := meta.FindStatusCondition(givenEventing.Status.Conditions,
+				string(v1alpha1.ConditionCredentialsRotated))
+			if tc.wantCondition == nil {
+				require.Nil(t, gotCondition)
+			} else {
+				require.NotNil(t, gotCondition)
+				require.Equal(t, tc.wantCondition.Status, gotCondition.Status)
+				require.Equal(t, tc.wantCondition.Reason, gotCondition.Reason)
+				require.Equal(t, tc.wantCondition.Message, gotCondition.Message)
+			}
+			if rotatorMock != nil {
+				rotatorMock.AssertExpectations(t)
+			}
+		})
+	}
+}
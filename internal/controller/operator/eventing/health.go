@@ -0,0 +1,73 @@
+package eventing
+
+import (
+	"context"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/kyma-project/eventing-manager/api/operator/v1alpha1"
+	"github.com/kyma-project/eventing-manager/pkg/health"
+	"github.com/kyma-project/eventing-manager/pkg/subscriptionmanager/manager"
+)
+
+// eventMeshHealthInstance is the name of the EventMesh backend instance in the health registry, since only one
+// EventMesh instance is supported per cluster.
+const eventMeshHealthInstance = "EventMesh"
+
+// healthCheckConditionTypes maps the backend health checks to the conditions reporting them in the Eventing CR.
+//
+//nolint:gochecknoglobals // the mapping is constant.
+var healthCheckConditionTypes = map[string]v1alpha1.ConditionType{
+	health.CheckJetStreamAccount: v1alpha1.ConditionJetStreamAccountAvailable,
+	health.CheckJetStreamStream:  v1alpha1.ConditionJetStreamStreamAvailable,
+	health.CheckEventMeshToken:   v1alpha1.ConditionEventMeshTokenAvailable,
+	health.CheckEventMeshAPI:     v1alpha1.ConditionEventMeshAPIAvailable,
+}
+
+// natsHealthInstance returns the name of the NATS backend instance of the given Eventing CR in the health registry,
+// the NATS server is resolved per namespace.
+func natsHealthInstance(eventing *v1alpha1.Eventing) string {
+	return "NATS/" + eventing.Namespace
+}
+
+// updateBackendHealth registers the health checks of the given started subscription manager, which run in the
+// background, and reports their last results in the conditions of the given Eventing CR.
+func (r *Reconciler) updateBackendHealth(_ context.Context, eventing *v1alpha1.Eventing, instance string,
+	subManager manager.Manager,
+) {
+	healthChecker, ok := subManager.(manager.HealthChecker)
+	if r.healthRegistry == nil || !ok {
+		return
+	}
+
+	r.healthRegistry.Set(instance, healthChecker.HealthChecks())
+	for _, result := range r.healthRegistry.Results(instance) {
+		conditionType, found := healthCheckConditionTypes[result.Name]
+		if !found {
+			continue
+		}
+		if result.Err != nil {
+			r.namedLogger().Warnw("Backend health check failed", "check", result.Name, "error", result.Err)
+		}
+		eventing.Status.UpdateConditionHealthCheck(conditionType, result.Name, result.Err)
+	}
+}
+
+// handleBackendHealthChange enqueues the Eventing CRs of the given backend instance, when the results of its health
+// checks changed, so that the results are reported in their conditions.
+func (r *Reconciler) handleBackendHealthChange(instance string) {
+	eventingList := &v1alpha1.EventingList{}
+	var opts []client.ListOption
+	if namespace, found := strings.CutPrefix(instance, natsHealthInstance(&v1alpha1.Eventing{})); found {
+		opts = append(opts, client.InNamespace(namespace))
+	}
+	if err := r.Client.List(context.Background(), eventingList, opts...); err != nil {
+		r.namedLogger().Errorw("Failed to list the Eventing CRs to report the backend health", "error", err)
+		return
+	}
+	for i := range eventingList.Items {
+		r.genericEvents <- event.GenericEvent{Object: &eventingList.Items[i]}
+	}
+}
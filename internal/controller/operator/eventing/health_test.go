@@ -0,0 +1,142 @@
+package eventing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kyma-project/eventing-manager/api/operator/v1alpha1"
+	"github.com/kyma-project/eventing-manager/pkg/health"
+	submgrmanager "github.com/kyma-project/eventing-manager/pkg/subscriptionmanager/manager"
+	submgrmanagermocks "github.com/kyma-project/eventing-manager/pkg/subscriptionmanager/manager/mocks"
+	"github.com/kyma-project/eventing-manager/test/utils"
+)
+
+var errStreamNotFound = errors.New("stream not found")
+
+// healthCheckingSubManagerMock is a subscription manager mock, which has health checks.
+type healthCheckingSubManagerMock struct {
+	*submgrmanagermocks.Manager
+	*submgrmanagermocks.HealthChecker
+}
+
+func Test_updateBackendHealth(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name                string
+		givenHealthChecker  bool
+		wantConditionStatus map[v1alpha1.ConditionType]kmetav1.ConditionStatus
+	}{
+		{
+			name:                "it should do nothing because the subscription manager has no health checks",
+			givenHealthChecker:  false,
+			wantConditionStatus: map[v1alpha1.ConditionType]kmetav1.ConditionStatus{},
+		},
+		{
+			name:               "it should report the results of the health checks",
+			givenHealthChecker: true,
+			wantConditionStatus: map[v1alpha1.ConditionType]kmetav1.ConditionStatus{
+				v1alpha1.ConditionJetStreamAccountAvailable: kmetav1.ConditionTrue,
+				v1alpha1.ConditionJetStreamStreamAvailable:  kmetav1.ConditionFalse,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenEventing := utils.NewEventingCR(utils.WithEventingCRNamespace("test-namespace"))
+			testEnv := NewMockedUnitTestEnvironment(t, givenEventing)
+			registry := health.NewRegistry(time.Second, time.Hour)
+			testEnv.Reconciler.healthRegistry = registry
+			var subManager submgrmanager.Manager = new(submgrmanagermocks.Manager)
+			if tc.givenHealthChecker {
+				healthCheckerMock := new(submgrmanagermocks.HealthChecker)
+				healthCheckerMock.On("HealthChecks").Return([]health.Check{
+					{Name: health.CheckJetStreamAccount, Run: func(context.Context) error { return nil }},
+					{Name: health.CheckJetStreamStream, Run: func(context.Context) error { return errStreamNotFound }},
+				}).Twice()
+				subManager = &healthCheckingSubManagerMock{
+					Manager:       new(submgrmanagermocks.Manager),
+					HealthChecker: healthCheckerMock,
+				}
+			}
+
+			// when the checks are registered, then run in the background, and then reported
+			testEnv.Reconciler.updateBackendHealth(context.Background(), givenEventing,
+				natsHealthInstance(givenEventing), subManager)
+			require.Empty(t, givenEventing.Status.Conditions)
+			registry.Refresh(context.Background())
+			testEnv.Reconciler.updateBackendHealth(context.Background(), givenEventing,
+				natsHealthInstance(givenEventing), subManager)
+
+			// then
+			for conditionType, wantStatus := range tc.wantConditionStatus {
+				gotCondition := meta.FindStatusCondition(givenEventing.Status.Conditions, string(conditionType))
+				require.NotNil(t, gotCondition)
+				require.Equal(t, wantStatus, gotCondition.Status)
+			}
+			require.Len(t, registry.Results("NATS/test-namespace"), len(tc.wantConditionStatus))
+
+			testEnv.Reconciler.healthRegistry.Remove(natsHealthInstance(givenEventing))
+			require.Empty(t, registry.Results("NATS/test-namespace"))
+		})
+	}
+}
+
+func Test_handleBackendHealthChange(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		givenInstance string
+		wantEnqueued  []string
+	}{
+		{
+			name:          "it should enqueue the Eventing CRs of the namespace of the NATS instance",
+			givenInstance: "NATS/tenant",
+			wantEnqueued:  []string{"tenant/eventing"},
+		},
+		{
+			name:          "it should enqueue all the Eventing CRs for the EventMesh instance",
+			givenInstance: eventMeshHealthInstance,
+			wantEnqueued:  []string{"kyma-system/eventing", "tenant/eventing"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			testEnv := NewMockedUnitTestEnvironment(t,
+				utils.NewEventingCR(utils.WithEventingCRName("eventing"), utils.WithEventingCRNamespace("kyma-system")),
+				utils.NewEventingCR(utils.WithEventingCRName("eventing"), utils.WithEventingCRNamespace("tenant")),
+			)
+			genericEvents := testEnv.Reconciler.genericEvents
+
+			// when
+			go func() {
+				testEnv.Reconciler.handleBackendHealthChange(tc.givenInstance)
+				close(genericEvents)
+			}()
+
+			// then
+			var enqueued []string
+			for genericEvent := range genericEvents {
+				enqueued = append(enqueued, genericEvent.Object.GetNamespace()+"/"+genericEvent.Object.GetName())
+			}
+			require.ElementsMatch(t, tc.wantEnqueued, enqueued)
+		})
+	}
+}
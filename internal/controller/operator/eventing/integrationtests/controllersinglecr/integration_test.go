@@ -64,7 +64,7 @@ func Test_PreventMultipleEventingCRs(t *testing.T) {
 	t.Parallel()
 
 	errMsg := fmt.Sprintf("Only a single Eventing CR with name: %s and namespace: %s "+
-		"is allowed to be created in a Kyma cluster.", "eventing",
+		"is allowed to be created in a Kyma cluster without a namespaceSelector.", "eventing",
 		"kyma-system")
 
 	testCases := []struct {
@@ -4,17 +4,25 @@ import (
 	"context"
 	"fmt"
 
+	natsio "github.com/nats-io/nats.go"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	kcorev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 
 	"github.com/kyma-project/eventing-manager/api/operator/v1alpha1"
+	natsconnection "github.com/kyma-project/eventing-manager/internal/connection/nats"
 	"github.com/kyma-project/eventing-manager/options"
 	"github.com/kyma-project/eventing-manager/pkg/env"
+	pkgeventing "github.com/kyma-project/eventing-manager/pkg/eventing"
 	"github.com/kyma-project/eventing-manager/pkg/k8s"
 	"github.com/kyma-project/eventing-manager/pkg/subscriptionmanager/manager"
 )
 
-var ErrCannotBuildNATSURL = errors.New("NATS CR is not found to build NATS server URL")
+var (
+	ErrCannotBuildNATSURL           = errors.New("NATS CR is not found to build NATS server URL")
+	ErrNATSCredentialsSecretMissing = errors.New("the specified NATS credentials secret is not found")
+)
 
 func (r *Reconciler) reconcileNATSSubManager(eventing *v1alpha1.Eventing, log *zap.SugaredLogger) error {
 	// get the subscription config
@@ -24,6 +32,10 @@ func (r *Reconciler) reconcileNATSSubManager(eventing *v1alpha1.Eventing, log *z
 	if err != nil {
 		return err
 	}
+	// the sharded dispatcher dispatches the consumers of the default Eventing instance only.
+	if r.isIsolatedInstance(eventing) {
+		natsConfig.JSShardedDispatch = false
+	}
 	// get the hash of current config
 	specHash, err := r.getNATSBackendConfigHash(defaultSubsConfig, *natsConfig)
 	if err != nil {
@@ -31,19 +43,19 @@ func (r *Reconciler) reconcileNATSSubManager(eventing *v1alpha1.Eventing, log *z
 	}
 
 	// update the config if hashes differ
-	if eventing.Status.BackendConfigHash != specHash && r.isNATSSubManagerStarted {
+	if eventing.Status.BackendConfigHash != specHash && r.isNATSSubManagerStarted[eventing.Namespace] {
 		log.Infof("specHash does not match, old hash: %v, new hash: %v", eventing.Status.BackendConfigHash, specHash)
 		// stop the subsManager without cleanup
-		if err := r.stopNATSSubManager(false, log); err != nil {
+		if err := r.stopNATSSubManager(eventing, false, log); err != nil {
 			return err
 		}
 	} else if eventing.Status.BackendConfigHash != specHash {
 		// in case spec is change and subManager is not started yet (e.g. due to error)
-		// make natsSubManager nil to create a subManager with new values
-		r.natsSubManager = nil
+		// remove the natsSubManager to create a subManager with new values
+		delete(r.natsSubManagers, eventing.Namespace)
 	}
 
-	if r.natsSubManager == nil {
+	if r.natsSubManagers[eventing.Namespace] == nil {
 		// create instance of NATS subscription manager
 		natsSubManager := r.subManagerFactory.NewJetStreamManager(*eventing, *natsConfig)
 
@@ -54,15 +66,15 @@ func (r *Reconciler) reconcileNATSSubManager(eventing *v1alpha1.Eventing, log *z
 
 		log.Info("NATS subscription-manager initialized")
 		// save instance only when init is successful.
-		r.natsSubManager = natsSubManager
+		r.natsSubManagers[eventing.Namespace] = natsSubManager
 	}
 
-	if r.isNATSSubManagerStarted {
+	if r.isNATSSubManagerStarted[eventing.Namespace] {
 		log.Info("NATS subscription-manager is already started")
 		return nil
 	}
 
-	err = r.startNATSSubManager(defaultSubsConfig, log)
+	err = r.startNATSSubManager(eventing, defaultSubsConfig, log)
 	if err != nil {
 		return err
 	}
@@ -74,14 +86,16 @@ func (r *Reconciler) reconcileNATSSubManager(eventing *v1alpha1.Eventing, log *z
 	return nil
 }
 
-func (r *Reconciler) startNATSSubManager(defaultSubsConfig env.DefaultSubscriptionConfig, log *zap.SugaredLogger) error {
-	if err := r.natsSubManager.Start(defaultSubsConfig, manager.Params{}); err != nil {
+func (r *Reconciler) startNATSSubManager(eventing *v1alpha1.Eventing, defaultSubsConfig env.DefaultSubscriptionConfig,
+	log *zap.SugaredLogger,
+) error {
+	if err := r.natsSubManagers[eventing.Namespace].Start(defaultSubsConfig, manager.Params{}); err != nil {
 		return err
 	}
 
 	log.Info("NATS subscription-manager started")
 	// update flag so it do not try to start the manager again.
-	r.isNATSSubManagerStarted = true
+	r.isNATSSubManagerStarted[eventing.Namespace] = true
 	return nil
 }
 
@@ -90,26 +104,37 @@ func (r *Reconciler) getDefaultSubscriptionConfig() env.DefaultSubscriptionConfi
 		DefaultSubscriptionConfig
 }
 
-func (r *Reconciler) stopNATSSubManager(runCleanup bool, log *zap.SugaredLogger) error {
+func (r *Reconciler) stopNATSSubManager(eventing *v1alpha1.Eventing, runCleanup bool, log *zap.SugaredLogger) error {
 	log.Debug("stopping NATS subscription-manager")
-	if r.natsSubManager == nil || !r.isNATSSubManagerStarted {
+	natsSubManager := r.natsSubManagers[eventing.Namespace]
+	if natsSubManager == nil || !r.isNATSSubManagerStarted[eventing.Namespace] {
 		log.Info("NATS subscription-manager is already stopped!")
 		return nil
 	}
 
 	// stop the subscription manager.
-	if err := r.natsSubManager.Stop(runCleanup); err != nil {
+	if err := natsSubManager.Stop(runCleanup); err != nil {
 		return err
 	}
 
 	log.Info("NATS subscription-manager stopped!")
 	// update flags so it does not try to stop the manager again.
-	r.isNATSSubManagerStarted = false
-	r.natsSubManager = nil
+	delete(r.isNATSSubManagerStarted, eventing.Namespace)
+	delete(r.natsSubManagers, eventing.Namespace)
+	r.healthRegistry.Remove(natsHealthInstance(eventing))
 
 	return nil
 }
 
+// releaseNATS disconnects from NATS and stops watching the NATS CR of the given Eventing CR, which is deleted.
+func (r *Reconciler) releaseNATS(eventing *v1alpha1.Eventing) {
+	r.stopNATSCRWatch(eventing)
+	if natsConnection, found := r.natsConnections[eventing.Namespace]; found {
+		natsConnection.Disconnect()
+		delete(r.natsConnections, eventing.Namespace)
+	}
+}
+
 func NewNatsConfigHandler(
 	kubeClient k8s.Client,
 	opts *options.Options,
@@ -140,10 +165,18 @@ func (n *NatsConfigHandlerImpl) GetNatsConfig(ctx context.Context, eventing v1al
 	if err != nil {
 		return nil, err
 	}
+	// sets the credentials of the manager NATS user, if configured
+	err = n.setCredentialsToNatsConfig(ctx, &eventing, &natsConfig)
+	if err != nil {
+		return nil, err
+	}
 	natsConfig.JSStreamStorageType = eventing.Spec.Backend.Config.NATSStreamStorageType
 	natsConfig.JSStreamReplicas = eventing.Spec.Backend.Config.NATSStreamReplicas
 	natsConfig.JSStreamMaxBytes = eventing.Spec.Backend.Config.NATSStreamMaxSize.String()
 	natsConfig.JSStreamMaxMsgsPerTopic = int64(eventing.Spec.Backend.Config.NATSMaxMsgsPerTopic)
+	natsConfig.JSStreamDuplicates = eventing.Spec.Backend.Config.NATSStreamDuplicatesWindow.Duration
+	natsConfig.JSIdempotencyCacheSize = eventing.Spec.Backend.Config.NATSIdempotencyCacheSize
+	natsConfig.JSIdempotencyCacheTTL = eventing.Spec.Backend.Config.NATSIdempotencyCacheTTL.Duration
 	natsConfig.EventTypePrefix = eventing.Spec.Backend.Config.EventTypePrefix
 	return &natsConfig, nil
 }
@@ -157,6 +190,59 @@ func (n *NatsConfigHandlerImpl) setURLToNatsConfig(ctx context.Context, eventing
 	return nil
 }
 
+func (n *NatsConfigHandlerImpl) setCredentialsToNatsConfig(ctx context.Context, eventing *v1alpha1.Eventing,
+	natsConfig *env.NATSConfig,
+) error {
+	secret, err := getNATSCredentialsSecret(ctx, n.kubeClient, eventing)
+	if err != nil || secret == nil {
+		return err
+	}
+	natsConfig.UserCredentials = string(secret.Data[pkgeventing.NATSCredentialsManagerCredsKey])
+	natsConfig.User = string(secret.Data[pkgeventing.NATSCredentialsManagerUserKey])
+	natsConfig.Password = string(secret.Data[pkgeventing.NATSCredentialsManagerPasswordKey])
+	return nil
+}
+
+// getNATSCredentialsSecret returns the Secret with the NATS credentials referenced in the Eventing CR,
+// or nil if it is not referenced.
+func getNATSCredentialsSecret(ctx context.Context, kubeClient k8s.Client, eventing *v1alpha1.Eventing,
+) (*kcorev1.Secret, error) {
+	secretName := eventing.Spec.Backend.Config.NATSCredentialsSecret
+	if secretName == "" {
+		return nil, nil //nolint:nilnil // anonymous access to NATS.
+	}
+	secret, err := kubeClient.GetSecret(ctx, fmt.Sprintf("%s/%s", eventing.Namespace, secretName))
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: %w", ErrNATSCredentialsSecretMissing, err)
+		}
+		return nil, err
+	}
+	return secret, nil
+}
+
+// getNATSAuthOptions returns the options to connect to NATS as the manager NATS user of the given Secret,
+// or no options to connect anonymously if the Secret is nil.
+func getNATSAuthOptions(secret *kcorev1.Secret) ([]natsio.Option, error) {
+	if secret == nil {
+		return nil, nil
+	}
+	return natsconnection.AuthOptions(
+		string(secret.Data[pkgeventing.NATSCredentialsManagerCredsKey]),
+		string(secret.Data[pkgeventing.NATSCredentialsManagerUserKey]),
+		string(secret.Data[pkgeventing.NATSCredentialsManagerPasswordKey]),
+	)
+}
+
+// natsCredentialsVersion returns the version of the given NATS credentials Secret, which changes with its data,
+// or an empty version if the Secret is nil.
+func natsCredentialsVersion(secret *kcorev1.Secret) string {
+	if secret == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s", secret.UID, secret.ResourceVersion)
+}
+
 func (n *NatsConfigHandlerImpl) getNATSUrl(ctx context.Context, namespace string) (string, error) {
 	natsList, err := n.kubeClient.GetNATSResources(ctx, namespace)
 	if err != nil {
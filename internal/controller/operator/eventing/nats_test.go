@@ -10,6 +10,7 @@ import (
 	natstestutils "github.com/kyma-project/nats-manager/testutils"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	kcorev1 "k8s.io/api/core/v1"
 
 	"github.com/kyma-project/eventing-manager/api/operator/v1alpha1"
 	"github.com/kyma-project/eventing-manager/internal/controller/operator/eventing/mocks"
@@ -77,7 +78,7 @@ func Test_reconcileNATSSubManager(t *testing.T) {
 		{
 			name:                         "it should do nothing because subscription manager is already started",
 			givenIsNATSSubManagerStarted: true,
-			givenHashBefore:              int64(-7550677537009891034),
+			givenHashBefore:              int64(-275948840624597140),
 			givenNATSSubManagerMock: func() *submgrmanagermocks.Manager {
 				jetStreamSubManagerMock := new(submgrmanagermocks.Manager)
 				jetStreamSubManagerMock.On("Start", mock.Anything, mock.Anything).Return(nil).Once()
@@ -97,7 +98,7 @@ func Test_reconcileNATSSubManager(t *testing.T) {
 			givenManagerFactoryMock: func(_ *submgrmanagermocks.Manager) *submgrmocks.ManagerFactory {
 				return nil
 			},
-			wantHashAfter: int64(-7550677537009891034),
+			wantHashAfter: int64(-275948840624597140),
 		},
 		{
 			name: "it should initialize and start subscription manager because " +
@@ -126,13 +127,13 @@ func Test_reconcileNATSSubManager(t *testing.T) {
 				return subManagerFactoryMock
 			},
 			wantAssertCheck: true,
-			wantHashAfter:   int64(-7550677537009891034),
+			wantHashAfter:   int64(-275948840624597140),
 		},
 		{
 			name: "it should retry to start subscription manager when subscription manager was " +
 				"successfully initialized but failed to start",
 			givenIsNATSSubManagerStarted: false,
-			givenHashBefore:              int64(-7550677537009891034),
+			givenHashBefore:              int64(-275948840624597140),
 			givenNATSSubManagerMock: func() *submgrmanagermocks.Manager {
 				jetStreamSubManagerMock := new(submgrmanagermocks.Manager)
 				jetStreamSubManagerMock.On("Init", mock.Anything).Return(nil).Once()
@@ -157,7 +158,7 @@ func Test_reconcileNATSSubManager(t *testing.T) {
 			wantAssertCheck:  true,
 			givenShouldRetry: true,
 			wantError:        ErrUseMeInMocks,
-			wantHashAfter:    int64(-7550677537009891034),
+			wantHashAfter:    int64(-275948840624597140),
 		},
 		{
 			name:                         "it should update the subscription manager when the backend config changes",
@@ -187,7 +188,7 @@ func Test_reconcileNATSSubManager(t *testing.T) {
 				return subManagerFactoryMock
 			},
 			wantAssertCheck: true,
-			wantHashAfter:   int64(-7550677537009891034),
+			wantHashAfter:   int64(-275948840624597140),
 		},
 		{
 			name: "it should update the subscription manager when the backend config changes" +
@@ -217,7 +218,7 @@ func Test_reconcileNATSSubManager(t *testing.T) {
 				return subManagerFactoryMock
 			},
 			wantAssertCheck: true,
-			wantHashAfter:   int64(-7550677537009891034),
+			wantHashAfter:   int64(-275948840624597140),
 		},
 	}
 
@@ -236,13 +237,12 @@ func Test_reconcileNATSSubManager(t *testing.T) {
 			givenNatConfigHandlerMock := tc.givenNatsConfigHandlerMock()
 
 			// connect mocks with reconciler.
-			testEnv.Reconciler.isNATSSubManagerStarted = tc.givenIsNATSSubManagerStarted
+			testEnv.Reconciler.isNATSSubManagerStarted[givenEventing.Namespace] = tc.givenIsNATSSubManagerStarted
 			testEnv.Reconciler.eventingManager = givenEventingManagerMock
 			testEnv.Reconciler.natsConfigHandler = givenNatConfigHandlerMock
 			testEnv.Reconciler.subManagerFactory = givenManagerFactoryMock
-			testEnv.Reconciler.natsSubManager = nil
 			if givenManagerFactoryMock == nil || tc.givenUpdateTest {
-				testEnv.Reconciler.natsSubManager = givenNATSSubManagerMock
+				testEnv.Reconciler.natsSubManagers[givenEventing.Namespace] = givenNATSSubManagerMock
 			}
 
 			// set the backend hash before depending on test
@@ -270,8 +270,8 @@ func Test_reconcileNATSSubManager(t *testing.T) {
 				require.Equal(t, tc.wantError.Error(), err.Error())
 			} else {
 				require.NoError(t, err)
-				require.NotNil(t, testEnv.Reconciler.natsSubManager)
-				require.True(t, testEnv.Reconciler.isNATSSubManagerStarted)
+				require.NotNil(t, testEnv.Reconciler.natsSubManagers[givenEventing.Namespace])
+				require.True(t, testEnv.Reconciler.isNATSSubManagerStarted[givenEventing.Namespace])
 			}
 
 			if tc.wantAssertCheck {
@@ -336,6 +336,7 @@ func Test_stopNATSSubManager(t *testing.T) {
 			t.Parallel()
 
 			// given
+			givenEventing := utils.NewEventingCR()
 			testEnv := NewMockedUnitTestEnvironment(t)
 			logger := testEnv.Reconciler.logger.WithContext().Named(ControllerName)
 
@@ -343,16 +344,16 @@ func Test_stopNATSSubManager(t *testing.T) {
 			givenNATSSubManagerMock := tc.givenNATSSubManagerMock()
 
 			// connect mocks with reconciler.
-			testEnv.Reconciler.natsSubManager = givenNATSSubManagerMock
-			testEnv.Reconciler.isNATSSubManagerStarted = tc.givenIsNATSSubManagerStarted
+			testEnv.Reconciler.natsSubManagers[givenEventing.Namespace] = givenNATSSubManagerMock
+			testEnv.Reconciler.isNATSSubManagerStarted[givenEventing.Namespace] = tc.givenIsNATSSubManagerStarted
 
 			// when
-			err := testEnv.Reconciler.stopNATSSubManager(true, logger)
+			err := testEnv.Reconciler.stopNATSSubManager(givenEventing, true, logger)
 			// then
 			if tc.wantError == nil {
 				require.NoError(t, err)
-				require.Nil(t, testEnv.Reconciler.natsSubManager)
-				require.False(t, testEnv.Reconciler.isNATSSubManagerStarted)
+				require.Nil(t, testEnv.Reconciler.natsSubManagers[givenEventing.Namespace])
+				require.False(t, testEnv.Reconciler.isNATSSubManagerStarted[givenEventing.Namespace])
 			} else {
 				require.Equal(t, tc.wantError.Error(), err.Error())
 			}
@@ -371,6 +372,7 @@ func Test_GetNatsConfig(t *testing.T) {
 		eventing           *v1alpha1.Eventing
 		expectedConfig     *env.NATSConfig
 		givenNatsResources []natsv1alpha1.NATS
+		givenSecret        *kcorev1.Secret
 		expectedError      error
 	}{
 		{
@@ -406,6 +408,60 @@ func Test_GetNatsConfig(t *testing.T) {
 				JSStreamRetentionPolicy: "interest",
 				JSStreamDiscardPolicy:   "new",
 				JSConsumerDeliverPolicy: "new",
+				JSDrainTimeout:          20 * time.Second,
+				JSReplyMaxHops:          3,
+				JSLagCollectionInterval: 30 * time.Second,
+				JSStreamMaxMessages:     -1,
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Update NATSConfig with the credentials of the manager NATS user",
+			eventing: utils.NewEventingCR(
+				utils.WithEventingCRName("test-eventing"),
+				utils.WithEventingCRNamespace("test-namespace"),
+				utils.WithEventingCRMinimal(),
+				utils.WithEventingStreamData("File", "700Mi", 2, 1000),
+				utils.WithEventingEventTypePrefix("test-prefix"),
+				utils.WithEventingNATSCredentialsSecret("test-nats-credentials"),
+			),
+			givenNatsResources: []natsv1alpha1.NATS{
+				*natstestutils.NewNATSCR(
+					natstestutils.WithNATSCRName("test-nats"),
+					natstestutils.WithNATSCRNamespace("test-namespace"),
+				),
+			},
+			givenSecret: &kcorev1.Secret{
+				Data: map[string][]byte{
+					"manager.user":       []byte("manager"),
+					"manager.password":   []byte("manager-password"),
+					"publisher.user":     []byte("publisher"),
+					"publisher.password": []byte("publisher-password"),
+				},
+			},
+			expectedConfig: &env.NATSConfig{
+				URL:                     "nats://test-nats.test-namespace.svc.cluster.local:4222",
+				User:                    "manager",
+				Password:                "manager-password",
+				EventTypePrefix:         "test-prefix",
+				JSStreamStorageType:     "File",
+				JSStreamReplicas:        2,
+				JSStreamMaxBytes:        "700Mi",
+				JSStreamMaxMsgsPerTopic: 1000,
+				MaxReconnects:           10,
+				ReconnectWait:           3 * time.Second,
+				MaxIdleConns:            50,
+				MaxConnsPerHost:         50,
+				MaxIdleConnsPerHost:     50,
+				IdleConnTimeout:         10 * time.Second,
+				JSStreamName:            "sap",
+				JSSubjectPrefix:         "",
+				JSStreamRetentionPolicy: "interest",
+				JSStreamDiscardPolicy:   "new",
+				JSConsumerDeliverPolicy: "new",
+				JSDrainTimeout:          20 * time.Second,
+				JSReplyMaxHops:          3,
+				JSLagCollectionInterval: 30 * time.Second,
 				JSStreamMaxMessages:     -1,
 			},
 			expectedError: nil,
@@ -436,6 +492,9 @@ func Test_GetNatsConfig(t *testing.T) {
 			kubeClient.On("GetNATSResources", ctx, tc.eventing.Namespace).Return(&natsv1alpha1.NATSList{
 				Items: tc.givenNatsResources,
 			}, tc.expectedError)
+			if tc.givenSecret != nil {
+				kubeClient.On("GetSecret", ctx, "test-namespace/test-nats-credentials").Return(tc.givenSecret, nil)
+			}
 
 			natsConfigHandler := NatsConfigHandlerImpl{
 				kubeClient: kubeClient,
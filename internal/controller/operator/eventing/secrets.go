@@ -0,0 +1,116 @@
+package eventing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/hashstructure/v2"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/kyma-project/eventing-manager/api/operator/v1alpha1"
+)
+
+// secretResyncPeriod is the period in which the Secrets used by the Eventing CRs are read to detect changed
+// credentials. The Secrets are read directly instead of being watched, to not cache all the Secrets of the cluster.
+const secretResyncPeriod = 30 * time.Second
+
+// resyncSecretsPeriodically resyncs the Secrets used by the Eventing CRs until the given context is done.
+func (r *Reconciler) resyncSecretsPeriodically(ctx context.Context) error {
+	ticker := time.NewTicker(secretResyncPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			r.resyncSecrets(ctx)
+		}
+	}
+}
+
+// resyncSecrets reads the Secrets used by the Eventing CRs either as the EventMesh Secret, as the webhook auth
+// Secret, or as the NATS credentials Secret, and enqueues the Eventing CRs whose Secrets changed their data since
+// the last resync, so that the changed credentials are rotated. The Secrets read for the first time are only
+// recorded, since the Eventing CRs are reconciled with them anyway.
+func (r *Reconciler) resyncSecrets(ctx context.Context) {
+	eventings := &v1alpha1.EventingList{}
+	if err := r.Client.List(ctx, eventings); err != nil {
+		r.namedLogger().Errorw("Failed to list Eventing CRs", "error", err)
+		return
+	}
+
+	versions := make(map[types.NamespacedName]string)
+	for i := range eventings.Items {
+		eventing := &eventings.Items[i]
+		changed := false
+		for _, secretName := range r.usedSecrets(eventing) {
+			version, found := versions[secretName]
+			if !found {
+				var err error
+				if version, err = r.secretDataVersion(ctx, secretName); err != nil {
+					r.namedLogger().Errorw("Failed to read the Secret used by the Eventing CR",
+						"secret", secretName.String(), "error", err)
+					// keep the last version, so that the change is detected in the next resync.
+					version = r.secretVersions[secretName]
+				}
+				versions[secretName] = version
+			}
+			lastVersion, known := r.secretVersions[secretName]
+			if known && lastVersion != version {
+				changed = true
+			}
+		}
+		if changed {
+			r.genericEvents <- event.GenericEvent{Object: eventing}
+		}
+	}
+	r.secretVersions = versions
+}
+
+// usedSecrets returns the Secrets used by the given Eventing CR.
+func (r *Reconciler) usedSecrets(eventing *v1alpha1.Eventing) []types.NamespacedName {
+	if eventing.Spec.Backend == nil {
+		return nil
+	}
+	var secrets []types.NamespacedName
+	switch eventing.Spec.Backend.Type {
+	case v1alpha1.EventMeshBackendType:
+		// the EventMesh Secret is referenced as <namespace>/<name>.
+		if namespace, name, found := strings.Cut(eventing.Spec.Backend.Config.EventMeshSecret, "/"); found {
+			secrets = append(secrets, types.NamespacedName{Namespace: namespace, Name: name})
+		}
+		secrets = append(secrets, types.NamespacedName{
+			Namespace: eventing.Namespace,
+			Name:      r.backendConfig.EventingWebhookAuthSecretName,
+		})
+	case v1alpha1.NatsBackendType:
+		if eventing.Spec.Backend.Config.NATSCredentialsSecret != "" {
+			secrets = append(secrets, types.NamespacedName{
+				Namespace: eventing.Namespace,
+				Name:      eventing.Spec.Backend.Config.NATSCredentialsSecret,
+			})
+		}
+	}
+	return secrets
+}
+
+// secretDataVersion returns the version of the data of the given Secret, which changes with its data only,
+// or an empty version if the Secret does not exist.
+func (r *Reconciler) secretDataVersion(ctx context.Context, secretName types.NamespacedName) (string, error) {
+	secret, err := r.kubeClient.GetSecret(ctx, secretName.String())
+	if kerrors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	hash, err := hashstructure.Hash(secret.Data, hashstructure.FormatV2, nil)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d", hash), nil
+}
@@ -0,0 +1,131 @@
+package eventing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	kcorev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/kyma-project/eventing-manager/pkg/env"
+	"github.com/kyma-project/eventing-manager/test/utils"
+)
+
+func Test_resyncSecrets(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name         string
+		givenSecret  *kcorev1.Secret
+		givenDeleted bool
+		wantEnqueued []string
+	}{
+		{
+			name:         "should enqueue the Eventing CR if the data of its EventMesh Secret changed",
+			givenSecret:  newSecret("eventmesh-secret", "test-namespace"),
+			wantEnqueued: []string{"test-namespace/eventmesh"},
+		},
+		{
+			name:         "should enqueue the Eventing CR if the data of its webhook auth Secret changed",
+			givenSecret:  newSecret(defaultEventingWebhookAuthSecretName, "test-namespace"),
+			wantEnqueued: []string{"test-namespace/eventmesh"},
+		},
+		{
+			name:         "should enqueue the Eventing CR if its webhook auth Secret is deleted",
+			givenSecret:  newSecret(defaultEventingWebhookAuthSecretName, "test-namespace"),
+			givenDeleted: true,
+			wantEnqueued: []string{"test-namespace/eventmesh"},
+		},
+		{
+			name:         "should enqueue the Eventing CR if the data of its NATS credentials Secret changed",
+			givenSecret:  newSecret("nats-credentials", "nats-namespace"),
+			wantEnqueued: []string{"nats-namespace/nats-credentials"},
+		},
+		{
+			name:         "should not enqueue any Eventing CR if the data of other Secrets changed",
+			givenSecret:  newSecret(defaultEventingWebhookAuthSecretName, "kyma-system"),
+			wantEnqueued: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			eventMeshEventing := utils.NewEventingCR(
+				utils.WithEventingCRName("eventmesh"),
+				utils.WithEventingCRNamespace("test-namespace"),
+				utils.WithEventMeshBackend("eventmesh-secret"),
+			)
+			natsEventing := utils.NewEventingCR(
+				utils.WithEventingCRName("nats"),
+				utils.WithEventingCRNamespace("test-namespace"),
+				utils.WithNATSBackend(),
+			)
+			natsCredentialsEventing := utils.NewEventingCR(
+				utils.WithEventingCRName("nats-credentials"),
+				utils.WithEventingCRNamespace("nats-namespace"),
+				utils.WithNATSBackend(),
+				utils.WithEventingNATSCredentialsSecret("nats-credentials"),
+			)
+			testEnv := NewMockedUnitTestEnvironment(t, eventMeshEventing, natsEventing, natsCredentialsEventing,
+				newSecret("eventmesh-secret", "test-namespace"),
+				newSecret(defaultEventingWebhookAuthSecretName, "test-namespace"),
+				newSecret(defaultEventingWebhookAuthSecretName, "kyma-system"),
+				newSecret("nats-credentials", "nats-namespace"),
+			)
+			testEnv.Reconciler.backendConfig = env.BackendConfig{
+				EventingWebhookAuthSecretName: defaultEventingWebhookAuthSecretName,
+			}
+
+			// when the Secrets are resynced for the first time
+			enqueued := resyncSecretsAndCollect(testEnv.Reconciler)
+
+			// then they are only recorded
+			require.Empty(t, enqueued)
+
+			// when the Secret changed
+			secret := &kcorev1.Secret{}
+			secretKey := client.ObjectKeyFromObject(tc.givenSecret)
+			require.NoError(t, testEnv.Client.Get(context.Background(), secretKey, secret))
+			if tc.givenDeleted {
+				require.NoError(t, testEnv.Client.Delete(context.Background(), secret))
+			} else {
+				secret.Data = map[string][]byte{"client_secret": []byte("rotated")}
+				require.NoError(t, testEnv.Client.Update(context.Background(), secret))
+			}
+			enqueued = resyncSecretsAndCollect(testEnv.Reconciler)
+
+			// then
+			require.Equal(t, tc.wantEnqueued, enqueued)
+
+			// when the Secrets are resynced again without changes
+			enqueued = resyncSecretsAndCollect(testEnv.Reconciler)
+
+			// then
+			require.Empty(t, enqueued)
+		})
+	}
+}
+
+// resyncSecretsAndCollect resyncs the Secrets and returns the namespaced names of the enqueued Eventing CRs.
+func resyncSecretsAndCollect(r *Reconciler) []string {
+	genericEvents := make(chan event.GenericEvent)
+	r.genericEvents = genericEvents
+	done := make(chan struct{})
+	var enqueued []string
+	go func() {
+		defer close(done)
+		for genericEvent := range genericEvents {
+			enqueued = append(enqueued, genericEvent.Object.GetNamespace()+"/"+genericEvent.Object.GetName())
+		}
+	}()
+	r.resyncSecrets(context.Background())
+	close(genericEvents)
+	<-done
+	return enqueued
+}
@@ -77,6 +77,19 @@ func (r *Reconciler) syncStatusWithPublisherProxyErrWithReason(ctx context.Conte
 	return errors.Join(err, r.syncEventingStatus(ctx, eventing, log))
 }
 
+// syncStatusWithNotAllowedErr updates Publisher Proxy condition and sets an error state, if the Eventing CR
+// is not allowed to be reconciled. Returns the error of the status update only, as retrying does not help.
+func (r *Reconciler) syncStatusWithNotAllowedErr(ctx context.Context,
+	eventing *operatorv1alpha1.Eventing, reason operatorv1alpha1.ConditionReason, message string,
+	log *zap.SugaredLogger,
+) error {
+	// Set error state in status
+	eventing.Status.SetStateError()
+	eventing.Status.UpdateConditionPublisherProxyReady(kmetav1.ConditionFalse, reason, message)
+
+	return r.syncEventingStatus(ctx, eventing, log)
+}
+
 // syncStatusWithSubscriptionManagerErr updates subscription manager condition and sets an error state.
 // Returns the relevant error.
 func (r *Reconciler) syncStatusWithSubscriptionManagerErr(ctx context.Context,
@@ -0,0 +1,26 @@
+package eventing
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/kyma-project/eventing-manager/api/operator/v1alpha1"
+	"github.com/kyma-project/eventing-manager/pkg/tracing"
+)
+
+// NewTracingEndpointSource returns the source of the OTLP endpoint of the tracing of the default Eventing CR with
+// the given key, since the tracing applies to the event dispatching of all the Eventing instances. The endpoint is
+// empty if the tracing is not configured, or if the default Eventing CR does not exist or is deleted.
+func NewTracingEndpointSource(reader client.Reader, key client.ObjectKey) tracing.EndpointSource {
+	return func(ctx context.Context) (string, error) {
+		eventingCR := &operatorv1alpha1.Eventing{}
+		if err := reader.Get(ctx, key, eventingCR); err != nil {
+			return "", client.IgnoreNotFound(err)
+		}
+		if eventingCR.Spec.Tracing == nil || !eventingCR.DeletionTimestamp.IsZero() {
+			return "", nil
+		}
+		return eventingCR.Spec.Tracing.Endpoint, nil
+	}
+}
@@ -0,0 +1,80 @@
+package eventing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha1 "github.com/kyma-project/eventing-manager/api/operator/v1alpha1"
+	"github.com/kyma-project/eventing-manager/test/utils"
+)
+
+func Test_NewTracingEndpointSource(t *testing.T) {
+	t.Parallel()
+
+	const endpoint = "http://telemetry-otlp-traces.kyma-system:4318"
+
+	testCases := []struct {
+		name         string
+		givenTracing *operatorv1alpha1.Tracing
+		givenDeleted bool
+		givenKeyName string
+		wantEndpoint string
+	}{
+		{
+			name:         "it should return the endpoint of the default Eventing CR",
+			givenTracing: &operatorv1alpha1.Tracing{Endpoint: endpoint},
+			wantEndpoint: endpoint,
+		},
+		{
+			name:         "it should return no endpoint if the tracing is not configured",
+			wantEndpoint: "",
+		},
+		{
+			name:         "it should return no endpoint if the default Eventing CR is deleted",
+			givenTracing: &operatorv1alpha1.Tracing{Endpoint: endpoint},
+			givenDeleted: true,
+			wantEndpoint: "",
+		},
+		{
+			name:         "it should return no endpoint if the default Eventing CR does not exist",
+			givenTracing: &operatorv1alpha1.Tracing{Endpoint: endpoint},
+			givenKeyName: "default",
+			wantEndpoint: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			givenEventing := utils.NewEventingCR(
+				utils.WithEventingCRName("eventing"),
+				utils.WithEventingCRNamespace("kyma-system"),
+				utils.WithEventingCRFinalizer(FinalizerName),
+			)
+			givenEventing.Spec.Tracing = tc.givenTracing
+			if tc.givenDeleted {
+				now := kmetav1.Now()
+				givenEventing.DeletionTimestamp = &now
+			}
+			testEnv := NewMockedUnitTestEnvironment(t, givenEventing)
+			key := client.ObjectKeyFromObject(givenEventing)
+			if tc.givenKeyName != "" {
+				key.Name = tc.givenKeyName
+			}
+
+			// when
+			gotEndpoint, err := NewTracingEndpointSource(testEnv.Client, key)(context.Background())
+
+			// then
+			require.NoError(t, err)
+			require.Equal(t, tc.wantEndpoint, gotEndpoint)
+		})
+	}
+}
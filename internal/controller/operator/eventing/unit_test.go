@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 	kadmissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	kcorev1 "k8s.io/api/core/v1"
+	kapiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	kapixclientsetfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -52,6 +53,8 @@ func NewMockedUnitTestEnvironment(t *testing.T, objs ...client.Object) *MockedUn
 	require.NoError(t, err)
 	err = kadmissionregistrationv1.AddToScheme(newScheme)
 	require.NoError(t, err)
+	err = kapiextensionsv1.AddToScheme(newScheme)
+	require.NoError(t, err)
 
 	// Create a fake dynamic client
 	fakeDynamicClient := kdynamicfake.NewSimpleDynamicClient(newScheme)
@@ -85,6 +88,8 @@ func NewMockedUnitTestEnvironment(t *testing.T, objs ...client.Object) *MockedUn
 		opts,
 		nil,
 		nil,
+		nil,
+		nil,
 	)
 	reconciler.ctrlManager = mockManager
 
@@ -45,8 +45,12 @@ func (r *Reconciler) getNATSBackendConfigHash(defaultSubscriptionConfig env.Defa
 	return int64(hash), nil
 }
 
-func getEventMeshBackendConfigHash(eventMeshSecret, eventTypePrefix, domain string) (int64, error) {
+func getEventMeshBackendConfigHash(eventMeshSecret, eventTypePrefix, domain, eventTypeRewrites string) (int64, error) {
 	eventMeshBackendConfig := fmt.Sprintf("[%s][%s][%s]", eventMeshSecret, eventTypePrefix, domain)
+	// the rewrites are appended only if set, so that the hash of the existing configs does not change.
+	if len(eventTypeRewrites) > 0 {
+		eventMeshBackendConfig += fmt.Sprintf("[%s]", eventTypeRewrites)
+	}
 	hash, err := hashstructure.Hash(eventMeshBackendConfig, hashstructure.FormatV2, nil)
 	if err != nil {
 		return 0, err
@@ -90,19 +90,23 @@ func Test_removeFinalizer(t *testing.T) {
 }
 
 func TestReconciler_getEventMeshBackendConfigHash(t *testing.T) {
-	hash, err := getEventMeshBackendConfigHash("kyma-system/eventing-backend", "sap.kyma.custom", "domain.com")
+	hash, err := getEventMeshBackendConfigHash("kyma-system/eventing-backend", "sap.kyma.custom", "domain.com", "")
 	require.NoError(t, err)
 	require.NotZero(t, hash)
 }
 
 func TestReconciler_getEventMeshBackendConfigHash_EnsureConsistencyAndUniqueness(t *testing.T) {
-	hash1, err1 := getEventMeshBackendConfigHash("kyma-system/eventing-backend", "sap.kyma.custom", "domain.com")
+	hash1, err1 := getEventMeshBackendConfigHash("kyma-system/eventing-backend", "sap.kyma.custom", "domain.com", "")
 	require.NoError(t, err1)
-	hash2, err2 := getEventMeshBackendConfigHash("kyma-system/eventing-backend", "sap.kyma.custom", "domain.com")
+	hash2, err2 := getEventMeshBackendConfigHash("kyma-system/eventing-backend", "sap.kyma.custom", "domain.com", "")
 	require.NoError(t, err2)
-	hash3, err3 := getEventMeshBackendConfigHash("kyma-system/eventing-backen", "dsap.kyma.cust", "omdomain.com")
+	hash3, err3 := getEventMeshBackendConfigHash("kyma-system/eventing-backen", "dsap.kyma.cust", "omdomain.com", "")
 	require.NoError(t, err3)
+	hash4, err4 := getEventMeshBackendConfigHash("kyma-system/eventing-backend", "sap.kyma.custom", "domain.com",
+		`[{"from":"sap.kyma.custom.oldapp.*","to":"sap.kyma.custom.newapp.*"}]`)
+	require.NoError(t, err4)
 
 	require.Equal(t, hash1, hash2)
 	require.NotEqual(t, hash1, hash3)
+	require.NotEqual(t, hash1, hash4)
 }
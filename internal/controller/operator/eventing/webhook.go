@@ -1,17 +1,19 @@
 package eventing
 
 import (
-	"bytes"
 	"context"
 
 	"github.com/pkg/errors"
+	kapiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	emerrors "github.com/kyma-project/eventing-manager/pkg/errors"
+	"github.com/kyma-project/eventing-manager/pkg/k8s"
+	"github.com/kyma-project/eventing-manager/pkg/webhookcert"
 )
 
 const (
-	TLSCertField = "tls.crt"
+	TLSCertField = webhookcert.TLSCertField
 )
 
 var (
@@ -19,7 +21,8 @@ var (
 	errInvalidObject  = errors.New("invalid object")
 )
 
-// reconcileWebhooksWithCABundle injects the CABundle into mutating and validating webhooks.
+// reconcileWebhooksWithCABundle injects the CABundle into mutating and validating webhooks, and into the conversion
+// webhook of the Subscription CRD.
 func (r *Reconciler) reconcileWebhooksWithCABundle(ctx context.Context) error {
 	// get the secret containing the certificate
 	secretKey := client.ObjectKey{
@@ -55,26 +58,31 @@ func (r *Reconciler) reconcileWebhooksWithCABundle(ctx context.Context) error {
 				r.backendConfig.ValidatingWebhookName))
 	}
 
-	// check if the CABundle present is valid
-	if !(mutatingWH.Webhooks[0].ClientConfig.CABundle != nil &&
-		bytes.Equal(mutatingWH.Webhooks[0].ClientConfig.CABundle, certificateSecret.Data[TLSCertField])) {
-		// update the ClientConfig for mutating WH config
-		mutatingWH.Webhooks[0].ClientConfig.CABundle = certificateSecret.Data[TLSCertField]
+	// inject the CABundle into all the webhooks.
+	caBundle := webhookcert.CABundle(certificateSecret.Data)
+	if webhookcert.InjectIntoMutatingWebhooks(mutatingWH, caBundle) {
 		// update the mutating WH on k8s.
 		if err = r.Client.Update(ctx, mutatingWH); err != nil {
 			return errors.Wrap(err, "while updating mutatingWH with caBundle")
 		}
 	}
-
-	if !(validatingWH.Webhooks[0].ClientConfig.CABundle != nil &&
-		bytes.Equal(validatingWH.Webhooks[0].ClientConfig.CABundle, certificateSecret.Data[TLSCertField])) {
-		// update the ClientConfig for validating WH config
-		validatingWH.Webhooks[0].ClientConfig.CABundle = certificateSecret.Data[TLSCertField]
+	if webhookcert.InjectIntoValidatingWebhooks(validatingWH, caBundle) {
 		// update the validating WH on k8s.
 		if err = r.Client.Update(ctx, validatingWH); err != nil {
 			return errors.Wrap(err, "while updating validatingWH with caBundle")
 		}
 	}
 
+	// inject the CABundle into the conversion webhook of the Subscription CRD, if it is installed.
+	crd := &kapiextensionsv1.CustomResourceDefinition{}
+	if err = r.Client.Get(ctx, client.ObjectKey{Name: k8s.SubscriptionCrdName}, crd); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if webhookcert.InjectIntoConversionWebhook(crd, caBundle) {
+		if err = r.Client.Update(ctx, crd); err != nil {
+			return errors.Wrap(err, "while updating the Subscription CRD with caBundle")
+		}
+	}
+
 	return nil
 }
@@ -8,10 +8,13 @@ import (
 	"github.com/stretchr/testify/require"
 	kadmissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	kcorev1 "k8s.io/api/core/v1"
+	kapiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kyma-project/eventing-manager/pkg/env"
+	"github.com/kyma-project/eventing-manager/pkg/k8s"
+	"github.com/kyma-project/eventing-manager/pkg/webhookcert"
 )
 
 func Test_ReconcileWebhooksWithCABundle(t *testing.T) {
@@ -30,6 +33,7 @@ func Test_ReconcileWebhooksWithCABundle(t *testing.T) {
 		givenObjects     []client.Object
 		wantMutatingWH   *kadmissionregistrationv1.MutatingWebhookConfiguration
 		wantValidatingWH *kadmissionregistrationv1.ValidatingWebhookConfiguration
+		wantCRDCABundle  []byte
 		wantError        error
 	}{
 		{
@@ -178,6 +182,49 @@ func Test_ReconcileWebhooksWithCABundle(t *testing.T) {
 			}),
 			wantError: nil,
 		},
+		{
+			name: "WHs and conversion webhook get the CA bundle of the secret",
+			givenObjects: []client.Object{
+				getSecretWithCABundle(newCABundle, dummyCABundle),
+				getMutatingWebhookConfig([]kadmissionregistrationv1.MutatingWebhook{
+					{
+						ClientConfig: kadmissionregistrationv1.WebhookClientConfig{},
+					},
+					{
+						ClientConfig: kadmissionregistrationv1.WebhookClientConfig{
+							CABundle: dummyCABundle,
+						},
+					},
+				}),
+				getValidatingWebhookConfig([]kadmissionregistrationv1.ValidatingWebhook{
+					{
+						ClientConfig: kadmissionregistrationv1.WebhookClientConfig{},
+					},
+				}),
+				getSubscriptionCRDWithConversionWebhook(dummyCABundle),
+			},
+			wantMutatingWH: getMutatingWebhookConfig([]kadmissionregistrationv1.MutatingWebhook{
+				{
+					ClientConfig: kadmissionregistrationv1.WebhookClientConfig{
+						CABundle: newCABundle,
+					},
+				},
+				{
+					ClientConfig: kadmissionregistrationv1.WebhookClientConfig{
+						CABundle: newCABundle,
+					},
+				},
+			}),
+			wantValidatingWH: getValidatingWebhookConfig([]kadmissionregistrationv1.ValidatingWebhook{
+				{
+					ClientConfig: kadmissionregistrationv1.WebhookClientConfig{
+						CABundle: newCABundle,
+					},
+				},
+			}),
+			wantCRDCABundle: newCABundle,
+			wantError:       nil,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -200,8 +247,13 @@ func Test_ReconcileWebhooksWithCABundle(t *testing.T) {
 				validatingWH, newErr := testEnv.Reconciler.kubeClient.GetValidatingWebHookConfiguration(ctx,
 					testEnv.Reconciler.backendConfig.ValidatingWebhookName)
 				require.NoError(t, newErr)
-				require.Equal(t, mutatingWH.Webhooks[0], tc.wantMutatingWH.Webhooks[0])
-				require.Equal(t, validatingWH.Webhooks[0], tc.wantValidatingWH.Webhooks[0])
+				require.Equal(t, tc.wantMutatingWH.Webhooks, mutatingWH.Webhooks)
+				require.Equal(t, tc.wantValidatingWH.Webhooks, validatingWH.Webhooks)
+			}
+			if tc.wantCRDCABundle != nil {
+				crd := &kapiextensionsv1.CustomResourceDefinition{}
+				require.NoError(t, testEnv.Client.Get(ctx, client.ObjectKey{Name: k8s.SubscriptionCrdName}, crd))
+				require.Equal(t, tc.wantCRDCABundle, crd.Spec.Conversion.Webhook.ClientConfig.CABundle)
 			}
 		})
 	}
@@ -223,6 +275,30 @@ func getSecretWithTLSSecret(dummyCABundle []byte) *kcorev1.Secret {
 	}
 }
 
+func getSecretWithCABundle(caBundle, servingCert []byte) *kcorev1.Secret {
+	secret := getSecretWithTLSSecret(servingCert)
+	secret.Data[webhookcert.CACertField] = caBundle
+	return secret
+}
+
+func getSubscriptionCRDWithConversionWebhook(caBundle []byte) *kapiextensionsv1.CustomResourceDefinition {
+	return &kapiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: kmetav1.ObjectMeta{
+			Name: k8s.SubscriptionCrdName,
+		},
+		Spec: kapiextensionsv1.CustomResourceDefinitionSpec{
+			Conversion: &kapiextensionsv1.CustomResourceConversion{
+				Strategy: kapiextensionsv1.WebhookConverter,
+				Webhook: &kapiextensionsv1.WebhookConversion{
+					ClientConfig: &kapiextensionsv1.WebhookClientConfig{
+						CABundle: caBundle,
+					},
+				},
+			},
+		},
+	}
+}
+
 func getMutatingWebhookConfig(webhook []kadmissionregistrationv1.MutatingWebhook) *kadmissionregistrationv1.MutatingWebhookConfiguration {
 	return &kadmissionregistrationv1.MutatingWebhookConfiguration{
 		ObjectMeta: kmetav1.ObjectMeta{
@@ -12,11 +12,13 @@ const (
 	KeyName      = "app.kubernetes.io/name"
 	KeyPartOf    = "app.kubernetes.io/part-of"
 	KeyBackend   = "eventing.kyma-project.io/backend"
+	KeyShard     = "eventing.kyma-project.io/shard"
 	KeyDashboard = "kyma-project.io/dashboard"
 
 	ValueEventingPublisherProxy = "eventing-publisher-proxy"
 	ValueEventingManager        = "eventing-manager"
 	ValueEventing               = "eventing"
+	ValueDispatcher             = "dispatcher"
 )
 
 func SelectorCreatedByEventingManager() labels.Selector {
@@ -17,6 +17,10 @@ const (
 	argNameProbeAddr       = "health-probe-bind-addr"
 	argNameReadyEndpoint   = "ready-check-endpoint"
 	argNameHealthEndpoint  = "health-check-endpoint"
+	argNameCatalogAddr     = "catalog-addr"
+	argNameCheckTimeout    = "backend-check-timeout"
+	argNameCheckInterval   = "backend-check-interval"
+	argNameProbeSinkAddr   = "delivery-probe-addr"
 
 	// All the available environment variables.
 	envNameLogFormat = "APP_LOG_FORMAT"
@@ -30,6 +34,10 @@ const (
 	defaultProbeAddr       = ":8081"
 	defaultReadyEndpoint   = "readyz"
 	defaultHealthEndpoint  = "healthz"
+	defaultCatalogAddr     = ":8082"
+	defaultCheckTimeout    = 5 * time.Second
+	defaultCheckInterval   = 30 * time.Second
+	defaultProbeSinkAddr   = ":8083"
 )
 
 // Options represents the controller options.
@@ -47,6 +55,10 @@ type Args struct {
 	ProbeAddr       string
 	ReadyEndpoint   string
 	HealthEndpoint  string
+	CatalogAddr     string
+	CheckTimeout    time.Duration
+	CheckInterval   time.Duration
+	ProbeSinkAddr   string
 }
 
 // Env represents the controller environment variables.
@@ -69,6 +81,10 @@ func (o *Options) Parse() error {
 	flag.StringVar(&o.ProbeAddr, argNameProbeAddr, defaultProbeAddr, "The TCP address that the controller should bind to for serving health probes.")
 	flag.StringVar(&o.ReadyEndpoint, argNameReadyEndpoint, defaultReadyEndpoint, "The endpoint of the readiness probe.")
 	flag.StringVar(&o.HealthEndpoint, argNameHealthEndpoint, defaultHealthEndpoint, "The endpoint of the health probe.")
+	flag.StringVar(&o.CatalogAddr, argNameCatalogAddr, defaultCatalogAddr, "The address the event catalog endpoint binds to.")
+	flag.DurationVar(&o.CheckTimeout, argNameCheckTimeout, defaultCheckTimeout, "Timeout of each health check of the messaging backend.")
+	flag.DurationVar(&o.CheckInterval, argNameCheckInterval, defaultCheckInterval, "Interval of the health checks of the messaging backend.")
+	flag.StringVar(&o.ProbeSinkAddr, argNameProbeSinkAddr, defaultProbeSinkAddr, "The address the sink of the delivery probe canary events binds to.")
 	flag.Parse()
 
 	if err := envconfig.Process("", &o.Env); err != nil {
@@ -80,7 +96,7 @@ func (o *Options) Parse() error {
 
 // String implements the fmt.Stringer interface.
 func (o Options) String() string {
-	return fmt.Sprintf("--%s=%v --%s=%v --%s=%v --%s=%v --%s=%v --%s=%v %s=%v %s=%v %s=%v",
+	return fmt.Sprintf("--%s=%v --%s=%v --%s=%v --%s=%v --%s=%v --%s=%v --%s=%v --%s=%v --%s=%v --%s=%v --%s=%v %s=%v %s=%v",
 		argNameMaxReconnects, o.MaxReconnects,
 		argNameMetricsAddr, o.MetricsAddr,
 		argNameReconnectWait, o.ReconnectWait,
@@ -88,6 +104,10 @@ func (o Options) String() string {
 		argNameProbeAddr, o.ProbeAddr,
 		argNameReadyEndpoint, o.ReadyEndpoint,
 		argNameHealthEndpoint, o.HealthEndpoint,
+		argNameCatalogAddr, o.CatalogAddr,
+		argNameCheckTimeout, o.CheckTimeout,
+		argNameCheckInterval, o.CheckInterval,
+		argNameProbeSinkAddr, o.ProbeSinkAddr,
 		envNameLogFormat, o.LogFormat,
 		envNameLogLevel, o.LogLevel,
 	)
@@ -5,6 +5,7 @@ import (
 	"regexp"
 	"strings"
 
+	operatorv1alpha1 "github.com/kyma-project/eventing-manager/api/operator/v1alpha1"
 	"github.com/kyma-project/eventing-manager/pkg/logger"
 )
 
@@ -22,8 +23,9 @@ var (
 	invalidEventMeshSourceSegment = regexp.MustCompile("[^a-zA-Z0-9]")
 )
 
-func NewEventMeshCleaner(logger *logger.Logger) Cleaner {
-	return &EventMeshCleaner{logger: logger}
+// NewEventMeshCleaner returns a cleaner for the EventMesh backend, which rewrites the event types by the given rules.
+func NewEventMeshCleaner(logger *logger.Logger, rewrites ...operatorv1alpha1.EventTypeRewrite) Cleaner {
+	return &EventMeshCleaner{rewriter: rewriter{rewrites: rewrites}, logger: logger}
 }
 
 func (c *EventMeshCleaner) CleanSource(source string) (string, error) {
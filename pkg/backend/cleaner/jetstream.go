@@ -3,6 +3,7 @@ package cleaner
 import (
 	"regexp"
 
+	operatorv1alpha1 "github.com/kyma-project/eventing-manager/api/operator/v1alpha1"
 	"github.com/kyma-project/eventing-manager/pkg/logger"
 )
 
@@ -15,8 +16,9 @@ var (
 // Perform a compile-time check.
 var _ Cleaner = &JetStreamCleaner{}
 
-func NewJetStreamCleaner(logger *logger.Logger) Cleaner {
-	return &JetStreamCleaner{logger: logger}
+// NewJetStreamCleaner returns a cleaner for the JetStream backend, which rewrites the event types by the given rules.
+func NewJetStreamCleaner(logger *logger.Logger, rewrites ...operatorv1alpha1.EventTypeRewrite) Cleaner {
+	return &JetStreamCleaner{rewriter: rewriter{rewrites: rewrites}, logger: logger}
 }
 
 func (c *JetStreamCleaner) CleanSource(source string) (string, error) {
@@ -0,0 +1,33 @@
+package cleaner
+
+import (
+	"strings"
+
+	operatorv1alpha1 "github.com/kyma-project/eventing-manager/api/operator/v1alpha1"
+)
+
+// wildcard is the suffix of the rewrite rules matching the event types by prefix.
+const wildcard = "*"
+
+// rewriter rewrites the event types by the first matching rule.
+type rewriter struct {
+	rewrites []operatorv1alpha1.EventTypeRewrite
+}
+
+// RewriteEventType returns the event type rewritten by the first matching rule,
+// or the given event type if no rule matches.
+func (r rewriter) RewriteEventType(eventType string) string {
+	for _, rewrite := range r.rewrites {
+		if !strings.HasSuffix(rewrite.From, wildcard) {
+			if eventType == rewrite.From {
+				return rewrite.To
+			}
+			continue
+		}
+		from := strings.TrimSuffix(rewrite.From, wildcard)
+		if strings.HasPrefix(eventType, from) {
+			return strings.TrimSuffix(rewrite.To, wildcard) + strings.TrimPrefix(eventType, from)
+		}
+	}
+	return eventType
+}
@@ -0,0 +1,64 @@
+package cleaner //nolint:testpackage
+
+import (
+	"testing"
+
+	kymalogger "github.com/kyma-project/kyma/common/logging/logger"
+	"github.com/stretchr/testify/require"
+
+	operatorv1alpha1 "github.com/kyma-project/eventing-manager/api/operator/v1alpha1"
+	"github.com/kyma-project/eventing-manager/pkg/logger"
+)
+
+func Test_RewriteEventType(t *testing.T) {
+	t.Parallel()
+
+	givenRewrites := []operatorv1alpha1.EventTypeRewrite{
+		{From: "sap.kyma.custom.oldapp.*", To: "sap.kyma.custom.newapp.*"},
+		{From: "sap.kyma.custom.app.order.created.v1", To: "sap.kyma.custom.app.order.placed.v1"},
+		{From: "sap.kyma.custom.*", To: "sap.kyma.other.*"},
+	}
+
+	testCases := []struct {
+		name           string
+		givenEventType string
+		wantEventType  string
+	}{
+		{
+			name:           "should rewrite the prefix of the event type",
+			givenEventType: "sap.kyma.custom.oldapp.order.created.v1",
+			wantEventType:  "sap.kyma.custom.newapp.order.created.v1",
+		},
+		{
+			name:           "should rewrite the exact event type",
+			givenEventType: "sap.kyma.custom.app.order.created.v1",
+			wantEventType:  "sap.kyma.custom.app.order.placed.v1",
+		},
+		{
+			name:           "should apply the first matching rule only",
+			givenEventType: "sap.kyma.custom.app.order.updated.v1",
+			wantEventType:  "sap.kyma.other.app.order.updated.v1",
+		},
+		{
+			name:           "should not rewrite the event type if no rule matches",
+			givenEventType: "order.created.v1",
+			wantEventType:  "order.created.v1",
+		},
+	}
+
+	defaultLogger, err := logger.New(string(kymalogger.JSON), string(kymalogger.INFO))
+	require.NoError(t, err)
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			for _, cleaner := range []Cleaner{
+				NewJetStreamCleaner(defaultLogger, givenRewrites...),
+				NewEventMeshCleaner(defaultLogger, givenRewrites...),
+			} {
+				require.Equal(t, tc.wantEventType, cleaner.RewriteEventType(tc.givenEventType))
+			}
+		})
+	}
+}
@@ -6,12 +6,17 @@ type Cleaner interface {
 	CleanSource(source string) (string, error)
 
 	CleanEventType(eventType string) (string, error)
+
+	// RewriteEventType applies the rewrite rules to the event type, before it is cleaned.
+	RewriteEventType(eventType string) string
 }
 
 type JetStreamCleaner struct {
+	rewriter
 	logger *logger.Logger
 }
 
 type EventMeshCleaner struct {
+	rewriter
 	logger *logger.Logger
 }
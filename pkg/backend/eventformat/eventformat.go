@@ -0,0 +1,27 @@
+// Package eventformat looks up the CloudEvents formats of the structured content mode.
+package eventformat
+
+import (
+	"errors"
+	"fmt"
+
+	pbformat "github.com/cloudevents/sdk-go/binding/format/protobuf/v2"
+	"github.com/cloudevents/sdk-go/v2/binding/format"
+
+	"github.com/kyma-project/eventing-manager/pkg/ems/api/events/types"
+)
+
+var ErrUnknownFormat = errors.New("unknown event format")
+
+// Lookup returns the format of the event format name of a Subscription, it defaults to JSON if the name is empty.
+// It returns an error for the names which are rejected by types.IsInvalidEventFormat.
+func Lookup(name string) (format.Format, error) {
+	switch name {
+	case "", types.EventFormatJSON:
+		return format.JSON, nil
+	case types.EventFormatProtobuf:
+		return pbformat.Protobuf, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, name)
+	}
+}
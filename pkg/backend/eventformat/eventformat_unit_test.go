@@ -0,0 +1,133 @@
+package eventformat
+
+import (
+	"testing"
+	"time"
+
+	pbformat "github.com/cloudevents/sdk-go/binding/format/protobuf/v2"
+	"github.com/cloudevents/sdk-go/v2/binding/format"
+	ceevent "github.com/cloudevents/sdk-go/v2/event"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/eventing-manager/pkg/ems/api/events/types"
+)
+
+func Test_Lookup(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		givenName  string
+		wantFormat format.Format
+		wantErr    error
+	}{
+		{
+			name:       "should default to JSON",
+			givenName:  "",
+			wantFormat: format.JSON,
+		},
+		{
+			name:       "should look up JSON",
+			givenName:  types.EventFormatJSON,
+			wantFormat: format.JSON,
+		},
+		{
+			name:       "should look up Protobuf",
+			givenName:  types.EventFormatProtobuf,
+			wantFormat: pbformat.Protobuf,
+		},
+		{
+			name:      "should return an error for an unknown format",
+			givenName: "AVRO",
+			wantErr:   ErrUnknownFormat,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			gotFormat, err := Lookup(tc.givenName)
+
+			// then
+			require.ErrorIs(t, err, tc.wantErr)
+			require.Equal(t, tc.wantFormat, gotFormat)
+			// the valid names are looked up, and the invalid ones are not.
+			if tc.givenName != "" {
+				require.Equal(t, types.IsInvalidEventFormat(tc.givenName), err != nil)
+			}
+		})
+	}
+}
+
+func Test_MarshalUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	newEvent := func(contentType string, data []byte) ceevent.Event {
+		event := ceevent.New()
+		event.SetID("id")
+		event.SetSource("/default/noapp")
+		event.SetType("order.created.v1")
+		event.SetSubject("orders")
+		event.SetDataSchema("https://schemas.local/order")
+		event.SetTime(time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC))
+		event.SetExtension("tenant", "acme")
+		event.SetExtension("priority", 7)
+		event.SetExtension("urgent", true)
+		require.NoError(t, event.SetData(contentType, data))
+		return event
+	}
+
+	testCases := []struct {
+		name      string
+		format    format.Format
+		wantMedia string
+	}{
+		{
+			name:      "JSON",
+			format:    format.JSON,
+			wantMedia: "application/cloudevents+json",
+		},
+		{
+			name:      "Protobuf",
+			format:    pbformat.Protobuf,
+			wantMedia: "application/cloudevents+protobuf",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.wantMedia, tc.format.MediaType())
+			for _, given := range []ceevent.Event{
+				newEvent(ceevent.ApplicationJSON, []byte(`{"orderId":1}`)),
+				newEvent("application/octet-stream", []byte{0, 1, 2, 255}),
+			} {
+				// when
+				b, err := tc.format.Marshal(&given)
+				require.NoError(t, err)
+				got := ceevent.New()
+				err = tc.format.Unmarshal(b, &got)
+
+				// then
+				require.NoError(t, err)
+				require.NoError(t, got.Validate())
+				require.Equal(t, given.ID(), got.ID())
+				require.Equal(t, given.Source(), got.Source())
+				require.Equal(t, given.Type(), got.Type())
+				require.Equal(t, given.SpecVersion(), got.SpecVersion())
+				require.Equal(t, given.Subject(), got.Subject())
+				require.Equal(t, given.DataSchema(), got.DataSchema())
+				require.Equal(t, given.DataContentType(), got.DataContentType())
+				require.True(t, given.Time().Equal(got.Time()))
+				require.Equal(t, given.Extensions(), got.Extensions())
+				require.Equal(t, given.Data(), got.Data())
+			}
+		})
+	}
+
+}
@@ -1,11 +1,16 @@
 package eventmesh
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
+	"sync"
 
 	apigatewayv1beta1 "github.com/kyma-project/api-gateway/apis/gateway/v1beta1"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	eventingv1alpha2 "github.com/kyma-project/eventing-manager/api/eventing/v1alpha2"
@@ -18,6 +23,7 @@ import (
 	"github.com/kyma-project/eventing-manager/pkg/env"
 	"github.com/kyma-project/eventing-manager/pkg/featureflags"
 	"github.com/kyma-project/eventing-manager/pkg/logger"
+	"github.com/kyma-project/eventing-manager/pkg/tracing"
 )
 
 const (
@@ -26,12 +32,22 @@ const (
 	eventTypeSegmentsLimit    = 7
 	subscriptionNameLogKey    = "eventMeshSubscriptionName"
 	errorLogKey               = "error"
+	// messagingSystem identifies EventMesh in the spans.
+	messagingSystem = "eventmesh"
+	// healthCheckSubscriptionName is the name of the subscription which the health check gets, it does not exist.
+	healthCheckSubscriptionName = "eventing-manager-health-check"
 )
 
 // Perform a compile time check.
 var _ Backend = &EventMesh{}
 
-var ErrEMSubjectInvalid = errors.New("EventMesh subject invalid")
+var (
+	ErrEMSubjectInvalid          = errors.New("EventMesh subject invalid")
+	ErrWildcardTypesNotSupported = errors.New("wildcard type matching is not supported by EventMesh")
+	ErrWebhookAuthNotSupported   = errors.New("webhook auth type is not supported by EventMesh")
+	ErrContentModeNotSupported   = errors.New("content mode or event format is not supported by EventMesh")
+	ErrNotInitialized            = errors.New("EventMesh client is not initialized")
+)
 
 type Backend interface {
 	// Initialize should initialize the communication layer with the messaging backend system
@@ -57,6 +73,8 @@ func NewEventMesh(credentials *OAuth2ClientCredentials, mapper backendutils.Name
 
 type EventMesh struct {
 	client            client.PublisherManager
+	tokenSource       *auth.RotatableTokenSource
+	credentialsMutex  sync.RWMutex
 	webhookAuth       *types.WebhookAuth
 	protocolSettings  *backendutils.ProtocolSettings
 	namespace         string
@@ -68,13 +86,14 @@ type EventMesh struct {
 
 func (em *EventMesh) Initialize(cfg env.Config) error {
 	if em.client == nil {
-		authenticatedClient := auth.NewAuthenticatedClient(cfg)
+		authenticatedClient, tokenSource := auth.NewRotatableAuthenticatedClient(cfg)
 		httpClient, err := httpclient.NewHTTPClient(cfg.BEBAPIURL, authenticatedClient)
 		if err != nil {
 			return err
 		}
 		em.client = client.NewClient(httpClient)
-		em.webhookAuth = getWebHookAuth(em.oAuth2credentials)
+		em.tokenSource = tokenSource
+		em.SetCredentials(em.oAuth2credentials)
 		em.protocolSettings = &backendutils.ProtocolSettings{
 			ContentMode:     &cfg.ContentMode,
 			ExemptHandshake: &cfg.ExemptHandshake,
@@ -101,9 +120,40 @@ func getWebHookAuth(credentials *OAuth2ClientCredentials) *types.WebhookAuth {
 // SyncSubscription synchronize the EV2 subscription with the EMS subscription.
 // It returns true, if the EV2 subscription status was changed.
 func (em *EventMesh) SyncSubscription(subscription *eventingv1alpha2.Subscription, cleaner cleaner.Cleaner, apiRule *apigatewayv1beta1.APIRule) (bool, error) {
+	// trace the synchronization, since EventMesh delivers the events to the sinks itself.
+	_, span := tracing.Tracer().Start(context.Background(), "eventmesh sync subscription",
+		trace.WithAttributes(
+			semconv.MessagingSystem(messagingSystem),
+			tracing.AttributeSubscriptionName.String(subscription.Name),
+			tracing.AttributeSubscriptionNamespace.String(subscription.Namespace),
+		),
+	)
+	defer span.End()
+
+	isStatusUpdated, err := em.syncSubscription(subscription, cleaner, apiRule)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return isStatusUpdated, err
+}
+
+func (em *EventMesh) syncSubscription(subscription *eventingv1alpha2.Subscription, cleaner cleaner.Cleaner, apiRule *apigatewayv1beta1.APIRule) (bool, error) {
 	// Format logger
 	log := backendutils.LoggerWithSubscription(em.namedLogger(), subscription)
 
+	// the webhook auth types with credentials from a Secret are supported by JetStream only
+	if types.IsSecretAuthType(subscription.Spec.Config[eventingv1alpha2.WebhookAuthType]) {
+		log.Errorw("Failed to process webhook auth", errorLogKey, ErrWebhookAuthNotSupported)
+		return false, ErrWebhookAuthNotSupported
+	}
+
+	// the raw content mode and the structured event formats other than JSON are supported by JetStream only
+	if !isSupportedContentMode(subscription) {
+		log.Errorw("Failed to process content mode", errorLogKey, ErrContentModeNotSupported)
+		return false, ErrContentModeNotSupported
+	}
+
 	// process event types
 	typesInfo, err := em.getProcessedEventTypes(subscription, cleaner)
 	if err != nil {
@@ -112,7 +162,7 @@ func (em *EventMesh) SyncSubscription(subscription *eventingv1alpha2.Subscriptio
 	}
 
 	// convert Kyma Subscription to EventMesh Subscription object
-	eventMeshSub, err := backendutils.ConvertKymaSubToEventMeshSub(subscription, typesInfo, apiRule, em.webhookAuth,
+	eventMeshSub, err := backendutils.ConvertKymaSubToEventMeshSub(subscription, typesInfo, apiRule, em.getWebhookAuth(),
 		em.protocolSettings, em.namespace, em.SubNameMapper)
 	if err != nil {
 		log.Errorw("Failed to get Kyma subscription internal view", errorLogKey, err)
@@ -190,15 +240,24 @@ func (em *EventMesh) DeleteSubscription(subscription *eventingv1alpha2.Subscript
 func (em *EventMesh) getProcessedEventTypes(kymaSubscription *eventingv1alpha2.Subscription,
 	cleaner cleaner.Cleaner,
 ) ([]backendutils.EventTypeInfo, error) {
+	if kymaSubscription.Spec.TypeMatching == eventingv1alpha2.TypeMatchingWildcard {
+		return nil, ErrWildcardTypesNotSupported
+	}
+
 	// deduplicate event types
 	uniqueTypes := kymaSubscription.GetUniqueTypes()
 
 	// process types including cleaning, appending prefixes
 	result := make([]backendutils.EventTypeInfo, 0, len(uniqueTypes))
 	for _, t := range uniqueTypes {
+		// apply the rewrite rules before any other processing.
+		rewrittenType := cleaner.RewriteEventType(t)
 		if kymaSubscription.Spec.TypeMatching == eventingv1alpha2.TypeMatchingExact {
 			// not do any processing if TypeMatching is exact.
-			result = append(result, backendutils.EventTypeInfo{OriginalType: t, CleanType: t, ProcessedType: t})
+			result = append(result, backendutils.EventTypeInfo{
+				OriginalType: t, CleanType: rewrittenType,
+				ProcessedType: rewrittenType,
+			})
 			continue
 		}
 
@@ -208,7 +267,7 @@ func (em *EventMesh) getProcessedEventTypes(kymaSubscription *eventingv1alpha2.S
 			return nil, err
 		}
 
-		cleanedType, err := cleaner.CleanEventType(t)
+		cleanedType, err := cleaner.CleanEventType(rewrittenType)
 		if err != nil {
 			return nil, err
 		}
@@ -452,9 +511,62 @@ func (em *EventMesh) namedLogger() *zap.SugaredLogger {
 	return em.logger.WithContext().Named(eventMeshHandlerName)
 }
 
-// SetCredentials sets the WebhookAuth credentials.
-// WARNING: This functions should be used for testing purposes only.
+// SetCredentials sets the WebhookAuth credentials. The existing EventMesh subscriptions are updated with the new
+// WebhookAuth when they are synchronized next time.
 func (em *EventMesh) SetCredentials(credentials *OAuth2ClientCredentials) {
-	em.namedLogger().Warn("This logic should be used for testing purposes only")
+	em.credentialsMutex.Lock()
+	defer em.credentialsMutex.Unlock()
 	em.webhookAuth = getWebHookAuth(credentials)
 }
+
+// SetClientCredentials swaps the client credentials used by the EventMesh HTTP client, without recreating it.
+func (em *EventMesh) SetClientCredentials(cfg env.Config) {
+	if em.tokenSource == nil {
+		em.namedLogger().Warn("Skipping the rotation of the client credentials, the client is not initialized")
+		return
+	}
+	em.tokenSource.SetCredentials(cfg)
+}
+
+// CheckToken checks whether a token of the client credentials can be fetched. A valid token is reused.
+func (em *EventMesh) CheckToken() error {
+	if em.tokenSource == nil {
+		return ErrNotInitialized
+	}
+	if _, err := em.tokenSource.Token(); err != nil {
+		return fmt.Errorf("fetch token failed: %w", err)
+	}
+	return nil
+}
+
+// CheckAPI checks whether the EventMesh API responds by getting a subscription which does not exist, which is
+// cheaper than listing the subscriptions. Both a found and a not found subscription prove that the API responds.
+func (em *EventMesh) CheckAPI() error {
+	if em.client == nil {
+		return ErrNotInitialized
+	}
+	_, resp, err := em.client.Get(healthCheckSubscriptionName)
+	if err != nil {
+		return fmt.Errorf("get subscription failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("get subscription failed: %w; %v",
+			HTTPStatusError{StatusCode: resp.StatusCode}, resp.Message)
+	}
+	return nil
+}
+
+func (em *EventMesh) getWebhookAuth() *types.WebhookAuth {
+	em.credentialsMutex.RLock()
+	defer em.credentialsMutex.RUnlock()
+	return em.webhookAuth
+}
+
+// isSupportedContentMode returns true if EventMesh supports the content mode and event format of the subscription.
+func isSupportedContentMode(subscription *eventingv1alpha2.Subscription) bool {
+	if subscription.Spec.Config[eventingv1alpha2.ProtocolSettingsContentMode] == types.ContentModeRaw {
+		return false
+	}
+	format, ok := subscription.Spec.Config[eventingv1alpha2.ProtocolSettingsFormat]
+	return !ok || format == types.EventFormatJSON
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	eventingv1alpha2 "github.com/kyma-project/eventing-manager/api/eventing/v1alpha2"
+	operatorv1alpha1 "github.com/kyma-project/eventing-manager/api/operator/v1alpha1"
 	"github.com/kyma-project/eventing-manager/pkg/backend/cleaner"
 	backendutils "github.com/kyma-project/eventing-manager/pkg/backend/utils"
 	emsclientmocks "github.com/kyma-project/eventing-manager/pkg/ems/api/events/client/mocks"
@@ -31,6 +32,7 @@ func Test_getProcessedEventTypes(t *testing.T) {
 		name                    string
 		givenSubscription       *eventingv1alpha2.Subscription
 		givenEventTypePrefix    string
+		givenRewrites           []operatorv1alpha1.EventTypeRewrite
 		wantProcessedEventTypes []backendutils.EventTypeInfo
 		wantError               bool
 	}{
@@ -117,6 +119,36 @@ func Test_getProcessedEventTypes(t *testing.T) {
 			},
 			wantError: false,
 		},
+		{
+			name: "success if the given subscription has rewritten event types",
+			givenSubscription: &eventingv1alpha2.Subscription{
+				Spec: eventingv1alpha2.SubscriptionSpec{
+					Types: []string{
+						"oldorder.created.v1",
+						"test1.test2.test3.oldorder.created.v1",
+					},
+					Source: "test",
+				},
+			},
+			givenEventTypePrefix: eventingtesting.EventMeshPrefix,
+			givenRewrites: []operatorv1alpha1.EventTypeRewrite{
+				{From: "oldorder.*", To: "order.*"},
+				{From: "test1.test2.test3.oldorder.*", To: "test1.test2.test3.order.*"},
+			},
+			wantProcessedEventTypes: []backendutils.EventTypeInfo{
+				{
+					OriginalType:  "oldorder.created.v1",
+					CleanType:     "order.created.v1",
+					ProcessedType: fmt.Sprintf("%s.test.order.created.v1", eventingtesting.EventMeshPrefix),
+				},
+				{
+					OriginalType:  "test1.test2.test3.oldorder.created.v1",
+					CleanType:     "test1test2test3order.created.v1",
+					ProcessedType: fmt.Sprintf("%s.test.test1test2test3order.created.v1", eventingtesting.EventMeshPrefix),
+				},
+			},
+			wantError: false,
+		},
 		{
 			name: "should fail if the given subscription types and EventMeshPrefix " +
 				"exceeds the EventMesh segments limit",
@@ -132,6 +164,21 @@ func Test_getProcessedEventTypes(t *testing.T) {
 			wantProcessedEventTypes: nil,
 			wantError:               true,
 		},
+		{
+			name: "should fail if the given subscription has the wildcard type matching",
+			givenSubscription: &eventingv1alpha2.Subscription{
+				Spec: eventingv1alpha2.SubscriptionSpec{
+					TypeMatching: eventingv1alpha2.TypeMatchingWildcard,
+					Types: []string{
+						"order.*.v1",
+					},
+					Source: "test",
+				},
+			},
+			givenEventTypePrefix:    eventingtesting.EventMeshPrefix,
+			wantProcessedEventTypes: nil,
+			wantError:               true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -141,7 +188,7 @@ func Test_getProcessedEventTypes(t *testing.T) {
 
 			// given
 			eventMesh := NewEventMesh(&OAuth2ClientCredentials{}, nameMapper, defaultLogger)
-			emCleaner := cleaner.NewEventMeshCleaner(defaultLogger)
+			emCleaner := cleaner.NewEventMeshCleaner(defaultLogger, tc.givenRewrites...)
 			err = eventMesh.Initialize(env.Config{EventTypePrefix: tc.givenEventTypePrefix})
 			require.NoError(t, err)
 
@@ -645,6 +692,23 @@ func Test_SyncSubscription(t *testing.T) {
 		})
 	}
 
+	// when the subscription uses a webhook auth type with credentials from a Secret
+	subscription.Spec.Config[eventingv1alpha2.WebhookAuthType] = string(types.AuthTypeBearer)
+	subscription.Spec.Config[eventingv1alpha2.WebhookAuthSecretName] = "sink-credentials"
+	_, err = eventMesh.SyncSubscription(subscription, cleaner.NewEventMeshCleaner(defaultLogger), apiRule)
+
+	// then
+	require.ErrorIs(t, err, ErrWebhookAuthNotSupported)
+
+	// when the subscription uses the raw content mode
+	delete(subscription.Spec.Config, eventingv1alpha2.WebhookAuthType)
+	delete(subscription.Spec.Config, eventingv1alpha2.WebhookAuthSecretName)
+	subscription.Spec.Config[eventingv1alpha2.ProtocolSettingsContentMode] = types.ContentModeRaw
+	_, err = eventMesh.SyncSubscription(subscription, cleaner.NewEventMeshCleaner(defaultLogger), apiRule)
+
+	// then
+	require.ErrorIs(t, err, ErrContentModeNotSupported)
+
 	// cleanup
 	eventMeshMock.Stop()
 }
@@ -745,6 +809,63 @@ func Test_handleWebhookAuthChange(t *testing.T) {
 	}
 }
 
+func Test_RotateCredentials(t *testing.T) {
+	// setup
+	mock := startEventMeshMock()
+	defer func() { mock.Stop() }()
+
+	defaultLogger, err := logger.New(string(kymalogger.JSON), string(kymalogger.INFO))
+	require.NoError(t, err)
+
+	credentials := &OAuth2ClientCredentials{ClientID: "client-id", ClientSecret: "client-secret"}
+	mapper := backendutils.NewBEBSubscriptionNameMapper("domain.com", MaxSubscriptionNameLength)
+	config := env.Config{
+		BEBAPIURL:     mock.MessagingURL,
+		ClientID:      "ems-client-id",
+		ClientSecret:  "ems-client-secret",
+		TokenEndpoint: mock.TokenURL,
+	}
+
+	eventMesh := NewEventMesh(credentials, mapper, defaultLogger)
+	require.NoError(t, eventMesh.Initialize(config))
+
+	// given a synchronized subscription using the default webhook auth
+	kymaSub := eventingtesting.NewSubscription(
+		"test-subscription", "test-namespace",
+		eventingtesting.WithSinkURL("https://webhook.xxx.com"),
+		eventingtesting.WithDefaultSource(),
+		eventingtesting.WithEventType(eventingtesting.OrderCreatedEventTypeNotClean),
+	)
+	apiRule := eventingtesting.NewAPIRule(
+		kymaSub,
+		eventingtesting.WithPath(),
+		eventingtesting.WithService("test-service", "http://localhost"),
+	)
+	_, err = eventMesh.SyncSubscription(kymaSub, cleaner.NewEventMeshCleaner(defaultLogger), apiRule)
+	require.NoError(t, err)
+	webhookAuthHash := kymaSub.Status.Backend.WebhookAuthHash
+	emSubName := mapper.MapSubscriptionName(kymaSub.Name, kymaSub.Namespace)
+	emSubURI := fmt.Sprintf("/messaging/events/subscriptions/%s", emSubName)
+	createURI := "/messaging/events/subscriptions"
+	createCount := mock.CountRequests(http.MethodPost, createURI)
+	patchCount := mock.CountRequests(http.MethodPatch, emSubURI)
+	require.Equal(t, 1, createCount)
+
+	// when the credentials are rotated
+	eventMesh.SetCredentials(&OAuth2ClientCredentials{ClientID: "new-client-id", ClientSecret: "new-client-secret"})
+	config.ClientID = "new-ems-client-id"
+	config.ClientSecret = "new-ems-client-secret"
+	eventMesh.SetClientCredentials(config)
+	_, err = eventMesh.SyncSubscription(kymaSub, cleaner.NewEventMeshCleaner(defaultLogger), apiRule)
+	require.NoError(t, err)
+
+	// then the webhook auth is updated without recreating the EventMesh subscription
+	require.Equal(t, "new-client-id", eventMesh.getWebhookAuth().ClientID)
+	require.NotEqual(t, webhookAuthHash, kymaSub.Status.Backend.WebhookAuthHash)
+	require.Equal(t, patchCount+1, mock.CountRequests(http.MethodPatch, emSubURI))
+	require.Equal(t, createCount, mock.CountRequests(http.MethodPost, createURI))
+}
+
 // fixtureValidSubscription returns a valid subscription.
 func fixtureValidSubscription(name, namespace string) *eventingv1alpha2.Subscription {
 	return eventingtesting.NewSubscription(
@@ -761,3 +882,55 @@ func startEventMeshMock() *eventingtesting.EventMeshMock {
 	eventMesh.Start()
 	return eventMesh
 }
+
+func Test_CheckAPI(t *testing.T) {
+	t.Parallel()
+
+	defaultLogger, err := logger.New(string(kymalogger.JSON), string(kymalogger.INFO))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name            string
+		givenStatusCode int
+		wantError       error
+	}{
+		{
+			name:            "should succeed if the subscription is found",
+			givenStatusCode: http.StatusOK,
+		},
+		{
+			name:            "should succeed if the subscription is not found",
+			givenStatusCode: http.StatusNotFound,
+		},
+		{
+			name:            "should fail if the API does not accept the token",
+			givenStatusCode: http.StatusUnauthorized,
+			wantError:       HTTPStatusError{StatusCode: http.StatusUnauthorized},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			eventMesh := NewEventMesh(&OAuth2ClientCredentials{}, nil, defaultLogger)
+			mockClient := new(emsclientmocks.PublisherManager)
+			mockClient.On("Get", healthCheckSubscriptionName).
+				Return(nil, &types.Response{StatusCode: tc.givenStatusCode}, nil).Once()
+			eventMesh.client = mockClient
+
+			// when
+			err := eventMesh.CheckAPI()
+
+			// then
+			if tc.wantError != nil {
+				require.ErrorIs(t, err, tc.wantError)
+			} else {
+				require.NoError(t, err)
+			}
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
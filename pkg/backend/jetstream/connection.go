@@ -3,6 +3,7 @@ package jetstream
 import (
 	"github.com/nats-io/nats.go"
 
+	natsconnection "github.com/kyma-project/eventing-manager/internal/connection/nats"
 	"github.com/kyma-project/eventing-manager/pkg/env"
 	"github.com/kyma-project/eventing-manager/pkg/errors"
 )
@@ -29,6 +30,11 @@ func (b ConnectionBuilder) Build() (ConnectionInterface, error) {
 		nats.ReconnectWait(config.ReconnectWait),
 		nats.Name("Kyma Controller"),
 	}
+	authOptions, err := natsconnection.AuthOptions(config.UserCredentials, config.User, config.Password)
+	if err != nil {
+		return nil, errors.MakeError(ErrConnect, err)
+	}
+	jsOptions = append(jsOptions, authOptions...)
 	conn, err := nats.Connect(config.URL, jsOptions...)
 	if err != nil || !conn.IsConnected() {
 		return nil, errors.MakeError(ErrConnect, err)
@@ -0,0 +1,152 @@
+package jetstream
+
+import (
+	"github.com/nats-io/nats.go"
+	pkgerrors "github.com/pkg/errors"
+
+	eventingv1alpha2 "github.com/kyma-project/eventing-manager/api/eventing/v1alpha2"
+	backendutils "github.com/kyma-project/eventing-manager/pkg/backend/utils"
+	"github.com/kyma-project/eventing-manager/pkg/env"
+	"github.com/kyma-project/eventing-manager/pkg/errors"
+)
+
+// consolidatedConsumerSubject identifies the consolidated consumer of a subscription, which filters all the
+// subjects of the subscription. It cannot collide with a JetStream subject, since these always have a prefix.
+const consolidatedConsumerSubject = ">"
+
+// subscriptionConsumer describes a JetStream consumer of a subscription and the subjects it filters.
+type subscriptionConsumer struct {
+	key SubscriptionSubjectIdentifier
+	// consolidated is true if the consumer filters all the subjects of the subscription.
+	consolidated bool
+	subjects     []string
+	// eventTypes are the event types of the subscription which are filtered by the consumer.
+	eventTypes []eventingv1alpha2.EventType
+}
+
+// subscribeSubject returns the subject to subscribe to the consumer with. It is empty for a consolidated consumer,
+// since a subscription to multiple filter subjects is bound by the consumer name only.
+func (c subscriptionConsumer) subscribeSubject() string {
+	if c.consolidated {
+		return ""
+	}
+	return c.subjects[0]
+}
+
+// getSubscriptionConsumers returns the consumers of the subscription, which is either one consolidated consumer
+// for all the event types, or one consumer per event type.
+func (js *JetStream) getSubscriptionConsumers(subscription *eventingv1alpha2.Subscription,
+	consolidated bool,
+) []subscriptionConsumer {
+	if !consolidated {
+		consumers := make([]subscriptionConsumer, 0, len(subscription.Status.Types))
+		for _, eventType := range subscription.Status.Types {
+			jsSubject := js.GetJetStreamSubject(subscription.Spec.Source, eventType.CleanType,
+				subscription.Spec.TypeMatching)
+			consumers = append(consumers, subscriptionConsumer{
+				key:        NewSubscriptionSubjectIdentifier(subscription, jsSubject),
+				subjects:   []string{jsSubject},
+				eventTypes: []eventingv1alpha2.EventType{eventType},
+			})
+		}
+		return consumers
+	}
+
+	if len(subscription.Status.Types) == 0 {
+		return nil
+	}
+	jsSubjects := getUniqueEventTypes(js.GetJetStreamSubjects(subscription.Spec.Source,
+		GetCleanEventTypesFromEventTypes(subscription.Status.Types), subscription.Spec.TypeMatching))
+	return []subscriptionConsumer{{
+		key:          NewSubscriptionSubjectIdentifier(subscription, consolidatedConsumerSubject),
+		consolidated: true,
+		subjects:     jsSubjects,
+		eventTypes:   subscription.Status.Types,
+	}}
+}
+
+// migrateConsumers replaces the consumers of the subscription which were created with the other consumer layout,
+// i.e. per event type if the consumers are consolidated, and vice versa. The new consumers start at the first
+// message which was not acknowledged by all the old consumers, so that no message is lost. The messages which were
+// acknowledged by some of the old consumers only are delivered again.
+// The subscription is checked once per process, since the layout is only changed by a restart.
+func (js *JetStream) migrateConsumers(subscription *eventingv1alpha2.Subscription) error {
+	subKeyPrefix := createKeyPrefix(subscription)
+	if _, ok := js.migratedSubscriptions.Load(subKeyPrefix); ok {
+		return nil
+	}
+
+	oldConsumers := js.getSubscriptionConsumers(subscription, !js.Config.JSConsolidatedConsumers)
+	var startSequence uint64
+	var existingConsumers []subscriptionConsumer
+	for _, consumer := range oldConsumers {
+		consumerInfo, err := js.jsCtx.ConsumerInfo(js.Config.JSStreamName, consumer.key.ConsumerName())
+		if err != nil {
+			if pkgerrors.Is(err, nats.ErrConsumerNotFound) {
+				continue
+			}
+			return errors.MakeError(ErrGetConsumer, err)
+		}
+		if len(existingConsumers) == 0 || consumerInfo.AckFloor.Stream+1 < startSequence {
+			startSequence = consumerInfo.AckFloor.Stream + 1
+		}
+		existingConsumers = append(existingConsumers, consumer)
+	}
+
+	if len(existingConsumers) > 0 {
+		log := backendutils.LoggerWithSubscription(js.namedLogger(), subscription)
+		log.Infow("Migrating JetStream consumers", "consolidated", js.Config.JSConsolidatedConsumers,
+			"consumers", len(existingConsumers), "startSequence", startSequence)
+
+		ecSubsConfig := env.DefaultSubscriptionConfig(js.subsConfig)
+		maxInFlight := subscription.GetMaxInFlightMessages(&ecSubsConfig)
+		for _, consumer := range js.getSubscriptionConsumers(subscription, js.Config.JSConsolidatedConsumers) {
+			// the consumer exists already if a previous migration was interrupted.
+			_, err := js.jsCtx.ConsumerInfo(js.Config.JSStreamName, consumer.key.ConsumerName())
+			if err == nil {
+				continue
+			}
+			if !pkgerrors.Is(err, nats.ErrConsumerNotFound) {
+				return errors.MakeError(ErrGetConsumer, err)
+			}
+			consumerConfig := js.getConsumerConfig(consumer, maxInFlight)
+			consumerConfig.DeliverPolicy = nats.DeliverByStartSequencePolicy
+			consumerConfig.OptStartSeq = startSequence
+			if _, err = js.jsCtx.AddConsumer(js.Config.JSStreamName, consumerConfig); err != nil {
+				return errors.MakeError(ErrAddConsumer, err)
+			}
+		}
+
+		for _, consumer := range existingConsumers {
+			if jsSub, ok := js.subscriptions[consumer.key]; ok {
+				if err := js.deleteSubscriptionFromJetStream(jsSub, consumer.key); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := js.deleteConsumerFromJetStream(consumer.key.ConsumerName()); err != nil {
+				return err
+			}
+		}
+	}
+
+	js.migratedSubscriptions.Store(subKeyPrefix, true)
+	return nil
+}
+
+// sameSubjects returns true if both slices contain the same unique subjects, in any order.
+func sameSubjects(got, want []string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	subjects := make(map[string]bool, len(got))
+	for _, subject := range got {
+		subjects[subject] = true
+	}
+	for _, subject := range want {
+		if !subjects[subject] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,99 @@
+package jetstream
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/binding/format"
+	ceprotocol "github.com/cloudevents/sdk-go/v2/protocol"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+
+	eventingv1alpha2 "github.com/kyma-project/eventing-manager/api/eventing/v1alpha2"
+	"github.com/kyma-project/eventing-manager/pkg/backend/eventformat"
+	"github.com/kyma-project/eventing-manager/pkg/ems/api/events/types"
+)
+
+// deliveryMode defines how the events are encoded when they are dispatched to the sink of a subscription.
+type deliveryMode struct {
+	contentMode string
+	// format is the event format of the structured content mode.
+	format format.Format
+}
+
+// storeDeliveryMode stores the delivery mode of the subscription, the subscriptions with the binary content mode
+// use the default client encoding. It returns an error if the event format is unknown.
+func (js *JetStream) storeDeliveryMode(subscription *eventingv1alpha2.Subscription) error {
+	subKeyPrefix := createKeyPrefix(subscription)
+	contentMode := subscription.Spec.Config[eventingv1alpha2.ProtocolSettingsContentMode]
+	if contentMode == "" || contentMode == types.ContentModeBinary {
+		js.deliveryModes.Delete(subKeyPrefix)
+		return nil
+	}
+	eventFormat, err := eventformat.Lookup(subscription.Spec.Config[eventingv1alpha2.ProtocolSettingsFormat])
+	if err != nil {
+		return err
+	}
+	js.deliveryModes.Store(subKeyPrefix, &deliveryMode{contentMode: contentMode, format: eventFormat})
+	return nil
+}
+
+// dispatchEvent sends the event to the sink of the subscription in its delivery mode. If withReply is true, it
+// returns the event replied by the sink.
+func (js *JetStream) dispatchEvent(ctx context.Context, subKeyPrefix, sink string, event *cloudevents.Event,
+	withReply bool) (*cloudevents.Event, ceprotocol.Result) {
+	if value, ok := js.deliveryModes.Load(subKeyPrefix); ok {
+		mode := value.(*deliveryMode)
+		switch mode.contentMode {
+		case types.ContentModeRaw:
+			return js.sendRawEvent(ctx, subKeyPrefix, sink, event, withReply)
+		case types.ContentModeStructured:
+			ctx = binding.UseFormatForEvent(binding.WithForceStructured(ctx), mode.format)
+		}
+	}
+
+	client := js.getSinkClient(subKeyPrefix)
+	if withReply {
+		return client.Request(ctx, *event)
+	}
+	return nil, client.Send(ctx, *event)
+}
+
+// sendRawEvent sends only the data of the event to the sink, without the CloudEvent attributes. The reply of the sink
+// is returned if it is a CloudEvent.
+func (js *JetStream) sendRawEvent(ctx context.Context, subKeyPrefix, sink string, event *cloudevents.Event,
+	withReply bool) (*cloudevents.Event, ceprotocol.Result) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, sink, bytes.NewReader(event.Data()))
+	if err != nil {
+		return nil, ceprotocol.NewReceipt(false, "%w", err)
+	}
+	if contentType := event.DataContentType(); contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+	for key, values := range cehttp.HeaderFrom(ctx) {
+		request.Header[key] = values
+	}
+
+	response, err := js.getSinkHTTPClient(subKeyPrefix).Do(request)
+	if err != nil {
+		return nil, ceprotocol.NewReceipt(false, "%w", err)
+	}
+	message := cehttp.NewMessageFromHttpResponse(response)
+	defer func() { _ = message.Finish(nil) }()
+
+	ack := ceprotocol.ResultNACK
+	if response.StatusCode/100 == 2 {
+		ack = ceprotocol.ResultACK
+	}
+	result := cehttp.NewResult(response.StatusCode, "%w", ack)
+	if !withReply || message.ReadEncoding() == binding.EncodingUnknown {
+		return nil, result
+	}
+	reply, err := binding.ToEvent(ctx, message)
+	if err != nil {
+		return nil, result
+	}
+	return reply, result
+}
@@ -0,0 +1,220 @@
+package jetstream
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	ktypes "k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// deliveryHealthWindowSize is the number of the latest delivery results per Subscription the health is computed
+	// from.
+	deliveryHealthWindowSize = 20
+	// deliveryHealthFailureRatio is the ratio of the failed deliveries in the window from which the deliveries of a
+	// Subscription are unhealthy.
+	deliveryHealthFailureRatio = 0.5
+	// deliveryHealthMinDeliveries is the minimum number of deliveries in the window from which the deliveries of a
+	// Subscription can be unhealthy, so that a few failures of a Subscription with little traffic do not flap its health.
+	deliveryHealthMinDeliveries = 5
+	// deliveryFailureReportInterval is the minimum interval between two reports of the failed deliveries of a
+	// Subscription, so that a failing sink does not flood the Kubernetes Events.
+	deliveryFailureReportInterval = time.Minute
+	// maxDeliveryErrorLength is the maximum length of the error summary of a failed delivery.
+	maxDeliveryErrorLength = 256
+)
+
+// DeliveryFailure describes a failed delivery of an event to the sink of a Subscription.
+type DeliveryFailure struct {
+	// StatusCode is the HTTP status code of the delivery, it is 500 if the sink could not be reached.
+	StatusCode int
+	// Error is the summary of the delivery error.
+	Error string
+}
+
+// DeliveryHealth is the health of the latest deliveries of a Subscription.
+type DeliveryHealth struct {
+	// Deliveries is the number of the latest deliveries in the window.
+	Deliveries int
+	// Failures is the number of the failed deliveries in the window.
+	Failures int
+	// LastFailure is the latest failed delivery in the window, it is nil if no delivery in the window failed.
+	LastFailure *DeliveryFailure
+}
+
+// Healthy returns true if the ratio of the failed deliveries is below deliveryHealthFailureRatio, or if there are
+// less than deliveryHealthMinDeliveries deliveries.
+func (h DeliveryHealth) Healthy() bool {
+	return h.Deliveries < deliveryHealthMinDeliveries ||
+		float64(h.Failures)/float64(h.Deliveries) < deliveryHealthFailureRatio
+}
+
+// Message returns the message of the DeliveryHealthy condition, it is empty if the deliveries are healthy.
+func (h DeliveryHealth) Message() string {
+	if h.Healthy() || h.LastFailure == nil {
+		return ""
+	}
+	return fmt.Sprintf("Most of the latest deliveries to the sink failed, the last one with status code %d",
+		h.LastFailure.StatusCode)
+}
+
+// DeliveryHealthHandler is called when the deliveries of a Subscription fail, at most once per
+// deliveryFailureReportInterval, or when their health changes. The failure is nil if the deliveries recovered.
+type DeliveryHealthHandler func(subscription ktypes.NamespacedName, failure *DeliveryFailure, healthChanged bool)
+
+// DeliveryHealthTracker tracks the results of the latest deliveries per Subscription in a sliding window, and reports
+// the failures and the health changes to its handler. It is safe for concurrent use, and a nil tracker tracks nothing.
+type DeliveryHealthTracker struct {
+	mutex   sync.Mutex
+	windows map[ktypes.NamespacedName]*deliveryWindow
+	handler DeliveryHealthHandler
+	now     func() time.Time
+	// asyncHandlers is true if the handler is called in its own goroutine, it is false in the unit tests only.
+	asyncHandlers bool
+}
+
+// deliveryWindow holds the latest delivery results of a Subscription in a ring buffer.
+type deliveryWindow struct {
+	failures    []*DeliveryFailure
+	next        int
+	full        bool
+	lastFailure *DeliveryFailure
+	lastReport  time.Time
+}
+
+// NewDeliveryHealthTracker returns a tracker without a handler.
+func NewDeliveryHealthTracker() *DeliveryHealthTracker {
+	return &DeliveryHealthTracker{
+		windows:       make(map[ktypes.NamespacedName]*deliveryWindow),
+		now:           time.Now,
+		asyncHandlers: true,
+	}
+}
+
+// SetHandler sets the handler of the failures and the health changes.
+func (t *DeliveryHealthTracker) SetHandler(handler DeliveryHealthHandler) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.handler = handler
+}
+
+// RecordSuccess records a successful delivery of an event to the sink of the Subscription.
+func (t *DeliveryHealthTracker) RecordSuccess(subscription ktypes.NamespacedName) {
+	t.record(subscription, nil)
+}
+
+// RecordFailure records a failed delivery of an event to the sink of the Subscription.
+func (t *DeliveryHealthTracker) RecordFailure(subscription ktypes.NamespacedName, statusCode int, err error) {
+	failure := &DeliveryFailure{StatusCode: statusCode}
+	if err != nil {
+		failure.Error = summarizeDeliveryError(err.Error())
+	}
+	t.record(subscription, failure)
+}
+
+// Get returns the health of the latest deliveries of the Subscription, and false if none were recorded.
+func (t *DeliveryHealthTracker) Get(subscription ktypes.NamespacedName) (DeliveryHealth, bool) {
+	if t == nil {
+		return DeliveryHealth{}, false
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	window, found := t.windows[subscription]
+	if !found {
+		return DeliveryHealth{}, false
+	}
+	return window.health(), true
+}
+
+// Delete forgets the delivery results of the Subscription.
+func (t *DeliveryHealthTracker) Delete(subscription ktypes.NamespacedName) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	delete(t.windows, subscription)
+}
+
+func (t *DeliveryHealthTracker) record(subscription ktypes.NamespacedName, failure *DeliveryFailure) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	window, found := t.windows[subscription]
+	if !found {
+		window = &deliveryWindow{failures: make([]*DeliveryFailure, deliveryHealthWindowSize)}
+		t.windows[subscription] = window
+	}
+	wasHealthy := window.health().Healthy()
+	window.add(failure)
+	healthChanged := wasHealthy != window.health().Healthy()
+
+	// report the failures at most once per interval, unless the health changed.
+	now := t.now()
+	report := healthChanged
+	if failure != nil && now.Sub(window.lastReport) >= deliveryFailureReportInterval {
+		report = true
+	}
+	if report && failure != nil {
+		window.lastReport = now
+	}
+	handler := t.handler
+	t.mutex.Unlock()
+
+	if !report || handler == nil {
+		return
+	}
+	// the handler is called in its own goroutine, so that it does not delay the dispatching.
+	if t.asyncHandlers {
+		go handler(subscription, failure, healthChanged)
+		return
+	}
+	handler(subscription, failure, healthChanged)
+}
+
+func (w *deliveryWindow) add(failure *DeliveryFailure) {
+	w.failures[w.next] = failure
+	w.next = (w.next + 1) % len(w.failures)
+	if w.next == 0 {
+		w.full = true
+	}
+	if failure != nil {
+		w.lastFailure = failure
+	}
+}
+
+func (w *deliveryWindow) health() DeliveryHealth {
+	health := DeliveryHealth{Deliveries: w.next}
+	if w.full {
+		health.Deliveries = len(w.failures)
+	}
+	for i := 0; i < health.Deliveries; i++ {
+		if w.failures[i] != nil {
+			health.Failures++
+		}
+	}
+	if health.Failures > 0 {
+		health.LastFailure = w.lastFailure
+	}
+	return health
+}
+
+// SetDeliveryHealthTracker sets the tracker of the delivery results of the Subscriptions, it is optional.
+func (js *JetStream) SetDeliveryHealthTracker(tracker *DeliveryHealthTracker) {
+	js.deliveryHealth = tracker
+}
+
+// summarizeDeliveryError truncates the delivery error to maxDeliveryErrorLength characters.
+func summarizeDeliveryError(err string) string {
+	runes := []rune(err)
+	if len(runes) <= maxDeliveryErrorLength {
+		return err
+	}
+	return string(runes[:maxDeliveryErrorLength]) + "..."
+}
@@ -0,0 +1,163 @@
+package jetstream
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	ktypes "k8s.io/apimachinery/pkg/types"
+)
+
+var errSinkUnavailable = errors.New("sink unavailable")
+
+// deliveryReport is a call of the DeliveryHealthHandler.
+type deliveryReport struct {
+	failure       *DeliveryFailure
+	healthChanged bool
+}
+
+func newTestDeliveryHealthTracker(now *time.Time) (*DeliveryHealthTracker, *[]deliveryReport) {
+	reports := &[]deliveryReport{}
+	tracker := NewDeliveryHealthTracker()
+	tracker.asyncHandlers = false
+	tracker.now = func() time.Time { return *now }
+	tracker.SetHandler(func(_ ktypes.NamespacedName, failure *DeliveryFailure, healthChanged bool) {
+		*reports = append(*reports, deliveryReport{failure: failure, healthChanged: healthChanged})
+	})
+	return tracker, reports
+}
+
+func TestDeliveryHealthTracker(t *testing.T) {
+	t.Parallel()
+
+	// given
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker, reports := newTestDeliveryHealthTracker(&now)
+	subscription := ktypes.NamespacedName{Name: "sub", Namespace: "test"}
+	wantFailure := &DeliveryFailure{StatusCode: http.StatusBadGateway, Error: errSinkUnavailable.Error()}
+
+	// when the first delivery fails
+	tracker.RecordFailure(subscription, http.StatusBadGateway, errSinkUnavailable)
+
+	// then the deliveries are still healthy, since there are too few of them, but the failure is reported
+	health, found := tracker.Get(subscription)
+	require.True(t, found)
+	require.True(t, health.Healthy())
+	require.Equal(t, DeliveryHealth{Deliveries: 1, Failures: 1, LastFailure: wantFailure}, health)
+	require.Equal(t, []deliveryReport{{failure: wantFailure, healthChanged: false}}, *reports)
+
+	// when the deliveries keep failing within the report interval
+	for i := 1; i < deliveryHealthMinDeliveries; i++ {
+		tracker.RecordFailure(subscription, http.StatusBadGateway, errSinkUnavailable)
+	}
+
+	// then the deliveries are unhealthy, and the health change is reported
+	health, _ = tracker.Get(subscription)
+	require.False(t, health.Healthy())
+	require.Equal(t, []deliveryReport{{failure: wantFailure, healthChanged: true}}, (*reports)[1:])
+
+	// when the deliveries keep failing within the report interval
+	tracker.RecordFailure(subscription, http.StatusBadGateway, errSinkUnavailable)
+	tracker.RecordSuccess(subscription)
+
+	// then the failures are not reported again
+	require.Len(t, *reports, 2)
+
+	// when the deliveries keep failing after the report interval
+	now = now.Add(deliveryFailureReportInterval)
+	tracker.RecordFailure(subscription, http.StatusBadGateway, errSinkUnavailable)
+
+	// then the failure is reported again
+	require.Equal(t, deliveryReport{failure: wantFailure, healthChanged: false}, (*reports)[2])
+
+	// when the deliveries succeed again
+	for i := 0; i < deliveryHealthWindowSize; i++ {
+		tracker.RecordSuccess(subscription)
+	}
+
+	// then the deliveries are healthy, and the recovery is reported
+	health, _ = tracker.Get(subscription)
+	require.True(t, health.Healthy())
+	require.Equal(t, DeliveryHealth{Deliveries: deliveryHealthWindowSize}, health)
+	require.Equal(t, []deliveryReport{{failure: nil, healthChanged: true}}, (*reports)[3:])
+
+	// when the subscription is deleted
+	tracker.Delete(subscription)
+
+	// then its deliveries are forgotten
+	_, found = tracker.Get(subscription)
+	require.False(t, found)
+}
+
+func TestDeliveryHealth_Healthy(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		givenHealth DeliveryHealth
+		wantHealthy bool
+	}{
+		{
+			name:        "should be healthy without deliveries",
+			givenHealth: DeliveryHealth{},
+			wantHealthy: true,
+		},
+		{
+			name:        "should be healthy if less than half of the deliveries failed",
+			givenHealth: DeliveryHealth{Deliveries: 20, Failures: 9},
+			wantHealthy: true,
+		},
+		{
+			name:        "should be unhealthy if half of the deliveries failed",
+			givenHealth: DeliveryHealth{Deliveries: 20, Failures: 10},
+			wantHealthy: false,
+		},
+		{
+			name: "should be healthy if all the deliveries failed, but there are less than the minimum",
+			givenHealth: DeliveryHealth{
+				Deliveries: deliveryHealthMinDeliveries - 1,
+				Failures:   deliveryHealthMinDeliveries - 1,
+			},
+			wantHealthy: true,
+		},
+		{
+			name:        "should be unhealthy if all the deliveries failed, and there are the minimum",
+			givenHealth: DeliveryHealth{Deliveries: deliveryHealthMinDeliveries, Failures: deliveryHealthMinDeliveries},
+			wantHealthy: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tc.wantHealthy, tc.givenHealth.Healthy())
+		})
+	}
+}
+
+func TestDeliveryHealthTracker_Nil(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var tracker *DeliveryHealthTracker
+	subscription := ktypes.NamespacedName{Name: "sub", Namespace: "test"}
+
+	// when
+	tracker.RecordFailure(subscription, http.StatusBadGateway, errSinkUnavailable)
+
+	// then
+	_, found := tracker.Get(subscription)
+	require.False(t, found)
+}
+
+func Test_summarizeDeliveryError(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "sink unavailable", summarizeDeliveryError("sink unavailable"))
+	summary := summarizeDeliveryError(strings.Repeat("x", maxDeliveryErrorLength+1))
+	require.Equal(t, strings.Repeat("x", maxDeliveryErrorLength)+"...", summary)
+}
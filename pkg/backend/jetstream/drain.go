@@ -0,0 +1,63 @@
+package jetstream
+
+import (
+	"sync"
+	"time"
+)
+
+// drainProgressInterval is the interval to report the in-flight deliveries while draining.
+const drainProgressInterval = time.Second
+
+// inFlightTracker counts the deliveries which are being dispatched to the sinks, and stops accepting new ones
+// once draining. It is safe for concurrent use.
+type inFlightTracker struct {
+	mutex    sync.Mutex
+	count    int
+	draining bool
+	idle     chan struct{}
+}
+
+func newInFlightTracker() *inFlightTracker {
+	return &inFlightTracker{idle: make(chan struct{})}
+}
+
+// begin starts tracking a delivery. It returns false if the tracker is draining, i.e. the delivery must not start.
+func (t *inFlightTracker) begin() bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.draining {
+		return false
+	}
+	t.count++
+	return true
+}
+
+// end stops tracking a delivery which was started by begin.
+func (t *inFlightTracker) end() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.count--
+	if t.draining && t.count == 0 {
+		close(t.idle)
+	}
+}
+
+// drain stops accepting new deliveries. It returns a channel which is closed once all the in-flight deliveries ended.
+func (t *inFlightTracker) drain() <-chan struct{} {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if !t.draining {
+		t.draining = true
+		if t.count == 0 {
+			close(t.idle)
+		}
+	}
+	return t.idle
+}
+
+// remaining returns the number of the in-flight deliveries.
+func (t *inFlightTracker) remaining() int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.count
+}
@@ -0,0 +1,46 @@
+package jetstream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInFlightTracker(t *testing.T) {
+	t.Parallel()
+
+	// given
+	tracker := newInFlightTracker()
+	require.True(t, tracker.begin())
+	require.True(t, tracker.begin())
+
+	// when
+	idle := tracker.drain()
+
+	// then no new deliveries are accepted while the in-flight deliveries are drained
+	require.False(t, tracker.begin())
+	require.Equal(t, 2, tracker.remaining())
+	require.Empty(t, idle)
+
+	// when the in-flight deliveries end
+	tracker.end()
+	tracker.end()
+
+	// then
+	require.Equal(t, 0, tracker.remaining())
+	<-idle
+}
+
+func TestInFlightTracker_DrainIdle(t *testing.T) {
+	t.Parallel()
+
+	// given
+	tracker := newInFlightTracker()
+
+	// when
+	idle := tracker.drain()
+
+	// then
+	<-idle
+	require.Equal(t, idle, tracker.drain())
+}
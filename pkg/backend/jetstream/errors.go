@@ -14,6 +14,7 @@ var (
 	ErrDeleteConsumer      = errors.New("failed to delete consumer")
 	ErrFailedSubscribe     = errors.New("failed to create NATS JetStream subscription")
 	ErrFailedUnsubscribe   = errors.New("failed to unsubscribe from NATS JetStream")
+	ErrSinkAuth            = errors.New("failed to set up the sink authentication")
 
 	ErrConnect           = errors.New("failed to connect to NATS JetStream")
 	ErrEmptyStreamName   = errors.New("stream name cannot be empty")
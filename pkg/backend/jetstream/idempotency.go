@@ -0,0 +1,76 @@
+package jetstream
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+)
+
+// idempotencyCache remembers the keys of the successfully dispatched events, so that their redelivery is skipped.
+// It evicts the least recently added key once it is full, and expires the keys after the TTL.
+// It is safe for concurrent use.
+type idempotencyCache struct {
+	mutex   sync.Mutex
+	size    int
+	ttl     time.Duration
+	entries map[string]*list.Element
+	order   *list.List
+	now     func() time.Time
+}
+
+type idempotencyEntry struct {
+	key     string
+	expires time.Time
+}
+
+func newIdempotencyCache(size int, ttl time.Duration) *idempotencyCache {
+	return &idempotencyCache{
+		size:    size,
+		ttl:     ttl,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+		now:     time.Now,
+	}
+}
+
+// idempotencyKey returns the key which identifies the event, since the event ID is unique per source only.
+func idempotencyKey(event *cloudevents.Event) string {
+	return event.Source() + separator + event.ID()
+}
+
+// contains returns true if the key was added and has not expired yet.
+func (c *idempotencyCache) contains(key string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return false
+	}
+	if c.ttl > 0 && c.now().After(element.Value.(*idempotencyEntry).expires) {
+		c.remove(element)
+		return false
+	}
+	return true
+}
+
+// add adds the key and evicts the oldest keys if the cache is full.
+func (c *idempotencyCache) add(key string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+	c.entries[key] = c.order.PushBack(&idempotencyEntry{key: key, expires: c.now().Add(c.ttl)})
+	for c.order.Len() > c.size {
+		c.remove(c.order.Front())
+	}
+}
+
+func (c *idempotencyCache) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*idempotencyEntry).key)
+}
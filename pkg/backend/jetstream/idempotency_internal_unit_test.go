@@ -0,0 +1,41 @@
+package jetstream
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyCache(t *testing.T) {
+	t.Parallel()
+
+	// given
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := newIdempotencyCache(2, time.Minute)
+	cache.now = func() time.Time { return now }
+
+	// when
+	cache.add("source/1")
+	cache.add("source/2")
+
+	// then
+	require.True(t, cache.contains("source/1"))
+	require.True(t, cache.contains("source/2"))
+	require.False(t, cache.contains("source/3"))
+
+	// when the cache is full
+	cache.add("source/3")
+
+	// then the oldest key is evicted
+	require.False(t, cache.contains("source/1"))
+	require.True(t, cache.contains("source/2"))
+	require.True(t, cache.contains("source/3"))
+
+	// when the TTL is over
+	now = now.Add(2 * time.Minute)
+
+	// then the keys are expired
+	require.False(t, cache.contains("source/2"))
+	require.False(t, cache.contains("source/3"))
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -14,10 +15,13 @@ import (
 	"github.com/nats-io/nats.go"
 	pkgerrors "github.com/pkg/errors"
 	"go.uber.org/zap"
+	ktypes "k8s.io/apimachinery/pkg/types"
 
 	eventingv1alpha2 "github.com/kyma-project/eventing-manager/api/eventing/v1alpha2"
+	registryv1alpha1 "github.com/kyma-project/eventing-manager/api/registry/v1alpha1"
 	"github.com/kyma-project/eventing-manager/pkg/backend/cleaner"
 	backendmetrics "github.com/kyma-project/eventing-manager/pkg/backend/metrics"
+	"github.com/kyma-project/eventing-manager/pkg/backend/schema"
 	backendutils "github.com/kyma-project/eventing-manager/pkg/backend/utils"
 	"github.com/kyma-project/eventing-manager/pkg/env"
 	"github.com/kyma-project/eventing-manager/pkg/errors"
@@ -36,6 +40,16 @@ const (
 	jsConsumerNakDelay     = 30 * time.Second
 	jsConsumerAckWait      = 30 * time.Second
 	originalTypeHeaderName = "originaltype"
+	// schemaViolationExtensionName is the CloudEvent extension set on the events delivered despite a schema violation.
+	schemaViolationExtensionName = "schemaviolation"
+	// deadLetterSuffix is appended to the stream name and to the subject prefix to build the name and the subject
+	// prefix of the dead-letter stream. The dead-letter subjects are not under the subject prefix of the event stream,
+	// so that no Subscription can match them.
+	deadLetterSuffix = "-deadletter"
+	// maxLastSeenLookups is the maximum number of subjects whose last message is looked up by GetSubjectStats.
+	maxLastSeenLookups = 100
+	// messagingSystem identifies NATS in the spans of the delivery attempts.
+	messagingSystem = "nats"
 )
 
 func NewJetStream(config env.NATSConfig, metricsCollector *backendmetrics.Collector,
@@ -48,9 +62,15 @@ func NewJetStream(config env.NATSConfig, metricsCollector *backendmetrics.Collec
 		metricsCollector: metricsCollector,
 		cleaner:          cleaner,
 		subsConfig:       subsConfig,
+		inFlight:         newInFlightTracker(),
 	}
 }
 
+// SetSchemaRegistry sets the registry used to validate the event data before dispatching it to the sink.
+func (js *JetStream) SetSchemaRegistry(registry *schema.Registry) {
+	js.schemaRegistry = registry
+}
+
 func (js *JetStream) Initialize(connCloseHandler backendutils.ConnClosedHandler) error {
 	if err := js.validateConfig(); err != nil {
 		return err
@@ -67,9 +87,57 @@ func (js *JetStream) Initialize(connCloseHandler backendutils.ConnClosedHandler)
 	return js.ensureStreamExistsAndIsConfiguredCorrectly()
 }
 
+// Shutdown drains the in-flight deliveries and closes the connection. The NATS subscriptions are unbound first,
+// so that no new events are fetched, and then the events being dispatched are given up to JSDrainTimeout to be
+// ACKed or NAKed. The consumers are kept, so that the events which were not fetched are not lost.
 func (js *JetStream) Shutdown() {
-	if js.Conn != nil && !js.Conn.IsClosed() {
-		js.Conn.Close()
+	if js.Conn == nil || js.Conn.IsClosed() {
+		return
+	}
+	js.drain()
+	js.Conn.Close()
+}
+
+// drain stops the dispatching, and waits up to JSDrainTimeout for the in-flight deliveries to end.
+func (js *JetStream) drain() {
+	idle := js.inFlight.drain()
+	for key, jsSub := range js.subscriptions {
+		if !jsSub.IsValid() {
+			continue
+		}
+		// the consumer is not deleted, since the subscription is bound to it.
+		if err := jsSub.Unsubscribe(); err != nil {
+			js.namedLogger().Errorw("Failed to unbind the consumer while draining",
+				"consumer", key.ConsumerName(), "error", err)
+		}
+	}
+
+	select {
+	case <-idle:
+		return
+	default:
+	}
+
+	timeout := time.NewTimer(js.Config.JSDrainTimeout)
+	defer timeout.Stop()
+	progress := time.NewTicker(drainProgressInterval)
+	defer progress.Stop()
+	js.namedLogger().Infow("Draining the in-flight deliveries",
+		"inFlight", js.inFlight.remaining(), "timeout", js.Config.JSDrainTimeout)
+	for {
+		select {
+		case <-idle:
+			js.namedLogger().Info("Drained the in-flight deliveries")
+			return
+		case <-progress.C:
+			js.namedLogger().Infow("Draining the in-flight deliveries", "inFlight", js.inFlight.remaining())
+		case <-timeout.C:
+			abandoned := js.inFlight.remaining()
+			js.metricsCollector.RecordAbandonedDeliveries(abandoned)
+			js.namedLogger().Warnw("Timed out draining the in-flight deliveries, they are redelivered after the ACK wait",
+				"abandoned", abandoned)
+			return
+		}
 	}
 }
 
@@ -79,6 +147,11 @@ func (js *JetStream) SyncSubscription(subscription *eventingv1alpha2.Subscriptio
 		return err
 	}
 
+	// the consumers of the other layout must be migrated before they are cleaned up as unnecessary.
+	if err := js.migrateConsumers(subscription); err != nil {
+		return err
+	}
+
 	if err := js.syncSubscriptionEventTypes(subscription); err != nil {
 		return err
 	}
@@ -88,11 +161,38 @@ func (js *JetStream) SyncSubscription(subscription *eventingv1alpha2.Subscriptio
 		js.sinks.Store(subKeyPrefix, subscription.Spec.Sink)
 	}
 
+	// add/update the aliases of the rewritten event types in map for callbacks
+	js.eventTypeAliases.Store(subKeyPrefix, js.getEventTypeAliases(subscription))
+
+	// add/update if the sink replies are published in map for callbacks
+	js.storeReplyEnabled(subscription)
+
+	// add/update the content mode of the dispatched events in map for callbacks
+	if err := js.storeDeliveryMode(subscription); err != nil {
+		return err
+	}
+
+	// add/update the client of the sink with authentication in map for callbacks
+	if err := js.syncSinkClient(subscription); err != nil {
+		return err
+	}
+
+	// add/update the transformation of the events in map for callbacks
+	if err := js.storeTransformer(subscription); err != nil {
+		return err
+	}
+
+	// add idempotency cache in map for callbacks, if enabled
+	if js.Config.JSIdempotencyCacheSize > 0 {
+		if _, ok := js.idempotencyCaches.Load(subKeyPrefix); !ok {
+			js.idempotencyCaches.Store(subKeyPrefix,
+				newIdempotencyCache(js.Config.JSIdempotencyCacheSize, js.Config.JSIdempotencyCacheTTL))
+		}
+	}
+
 	// async callback for maxInflight messages
 	callback := js.getCallback(subKeyPrefix, subscription.Name, subscription.Namespace)
-	asyncCallback := func(m *nats.Msg) {
-		go callback(m)
-	}
+	asyncCallback := js.getAsyncCallback(callback)
 
 	if err := js.syncConsumerAndSubscription(subscription, asyncCallback); err != nil {
 		return err
@@ -119,16 +219,22 @@ func (js *JetStream) DeleteSubscription(subscription *eventingv1alpha2.Subscript
 
 	// cleanup consumers on nats-server
 	// in-case data in js.subscriptions[] was lost due to handler restart
-	for _, subject := range subscription.Status.Types {
-		jsSubject := js.GetJetStreamSubject(subscription.Spec.Source, subject.CleanType, subscription.Spec.TypeMatching)
-		jsSubKey := NewSubscriptionSubjectIdentifier(subscription, jsSubject)
-		if err := js.deleteConsumerFromJetStream(jsSubKey.ConsumerName()); err != nil {
+	for _, consumer := range js.getSubscriptionConsumers(subscription, js.Config.JSConsolidatedConsumers) {
+		if err := js.deleteConsumerFromJetStream(consumer.key.ConsumerName()); err != nil {
 			return err
 		}
 	}
 
-	// delete subscription sink info from storage
+	// delete subscription sink info and idempotency cache from storage
 	js.sinks.Delete(createKeyPrefix(subscription))
+	js.idempotencyCaches.Delete(createKeyPrefix(subscription))
+	js.eventTypeAliases.Delete(createKeyPrefix(subscription))
+	js.migratedSubscriptions.Delete(createKeyPrefix(subscription))
+	js.replySubscriptions.Delete(createKeyPrefix(subscription))
+	js.sinkClients.Delete(createKeyPrefix(subscription))
+	js.transformers.Delete(createKeyPrefix(subscription))
+	js.deliveryModes.Delete(createKeyPrefix(subscription))
+	js.deliveryHealth.Delete(ktypes.NamespacedName{Name: subscription.Name, Namespace: subscription.Namespace})
 
 	return nil
 }
@@ -156,6 +262,74 @@ func (js *JetStream) DeleteSubscriptionsOnly(subscription *eventingv1alpha2.Subs
 	return nil
 }
 
+// SyncDispatch binds a NATS subscription to each existing consumer of the given Subscriptions which is owned
+// according to the given function, and unbinds the NATS subscriptions of the consumers which are not owned anymore.
+// The consumers are not created nor deleted, since they are managed by SyncSubscription of the leader.
+// The consumers which cannot be bound yet, e.g. because they are still bound by their previous owner, are bound
+// by a later call.
+func (js *JetStream) SyncDispatch(subscriptions []eventingv1alpha2.Subscription, owns func(key string) bool) error {
+	if err := js.checkJetStreamConnection(); err != nil {
+		return err
+	}
+
+	owned := make(map[SubscriptionSubjectIdentifier]bool)
+	for i := range subscriptions {
+		subscription := &subscriptions[i]
+		subKeyPrefix := createKeyPrefix(subscription)
+		js.sinks.Store(subKeyPrefix, subscription.Spec.Sink)
+		js.eventTypeAliases.Store(subKeyPrefix, js.getEventTypeAliases(subscription))
+		js.storeReplyEnabled(subscription)
+		if err := js.storeDeliveryMode(subscription); err != nil {
+			backendutils.LoggerWithSubscription(js.namedLogger(), subscription).Errorw(
+				"Failed to look up the event format, retrying later", "error", err)
+			continue
+		}
+		if err := js.syncSinkClient(subscription); err != nil {
+			// the consumers are not bound, since the events cannot be dispatched without the sink authentication.
+			backendutils.LoggerWithSubscription(js.namedLogger(), subscription).Errorw(
+				"Failed to sync the sink client, retrying later", "error", err)
+			continue
+		}
+		if err := js.storeTransformer(subscription); err != nil {
+			backendutils.LoggerWithSubscription(js.namedLogger(), subscription).Errorw(
+				"Failed to compile the transformation, retrying later", "error", err)
+			continue
+		}
+		if js.Config.JSIdempotencyCacheSize > 0 {
+			if _, ok := js.idempotencyCaches.Load(subKeyPrefix); !ok {
+				js.idempotencyCaches.Store(subKeyPrefix,
+					newIdempotencyCache(js.Config.JSIdempotencyCacheSize, js.Config.JSIdempotencyCacheTTL))
+			}
+		}
+		callback := js.getCallback(subKeyPrefix, subscription.Name, subscription.Namespace)
+		asyncCallback := js.getAsyncCallback(callback)
+
+		for _, consumer := range js.getSubscriptionConsumers(subscription, js.Config.JSConsolidatedConsumers) {
+			if !owns(consumer.key.ShardKey()) {
+				continue
+			}
+			owned[consumer.key] = true
+			if jsSub, ok := js.subscriptions[consumer.key]; ok && jsSub.IsValid() {
+				continue
+			}
+			if err := js.bindInvalidSubscriptions(consumer, asyncCallback); err != nil {
+				backendutils.LoggerWithSubscription(js.namedLogger(), subscription).Debugw(
+					"Failed to bind the consumer, retrying later", "consumer", consumer.key.ConsumerName(), "error", err)
+			}
+		}
+	}
+
+	for key, jsSub := range js.subscriptions {
+		if owned[key] {
+			continue
+		}
+		if err := js.deleteSubscriptionFromJetStreamOnly(jsSub, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // GetJetStreamSubjects returns a list of subjects appended with prefix if needed.
 func (js *JetStream) GetJetStreamSubjects(source string, subjects []string,
 	typeMatching eventingv1alpha2.TypeMatching,
@@ -172,6 +346,38 @@ func (js *JetStream) GetJetStreamContext() nats.JetStreamContext {
 	return js.jsCtx
 }
 
+// GetSubjectStats returns the stats of all the subjects stored in the stream.
+func (js *JetStream) GetSubjectStats() (map[string]SubjectStats, error) {
+	if js.Conn == nil || js.Conn.Status() != nats.CONNECTED {
+		return nil, ErrConnect
+	}
+	info, err := js.jsCtx.StreamInfo(js.Config.JSStreamName, &nats.StreamInfoRequest{SubjectsFilter: ">"})
+	if err != nil {
+		return nil, err
+	}
+	subjects := make([]string, 0, len(info.State.Subjects))
+	for subject := range info.State.Subjects {
+		subjects = append(subjects, subject)
+	}
+	sort.Strings(subjects)
+
+	stats := make(map[string]SubjectStats, len(subjects))
+	for i, subject := range subjects {
+		subjectStats := SubjectStats{Messages: info.State.Subjects[subject]}
+		// the last-seen time is best-effort, since the message might be removed from the stream in the meantime.
+		// It is only looked up for the first subjects, to bound the requests to the stream.
+		if i < maxLastSeenLookups {
+			if msg, err := js.jsCtx.GetLastMsg(js.Config.JSStreamName, subject); err == nil {
+				subjectStats.LastSeen = msg.Time
+			}
+		} else {
+			subjectStats.LastSeenSkipped = true
+		}
+		stats[subject] = subjectStats
+	}
+	return stats, nil
+}
+
 // GetJetStreamSubject appends the prefix and the cleaned source to subject.
 func (js *JetStream) GetJetStreamSubject(source, subject string, typeMatching eventingv1alpha2.TypeMatching) string {
 	if typeMatching == eventingv1alpha2.TypeMatchingExact {
@@ -214,6 +420,11 @@ func (js *JetStream) isConsumerUsedByKymaSub(consumerName string, subscriptions
 				return true
 			}
 		}
+
+		// the consolidated consumer is used in either layout, since it is migrated by the subscription sync.
+		if len(cleanedTypes) > 0 && consumerName == computeConsumerName(&subscriptions[ix], consolidatedConsumerSubject) {
+			return true
+		}
 	}
 	return false
 }
@@ -280,7 +491,15 @@ func (js *JetStream) ensureStreamExistsAndIsConfiguredCorrectly() error {
 	if err != nil {
 		return err
 	}
-	info, err := js.jsCtx.StreamInfo(js.Config.JSStreamName)
+	if err = js.ensureStream(streamConfig); err != nil {
+		return err
+	}
+	return js.ensureStream(getDeadLetterStreamConfig(streamConfig, js.Config))
+}
+
+// ensureStream creates the stream if it does not exist, or updates it if it is not configured as given.
+func (js *JetStream) ensureStream(streamConfig *nats.StreamConfig) error {
+	info, err := js.jsCtx.StreamInfo(streamConfig.Name)
 	if pkgerrors.Is(err, nats.ErrStreamNotFound) {
 		info, err = js.jsCtx.AddStream(streamConfig)
 		if err != nil {
@@ -315,7 +534,12 @@ func streamIsConfiguredCorrectly(got nats.StreamConfig, want nats.StreamConfig)
 		got.Retention != want.Retention ||
 		got.MaxMsgs != want.MaxMsgs ||
 		got.MaxBytes != want.MaxBytes ||
-		got.Discard != want.Discard {
+		got.Discard != want.Discard ||
+		got.AllowDirect != want.AllowDirect {
+		return false
+	}
+	// the NATS server applies its default duplicates window if none is configured.
+	if want.Duplicates != 0 && got.Duplicates != want.Duplicates {
 		return false
 	}
 	return reflect.DeepEqual(got.Subjects, want.Subjects)
@@ -346,6 +570,7 @@ func (js *JetStream) initCloudEventClient(config env.NATSConfig) error {
 		return err
 	}
 	js.client = client
+	js.transport = transport
 	return nil
 }
 
@@ -421,10 +646,8 @@ func (js *JetStream) cleanupUnnecessaryJetStreamSubscribers(
 func (js *JetStream) runtimeSubscriptionExistsInKymaSub(runtimeSubscriptionKey SubscriptionSubjectIdentifier,
 	subscription *eventingv1alpha2.Subscription,
 ) bool {
-	for _, subject := range subscription.Status.Types {
-		jsSubject := js.getJetStreamSubject(subscription.Spec.Source, subject.CleanType, subscription.Spec.TypeMatching)
-		jsSubKey := NewSubscriptionSubjectIdentifier(subscription, jsSubject)
-		if runtimeSubscriptionKey.consumerName == jsSubKey.consumerName {
+	for _, consumer := range js.getSubscriptionConsumers(subscription, js.Config.JSConsolidatedConsumers) {
+		if runtimeSubscriptionKey.consumerName == consumer.key.consumerName {
 			return true
 		}
 	}
@@ -435,6 +658,10 @@ func (js *JetStream) runtimeSubscriptionExistsInKymaSub(runtimeSubscriptionKey S
 func (js *JetStream) consumerSubjectExistsInKymaSub(consumer *nats.ConsumerInfo,
 	subscription *eventingv1alpha2.Subscription,
 ) bool {
+	if js.Config.JSConsolidatedConsumers {
+		return consumer.Name == computeConsumerName(subscription, consolidatedConsumerSubject) &&
+			len(subscription.Status.Types) > 0
+	}
 	return utils.ContainsString(
 		js.GetJetStreamSubjects(
 			subscription.Spec.Source,
@@ -491,7 +718,42 @@ func (js *JetStream) revertEventTypeToOriginal(event *cloudevents.Event, sugared
 	sugaredLogger.Debugw("type reverted to original type by trimming prefixes")
 }
 
+// getEventTypeAliases returns the subscribed event types by the subjects they were rewritten to.
+// A subject is not aliased if the subscription also subscribes to its event type without rewriting.
+func (js *JetStream) getEventTypeAliases(subscription *eventingv1alpha2.Subscription) map[string]string {
+	aliases := make(map[string]string)
+	subscribed := make(map[string]bool)
+	for _, eventType := range GetCleanEventTypes(subscription, js.cleaner) {
+		subject := js.GetJetStreamSubject(subscription.Spec.Source, eventType.CleanType, subscription.Spec.TypeMatching)
+		if js.cleaner.RewriteEventType(eventType.OriginalType) == eventType.OriginalType {
+			subscribed[subject] = true
+			continue
+		}
+		aliases[subject] = eventType.OriginalType
+	}
+	for subject := range subscribed {
+		delete(aliases, subject)
+	}
+	return aliases
+}
+
+// aliasEventType sets the subscribed event type to the event received on a rewritten subject.
+func (js *JetStream) aliasEventType(subKeyPrefix, subject string, event *cloudevents.Event,
+	sugaredLogger *zap.SugaredLogger,
+) {
+	value, ok := js.eventTypeAliases.Load(subKeyPrefix)
+	if !ok {
+		return
+	}
+	aliases, _ := value.(map[string]string)
+	if alias, ok := aliases[subject]; ok {
+		event.SetType(alias)
+		sugaredLogger.Debugw("type set to the subscribed type of the rewritten subject", "alias", alias)
+	}
+}
+
 func (js *JetStream) getCallback(subKeyPrefix, subscriptionName, subscriptionNamespace string) nats.MsgHandler {
+	subscriptionKey := ktypes.NamespacedName{Name: subscriptionName, Namespace: subscriptionNamespace}
 	return func(msg *nats.Msg) {
 		// fetch sink info from storage
 		sinkValue, ok := js.sinks.Load(subKeyPrefix)
@@ -525,14 +787,53 @@ func (js *JetStream) getCallback(subKeyPrefix, subscriptionName, subscriptionNam
 		// decorate the logger with CloudEvent context
 		ceLogger := js.namedLogger().With("id", ce.ID(), "source", ce.Source(), "type", ce.Type(), "sink", sink)
 
+		// skip the redelivery of the events which were already dispatched successfully
+		cache := js.getIdempotencyCache(subKeyPrefix)
+		if cache != nil && cache.contains(idempotencyKey(ce)) {
+			ceLogger.Debugw("Skipping the already dispatched CloudEvent")
+			if ackErr := msg.Ack(); ackErr != nil {
+				ceLogger.Errorw("Failed to ACK an event on JetStream")
+			}
+			return
+		}
+
 		// revert the event type to original form
 		js.revertEventTypeToOriginal(ce, ceLogger)
 
+		// deliver the event with the type the subscriber subscribed to, if it was rewritten
+		js.aliasEventType(subKeyPrefix, msg.Subject, ce, ceLogger)
+
+		// validate the event data against the schema registered for the event type
+		if violation := js.validateEventSchema(ce); violation != nil {
+			js.metricsCollector.RecordSchemaValidationFailure(subscriptionName, subscriptionNamespace, ce.Type(), ci.Config.Name, string(violation.Policy))
+			if !js.handleSchemaViolation(msg, ce, violation, ceLogger) {
+				return
+			}
+		}
+
+		// transform the event to dispatch, the original event is kept for the metrics and the reply
+		dispatched, err := js.transformEvent(subKeyPrefix, ce)
+		if err != nil {
+			js.metricsCollector.RecordTransformationFailure(subscriptionName, subscriptionNamespace, ce.Type(), ci.Config.Name)
+			js.handleTransformationFailure(msg, err, ceLogger)
+			return
+		}
+
 		ceLogger.Debugw("Sending the CloudEvent")
 
-		// dispatch the event to sink
+		// trace the delivery attempt as a consumer span of the publish span
+		spanCtx, span := tracing.StartDeliverySpan(traceCtxWithCE, tracing.Tracer(), ce, tracing.DeliveryAttempt{
+			System:                messagingSystem,
+			SubscriptionName:      subscriptionName,
+			SubscriptionNamespace: subscriptionNamespace,
+			ConsumerName:          ci.Config.Name,
+			Attempt:               deliveryAttempt(msg),
+			StoredAt:              storedAt(msg),
+		})
+
+		// dispatch the event to sink, and receive its reply if the replies are published
 		start := time.Now()
-		result := js.client.Send(traceCtxWithCE, *ce)
+		reply, result := js.dispatchEvent(spanCtx, subKeyPrefix, sink, dispatched, js.isReplyEnabled(subKeyPrefix))
 		duration := time.Since(start)
 		var res *cehttp.Result
 		if !ceprotocol.IsACK(result) {
@@ -540,6 +841,8 @@ func (js *JetStream) getCallback(subKeyPrefix, subscriptionName, subscriptionNam
 			if cloudevents.ResultAs(result, &res) {
 				status = res.StatusCode
 			}
+			tracing.EndDeliverySpan(span, status, result)
+			js.deliveryHealth.RecordFailure(subscriptionKey, status, result)
 
 			js.metricsCollector.RecordDeliveryPerSubscription(subscriptionName, subscriptionNamespace, ce.Type(), ci.Config.Name, sink, status)
 			js.metricsCollector.RecordLatencyPerSubscription(duration, subscriptionName, subscriptionNamespace, ce.Type(), ci.Config.Name, sink, status)
@@ -553,21 +856,115 @@ func (js *JetStream) getCallback(subKeyPrefix, subscriptionName, subscriptionNam
 			return
 		}
 
-		// event was successfully dispatched, check if acknowledged by the NATS server
-		// if not, the message is redelivered.
-		if ackErr := msg.Ack(); ackErr != nil {
-			ceLogger.Errorw("Failed to ACK an event on JetStream")
-		}
-
 		status := http.StatusOK
 		if cloudevents.ResultAs(result, &res) {
 			status = res.StatusCode
 		}
+		tracing.EndDeliverySpan(span, status, nil)
+		js.deliveryHealth.RecordSuccess(subscriptionKey)
 
 		js.metricsCollector.RecordDeliveryPerSubscription(subscriptionName, subscriptionNamespace, ce.Type(), ci.Config.Name, sink, status)
 		js.metricsCollector.RecordLatencyPerSubscription(duration, subscriptionName, subscriptionNamespace, ce.Type(), ci.Config.Name, sink, status)
 		ceLogger.Debugw("CloudEvent was dispatched")
+
+		// publish the event replied by the sink. The event is acknowledged even if the reply cannot be published,
+		// so that the sink is not called again for an event it processed already.
+		if reply != nil && (status == http.StatusOK || status == http.StatusAccepted) {
+			replyResult, err := js.publishReply(ce, reply, subscriptionName, subscriptionNamespace, ceLogger)
+			js.metricsCollector.RecordReplyEvent(subscriptionName, subscriptionNamespace, reply.Type(), ci.Config.Name, replyResult)
+			if err != nil {
+				ceLogger.Errorw("Failed to publish the reply event", "replyID", reply.ID(), "error", err)
+			}
+		}
+
+		// event was successfully dispatched, remember it to skip its redelivery
+		if cache != nil {
+			cache.add(idempotencyKey(ce))
+		}
+
+		// event was successfully dispatched, check if acknowledged by the NATS server
+		// if not, the message is redelivered.
+		if ackErr := msg.Ack(); ackErr != nil {
+			ceLogger.Errorw("Failed to ACK an event on JetStream")
+		}
+	}
+}
+
+// getAsyncCallback returns a handler which dispatches the events asynchronously, so that up to maxInFlight events
+// are dispatched concurrently. The in-flight deliveries are tracked to drain them on shutdown.
+func (js *JetStream) getAsyncCallback(callback nats.MsgHandler) nats.MsgHandler {
+	return func(msg *nats.Msg) {
+		if !js.inFlight.begin() {
+			// the dispatcher is draining, so that the event is redelivered once the consumer is bound again.
+			if err := msg.Nak(); err != nil {
+				js.namedLogger().Errorw("Failed to NAK an event on JetStream while draining", "error", err)
+			}
+			return
+		}
+		js.metricsCollector.IncInFlightDeliveries()
+		go func() {
+			defer js.inFlight.end()
+			defer js.metricsCollector.DecInFlightDeliveries()
+			callback(msg)
+		}()
+	}
+}
+
+func (js *JetStream) getIdempotencyCache(subKeyPrefix string) *idempotencyCache {
+	value, ok := js.idempotencyCaches.Load(subKeyPrefix)
+	if !ok {
+		return nil
+	}
+	cache, _ := value.(*idempotencyCache)
+	return cache
+}
+
+func (js *JetStream) validateEventSchema(event *cloudevents.Event) *schema.Violation {
+	if js.schemaRegistry == nil {
+		return nil
 	}
+	return js.schemaRegistry.Validate(event)
+}
+
+// handleSchemaViolation applies the validation policy to the event which does not conform to its schema.
+// It returns true if the event should still be dispatched to the sink.
+func (js *JetStream) handleSchemaViolation(msg *nats.Msg, event *cloudevents.Event, violation *schema.Violation,
+	ceLogger *zap.SugaredLogger,
+) bool {
+	switch violation.Policy {
+	case registryv1alpha1.ValidationPolicyDrop:
+		ceLogger.Warnw("Dropping the CloudEvent not conforming to its schema", "error", violation)
+		if err := msg.Ack(); err != nil {
+			ceLogger.Errorw("Failed to ACK an event on JetStream")
+		}
+		return false
+	case registryv1alpha1.ValidationPolicyDeadLetter:
+		subject := js.getDeadLetterSubject(msg.Subject)
+		ceLogger.Warnw("Dead-lettering the CloudEvent not conforming to its schema", "error", violation, "subject", subject)
+		if _, err := js.jsCtx.Publish(subject, msg.Data); err != nil {
+			ceLogger.Errorw("Failed to publish the CloudEvent to the dead-letter subject", "error", err)
+			// NAK the msg with a delay so it is redelivered after jsConsumerNakDelay period.
+			if err := msg.NakWithDelay(jsConsumerNakDelay); err != nil {
+				ceLogger.Errorw("failed to NAK an event on JetStream")
+			}
+			return false
+		}
+		if err := msg.Ack(); err != nil {
+			ceLogger.Errorw("Failed to ACK an event on JetStream")
+		}
+		return false
+	default:
+		ceLogger.Warnw("Dispatching the CloudEvent not conforming to its schema", "error", violation)
+		event.SetExtension(schemaViolationExtensionName, violation.Err.Error())
+		return true
+	}
+}
+
+// getDeadLetterSubject returns the subject for dead-lettering the messages received on the given subject.
+// It replaces the subject prefix of the event stream with the one of the dead-letter stream.
+func (js *JetStream) getDeadLetterSubject(subject string) string {
+	return fmt.Sprintf("%s.%s", getDeadLetterSubjectPrefix(js.Config),
+		strings.TrimPrefix(subject, js.Config.JSSubjectPrefix+"."))
 }
 
 // deleteConsumerFromJS deletes consumer on NATS Server.
@@ -586,38 +983,43 @@ func (js *JetStream) deleteConsumerFromJetStream(name string) error {
 func (js *JetStream) syncConsumerAndSubscription(subscription *eventingv1alpha2.Subscription,
 	asyncCallback func(m *nats.Msg),
 ) error {
-	for _, eventType := range subscription.Status.Types {
-		jsSubject := js.GetJetStreamSubject(subscription.Spec.Source, eventType.CleanType, subscription.Spec.TypeMatching)
-		jsSubKey := NewSubscriptionSubjectIdentifier(subscription, jsSubject)
-
-		consumerInfo, err := js.getOrCreateConsumer(subscription, eventType)
+	for _, consumer := range js.getSubscriptionConsumers(subscription, js.Config.JSConsolidatedConsumers) {
+		consumerInfo, err := js.getOrCreateConsumer(subscription, consumer)
 		if err != nil {
 			return err
 		}
 
-		natsSubscription, subExists := js.subscriptions[jsSubKey]
+		// the consumer is dispatched by the sharded dispatchers, see SyncDispatch.
+		if js.Config.JSShardedDispatch {
+			if syncConfigErr := js.syncConsumerConfig(subscription, consumer, *consumerInfo); syncConfigErr != nil {
+				return syncConfigErr
+			}
+			continue
+		}
+
+		natsSubscription, subExists := js.subscriptions[consumer.key]
 
 		// try to create a NATS Subscription if it doesn't exist
 		if !subExists && !consumerInfo.PushBound {
-			if createErr := js.createNATSSubscription(subscription, eventType, asyncCallback); createErr != nil {
+			if createErr := js.createNATSSubscription(subscription, consumer, *consumerInfo, asyncCallback); createErr != nil {
 				return createErr
 			}
 		}
 
-		if _, ok := js.subscriptions[jsSubKey]; !ok {
+		if _, ok := js.subscriptions[consumer.key]; !ok {
 			return errors.MakeError(ErrMissingSubscription, err)
 		}
 
 		// try to bind invalid NATS Subscriptions
 		if subExists && !natsSubscription.IsValid() {
-			if bindErr := js.bindInvalidSubscriptions(subscription, eventType, asyncCallback); bindErr != nil {
+			if bindErr := js.bindInvalidSubscriptions(consumer, asyncCallback); bindErr != nil {
 				return bindErr
 			}
 		}
 
 		// checks and updates the NATS consumer configs in case they are not up-to-date with the Subscription CR.
-		if syncMaxInFlightErr := js.syncConsumerMaxInFlight(subscription, *consumerInfo); syncMaxInFlightErr != nil {
-			return syncMaxInFlightErr
+		if syncConfigErr := js.syncConsumerConfig(subscription, consumer, *consumerInfo); syncConfigErr != nil {
+			return syncConfigErr
 		}
 	}
 	return nil
@@ -625,18 +1027,15 @@ func (js *JetStream) syncConsumerAndSubscription(subscription *eventingv1alpha2.
 
 // getOrCreateConsumer fetches the ConsumerInfo from NATS Server or creates it in case it doesn't exist.
 func (js *JetStream) getOrCreateConsumer(subscription *eventingv1alpha2.Subscription,
-	subject eventingv1alpha2.EventType,
+	consumer subscriptionConsumer,
 ) (*nats.ConsumerInfo, error) {
-	jsSubject := js.GetJetStreamSubject(subscription.Spec.Source, subject.CleanType, subscription.Spec.TypeMatching)
-	jsSubKey := NewSubscriptionSubjectIdentifier(subscription, jsSubject)
-
-	consumerInfo, err := js.jsCtx.ConsumerInfo(js.Config.JSStreamName, jsSubKey.ConsumerName())
+	consumerInfo, err := js.jsCtx.ConsumerInfo(js.Config.JSStreamName, consumer.key.ConsumerName())
 	if err != nil {
 		if pkgerrors.Is(err, nats.ErrConsumerNotFound) {
 			ecSubsConfig := env.DefaultSubscriptionConfig(js.subsConfig)
 			consumerInfo, err = js.jsCtx.AddConsumer(
 				js.Config.JSStreamName,
-				js.getConsumerConfig(jsSubKey, jsSubject, subscription.GetMaxInFlightMessages(&ecSubsConfig)),
+				js.getConsumerConfig(consumer, subscription.GetMaxInFlightMessages(&ecSubsConfig)),
 			)
 			if err != nil {
 				return nil, errors.MakeError(ErrAddConsumer, err)
@@ -650,67 +1049,72 @@ func (js *JetStream) getOrCreateConsumer(subscription *eventingv1alpha2.Subscrip
 
 // createNATSSubscription creates a NATS Subscription and binds it to the already existing consumer.
 func (js *JetStream) createNATSSubscription(subscription *eventingv1alpha2.Subscription,
-	subject eventingv1alpha2.EventType, asyncCallback func(m *nats.Msg),
+	consumer subscriptionConsumer, consumerInfo nats.ConsumerInfo, asyncCallback func(m *nats.Msg),
 ) error {
-	jsSubject := js.GetJetStreamSubject(subscription.Spec.Source, subject.CleanType, subscription.Spec.TypeMatching)
-	jsSubKey := NewSubscriptionSubjectIdentifier(subscription, jsSubject)
-
 	ecSubsConfig := env.DefaultSubscriptionConfig(js.subsConfig)
+	subOpts := js.getDefaultSubscriptionOptions(consumer.key, subscription.GetMaxInFlightMessages(&ecSubsConfig))
+	// the consumers which were migrated from the other layout start at a sequence instead of the deliver policy.
+	if consumerInfo.Config.DeliverPolicy == nats.DeliverByStartSequencePolicy {
+		subOpts = append(subOpts, nats.StartSequence(consumerInfo.Config.OptStartSeq))
+	}
 	jsSubscription, err := js.jsCtx.Subscribe(
-		jsSubject,
+		consumer.subscribeSubject(),
 		asyncCallback,
-		js.getDefaultSubscriptionOptions(jsSubKey, subscription.GetMaxInFlightMessages(&ecSubsConfig))...,
+		subOpts...,
 	)
 	if err != nil {
 		return errors.MakeError(ErrFailedSubscribe, err)
 	}
 	// save created JetStream subscription in storage
-	js.subscriptions[jsSubKey] = &Subscription{Subscription: jsSubscription}
-	js.metricsCollector.RecordEventTypes(
-		subscription.Name,
-		subscription.Namespace,
-		subject.CleanType,
-		jsSubKey.ConsumerName(),
-	)
+	js.subscriptions[consumer.key] = &Subscription{Subscription: jsSubscription}
+	for _, eventType := range consumer.eventTypes {
+		js.metricsCollector.RecordEventTypes(
+			subscription.Name,
+			subscription.Namespace,
+			eventType.CleanType,
+			consumer.key.ConsumerName(),
+		)
+	}
 
 	return nil
 }
 
 // bindInvalidSubscriptions tries to bind the invalid NATS Subscription to the existing consumer.
-func (js *JetStream) bindInvalidSubscriptions(subscription *eventingv1alpha2.Subscription,
-	subject eventingv1alpha2.EventType, asyncCallback func(m *nats.Msg),
-) error {
-	jsSubject := js.GetJetStreamSubject(subscription.Spec.Source, subject.CleanType, subscription.Spec.TypeMatching)
-	jsSubKey := NewSubscriptionSubjectIdentifier(subscription, jsSubject)
+func (js *JetStream) bindInvalidSubscriptions(consumer subscriptionConsumer, asyncCallback func(m *nats.Msg)) error {
 	// bind the existing consumer to a new subscription on JetStream
 	jsSubscription, err := js.jsCtx.Subscribe(
-		jsSubject,
+		consumer.subscribeSubject(),
 		asyncCallback,
-		nats.Bind(js.Config.JSStreamName, jsSubKey.ConsumerName()),
+		nats.Bind(js.Config.JSStreamName, consumer.key.ConsumerName()),
 	)
 	if err != nil {
 		return errors.MakeError(ErrFailedSubscribe, err)
 	}
 	// save recreated JetStream subscription in storage
-	js.subscriptions[jsSubKey] = &Subscription{Subscription: jsSubscription}
+	js.subscriptions[consumer.key] = &Subscription{Subscription: jsSubscription}
 	return nil
 }
 
-// syncConsumerMaxInFlight checks that the latest Subscription's maxInFlight value
-// is propagated to the NATS consumer as MaxAckPending.
-func (js *JetStream) syncConsumerMaxInFlight(subscription *eventingv1alpha2.Subscription,
-	consumerInfo nats.ConsumerInfo,
+// syncConsumerConfig checks that the latest Subscription's maxInFlight value is propagated to the NATS consumer
+// as MaxAckPending, and the latest subjects of the Subscription to the filter subjects of a consolidated consumer.
+func (js *JetStream) syncConsumerConfig(subscription *eventingv1alpha2.Subscription,
+	consumer subscriptionConsumer, consumerInfo nats.ConsumerInfo,
 ) error {
 	ecSubsConfig := env.DefaultSubscriptionConfig(js.subsConfig)
 	maxInFlight := subscription.GetMaxInFlightMessages(&ecSubsConfig)
+	filterSubjectsUpToDate := !consumer.consolidated ||
+		sameSubjects(consumerInfo.Config.FilterSubjects, consumer.subjects)
 
-	if consumerInfo.Config.MaxAckPending == maxInFlight {
+	if consumerInfo.Config.MaxAckPending == maxInFlight && filterSubjectsUpToDate {
 		return nil
 	}
 
-	// set the new maxInFlight value
+	// set the new maxInFlight value and filter subjects
 	consumerConfig := consumerInfo.Config
 	consumerConfig.MaxAckPending = maxInFlight
+	if consumer.consolidated {
+		consumerConfig.FilterSubjects = consumer.subjects
+	}
 
 	// update the consumer
 	if _, updateErr := js.jsCtx.UpdateConsumer(js.Config.JSStreamName, &consumerConfig); updateErr != nil {
@@ -2,17 +2,32 @@ package jetstream
 
 import (
 	"fmt"
+	"io"
 	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	pbformat "github.com/cloudevents/sdk-go/binding/format/protobuf/v2"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding/format"
 	kymalogger "github.com/kyma-project/kyma/common/logging/logger"
 	"github.com/nats-io/nats.go"
 	"github.com/stretchr/testify/require"
+	kcorev1 "k8s.io/api/core/v1"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	eventingv1alpha2 "github.com/kyma-project/eventing-manager/api/eventing/v1alpha2"
+	operatorv1alpha1 "github.com/kyma-project/eventing-manager/api/operator/v1alpha1"
 	"github.com/kyma-project/eventing-manager/pkg/backend/cleaner"
 	"github.com/kyma-project/eventing-manager/pkg/backend/metrics"
+	"github.com/kyma-project/eventing-manager/pkg/backend/sinkauth"
 	"github.com/kyma-project/eventing-manager/pkg/ems/api/events/types"
 	"github.com/kyma-project/eventing-manager/pkg/env"
 	"github.com/kyma-project/eventing-manager/pkg/logger"
@@ -180,6 +195,924 @@ func TestMultipleJSSubscriptionsToSameEvent(t *testing.T) {
 	require.Error(t, subscriber.CheckEvent(eventingtesting.CloudEventData2))
 }
 
+// TestJetStream_ShardedDispatch tests that the consumers are managed by one instance and dispatched
+// by the instance owning them.
+func TestJetStream_ShardedDispatch(t *testing.T) {
+	// given
+	testEnvironment := setupTestEnvironment(t)
+	defer testEnvironment.natsServer.Shutdown()
+	defer testEnvironment.jsClient.natsConn.Close()
+	testEnvironment.natsConfig.JSShardedDispatch = true
+	jsBackend := NewJetStream(testEnvironment.natsConfig, metrics.NewCollector(), testEnvironment.cleaner,
+		env.DefaultSubscriptionConfig{MaxInFlightMessages: 9}, testEnvironment.logger)
+	require.NoError(t, jsBackend.Initialize(nil))
+	defer jsBackend.Shutdown()
+	dispatcher := NewJetStream(testEnvironment.natsConfig, metrics.NewCollector(), testEnvironment.cleaner,
+		env.DefaultSubscriptionConfig{MaxInFlightMessages: 9}, testEnvironment.logger)
+	require.NoError(t, dispatcher.Initialize(nil))
+	defer dispatcher.Shutdown()
+
+	subscriber := eventingtesting.NewSubscriber()
+	defer subscriber.Shutdown()
+	require.True(t, subscriber.IsRunning())
+
+	sub := eventingtesting.NewSubscription("sub", "foo",
+		eventingtesting.WithSourceAndType(eventingtesting.EventSource, eventingtesting.OrderCreatedEventType),
+		eventingtesting.WithSinkURL(subscriber.SinkURL),
+		eventingtesting.WithTypeMatchingStandard(),
+		eventingtesting.WithMaxInFlight(DefaultMaxInFlights),
+	)
+	AddJSCleanEventTypesToStatus(sub, testEnvironment.cleaner)
+	subject := jsBackend.GetJetStreamSubject(eventingtesting.EventSource,
+		eventingtesting.OrderCreatedEventType, eventingv1alpha2.TypeMatchingStandard)
+
+	// when
+	require.NoError(t, jsBackend.SyncSubscription(sub))
+
+	// then the consumer is created but not bound
+	require.Empty(t, jsBackend.GetNATSSubscriptions())
+	consumerInfo, err := jsBackend.GetJetStreamContext().ConsumerInfo(
+		testEnvironment.natsConfig.JSStreamName, NewSubscriptionSubjectIdentifier(sub, subject).ConsumerName())
+	require.NoError(t, err)
+	require.False(t, consumerInfo.PushBound)
+
+	// when the consumer is not owned
+	require.NoError(t, dispatcher.SyncDispatch([]eventingv1alpha2.Subscription{*sub},
+		func(string) bool { return false }))
+
+	// then
+	require.Empty(t, dispatcher.GetNATSSubscriptions())
+
+	// when the consumer is owned
+	require.NoError(t, dispatcher.SyncDispatch([]eventingv1alpha2.Subscription{*sub},
+		func(string) bool { return true }))
+
+	// then the events are dispatched
+	require.Len(t, dispatcher.GetNATSSubscriptions(), 1)
+	require.NoError(t, SendCloudEventToJetStream(dispatcher, subject, eventingtesting.CloudEventData,
+		types.ContentModeBinary))
+	require.NoError(t, subscriber.CheckEvent(eventingtesting.CloudEventData))
+
+	// when the consumer is not owned anymore
+	require.NoError(t, dispatcher.SyncDispatch([]eventingv1alpha2.Subscription{*sub},
+		func(string) bool { return false }))
+
+	// then it is unbound but not deleted
+	require.Empty(t, dispatcher.GetNATSSubscriptions())
+	_, err = jsBackend.GetJetStreamContext().ConsumerInfo(
+		testEnvironment.natsConfig.JSStreamName, NewSubscriptionSubjectIdentifier(sub, subject).ConsumerName())
+	require.NoError(t, err)
+}
+
+// TestJetStream_ShutdownDrainsInFlightDeliveries tests that Shutdown waits for the events being dispatched
+// to be ACKed, so that they are not redelivered.
+func TestJetStream_ShutdownDrainsInFlightDeliveries(t *testing.T) {
+	// given
+	testEnvironment := setupTestEnvironment(t)
+	defer testEnvironment.natsServer.Shutdown()
+	defer testEnvironment.jsClient.natsConn.Close()
+	testEnvironment.natsConfig.JSDrainTimeout = 10 * time.Second
+	jsBackend := NewJetStream(testEnvironment.natsConfig, metrics.NewCollector(), testEnvironment.cleaner,
+		env.DefaultSubscriptionConfig{MaxInFlightMessages: 9}, testEnvironment.logger)
+	require.NoError(t, jsBackend.Initialize(nil))
+
+	received := make(chan struct{}, 1)
+	var delivered atomic.Bool
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		received <- struct{}{}
+		time.Sleep(time.Second)
+		delivered.Store(true)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer sink.Close()
+
+	sub := eventingtesting.NewSubscription("sub", "foo",
+		eventingtesting.WithSourceAndType(eventingtesting.EventSource, eventingtesting.OrderCreatedEventType),
+		eventingtesting.WithSinkURL(sink.URL),
+		eventingtesting.WithTypeMatchingStandard(),
+		eventingtesting.WithMaxInFlight(DefaultMaxInFlights),
+	)
+	AddJSCleanEventTypesToStatus(sub, testEnvironment.cleaner)
+	require.NoError(t, jsBackend.SyncSubscription(sub))
+	subject := jsBackend.GetJetStreamSubject(eventingtesting.EventSource,
+		eventingtesting.OrderCreatedEventType, eventingv1alpha2.TypeMatchingStandard)
+	require.NoError(t, SendCloudEventToJetStream(jsBackend, subject, eventingtesting.CloudEventData,
+		types.ContentModeBinary))
+	<-received
+
+	// when
+	jsBackend.Shutdown()
+
+	// then the in-flight delivery is ACKed before the connection is closed
+	require.True(t, delivered.Load())
+	require.True(t, jsBackend.Conn.IsClosed())
+	require.Eventually(t, func() bool {
+		consumerInfo, err := testEnvironment.jsClient.ConsumerInfo(
+			testEnvironment.natsConfig.JSStreamName, NewSubscriptionSubjectIdentifier(sub, subject).ConsumerName())
+		return err == nil && consumerInfo.NumAckPending == 0 && consumerInfo.NumRedelivered == 0
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+// TestJetStream_EventTypeRewrite tests that a subscription to a rewritten event type receives the events
+// of the type it was rewritten to, with the type it subscribed to.
+func TestJetStream_EventTypeRewrite(t *testing.T) {
+	// given
+	testEnvironment := setupTestEnvironment(t)
+	defer testEnvironment.natsServer.Shutdown()
+	defer testEnvironment.jsClient.natsConn.Close()
+	oldEventType := eventingtesting.EventTypePrefix + ".oldapp." + eventingtesting.OrderCreatedV1Event
+	jsCleaner := cleaner.NewJetStreamCleaner(testEnvironment.logger, operatorv1alpha1.EventTypeRewrite{
+		From: eventingtesting.EventTypePrefix + ".oldapp.*",
+		To:   eventingtesting.EventTypePrefix + "." + eventingtesting.ApplicationName + ".*",
+	})
+	jsBackend := NewJetStream(testEnvironment.natsConfig, metrics.NewCollector(), jsCleaner,
+		env.DefaultSubscriptionConfig{MaxInFlightMessages: 9}, testEnvironment.logger)
+	require.NoError(t, jsBackend.Initialize(nil))
+	defer jsBackend.Shutdown()
+
+	receivedTypes := make(chan string, 1)
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedTypes <- r.Header.Get("Ce-Type")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer sink.Close()
+
+	sub := eventingtesting.NewSubscription("sub", "foo",
+		eventingtesting.WithSourceAndType(eventingtesting.EventSource, oldEventType),
+		eventingtesting.WithSinkURL(sink.URL),
+		eventingtesting.WithTypeMatchingStandard(),
+		eventingtesting.WithMaxInFlight(DefaultMaxInFlights),
+	)
+	AddJSCleanEventTypesToStatus(sub, jsCleaner)
+
+	// when
+	require.NoError(t, jsBackend.SyncSubscription(sub))
+
+	// then the consumer is bound to the subject of the rewritten type
+	require.Equal(t, eventingtesting.OrderCreatedEventType, sub.Status.Types[0].CleanType)
+	subject := jsBackend.GetJetStreamSubject(eventingtesting.EventSource,
+		eventingtesting.OrderCreatedEventType, eventingv1alpha2.TypeMatchingStandard)
+	_, err := jsBackend.GetJetStreamContext().ConsumerInfo(
+		testEnvironment.natsConfig.JSStreamName, NewSubscriptionSubjectIdentifier(sub, subject).ConsumerName())
+	require.NoError(t, err)
+
+	// when an event of the rewritten type is published
+	require.NoError(t, SendCloudEventToJetStream(jsBackend, subject, eventingtesting.CloudEventData,
+		types.ContentModeBinary))
+
+	// then it is dispatched with the subscribed type
+	select {
+	case receivedType := <-receivedTypes:
+		require.Equal(t, oldEventType, receivedType)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the event was not dispatched")
+	}
+}
+
+// TestJetStream_WildcardTypeMatching tests that a subscription with wildcard types receives the events of the
+// matching types, and that its consumer is not deleted as invalid.
+func TestJetStream_WildcardTypeMatching(t *testing.T) {
+	// given
+	testEnvironment := setupTestEnvironment(t)
+	jsBackend := testEnvironment.jsBackend
+	defer testEnvironment.natsServer.Shutdown()
+	defer testEnvironment.jsClient.natsConn.Close()
+	require.NoError(t, jsBackend.Initialize(nil))
+
+	receivedTypes := make(chan string, 1)
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedTypes <- r.Header.Get("Ce-Type")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer sink.Close()
+
+	wildcardType := eventingtesting.EventTypePrefix + "." + eventingtesting.ApplicationName + ".order.*.v1"
+	sub := eventingtesting.NewSubscription("sub", "foo",
+		eventingtesting.WithSourceAndType(eventingtesting.EventSource, wildcardType),
+		eventingtesting.WithSinkURL(sink.URL),
+		eventingtesting.WithTypeMatching(eventingv1alpha2.TypeMatchingWildcard),
+		eventingtesting.WithMaxInFlight(DefaultMaxInFlights),
+	)
+	AddJSCleanEventTypesToStatus(sub, testEnvironment.cleaner)
+
+	// when
+	require.NoError(t, jsBackend.SyncSubscription(sub))
+
+	// then the consumer is bound to the wildcard subject
+	wildcardSubject := jsBackend.GetJetStreamSubject(eventingtesting.EventSource, wildcardType,
+		eventingv1alpha2.TypeMatchingWildcard)
+	consumerName := NewSubscriptionSubjectIdentifier(sub, wildcardSubject).ConsumerName()
+	consumerInfo, err := jsBackend.GetJetStreamContext().ConsumerInfo(testEnvironment.natsConfig.JSStreamName,
+		consumerName)
+	require.NoError(t, err)
+	require.Equal(t, wildcardSubject, consumerInfo.Config.FilterSubject)
+
+	// when an event of a matching type is published
+	subject := jsBackend.GetJetStreamSubject(eventingtesting.EventSource, eventingtesting.OrderCreatedEventType,
+		eventingv1alpha2.TypeMatchingStandard)
+	require.NoError(t, SendCloudEventToJetStream(jsBackend, subject, eventingtesting.CloudEventData,
+		types.ContentModeBinary))
+
+	// then it is dispatched with its own type
+	select {
+	case receivedType := <-receivedTypes:
+		require.Equal(t, eventingtesting.OrderCreatedEventType, receivedType)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the event was not dispatched")
+	}
+
+	// when the consumer has no interest and the invalid consumers are deleted
+	for _, subscription := range jsBackend.subscriptions {
+		require.NoError(t, subscription.Unsubscribe())
+	}
+	require.Eventually(t, func() bool {
+		info, infoErr := jsBackend.GetJetStreamContext().ConsumerInfo(testEnvironment.natsConfig.JSStreamName,
+			consumerName)
+		return infoErr == nil && !info.PushBound
+	}, 5*time.Second, 100*time.Millisecond)
+	require.NoError(t, jsBackend.DeleteInvalidConsumers([]eventingv1alpha2.Subscription{*sub}))
+
+	// then the consumer of the wildcard subject is kept
+	_, err = jsBackend.GetJetStreamContext().ConsumerInfo(testEnvironment.natsConfig.JSStreamName, consumerName)
+	require.NoError(t, err)
+}
+
+// TestJetStream_Reply tests that the events replied by the sink of a subscription with reply enabled are published
+// back to the stream with their lineage, until the maximum hops are reached.
+func TestJetStream_Reply(t *testing.T) {
+	// given
+	testEnvironment := setupTestEnvironment(t)
+	jsBackend := testEnvironment.jsBackend
+	defer testEnvironment.natsServer.Shutdown()
+	defer testEnvironment.jsClient.natsConn.Close()
+	jsBackend.Config.JSReplyMaxHops = 2
+	require.NoError(t, jsBackend.Initialize(nil))
+
+	// the sink replies to each event with a new event of the same type, which would loop without the hops limit.
+	var replies atomic.Int32
+	received := make(chan http.Header, 10)
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Clone()
+		w.Header().Set("Ce-Specversion", "1.0")
+		w.Header().Set("Ce-Id", fmt.Sprintf("reply-%d", replies.Add(1)))
+		w.Header().Set("Ce-Source", eventingtesting.EventSource)
+		w.Header().Set("Ce-Type", eventingtesting.OrderCreatedEventType)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(eventingtesting.CloudEventData))
+	}))
+	defer sink.Close()
+
+	sub := eventingtesting.NewSubscription("sub", "foo",
+		eventingtesting.WithSourceAndType(eventingtesting.EventSource, eventingtesting.OrderCreatedEventType),
+		eventingtesting.WithSinkURL(sink.URL),
+		eventingtesting.WithTypeMatchingStandard(),
+		eventingtesting.WithMaxInFlight(DefaultMaxInFlights),
+		eventingtesting.WithConfigValue(eventingv1alpha2.ReplyEnabled, "true"),
+	)
+	AddJSCleanEventTypesToStatus(sub, testEnvironment.cleaner)
+	require.NoError(t, jsBackend.SyncSubscription(sub))
+
+	// when
+	subject := jsBackend.GetJetStreamSubject(eventingtesting.EventSource, eventingtesting.OrderCreatedEventType,
+		eventingv1alpha2.TypeMatchingStandard)
+	require.NoError(t, SendCloudEventToJetStream(jsBackend, subject, eventingtesting.CloudEventData,
+		types.ContentModeBinary))
+
+	// then the event and its replies up to the maximum hops are dispatched, each one with its lineage
+	for hops := 0; hops <= jsBackend.Config.JSReplyMaxHops; hops++ {
+		select {
+		case header := <-received:
+			if hops == 0 {
+				require.Empty(t, header.Get("Ce-Replyhops"))
+				continue
+			}
+			require.Equal(t, fmt.Sprintf("reply-%d", hops), header.Get("Ce-Id"))
+			require.Equal(t, strconv.Itoa(hops), header.Get("Ce-Replyhops"))
+			require.Equal(t, "foo/sub", header.Get("Ce-Replysubscription"))
+			require.NotEmpty(t, header.Get("Ce-Replyeventid"))
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "the event was not dispatched", "hops", hops)
+		}
+	}
+
+	// then the reply exceeding the maximum hops is dropped
+	select {
+	case header := <-received:
+		require.Fail(t, "the reply exceeding the maximum hops was dispatched", "id", header.Get("Ce-Id"))
+	case <-time.After(2 * time.Second):
+	}
+}
+
+// TestJetStream_ReplyDeadLettered tests that a reply which cannot be published is dead-lettered, and that the replied
+// event is acknowledged, so that it is not delivered to the sink again.
+func TestJetStream_ReplyDeadLettered(t *testing.T) {
+	// given
+	testEnvironment := setupTestEnvironment(t)
+	jsBackend := testEnvironment.jsBackend
+	defer testEnvironment.natsServer.Shutdown()
+	defer testEnvironment.jsClient.natsConn.Close()
+	jsBackend.Config.JSReplyMaxHops = 1
+	require.NoError(t, jsBackend.Initialize(nil))
+
+	// the stream rejects the reply, since it is larger than the maximum message size.
+	streamInfo, err := jsBackend.jsCtx.StreamInfo(testEnvironment.natsConfig.JSStreamName)
+	require.NoError(t, err)
+	streamConfig := streamInfo.Config
+	streamConfig.MaxMsgSize = 1024
+	_, err = jsBackend.jsCtx.UpdateStream(&streamConfig)
+	require.NoError(t, err)
+
+	received := make(chan string, 10)
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Get("Ce-Id")
+		w.Header().Set("Ce-Specversion", "1.0")
+		w.Header().Set("Ce-Id", "reply")
+		w.Header().Set("Ce-Source", eventingtesting.EventSource)
+		w.Header().Set("Ce-Type", eventingtesting.OrderCreatedEventType)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"padding":%q}`, strings.Repeat("x", 2048))))
+	}))
+	defer sink.Close()
+
+	sub := eventingtesting.NewSubscription("sub", "foo",
+		eventingtesting.WithSourceAndType(eventingtesting.EventSource, eventingtesting.OrderCreatedEventType),
+		eventingtesting.WithSinkURL(sink.URL),
+		eventingtesting.WithTypeMatchingStandard(),
+		eventingtesting.WithMaxInFlight(DefaultMaxInFlights),
+		eventingtesting.WithConfigValue(eventingv1alpha2.ReplyEnabled, "true"),
+	)
+	AddJSCleanEventTypesToStatus(sub, testEnvironment.cleaner)
+	require.NoError(t, jsBackend.SyncSubscription(sub))
+
+	// when
+	subject := jsBackend.GetJetStreamSubject(eventingtesting.EventSource, eventingtesting.OrderCreatedEventType,
+		eventingv1alpha2.TypeMatchingStandard)
+	require.NoError(t, SendCloudEventToJetStream(jsBackend, subject, eventingtesting.CloudEventData,
+		types.ContentModeBinary))
+
+	// then the event is dispatched once
+	select {
+	case <-received:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the event was not dispatched")
+	}
+
+	// then the reply is dead-lettered
+	deadLetterStreamName := testEnvironment.natsConfig.JSStreamName + deadLetterSuffix
+	require.Eventually(t, func() bool {
+		info, infoErr := jsBackend.jsCtx.StreamInfo(deadLetterStreamName)
+		return infoErr == nil && info.State.Msgs == 1
+	}, 5*time.Second, 100*time.Millisecond)
+
+	// then the replied event is acknowledged
+	consumerName := NewSubscriptionSubjectIdentifier(sub, subject).ConsumerName()
+	require.Eventually(t, func() bool {
+		info, infoErr := jsBackend.jsCtx.ConsumerInfo(testEnvironment.natsConfig.JSStreamName, consumerName)
+		return infoErr == nil && info.NumAckPending == 0 && info.AckFloor.Stream == 1
+	}, 5*time.Second, 100*time.Millisecond)
+	select {
+	case id := <-received:
+		require.Fail(t, "the event was dispatched again", "id", id)
+	default:
+	}
+}
+
+// TestJetStream_Transformation tests that the events are dispatched with the transformation of the subscription.
+func TestJetStream_Transformation(t *testing.T) {
+	// given
+	testEnvironment := setupTestEnvironment(t)
+	jsBackend := testEnvironment.jsBackend
+	defer testEnvironment.natsServer.Shutdown()
+	defer testEnvironment.jsClient.natsConn.Close()
+	require.NoError(t, jsBackend.Initialize(nil))
+
+	type delivery struct {
+		header http.Header
+		body   string
+	}
+	received := make(chan delivery, 10)
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- delivery{header: r.Header.Clone(), body: string(body)}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer sink.Close()
+
+	sub := eventingtesting.NewSubscription("sub", "foo",
+		eventingtesting.WithSourceAndType(eventingtesting.EventSource, eventingtesting.OrderCreatedEventType),
+		eventingtesting.WithSinkURL(sink.URL),
+		eventingtesting.WithTypeMatchingStandard(),
+		eventingtesting.WithMaxInFlight(DefaultMaxInFlights),
+		eventingtesting.WithTransformation(&eventingv1alpha2.Transformation{
+			Projection:    map[string]string{"value": ".foo"},
+			SetExtensions: map[string]string{"tenant": "acme"},
+			Type:          "order.transformed.v1",
+		}),
+	)
+	AddJSCleanEventTypesToStatus(sub, testEnvironment.cleaner)
+	require.NoError(t, jsBackend.SyncSubscription(sub))
+	subject := jsBackend.GetJetStreamSubject(eventingtesting.EventSource, eventingtesting.OrderCreatedEventType,
+		eventingv1alpha2.TypeMatchingStandard)
+
+	// when
+	require.NoError(t, SendCloudEventToJetStream(jsBackend, subject, eventingtesting.CloudEventData,
+		types.ContentModeBinary))
+
+	// then the event is dispatched transformed
+	select {
+	case got := <-received:
+		require.JSONEq(t, `{"value":"bar"}`, got.body)
+		require.Equal(t, "acme", got.header.Get("Ce-Tenant"))
+		require.Equal(t, "order.transformed.v1", got.header.Get("Ce-Type"))
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the event was not dispatched")
+	}
+}
+
+// TestJetStream_ContentMode tests that the events are dispatched in the content mode and event format of the
+// subscriptions.
+func TestJetStream_ContentMode(t *testing.T) {
+	// given
+	testEnvironment := setupTestEnvironment(t)
+	jsBackend := testEnvironment.jsBackend
+	defer testEnvironment.natsServer.Shutdown()
+	defer testEnvironment.jsClient.natsConn.Close()
+	require.NoError(t, jsBackend.Initialize(nil))
+
+	testCases := []struct {
+		name            string
+		givenConfig     map[string]string
+		wantContentType string
+		wantEvent       func(t *testing.T, header http.Header, body []byte)
+	}{
+		{
+			name:            "binary",
+			givenConfig:     map[string]string{eventingv1alpha2.ProtocolSettingsContentMode: types.ContentModeBinary},
+			wantContentType: "",
+			wantEvent: func(t *testing.T, header http.Header, body []byte) {
+				t.Helper()
+				require.Equal(t, eventingtesting.OrderCreatedEventType, header.Get("Ce-Type"))
+				require.JSONEq(t, eventingtesting.CloudEventData, string(body))
+			},
+		},
+		{
+			name:            "structured",
+			givenConfig:     map[string]string{eventingv1alpha2.ProtocolSettingsContentMode: types.ContentModeStructured},
+			wantContentType: "application/cloudevents+json",
+			wantEvent: func(t *testing.T, header http.Header, body []byte) {
+				t.Helper()
+				require.Empty(t, header.Get("Ce-Type"))
+				event := cloudevents.NewEvent()
+				require.NoError(t, format.JSON.Unmarshal(body, &event))
+				require.Equal(t, eventingtesting.OrderCreatedEventType, event.Type())
+				require.JSONEq(t, eventingtesting.CloudEventData, string(event.Data()))
+			},
+		},
+		{
+			name: "protobuf",
+			givenConfig: map[string]string{
+				eventingv1alpha2.ProtocolSettingsContentMode: types.ContentModeStructured,
+				eventingv1alpha2.ProtocolSettingsFormat:      types.EventFormatProtobuf,
+			},
+			wantContentType: "application/cloudevents+protobuf",
+			wantEvent: func(t *testing.T, _ http.Header, body []byte) {
+				t.Helper()
+				event := cloudevents.NewEvent()
+				require.NoError(t, pbformat.Protobuf.Unmarshal(body, &event))
+				require.Equal(t, eventingtesting.OrderCreatedEventType, event.Type())
+				require.JSONEq(t, eventingtesting.CloudEventData, string(event.Data()))
+			},
+		},
+		{
+			name:            "raw",
+			givenConfig:     map[string]string{eventingv1alpha2.ProtocolSettingsContentMode: types.ContentModeRaw},
+			wantContentType: "",
+			wantEvent: func(t *testing.T, header http.Header, body []byte) {
+				t.Helper()
+				for key := range header {
+					require.NotContains(t, strings.ToLower(key), "ce-")
+				}
+				require.JSONEq(t, eventingtesting.CloudEventData, string(body))
+			},
+		},
+	}
+
+	type delivery struct {
+		header http.Header
+		body   []byte
+	}
+	received := make(map[string]chan delivery, len(testCases))
+	for _, tc := range testCases {
+		deliveries := make(chan delivery, 1)
+		received[tc.name] = deliveries
+		sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			deliveries <- delivery{header: r.Header.Clone(), body: body}
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer sink.Close()
+
+		opts := []eventingtesting.SubscriptionOpt{
+			eventingtesting.WithSourceAndType(eventingtesting.EventSource, eventingtesting.OrderCreatedEventType),
+			eventingtesting.WithSinkURL(sink.URL),
+			eventingtesting.WithTypeMatchingStandard(),
+			eventingtesting.WithMaxInFlight(DefaultMaxInFlights),
+		}
+		for key, value := range tc.givenConfig {
+			opts = append(opts, eventingtesting.WithConfigValue(key, value))
+		}
+		sub := eventingtesting.NewSubscription(tc.name, "foo", opts...)
+		AddJSCleanEventTypesToStatus(sub, testEnvironment.cleaner)
+		require.NoError(t, jsBackend.SyncSubscription(sub))
+	}
+
+	// when
+	subject := jsBackend.GetJetStreamSubject(eventingtesting.EventSource, eventingtesting.OrderCreatedEventType,
+		eventingv1alpha2.TypeMatchingStandard)
+	require.NoError(t, SendCloudEventToJetStream(jsBackend, subject, eventingtesting.CloudEventData,
+		types.ContentModeBinary))
+
+	// then
+	for _, tc := range testCases {
+		select {
+		case got := <-received[tc.name]:
+			require.Equal(t, tc.wantContentType, got.header.Get("Content-Type"), tc.name)
+			tc.wantEvent(t, got.header, got.body)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "the event was not dispatched", tc.name)
+		}
+	}
+}
+
+// TestJetStream_SinkAuth tests that the events are dispatched with the credentials of the webhook auth Secret
+// of the subscription.
+func TestJetStream_SinkAuth(t *testing.T) {
+	// given
+	testEnvironment := setupTestEnvironment(t)
+	jsBackend := testEnvironment.jsBackend
+	defer testEnvironment.natsServer.Shutdown()
+	defer testEnvironment.jsClient.natsConn.Close()
+	require.NoError(t, jsBackend.Initialize(nil))
+
+	secret := &kcorev1.Secret{
+		ObjectMeta: kmetav1.ObjectMeta{Name: "sink-credentials", Namespace: "foo"},
+		Data:       map[string][]byte{sinkauth.SecretKeyToken: []byte("my-token")},
+	}
+	jsBackend.SetSecretReader(fake.NewClientBuilder().WithObjects(secret).Build())
+
+	receivedAuthorizations := make(chan string, 1)
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedAuthorizations <- r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer sink.Close()
+
+	sub := eventingtesting.NewSubscription("sub", "foo",
+		eventingtesting.WithSourceAndType(eventingtesting.EventSource, eventingtesting.OrderCreatedEventType),
+		eventingtesting.WithSinkURL(sink.URL),
+		eventingtesting.WithTypeMatchingStandard(),
+		eventingtesting.WithMaxInFlight(DefaultMaxInFlights),
+		eventingtesting.WithConfigValue(eventingv1alpha2.WebhookAuthType, "bearer"),
+		eventingtesting.WithConfigValue(eventingv1alpha2.WebhookAuthSecretName, "unknown"),
+	)
+	AddJSCleanEventTypesToStatus(sub, testEnvironment.cleaner)
+
+	// when the Secret does not exist
+	err := jsBackend.SyncSubscription(sub)
+
+	// then
+	require.ErrorIs(t, err, ErrSinkAuth)
+
+	// when
+	sub.Spec.Config[eventingv1alpha2.WebhookAuthSecretName] = secret.Name
+	require.NoError(t, jsBackend.SyncSubscription(sub))
+	subject := jsBackend.GetJetStreamSubject(eventingtesting.EventSource, eventingtesting.OrderCreatedEventType,
+		eventingv1alpha2.TypeMatchingStandard)
+	require.NoError(t, SendCloudEventToJetStream(jsBackend, subject, eventingtesting.CloudEventData,
+		types.ContentModeBinary))
+
+	// then
+	select {
+	case authorization := <-receivedAuthorizations:
+		require.Equal(t, "Bearer my-token", authorization)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the event was not dispatched")
+	}
+}
+
+// TestJetStream_ConsolidatedConsumers tests that the per-type consumers of a subscription are migrated to one
+// consolidated consumer without losing the pending events, and that its filter subjects follow the event types.
+func TestJetStream_ConsolidatedConsumers(t *testing.T) {
+	// given
+	testEnvironment := setupTestEnvironment(t)
+	defer testEnvironment.natsServer.Shutdown()
+	defer testEnvironment.jsClient.natsConn.Close()
+	perTypeBackend := testEnvironment.jsBackend
+	require.NoError(t, perTypeBackend.Initialize(nil))
+
+	receivedTypes := make(chan string, 2)
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedTypes <- r.Header.Get("Ce-Type")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer sink.Close()
+
+	createdType := eventingtesting.OrderCreatedEventType
+	updatedType := eventingtesting.EventTypePrefix + "." + eventingtesting.ApplicationName + ".order.updated.v1"
+	sub := eventingtesting.NewSubscription("sub", "foo",
+		eventingtesting.WithSource(eventingtesting.EventSource),
+		eventingtesting.WithTypes([]string{createdType, updatedType}),
+		eventingtesting.WithSinkURL(sink.URL),
+		eventingtesting.WithTypeMatchingStandard(),
+		eventingtesting.WithMaxInFlight(DefaultMaxInFlights),
+	)
+	AddJSCleanEventTypesToStatus(sub, testEnvironment.cleaner)
+	require.NoError(t, perTypeBackend.SyncSubscription(sub))
+	perTypeConsumers := perTypeBackend.getSubscriptionConsumers(sub, false)
+	require.Len(t, perTypeConsumers, 2)
+
+	// when events are published while the subscription is not dispatched
+	perTypeBackend.Shutdown()
+	for _, eventType := range []string{createdType, updatedType} {
+		subject := testEnvironment.jsBackend.GetJetStreamSubject(eventingtesting.EventSource, eventType,
+			eventingv1alpha2.TypeMatchingStandard)
+		_, err := testEnvironment.jsClient.Publish(subject, []byte(NewNatsMessagePayload(
+			"data", eventType, eventingtesting.EventSource, time.Now().Format(time.RFC3339), eventType)))
+		require.NoError(t, err)
+	}
+
+	// and the subscription is synced with consolidated consumers
+	natsConfig := testEnvironment.natsConfig
+	natsConfig.JSConsolidatedConsumers = true
+	consolidatedBackend := NewJetStream(natsConfig, metrics.NewCollector(), testEnvironment.cleaner,
+		env.DefaultSubscriptionConfig{MaxInFlightMessages: 9}, testEnvironment.logger)
+	require.NoError(t, consolidatedBackend.Initialize(nil))
+	defer consolidatedBackend.Shutdown()
+	require.NoError(t, consolidatedBackend.SyncSubscription(sub))
+
+	// then the pending events are dispatched by the consolidated consumer
+	var gotTypes []string
+	for range []string{createdType, updatedType} {
+		select {
+		case receivedType := <-receivedTypes:
+			gotTypes = append(gotTypes, receivedType)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "the pending events were not dispatched")
+		}
+	}
+	require.ElementsMatch(t, []string{createdType, updatedType}, gotTypes)
+
+	// and the per-type consumers are deleted
+	jsCtx := consolidatedBackend.GetJetStreamContext()
+	for _, consumer := range perTypeConsumers {
+		_, err := jsCtx.ConsumerInfo(natsConfig.JSStreamName, consumer.key.ConsumerName())
+		require.ErrorIs(t, err, nats.ErrConsumerNotFound)
+	}
+	consolidatedConsumer := consolidatedBackend.getSubscriptionConsumers(sub, true)[0]
+	consumerInfo, err := jsCtx.ConsumerInfo(natsConfig.JSStreamName, consolidatedConsumer.key.ConsumerName())
+	require.NoError(t, err)
+	require.ElementsMatch(t, consolidatedConsumer.subjects, consumerInfo.Config.FilterSubjects)
+
+	// when an event type is removed from the subscription
+	sub.Spec.Types = []string{createdType}
+	AddJSCleanEventTypesToStatus(sub, testEnvironment.cleaner)
+	require.NoError(t, consolidatedBackend.SyncSubscription(sub))
+
+	// then the filter subjects of the consolidated consumer are updated
+	consumerInfo, err = jsCtx.ConsumerInfo(natsConfig.JSStreamName, consolidatedConsumer.key.ConsumerName())
+	require.NoError(t, err)
+	require.Equal(t, []string{consolidatedConsumer.subjects[0]}, consumerInfo.Config.FilterSubjects)
+	require.Len(t, consolidatedBackend.GetNATSSubscriptions(), 1)
+}
+
+// TestJetStream_StreamMessages tests that the recent messages of a subject and the messages by sequence are read
+// from the stream.
+func TestJetStream_StreamMessages(t *testing.T) {
+	// given
+	testEnvironment := setupTestEnvironment(t)
+	jsBackend := testEnvironment.jsBackend
+	defer testEnvironment.natsServer.Shutdown()
+	defer testEnvironment.jsClient.natsConn.Close()
+	require.NoError(t, jsBackend.Initialize(nil))
+
+	// the failing sink keeps the messages in the stream
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer sink.Close()
+
+	otherType := "order.updated.v1"
+	sub := eventingtesting.NewSubscription("sub", "foo",
+		eventingtesting.WithSourceAndType(eventingtesting.EventSource, eventingtesting.OrderCreatedEventType),
+		eventingtesting.WithSourceAndType(eventingtesting.EventSource, otherType),
+		eventingtesting.WithSinkURL(sink.URL),
+		eventingtesting.WithTypeMatchingStandard(),
+		eventingtesting.WithMaxInFlight(DefaultMaxInFlights),
+	)
+	AddJSCleanEventTypesToStatus(sub, testEnvironment.cleaner)
+	require.NoError(t, jsBackend.SyncSubscription(sub))
+
+	subject := jsBackend.GetJetStreamSubject(eventingtesting.EventSource, eventingtesting.OrderCreatedEventType,
+		eventingv1alpha2.TypeMatchingStandard)
+	otherSubject := jsBackend.GetJetStreamSubject(eventingtesting.EventSource, otherType,
+		eventingv1alpha2.TypeMatchingStandard)
+	for _, subj := range []string{subject, otherSubject, subject, subject} {
+		require.NoError(t, SendCloudEventToJetStream(jsBackend, subj, eventingtesting.CloudEventData,
+			types.ContentModeBinary))
+	}
+
+	// when
+	messages, err := jsBackend.GetSubjectMessages(subject, 2)
+
+	// then the most recent messages of the subject are returned
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	require.Equal(t, uint64(4), messages[0].Sequence)
+	require.Equal(t, uint64(3), messages[1].Sequence)
+	for _, msg := range messages {
+		require.Equal(t, subject, msg.Subject)
+	}
+
+	// when
+	messages, err = jsBackend.GetSubjectMessages(subject+".unknown", 2)
+
+	// then no messages are returned for a subject without messages
+	require.NoError(t, err)
+	require.Empty(t, messages)
+
+	// when
+	msg, err := jsBackend.GetStreamMessage(2)
+
+	// then the message is returned by its sequence
+	require.NoError(t, err)
+	require.Equal(t, otherSubject, msg.Subject)
+
+	// when
+	_, err = jsBackend.GetStreamMessage(10)
+
+	// then
+	require.ErrorIs(t, err, nats.ErrMsgNotFound)
+}
+
+// TestJetStream_ConsumerLag tests that the backlog of the consumers and the usage of the stream are read.
+func TestJetStream_DeliveryHealth(t *testing.T) {
+	// given
+	testEnvironment := setupTestEnvironment(t)
+	jsBackend := testEnvironment.jsBackend
+	defer testEnvironment.natsServer.Shutdown()
+	defer testEnvironment.jsClient.natsConn.Close()
+	tracker := NewDeliveryHealthTracker()
+	reports := make(chan *DeliveryFailure, 10)
+	tracker.SetHandler(func(_ ktypes.NamespacedName, failure *DeliveryFailure, _ bool) {
+		reports <- failure
+	})
+	jsBackend.SetDeliveryHealthTracker(tracker)
+	require.NoError(t, jsBackend.Initialize(nil))
+
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer sink.Close()
+
+	sub := eventingtesting.NewSubscription("sub", "foo",
+		eventingtesting.WithSourceAndType(eventingtesting.EventSource, eventingtesting.OrderCreatedEventType),
+		eventingtesting.WithSinkURL(sink.URL),
+		eventingtesting.WithTypeMatchingStandard(),
+		eventingtesting.WithMaxInFlight(DefaultMaxInFlights),
+	)
+	AddJSCleanEventTypesToStatus(sub, testEnvironment.cleaner)
+	require.NoError(t, jsBackend.SyncSubscription(sub))
+	subject := jsBackend.GetJetStreamSubject(eventingtesting.EventSource, eventingtesting.OrderCreatedEventType,
+		eventingv1alpha2.TypeMatchingStandard)
+
+	// when
+	for i := 0; i < deliveryHealthMinDeliveries; i++ {
+		require.NoError(t, SendCloudEventToJetStream(jsBackend, subject, eventingtesting.CloudEventData,
+			types.ContentModeBinary))
+	}
+
+	// then the failed delivery is reported with its status code
+	var failure *DeliveryFailure
+	select {
+	case failure = <-reports:
+	case <-time.After(10 * time.Second):
+		t.Fatal("the failed delivery was not reported")
+	}
+	require.NotNil(t, failure)
+	require.Equal(t, http.StatusServiceUnavailable, failure.StatusCode)
+
+	// then the deliveries become unhealthy
+	require.Eventually(t, func() bool {
+		health, found := tracker.Get(ktypes.NamespacedName{Name: sub.Name, Namespace: sub.Namespace})
+		return found && !health.Healthy()
+	}, 10*time.Second, 100*time.Millisecond)
+
+	// when
+	require.NoError(t, jsBackend.DeleteSubscription(sub))
+
+	// then
+	_, found := tracker.Get(ktypes.NamespacedName{Name: sub.Name, Namespace: sub.Namespace})
+	require.False(t, found)
+}
+
+func TestJetStream_DeadLetterStream(t *testing.T) {
+	// given
+	testEnvironment := setupTestEnvironment(t)
+	jsBackend := testEnvironment.jsBackend
+	defer testEnvironment.natsServer.Shutdown()
+	defer testEnvironment.jsClient.natsConn.Close()
+
+	// when
+	require.NoError(t, jsBackend.Initialize(nil))
+
+	// then the dead-letter stream is created next to the event stream
+	deadLetterStreamName := testEnvironment.natsConfig.JSStreamName + deadLetterSuffix
+	info, err := jsBackend.jsCtx.StreamInfo(deadLetterStreamName)
+	require.NoError(t, err)
+	require.Equal(t, []string{testEnvironment.natsConfig.JSSubjectPrefix + deadLetterSuffix + ".>"}, info.Config.Subjects)
+
+	// when
+	subject := jsBackend.GetJetStreamSubject(eventingtesting.EventSource, eventingtesting.OrderCreatedEventType,
+		eventingv1alpha2.TypeMatchingStandard)
+	_, err = jsBackend.jsCtx.Publish(jsBackend.getDeadLetterSubject(subject), []byte(eventingtesting.CloudEventData))
+	require.NoError(t, err)
+
+	// then the dead-lettered event is stored in the dead-letter stream only
+	info, err = jsBackend.jsCtx.StreamInfo(deadLetterStreamName)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), info.State.Msgs)
+	info, err = jsBackend.jsCtx.StreamInfo(testEnvironment.natsConfig.JSStreamName)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), info.State.Msgs)
+}
+
+func TestJetStream_ConsumerLag(t *testing.T) {
+	// given
+	testEnvironment := setupTestEnvironment(t)
+	jsBackend := testEnvironment.jsBackend
+	defer testEnvironment.natsServer.Shutdown()
+	defer testEnvironment.jsClient.natsConn.Close()
+	require.NoError(t, jsBackend.Initialize(nil))
+
+	// the failing sink keeps the messages unacknowledged
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer sink.Close()
+
+	otherType := "order.updated.v1"
+	sub := eventingtesting.NewSubscription("sub", "foo",
+		eventingtesting.WithSourceAndType(eventingtesting.EventSource, eventingtesting.OrderCreatedEventType),
+		eventingtesting.WithSourceAndType(eventingtesting.EventSource, otherType),
+		eventingtesting.WithSinkURL(sink.URL),
+		eventingtesting.WithTypeMatchingStandard(),
+		eventingtesting.WithMaxInFlight(DefaultMaxInFlights),
+	)
+	AddJSCleanEventTypesToStatus(sub, testEnvironment.cleaner)
+	require.NoError(t, jsBackend.SyncSubscription(sub))
+
+	subject := jsBackend.GetJetStreamSubject(eventingtesting.EventSource, eventingtesting.OrderCreatedEventType,
+		eventingv1alpha2.TypeMatchingStandard)
+	otherSubject := jsBackend.GetJetStreamSubject(eventingtesting.EventSource, otherType,
+		eventingv1alpha2.TypeMatchingStandard)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, SendCloudEventToJetStream(jsBackend, subject, eventingtesting.CloudEventData,
+			types.ContentModeBinary))
+	}
+	consumerName := NewSubscriptionSubjectIdentifier(sub, subject).ConsumerName()
+	otherConsumerName := NewSubscriptionSubjectIdentifier(sub, otherSubject).ConsumerName()
+
+	// when
+	var lag ConsumerLag
+	require.Eventually(t, func() bool {
+		var err error
+		lag, err = jsBackend.GetConsumerLag(consumerName)
+		return err == nil && lag.AckPending == 3
+	}, 10*time.Second, 100*time.Millisecond)
+	otherLag, err := jsBackend.GetConsumerLag(otherConsumerName)
+	require.NoError(t, err)
+	usage, err := jsBackend.GetStreamUsage()
+	require.NoError(t, err)
+
+	// then the consumer of the failing events has a backlog
+	require.Equal(t, uint64(0), lag.Pending)
+	require.Positive(t, lag.OldestUnackedAge)
+	// then the consumer without events has no backlog
+	require.Equal(t, ConsumerLag{}, otherLag)
+	// then the usage of the stream is read
+	require.Equal(t, testEnvironment.natsConfig.JSStreamName, usage.Name)
+	require.Equal(t, uint64(3), usage.Messages)
+	require.Positive(t, usage.Bytes)
+
+	// when
+	_, err = jsBackend.GetConsumerLag("unknown")
+
+	// then
+	require.ErrorIs(t, err, nats.ErrConsumerNotFound)
+}
+
 // TestJSSubscriptionRedeliverWithFailedDispatch tests the redelivering
 // of event when the dispatch fails.
 func TestJSSubscriptionRedeliverWithFailedDispatch(t *testing.T) {
@@ -1,7 +1,11 @@
 package jetstream
 
 import (
+	"errors"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	ceevent "github.com/cloudevents/sdk-go/v2/event"
 	kymalogger "github.com/kyma-project/kyma/common/logging/logger"
@@ -11,9 +15,11 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/kyma-project/eventing-manager/api/eventing/v1alpha2"
+	registryv1alpha1 "github.com/kyma-project/eventing-manager/api/registry/v1alpha1"
 	"github.com/kyma-project/eventing-manager/pkg/backend/cleaner"
 	backendjetstreammocks "github.com/kyma-project/eventing-manager/pkg/backend/jetstream/mocks"
 	"github.com/kyma-project/eventing-manager/pkg/backend/metrics"
+	"github.com/kyma-project/eventing-manager/pkg/backend/schema"
 	"github.com/kyma-project/eventing-manager/pkg/env"
 	"github.com/kyma-project/eventing-manager/pkg/logger"
 	eventingtesting "github.com/kyma-project/eventing-manager/testing"
@@ -103,6 +109,64 @@ func Test_streamIsConfiguredCorrectly(t *testing.T) {
 			},
 			wantResult: false,
 		},
+		{
+			name:            "Different direct get config should return false",
+			ecDefinedConfig: *streamConfig,
+			natsConfig: nats.StreamConfig{
+				Name:        streamConfig.Name,
+				Storage:     streamConfig.Storage,
+				Replicas:    streamConfig.Replicas,
+				Retention:   streamConfig.Retention,
+				MaxMsgs:     streamConfig.MaxMsgs,
+				MaxBytes:    streamConfig.MaxBytes,
+				Discard:     streamConfig.Discard,
+				Subjects:    streamConfig.Subjects,
+				AllowDirect: true,
+			},
+			wantResult: false,
+		},
+		{
+			name: "Different duplicates window should return false",
+			ecDefinedConfig: nats.StreamConfig{
+				Name:       streamConfig.Name,
+				Storage:    streamConfig.Storage,
+				Replicas:   streamConfig.Replicas,
+				Retention:  streamConfig.Retention,
+				MaxMsgs:    streamConfig.MaxMsgs,
+				MaxBytes:   streamConfig.MaxBytes,
+				Discard:    streamConfig.Discard,
+				Subjects:   streamConfig.Subjects,
+				Duplicates: 5 * time.Minute,
+			},
+			natsConfig: nats.StreamConfig{
+				Name:       streamConfig.Name,
+				Storage:    streamConfig.Storage,
+				Replicas:   streamConfig.Replicas,
+				Retention:  streamConfig.Retention,
+				MaxMsgs:    streamConfig.MaxMsgs,
+				MaxBytes:   streamConfig.MaxBytes,
+				Discard:    streamConfig.Discard,
+				Subjects:   streamConfig.Subjects,
+				Duplicates: 2 * time.Minute,
+			},
+			wantResult: false,
+		},
+		{
+			name:            "Server default duplicates window should return true if none is defined",
+			ecDefinedConfig: *streamConfig,
+			natsConfig: nats.StreamConfig{
+				Name:       streamConfig.Name,
+				Storage:    streamConfig.Storage,
+				Replicas:   streamConfig.Replicas,
+				Retention:  streamConfig.Retention,
+				MaxMsgs:    streamConfig.MaxMsgs,
+				MaxBytes:   streamConfig.MaxBytes,
+				Discard:    streamConfig.Discard,
+				Subjects:   streamConfig.Subjects,
+				Duplicates: 2 * time.Minute,
+			},
+			wantResult: true,
+		},
 	}
 	for _, testCase := range testCases {
 		tc := testCase
@@ -161,10 +225,10 @@ func Test_GetOrCreateConsumer(t *testing.T) {
 				cleaner:       &cleaner.JetStreamCleaner{},
 			}
 			sub := NewSubscriptionWithOneType()
-			eventType := sub.Status.Types[0]
+			consumer := js.getSubscriptionConsumers(sub, false)[0]
 
 			// when
-			consumerInfo, err := js.getOrCreateConsumer(sub, eventType)
+			consumerInfo, err := js.getOrCreateConsumer(sub, consumer)
 
 			// then
 			assert.Equal(t, tc.wantConsumerInfo, consumerInfo)
@@ -257,7 +321,7 @@ func Test_SyncConsumersAndSubscriptions_ForBindInvalidSubscriptions(t *testing.T
 }
 
 // Test_SyncConsumersAndSubscriptions_ForSyncConsumerMaxInFlight tests
-// the behaviour of the syncConsumerMaxInFlight function.
+// the MaxAckPending behaviour of the syncConsumerConfig function.
 func Test_SyncConsumersAndSubscriptions_ForSyncConsumerMaxInFlight(t *testing.T) {
 	testCases := []struct {
 		name                       string
@@ -316,7 +380,7 @@ func Test_SyncConsumersAndSubscriptions_ForSyncConsumerMaxInFlight(t *testing.T)
 			tc.givenjetstreammocks(js, jsCtxMock, tc.wantConfigToUpdate)
 
 			// when
-			err := js.syncConsumerMaxInFlight(sub, consumer)
+			err := js.syncConsumerConfig(sub, subscriptionConsumer{}, consumer)
 
 			// then
 			require.NoError(t, err)
@@ -778,6 +842,296 @@ func Test_revertEventTypeToOriginal(t *testing.T) {
 	}
 }
 
+func Test_handleSchemaViolation(t *testing.T) {
+	// pre-requisites
+	defaultLogger, err := logger.New(string(kymalogger.JSON), string(kymalogger.INFO))
+	require.NoError(t, err)
+
+	const (
+		givenSubject       = "kyma.noapp.order.created.v1"
+		wantDeadLetterSubj = "kyma-deadletter.noapp.order.created.v1"
+	)
+	givenData := []byte(`{"orderId":1}`)
+
+	// test cases
+	testCases := []struct {
+		name          string
+		givenPolicy   registryv1alpha1.ValidationPolicy
+		givenPublish  error
+		wantDispatch  bool
+		wantPublish   bool
+		wantExtension bool
+	}{
+		{
+			name:         "Should not dispatch the event with the Drop policy",
+			givenPolicy:  registryv1alpha1.ValidationPolicyDrop,
+			wantDispatch: false,
+		},
+		{
+			name:         "Should publish the event to the dead-letter subject with the DeadLetter policy",
+			givenPolicy:  registryv1alpha1.ValidationPolicyDeadLetter,
+			wantDispatch: false,
+			wantPublish:  true,
+		},
+		{
+			name:         "Should not dispatch the event if publishing to the dead-letter subject fails",
+			givenPolicy:  registryv1alpha1.ValidationPolicyDeadLetter,
+			givenPublish: nats.ErrTimeout,
+			wantDispatch: false,
+			wantPublish:  true,
+		},
+		{
+			name:          "Should dispatch the event with a warning extension with the DeliverWithWarning policy",
+			givenPolicy:   registryv1alpha1.ValidationPolicyDeliverWithWarning,
+			wantDispatch:  true,
+			wantExtension: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			jsCtx := &backendjetstreammocks.JetStreamContext{}
+			if tc.wantPublish {
+				jsCtx.On("Publish", wantDeadLetterSubj, givenData).Return(&nats.PubAck{}, tc.givenPublish)
+			}
+			jsBackend := &JetStream{
+				Config: env.NATSConfig{
+					JSSubjectPrefix: "kyma",
+				},
+				jsCtx:  jsCtx,
+				logger: defaultLogger,
+			}
+			msg := &nats.Msg{Subject: givenSubject, Data: givenData}
+			ce := ceevent.New(ceevent.CloudEventsVersionV1)
+			violation := &schema.Violation{Policy: tc.givenPolicy, Err: errors.New("orderId must be of type string")}
+
+			// when
+			dispatch := jsBackend.handleSchemaViolation(msg, &ce, violation, jsBackend.namedLogger())
+
+			// then
+			require.Equal(t, tc.wantDispatch, dispatch)
+			_, hasExtension := ce.Extensions()[schemaViolationExtensionName]
+			require.Equal(t, tc.wantExtension, hasExtension)
+			jsCtx.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_transformEvent_ConsecutiveAndConcurrentEvents(t *testing.T) {
+	t.Parallel()
+
+	// given a projection with a range, which keeps the state of its evaluation in the parsed expression
+	jsBackend := &JetStream{}
+	sub := eventingtesting.NewSubscription("sub", "test",
+		eventingtesting.WithTransformation(&v1alpha2.Transformation{
+			Projection: map[string]string{"ids": "{range .items[*]}{.id}{end}"},
+		}))
+	require.NoError(t, jsBackend.storeTransformer(sub))
+
+	const events = 20
+	transform := func(i int) error {
+		ce := ceevent.New(ceevent.CloudEventsVersionV1)
+		ce.SetID(fmt.Sprintf("id-%d", i))
+		ce.SetType("order.created.v1")
+		ce.SetSource("noapp")
+		data := fmt.Sprintf(`{"items":[{"id":"%d-a"},{"id":"%d-b"}]}`, i, i)
+		if err := ce.SetData(ceevent.ApplicationJSON, []byte(data)); err != nil {
+			return err
+		}
+		transformed, err := jsBackend.transformEvent(createKeyPrefix(sub), &ce)
+		if err != nil {
+			return err
+		}
+		want := fmt.Sprintf(`{"ids":["%d-a","%d-b"]}`, i, i)
+		if string(transformed.Data()) != want {
+			return fmt.Errorf("event %d: want data %s, got %s", i, want, transformed.Data())
+		}
+		return nil
+	}
+
+	// when the events are transformed one after another
+	for i := 0; i < events; i++ {
+		require.NoError(t, transform(i))
+	}
+
+	// when the events are transformed concurrently
+	errs := make(chan error, events)
+	var wg sync.WaitGroup
+	for i := 0; i < events; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- transform(i)
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	// then every event is projected on its own data
+	for err := range errs {
+		require.NoError(t, err)
+	}
+}
+
+func Test_handleTransformationFailure(t *testing.T) {
+	// pre-requisites
+	defaultLogger, err := logger.New(string(kymalogger.JSON), string(kymalogger.INFO))
+	require.NoError(t, err)
+
+	const (
+		givenSubject       = "kyma.noapp.order.created.v1"
+		wantDeadLetterSubj = "kyma-deadletter.noapp.order.created.v1"
+	)
+	givenData := []byte(`{"orderId":1}`)
+
+	// test cases
+	testCases := []struct {
+		name         string
+		givenPublish error
+	}{
+		{
+			name: "Should publish the event to the dead-letter subject",
+		},
+		{
+			name:         "Should handle the failure to publish the event to the dead-letter subject",
+			givenPublish: nats.ErrTimeout,
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			jsCtx := &backendjetstreammocks.JetStreamContext{}
+			jsCtx.On("Publish", wantDeadLetterSubj, givenData).Return(&nats.PubAck{}, tc.givenPublish)
+			jsBackend := &JetStream{
+				Config: env.NATSConfig{
+					JSSubjectPrefix: "kyma",
+				},
+				jsCtx:  jsCtx,
+				logger: defaultLogger,
+			}
+			msg := &nats.Msg{Subject: givenSubject, Data: givenData}
+
+			// when
+			jsBackend.handleTransformationFailure(msg, ErrTransformEvent, jsBackend.namedLogger())
+
+			// then
+			jsCtx.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_transformEvent(t *testing.T) {
+	t.Parallel()
+
+	const givenData = `{"order":{"id":1,"items":[{"sku":"a"},{"sku":"b"}]}}`
+
+	testCases := []struct {
+		name                string
+		givenTransformation *v1alpha2.Transformation
+		givenData           string
+		wantData            string
+		wantExtensions      map[string]interface{}
+		wantType            string
+		wantSource          string
+		wantErr             error
+	}{
+		{
+			name:       "Should dispatch the event as is without a transformation",
+			givenData:  givenData,
+			wantData:   givenData,
+			wantType:   "order.created.v1",
+			wantSource: "noapp",
+			wantExtensions: map[string]interface{}{
+				"tenant": "foo",
+			},
+		},
+		{
+			name: "Should project the data and omit the missing fields",
+			givenTransformation: &v1alpha2.Transformation{
+				Projection: map[string]string{
+					"id":      ".order.id",
+					"skus":    "{.order.items[*].sku}",
+					"missing": ".order.missing",
+				},
+			},
+			givenData:  givenData,
+			wantData:   `{"id":1,"skus":["a","b"]}`,
+			wantType:   "order.created.v1",
+			wantSource: "noapp",
+			wantExtensions: map[string]interface{}{
+				"tenant": "foo",
+			},
+		},
+		{
+			name: "Should set and remove the extensions and remap the type and source",
+			givenTransformation: &v1alpha2.Transformation{
+				SetExtensions:    map[string]string{"region": "eu"},
+				RemoveExtensions: []string{"tenant"},
+				Type:             "order.received.v1",
+				Source:           "shop",
+			},
+			givenData:  givenData,
+			wantData:   givenData,
+			wantType:   "order.received.v1",
+			wantSource: "shop",
+			wantExtensions: map[string]interface{}{
+				"region": "eu",
+			},
+		},
+		{
+			name: "Should fail to project the data which is not JSON",
+			givenTransformation: &v1alpha2.Transformation{
+				Projection: map[string]string{"id": ".order.id"},
+			},
+			givenData: "not-json",
+			wantErr:   ErrTransformEvent,
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			jsBackend := &JetStream{}
+			sub := eventingtesting.NewSubscription("sub", "test",
+				eventingtesting.WithTransformation(tc.givenTransformation))
+			require.NoError(t, jsBackend.storeTransformer(sub))
+
+			ce := ceevent.New(ceevent.CloudEventsVersionV1)
+			ce.SetID("id")
+			ce.SetType("order.created.v1")
+			ce.SetSource("noapp")
+			ce.SetExtension("tenant", "foo")
+			require.NoError(t, ce.SetData(ceevent.ApplicationJSON, []byte(tc.givenData)))
+
+			// when
+			transformed, err := jsBackend.transformEvent(createKeyPrefix(sub), &ce)
+
+			// then
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.JSONEq(t, tc.wantData, string(transformed.Data()))
+			require.Equal(t, tc.wantType, transformed.Type())
+			require.Equal(t, tc.wantSource, transformed.Source())
+			require.Equal(t, tc.wantExtensions, transformed.Extensions())
+
+			// the original event is not changed
+			require.JSONEq(t, givenData, string(ce.Data()))
+			require.Equal(t, "order.created.v1", ce.Type())
+			require.Equal(t, map[string]interface{}{"tenant": "foo"}, ce.Extensions())
+		})
+	}
+}
+
 // HELPER FUNCTIONS
 
 func NewSubscriptionWithEmptyTypes() *v1alpha2.Subscription {
@@ -0,0 +1,103 @@
+package jetstream
+
+import (
+	"errors"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// ConsumerLag is the backlog of a consumer of the stream.
+type ConsumerLag struct {
+	// Pending is the number of messages in the stream not delivered to the consumer yet.
+	Pending uint64
+	// AckPending is the number of messages delivered to the consumer, but not acknowledged yet.
+	AckPending int
+	// Redelivered is the number of messages being redelivered to the consumer.
+	Redelivered int
+	// OldestUnackedAge is the age of the oldest message not acknowledged by the consumer, it is zero if the consumer
+	// has no backlog.
+	OldestUnackedAge time.Duration
+}
+
+// StreamUsage is the usage of the stream and its limits, the limits are not positive if the stream is unlimited.
+type StreamUsage struct {
+	Name        string
+	Messages    uint64
+	Bytes       uint64
+	MaxMessages int64
+	MaxBytes    int64
+}
+
+// GetConsumerLag returns the backlog of the consumer of the stream.
+func (js *JetStream) GetConsumerLag(consumerName string) (ConsumerLag, error) {
+	info, err := js.GetConsumerInfo(consumerName)
+	if err != nil {
+		return ConsumerLag{}, err
+	}
+
+	lag := ConsumerLag{
+		Pending:     info.NumPending,
+		AckPending:  info.NumAckPending,
+		Redelivered: info.NumRedelivered,
+	}
+	if lag.Pending == 0 && lag.AckPending == 0 {
+		return lag, nil
+	}
+	oldest, err := js.getOldestUnackedMessage(info)
+	if err != nil {
+		return ConsumerLag{}, err
+	}
+	if oldest != nil {
+		lag.OldestUnackedAge = time.Since(oldest.Time)
+	}
+	return lag, nil
+}
+
+// GetStreamUsage returns the usage of the stream.
+func (js *JetStream) GetStreamUsage() (StreamUsage, error) {
+	if js.Conn == nil || js.Conn.Status() != nats.CONNECTED {
+		return StreamUsage{}, ErrConnect
+	}
+	info, err := js.jsCtx.StreamInfo(js.Config.JSStreamName)
+	if err != nil {
+		return StreamUsage{}, err
+	}
+	return StreamUsage{
+		Name:        info.Config.Name,
+		Messages:    info.State.Msgs,
+		Bytes:       info.State.Bytes,
+		MaxMessages: info.Config.MaxMsgs,
+		MaxBytes:    info.Config.MaxBytes,
+	}, nil
+}
+
+// getOldestUnackedMessage returns the oldest message not acknowledged by the consumer. All the messages of the
+// consumer up to the ack floor are acknowledged, so it is the first message of the consumer after the ack floor.
+// It is read with a single direct get per filter subject of the consumer. It returns nil if there is no such message.
+func (js *JetStream) getOldestUnackedMessage(info *nats.ConsumerInfo) (*nats.RawStreamMsg, error) {
+	filters := info.Config.FilterSubjects
+	if info.Config.FilterSubject != "" {
+		filters = append(filters, info.Config.FilterSubject)
+	}
+	if len(filters) == 0 {
+		filters = []string{">"}
+	}
+
+	var oldest *nats.RawStreamMsg
+	for _, filter := range filters {
+		msg, err := js.jsCtx.GetMsg(js.Config.JSStreamName, info.AckFloor.Stream+1,
+			nats.DirectGet(), nats.DirectGetNext(filter))
+		if errors.Is(err, nats.ErrMsgNotFound) {
+			// the consumer has no message of the filter subject after the ack floor.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if oldest == nil || msg.Sequence < oldest.Sequence {
+			oldest = msg
+		}
+	}
+	return oldest, nil
+}
@@ -0,0 +1,115 @@
+package jetstream
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	cetypes "github.com/cloudevents/sdk-go/v2/types"
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+
+	eventingv1alpha2 "github.com/kyma-project/eventing-manager/api/eventing/v1alpha2"
+)
+
+const (
+	// replyEventIDExtensionName is the CloudEvent extension set on the reply events to the ID of the replied event.
+	replyEventIDExtensionName = "replyeventid"
+	// replySubscriptionExtensionName is the CloudEvent extension set on the reply events to the subscription
+	// whose sink replied, in the form namespace/name.
+	replySubscriptionExtensionName = "replysubscription"
+	// replyHopsExtensionName is the CloudEvent extension set on the reply events to the number of replies
+	// which led to the event.
+	replyHopsExtensionName = "replyhops"
+
+	// replyPublishAttempts is the number of attempts to publish a reply event, before it is dead-lettered.
+	replyPublishAttempts = 3
+	// replyPublishRetryDelay is the delay between the attempts to publish a reply event.
+	replyPublishRetryDelay = 500 * time.Millisecond
+
+	// results of the handled reply events, used as metric labels.
+	replyResultPublished    = "published"
+	replyResultDropped      = "dropped"
+	replyResultDeadLettered = "deadlettered"
+	replyResultFailed       = "failed"
+)
+
+// storeReplyEnabled stores if the replies of the sink of the subscription are published back to the stream.
+func (js *JetStream) storeReplyEnabled(subscription *eventingv1alpha2.Subscription) {
+	subKeyPrefix := createKeyPrefix(subscription)
+	if !subscription.IsReplyEnabled() {
+		js.replySubscriptions.Delete(subKeyPrefix)
+		return
+	}
+	js.replySubscriptions.Store(subKeyPrefix, true)
+}
+
+// isReplyEnabled returns true if the replies of the sink of the subscription are published back to the stream.
+func (js *JetStream) isReplyEnabled(subKeyPrefix string) bool {
+	_, ok := js.replySubscriptions.Load(subKeyPrefix)
+	return ok
+}
+
+// getReplyHops returns the number of replies which led to the event, which is 0 for an event which is not a reply.
+func getReplyHops(event *cloudevents.Event) int {
+	value, ok := event.Extensions()[replyHopsExtensionName]
+	if !ok {
+		return 0
+	}
+	hops, err := cetypes.ToInteger(value)
+	if err != nil {
+		return 0
+	}
+	return int(hops)
+}
+
+// publishReply publishes the event replied by the sink to the stream, with the lineage of the replied event.
+// The reply is dropped if it exceeds the maximum number of hops, and it is dead-lettered if it cannot be published
+// after replyPublishAttempts attempts, since the replied event is not redelivered to the sink. It returns the result
+// of handling the reply, and an error if the reply could neither be published nor dead-lettered.
+func (js *JetStream) publishReply(event, reply *cloudevents.Event, subscriptionName, subscriptionNamespace string,
+	ceLogger *zap.SugaredLogger,
+) (string, error) {
+	hops := getReplyHops(event) + 1
+	if hops > js.Config.JSReplyMaxHops {
+		ceLogger.Warnw("Dropping the reply event exceeding the maximum hops", "replyID", reply.ID(),
+			"replyType", reply.Type(), "hops", hops, "maxHops", js.Config.JSReplyMaxHops)
+		return replyResultDropped, nil
+	}
+
+	reply.SetExtension(replyEventIDExtensionName, event.ID())
+	reply.SetExtension(replySubscriptionExtensionName, fmt.Sprintf("%s/%s", subscriptionNamespace, subscriptionName))
+	reply.SetExtension(replyHopsExtensionName, hops)
+	reply.SetExtension(originalTypeHeaderName, reply.Type())
+
+	cleanType, err := js.cleaner.CleanEventType(js.cleaner.RewriteEventType(reply.Type()))
+	if err != nil {
+		return replyResultFailed, err
+	}
+	data, err := json.Marshal(reply)
+	if err != nil {
+		return replyResultFailed, err
+	}
+	subject := js.GetJetStreamSubject(reply.Source(), cleanType, eventingv1alpha2.TypeMatchingStandard)
+	// the reply ID deduplicates the reply within the duplicates window if a publish attempt succeeded unnoticed.
+	var publishErr error
+	for attempt := 1; attempt <= replyPublishAttempts; attempt++ {
+		if _, publishErr = js.jsCtx.Publish(subject, data, nats.MsgId(reply.ID())); publishErr == nil {
+			ceLogger.Debugw("Published the reply event", "replyID", reply.ID(), "subject", subject, "hops", hops)
+			return replyResultPublished, nil
+		}
+		if attempt < replyPublishAttempts {
+			time.Sleep(replyPublishRetryDelay)
+		}
+	}
+
+	deadLetterSubject := js.getDeadLetterSubject(subject)
+	if _, err := js.jsCtx.Publish(deadLetterSubject, data, nats.MsgId(reply.ID())); err != nil {
+		return replyResultFailed, fmt.Errorf("failed to publish the reply event: %w, "+
+			"and to dead-letter it: %w", publishErr, err)
+	}
+	ceLogger.Warnw("Dead-lettered the reply event which could not be published", "replyID", reply.ID(),
+		"subject", deadLetterSubject, "error", publishErr)
+	return replyResultDeadLettered, nil
+}
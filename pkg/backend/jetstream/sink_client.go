@@ -0,0 +1,79 @@
+package jetstream
+
+import (
+	"context"
+	"net/http"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	eventingv1alpha2 "github.com/kyma-project/eventing-manager/api/eventing/v1alpha2"
+	"github.com/kyma-project/eventing-manager/pkg/backend/sinkauth"
+	"github.com/kyma-project/eventing-manager/pkg/errors"
+)
+
+// sinkClient is the CloudEvents client dispatching the events to a sink which requires authentication.
+type sinkClient struct {
+	// version identifies the credentials the client was created with.
+	version string
+	client  cloudevents.Client
+	// httpClient sends the events of the raw content mode.
+	httpClient *http.Client
+}
+
+// SetSecretReader sets the reader of the Secrets holding the credentials of the sinks.
+func (js *JetStream) SetSecretReader(reader client.Reader) {
+	js.secretReader = reader
+}
+
+// syncSinkClient creates the client dispatching the events to the sink of the subscription with its webhook auth,
+// or recreates it if the credentials changed. The subscriptions without webhook auth use the default client.
+func (js *JetStream) syncSinkClient(subscription *eventingv1alpha2.Subscription) error {
+	subKeyPrefix := createKeyPrefix(subscription)
+	credentials, err := sinkauth.GetCredentials(context.Background(), js.secretReader, subscription)
+	if err != nil {
+		return errors.MakeError(ErrSinkAuth, err)
+	}
+	if credentials == nil {
+		js.sinkClients.Delete(subKeyPrefix)
+		return nil
+	}
+
+	version := credentials.Version()
+	if value, ok := js.sinkClients.Load(subKeyPrefix); ok && value.(*sinkClient).version == version {
+		return nil
+	}
+	transport, err := credentials.Transport(js.transport)
+	if err != nil {
+		return errors.MakeError(ErrSinkAuth, err)
+	}
+	ceClient, err := cloudevents.NewClientHTTP(cloudevents.WithRoundTripper(transport))
+	if err != nil {
+		return errors.MakeError(ErrSinkAuth, err)
+	}
+	js.sinkClients.Store(subKeyPrefix, &sinkClient{
+		version:    version,
+		client:     ceClient,
+		httpClient: &http.Client{Transport: transport},
+	})
+	return nil
+}
+
+// getSinkClient returns the client dispatching the events to the sink of the subscription.
+func (js *JetStream) getSinkClient(subKeyPrefix string) cloudevents.Client {
+	if value, ok := js.sinkClients.Load(subKeyPrefix); ok {
+		return value.(*sinkClient).client
+	}
+	return js.client
+}
+
+// getSinkHTTPClient returns the HTTP client sending the events of the raw content mode to the sink of the subscription.
+func (js *JetStream) getSinkHTTPClient(subKeyPrefix string) *http.Client {
+	if value, ok := js.sinkClients.Load(subKeyPrefix); ok {
+		return value.(*sinkClient).httpClient
+	}
+	if js.transport == nil {
+		return http.DefaultClient
+	}
+	return &http.Client{Transport: js.transport}
+}
@@ -0,0 +1,79 @@
+package jetstream
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+)
+
+// maxScannedMessages is the maximum number of stream sequences scanned to find the recent messages of a subject.
+const maxScannedMessages = 1000
+
+// GetSubjectMessages returns up to limit of the most recent messages stored in the stream for the subject, which may
+// contain wildcards, starting with the most recent one. The messages stored before the last maxScannedMessages
+// sequences of the subject are not returned.
+func (js *JetStream) GetSubjectMessages(subject string, limit int) ([]*nats.RawStreamMsg, error) {
+	if js.Conn == nil || js.Conn.Status() != nats.CONNECTED {
+		return nil, ErrConnect
+	}
+	info, err := js.jsCtx.StreamInfo(js.Config.JSStreamName)
+	if err != nil {
+		return nil, err
+	}
+	last, err := js.jsCtx.GetLastMsg(js.Config.JSStreamName, subject)
+	if errors.Is(err, nats.ErrMsgNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	messages := []*nats.RawStreamMsg{last}
+	for seq := last.Sequence - 1; seq >= info.State.FirstSeq && seq > 0 && len(messages) < limit &&
+		last.Sequence-seq <= maxScannedMessages; seq-- {
+		msg, err := js.jsCtx.GetMsg(js.Config.JSStreamName, seq)
+		if errors.Is(err, nats.ErrMsgNotFound) {
+			// the message was removed from the stream
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if SubjectMatches(subject, msg.Subject) {
+			messages = append(messages, msg)
+		}
+	}
+	return messages, nil
+}
+
+// GetStreamMessage returns the message stored in the stream with the sequence.
+func (js *JetStream) GetStreamMessage(seq uint64) (*nats.RawStreamMsg, error) {
+	if js.Conn == nil || js.Conn.Status() != nats.CONNECTED {
+		return nil, ErrConnect
+	}
+	return js.jsCtx.GetMsg(js.Config.JSStreamName, seq)
+}
+
+// GetConsumerInfo returns the state of the consumer of the stream.
+func (js *JetStream) GetConsumerInfo(consumerName string) (*nats.ConsumerInfo, error) {
+	if js.Conn == nil || js.Conn.Status() != nats.CONNECTED {
+		return nil, ErrConnect
+	}
+	return js.jsCtx.ConsumerInfo(js.Config.JSStreamName, consumerName)
+}
+
+// SubjectMatches returns true if the subject matches the filter subject, which may contain the * and > wildcards.
+func SubjectMatches(filter, subject string) bool {
+	filterTokens := strings.Split(filter, ".")
+	subjectTokens := strings.Split(subject, ".")
+	for i, token := range filterTokens {
+		if token == ">" {
+			return len(subjectTokens) > i
+		}
+		if i >= len(subjectTokens) || (token != "*" && token != subjectTokens[i]) {
+			return false
+		}
+	}
+	return len(filterTokens) == len(subjectTokens)
+}
@@ -0,0 +1,144 @@
+package jetstream
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+
+	eventingv1alpha2 "github.com/kyma-project/eventing-manager/api/eventing/v1alpha2"
+)
+
+var ErrTransformEvent = errors.New("failed to transform the event")
+
+// transformer applies the transformation of a subscription to the events before they are dispatched.
+// It is used by the concurrent deliveries of the subscription.
+type transformer struct {
+	transformation *eventingv1alpha2.Transformation
+}
+
+func newTransformer(transformation *eventingv1alpha2.Transformation) (*transformer, error) {
+	for name, expression := range transformation.Projection {
+		if _, err := eventingv1alpha2.ParseProjectionField(name, expression); err != nil {
+			return nil, fmt.Errorf("%w: invalid projection of %s: %w", ErrTransformEvent, name, err)
+		}
+	}
+	return &transformer{transformation: transformation}, nil
+}
+
+// transform returns a copy of the event with the transformation applied, the given event is not changed.
+func (t *transformer) transform(event *cloudevents.Event) (*cloudevents.Event, error) {
+	transformed := event.Clone()
+	if len(t.transformation.Projection) > 0 {
+		data, err := t.project(event)
+		if err != nil {
+			return nil, err
+		}
+		if err = transformed.SetData(cloudevents.ApplicationJSON, data); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrTransformEvent, err)
+		}
+	}
+	for _, name := range t.transformation.RemoveExtensions {
+		transformed.SetExtension(name, nil)
+	}
+	for name, value := range t.transformation.SetExtensions {
+		transformed.SetExtension(name, value)
+	}
+	if t.transformation.Type != "" {
+		transformed.SetType(t.transformation.Type)
+	}
+	if t.transformation.Source != "" {
+		transformed.SetSource(t.transformation.Source)
+	}
+	return &transformed, nil
+}
+
+// project returns an object of the projected fields of the event data. The fields which are missing in the data
+// are omitted, and the fields matching multiple values are projected as arrays.
+// The expressions are parsed for every event, because a parsed expression keeps the state of its last evaluation,
+// e.g. of a range, so it can neither be reused nor shared by the concurrent deliveries.
+func (t *transformer) project(event *cloudevents.Event) (map[string]interface{}, error) {
+	var data interface{}
+	if err := json.Unmarshal(event.Data(), &data); err != nil {
+		return nil, fmt.Errorf("%w: the data is not JSON: %w", ErrTransformEvent, err)
+	}
+	projected := make(map[string]interface{}, len(t.transformation.Projection))
+	for name, expression := range t.transformation.Projection {
+		parser, err := eventingv1alpha2.ParseProjectionField(name, expression)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid projection of %s: %w", ErrTransformEvent, name, err)
+		}
+		results, err := parser.FindResults(data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to project %s: %w", ErrTransformEvent, name, err)
+		}
+		var values []interface{}
+		for _, result := range results {
+			for _, value := range result {
+				values = append(values, valueInterface(value))
+			}
+		}
+		switch len(values) {
+		case 0:
+		case 1:
+			projected[name] = values[0]
+		default:
+			projected[name] = values
+		}
+	}
+	return projected, nil
+}
+
+func valueInterface(value reflect.Value) interface{} {
+	if !value.IsValid() || !value.CanInterface() {
+		return nil
+	}
+	return value.Interface()
+}
+
+// storeTransformer stores the transformer of the subscription, or deletes it if the subscription has none.
+func (js *JetStream) storeTransformer(subscription *eventingv1alpha2.Subscription) error {
+	subKeyPrefix := createKeyPrefix(subscription)
+	if subscription.Spec.Transformation == nil {
+		js.transformers.Delete(subKeyPrefix)
+		return nil
+	}
+	transformer, err := newTransformer(subscription.Spec.Transformation.DeepCopy())
+	if err != nil {
+		return err
+	}
+	js.transformers.Store(subKeyPrefix, transformer)
+	return nil
+}
+
+// transformEvent returns the event to dispatch to the sink of the subscription, which is transformed
+// if the subscription has a transformation.
+func (js *JetStream) transformEvent(subKeyPrefix string, event *cloudevents.Event) (*cloudevents.Event, error) {
+	value, ok := js.transformers.Load(subKeyPrefix)
+	if !ok {
+		return event, nil
+	}
+	return value.(*transformer).transform(event)
+}
+
+// handleTransformationFailure dead-letters the event which cannot be transformed. The transformation fails the same
+// way on every redelivery, so the event is not redelivered unless it cannot be dead-lettered.
+func (js *JetStream) handleTransformationFailure(msg *nats.Msg, transformErr error, ceLogger *zap.SugaredLogger) {
+	subject := js.getDeadLetterSubject(msg.Subject)
+	ceLogger.Errorw("Dead-lettering the CloudEvent which cannot be transformed", "error", transformErr, "subject", subject)
+	if _, err := js.jsCtx.Publish(subject, msg.Data); err != nil {
+		ceLogger.Errorw("Failed to publish the CloudEvent to the dead-letter subject", "error", err)
+		// NAK the msg with a delay so it is redelivered after jsConsumerNakDelay period.
+		if err := msg.NakWithDelay(jsConsumerNakDelay); err != nil {
+			ceLogger.Errorw("failed to NAK an event on JetStream")
+		}
+		return
+	}
+	if err := msg.Ack(); err != nil {
+		ceLogger.Errorw("Failed to ACK an event on JetStream")
+	}
+}
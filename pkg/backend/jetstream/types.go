@@ -3,14 +3,18 @@
 package jetstream
 
 import (
+	"net/http"
 	"sync"
+	"time"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/nats-io/nats.go"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	eventingv1alpha2 "github.com/kyma-project/eventing-manager/api/eventing/v1alpha2"
 	"github.com/kyma-project/eventing-manager/pkg/backend/cleaner"
 	backendmetrics "github.com/kyma-project/eventing-manager/pkg/backend/metrics"
+	"github.com/kyma-project/eventing-manager/pkg/backend/schema"
 	backendutils "github.com/kyma-project/eventing-manager/pkg/backend/utils"
 	"github.com/kyma-project/eventing-manager/pkg/env"
 	"github.com/kyma-project/eventing-manager/pkg/logger"
@@ -52,18 +56,42 @@ type Backend interface {
 }
 
 type JetStream struct {
-	Config        env.NATSConfig
-	Conn          *nats.Conn
-	jsCtx         nats.JetStreamContext
-	client        cloudevents.Client
+	Config env.NATSConfig
+	Conn   *nats.Conn
+	jsCtx  nats.JetStreamContext
+	client cloudevents.Client
+	// transport is the transport of the client, which is shared by the clients of the sinks with authentication.
+	transport     *http.Transport
 	subscriptions map[SubscriptionSubjectIdentifier]Subscriber
 	sinks         sync.Map
+	// idempotencyCaches holds an *idempotencyCache per subscription if the idempotency cache is enabled.
+	idempotencyCaches sync.Map
+	// eventTypeAliases holds per subscription the subscribed event types of the subjects which were rewritten.
+	eventTypeAliases sync.Map
+	// migratedSubscriptions holds the subscriptions whose consumers were migrated to the configured layout.
+	migratedSubscriptions sync.Map
+	// replySubscriptions holds the subscriptions whose sink replies are published back to the stream.
+	replySubscriptions sync.Map
+	// sinkClients holds a *sinkClient per subscription whose sink requires authentication.
+	sinkClients sync.Map
+	// deliveryModes holds a *deliveryMode per subscription whose events are not dispatched in the binary content mode.
+	deliveryModes sync.Map
+	// transformers holds a *transformer per subscription whose events are transformed before dispatching.
+	transformers sync.Map
+	// secretReader reads the Secrets of the sink authentication, it is optional.
+	secretReader client.Reader
 	// connClosedHandler gets called by the NATS server when Conn is closed and retry attempts are exhausted.
 	connClosedHandler backendutils.ConnClosedHandler
 	logger            *logger.Logger
 	metricsCollector  *backendmetrics.Collector
 	cleaner           cleaner.Cleaner
 	subsConfig        env.DefaultSubscriptionConfig
+	// schemaRegistry holds the schemas used to validate the event data before dispatching, it is optional.
+	schemaRegistry *schema.Registry
+	// inFlight tracks the events being dispatched, to drain them on shutdown.
+	inFlight *inFlightTracker
+	// deliveryHealth tracks the results of the latest deliveries per subscription, it is optional.
+	deliveryHealth *DeliveryHealthTracker
 }
 
 func (js *JetStream) GetConfig() env.NATSConfig {
@@ -91,6 +119,17 @@ type SubscriptionSubjectIdentifier struct {
 
 type DefaultSubOpts []nats.SubOpt
 
+// SubjectStats describes the messages stored in the stream for a subject.
+type SubjectStats struct {
+	// Messages is the number of messages stored for the subject.
+	Messages uint64
+	// LastSeen is the time the last message stored for the subject was published.
+	LastSeen time.Time
+	// LastSeenSkipped is true if the last message of the subject was not looked up, since the stream stores more
+	// subjects than are looked up.
+	LastSeenSkipped bool
+}
+
 //----------------------------------------
 // JetStream Backend Test Types
 //----------------------------------------
@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/nats-io/nats.go"
 	pkgerrors "github.com/pkg/errors"
@@ -157,34 +158,58 @@ func getStreamConfig(natsConfig env.NATSConfig) (*nats.StreamConfig, error) {
 		MaxBytes:          maxBytes.Value(),
 		Discard:           discardPolicy,
 		MaxMsgsPerSubject: natsConfig.JSStreamMaxMsgsPerTopic,
+		Duplicates:        natsConfig.JSStreamDuplicates,
 		// Since one stream is used to store events of all types, the stream has to match all event types, and therefore
 		// we use the wildcard char >. However, to avoid matching internal JetStream and non-Kyma-related subjects, we
 		// use a prefix. This prefix is handled only on the JetStream level (i.e. JetStream handler
 		// and EPP) and should not be exposed in the Kyma subscription. Any Kyma event type gets appended with the
 		// configured stream's subject prefix.
 		Subjects: []string{fmt.Sprintf("%s.>", natsConfig.JSSubjectPrefix)},
+		// The direct get allows reading the next message of a subject after a sequence in a single request, e.g. the
+		// oldest message not acknowledged by a consumer.
+		AllowDirect: true,
 	}
 	return streamConfig, nil
 }
 
+// getDeadLetterStreamConfig returns the config of the stream storing the dead-lettered events. It has the limits of
+// the event stream, but its own subjects, which do not overlap with the subjects of the event stream. It always
+// retains the events by limits, because no consumer is interested in them.
+func getDeadLetterStreamConfig(streamConfig *nats.StreamConfig, natsConfig env.NATSConfig) *nats.StreamConfig {
+	deadLetterConfig := *streamConfig
+	deadLetterConfig.Name = streamConfig.Name + deadLetterSuffix
+	deadLetterConfig.Retention = nats.LimitsPolicy
+	deadLetterConfig.Subjects = []string{fmt.Sprintf("%s.>", getDeadLetterSubjectPrefix(natsConfig))}
+	return &deadLetterConfig
+}
+
+// getDeadLetterSubjectPrefix returns the subject prefix of the dead-letter stream.
+func getDeadLetterSubjectPrefix(natsConfig env.NATSConfig) string {
+	return natsConfig.JSSubjectPrefix + deadLetterSuffix
+}
+
 // getConsumerConfig return the consumerConfig according to the default configuration.
-func (js *JetStream) getConsumerConfig(jsSubKey SubscriptionSubjectIdentifier,
-	jsSubject string, maxInFlight int,
-) *nats.ConsumerConfig {
-	return &nats.ConsumerConfig{
-		Durable:        jsSubKey.ConsumerName(),
-		Description:    jsSubKey.namespacedSubjectName,
+// A consolidated consumer filters all its subjects by the filter subjects, otherwise by the filter subject.
+func (js *JetStream) getConsumerConfig(consumer subscriptionConsumer, maxInFlight int) *nats.ConsumerConfig {
+	consumerConfig := &nats.ConsumerConfig{
+		Durable:        consumer.key.ConsumerName(),
+		Description:    consumer.key.namespacedSubjectName,
 		DeliverPolicy:  toJetStreamConsumerDeliverPolicy(js.Config.JSConsumerDeliverPolicy),
 		FlowControl:    true,
 		MaxAckPending:  maxInFlight,
 		AckPolicy:      nats.AckExplicitPolicy,
 		AckWait:        jsConsumerAckWait,
 		MaxDeliver:     jsConsumerMaxRedeliver,
-		FilterSubject:  jsSubject,
 		ReplayPolicy:   nats.ReplayInstantPolicy,
 		DeliverSubject: nats.NewInbox(),
 		Heartbeat:      idleHeartBeatDuration,
 	}
+	if consumer.consolidated {
+		consumerConfig.FilterSubjects = consumer.subjects
+	} else {
+		consumerConfig.FilterSubject = consumer.subscribeSubject()
+	}
+	return consumerConfig
 }
 
 func createKeyPrefix(sub *eventingv1alpha2.Subscription) string {
@@ -218,13 +243,19 @@ func getUniqueEventTypes(eventTypes []string) []string {
 }
 
 // GetCleanEventTypes returns a list of clean eventTypes from the unique types in the subscription.
+// The types are rewritten by the rules of the cleaner, and cleaned unless the type matching is exact.
+// With the wildcard type matching, the wildcard segments are kept, so that they map to the NATS wildcards.
 func GetCleanEventTypes(sub *eventingv1alpha2.Subscription, cleaner cleaner.Cleaner) []eventingv1alpha2.EventType {
 	uniqueTypes := getUniqueEventTypes(sub.Spec.Types)
 	var cleanEventTypes []eventingv1alpha2.EventType
 	for _, eventType := range uniqueTypes {
-		cleanType := eventType
-		if sub.Spec.TypeMatching != eventingv1alpha2.TypeMatchingExact {
-			cleanType, _ = cleaner.CleanEventType(eventType)
+		cleanType := cleaner.RewriteEventType(eventType)
+		switch sub.Spec.TypeMatching {
+		case eventingv1alpha2.TypeMatchingExact:
+		case eventingv1alpha2.TypeMatchingWildcard:
+			cleanType = cleanWildcardEventType(cleanType, cleaner)
+		default:
+			cleanType, _ = cleaner.CleanEventType(cleanType)
 		}
 		newEventType := eventingv1alpha2.EventType{
 			OriginalType: eventType,
@@ -235,10 +266,23 @@ func GetCleanEventTypes(sub *eventingv1alpha2.Subscription, cleaner cleaner.Clea
 	return cleanEventTypes
 }
 
+// cleanWildcardEventType cleans the segments of the event type, except for the wildcard segments.
+func cleanWildcardEventType(eventType string, cleaner cleaner.Cleaner) string {
+	segments := strings.Split(eventType, ".")
+	for i, segment := range segments {
+		if segment == eventingv1alpha2.WildcardSegment || segment == eventingv1alpha2.WildcardTrailingSegments {
+			continue
+		}
+		segments[i], _ = cleaner.CleanEventType(segment)
+	}
+	return strings.Join(segments, ".")
+}
+
 // GetBackendJetStreamTypes gets the original event type and the consumer name for all the subscriptions
 // and this slice is set as the backend specific status for JetStream.
+// If the consumers are consolidated, all the event types have the same consumer.
 func GetBackendJetStreamTypes(subscription *eventingv1alpha2.Subscription,
-	jsSubjects []string,
+	jsSubjects []string, consolidated bool,
 ) ([]eventingv1alpha2.JetStreamTypes, error) {
 	if len(jsSubjects) != len(subscription.Spec.Types) {
 		return nil, pkgerrors.New("length of JetStream subjects do not match with eventTypes from spec")
@@ -246,9 +290,13 @@ func GetBackendJetStreamTypes(subscription *eventingv1alpha2.Subscription,
 
 	var jsTypes []eventingv1alpha2.JetStreamTypes
 	for i, ot := range subscription.Spec.Types {
+		consumerSubject := jsSubjects[i]
+		if consolidated {
+			consumerSubject = consolidatedConsumerSubject
+		}
 		jt := eventingv1alpha2.JetStreamTypes{
 			OriginalType: ot,
-			ConsumerName: computeConsumerName(subscription, jsSubjects[i]),
+			ConsumerName: computeConsumerName(subscription, consumerSubject),
 		}
 		jsTypes = append(jsTypes, jt)
 	}
@@ -277,6 +325,11 @@ func (s SubscriptionSubjectIdentifier) ConsumerName() string {
 	return s.consumerName
 }
 
+// ShardKey returns the key used to assign the consumer to a dispatcher replica.
+func (s SubscriptionSubjectIdentifier) ShardKey() string {
+	return s.namespacedSubjectName
+}
+
 // NewSubscriptionSubjectIdentifier returns a new SubscriptionSubjectIdentifier instance.
 func NewSubscriptionSubjectIdentifier(subscription *eventingv1alpha2.Subscription,
 	subject string,
@@ -299,3 +352,21 @@ func computeConsumerName(subscription *eventingv1alpha2.Subscription, subject st
 func computeNamespacedSubjectName(subscription *eventingv1alpha2.Subscription, subject string) string {
 	return subscription.Namespace + separator + subscription.Name + separator + subject
 }
+
+// deliveryAttempt returns the number of the delivery attempt of the given message, or zero if it is unknown.
+func deliveryAttempt(msg *nats.Msg) uint64 {
+	metadata, err := msg.Metadata()
+	if err != nil {
+		return 0
+	}
+	return metadata.NumDelivered
+}
+
+// storedAt returns the time the message was stored in the stream, or zero if it is unknown.
+func storedAt(msg *nats.Msg) time.Time {
+	metadata, err := msg.Metadata()
+	if err != nil {
+		return time.Time{}
+	}
+	return metadata.Timestamp
+}
@@ -4,12 +4,14 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	kymalogger "github.com/kyma-project/kyma/common/logging/logger"
 	"github.com/nats-io/nats.go"
 	"github.com/stretchr/testify/require"
 
 	eventingv1alpha2 "github.com/kyma-project/eventing-manager/api/eventing/v1alpha2"
+	operatorv1alpha1 "github.com/kyma-project/eventing-manager/api/operator/v1alpha1"
 	"github.com/kyma-project/eventing-manager/pkg/backend/cleaner"
 	"github.com/kyma-project/eventing-manager/pkg/env"
 	"github.com/kyma-project/eventing-manager/pkg/logger"
@@ -137,14 +139,15 @@ func TestGetStreamConfig(t *testing.T) {
 				JSStreamDiscardPolicy:   DiscardPolicyNew,
 			},
 			wantStreamConfig: &nats.StreamConfig{
-				Name:      DefaultStreamName,
-				Discard:   nats.DiscardNew,
-				Storage:   nats.MemoryStorage,
-				Replicas:  3,
-				Retention: nats.LimitsPolicy,
-				MaxMsgs:   -1,
-				MaxBytes:  -1,
-				Subjects:  []string{fmt.Sprintf("%s.>", DefaultJetStreamSubjectPrefix)},
+				Name:        DefaultStreamName,
+				Discard:     nats.DiscardNew,
+				Storage:     nats.MemoryStorage,
+				Replicas:    3,
+				Retention:   nats.LimitsPolicy,
+				MaxMsgs:     -1,
+				MaxBytes:    -1,
+				Subjects:    []string{fmt.Sprintf("%s.>", DefaultJetStreamSubjectPrefix)},
+				AllowDirect: true,
 			},
 			wantError: false,
 		},
@@ -161,14 +164,15 @@ func TestGetStreamConfig(t *testing.T) {
 				JSStreamMaxBytes:        "10485760",
 			},
 			wantStreamConfig: &nats.StreamConfig{
-				Name:      DefaultStreamName,
-				Discard:   nats.DiscardNew,
-				Storage:   nats.MemoryStorage,
-				Replicas:  3,
-				Retention: nats.LimitsPolicy,
-				MaxMsgs:   -1,
-				MaxBytes:  10485760,
-				Subjects:  []string{fmt.Sprintf("%s.>", DefaultJetStreamSubjectPrefix)},
+				Name:        DefaultStreamName,
+				Discard:     nats.DiscardNew,
+				Storage:     nats.MemoryStorage,
+				Replicas:    3,
+				Retention:   nats.LimitsPolicy,
+				MaxMsgs:     -1,
+				MaxBytes:    10485760,
+				Subjects:    []string{fmt.Sprintf("%s.>", DefaultJetStreamSubjectPrefix)},
+				AllowDirect: true,
 			},
 			wantError: false,
 		},
@@ -185,14 +189,42 @@ func TestGetStreamConfig(t *testing.T) {
 				JSStreamMaxBytes:        "10Mi",
 			},
 			wantStreamConfig: &nats.StreamConfig{
-				Name:      DefaultStreamName,
-				Discard:   nats.DiscardNew,
-				Storage:   nats.MemoryStorage,
-				Replicas:  3,
-				Retention: nats.LimitsPolicy,
-				MaxMsgs:   -1,
-				MaxBytes:  10485760,
-				Subjects:  []string{fmt.Sprintf("%s.>", DefaultJetStreamSubjectPrefix)},
+				Name:        DefaultStreamName,
+				Discard:     nats.DiscardNew,
+				Storage:     nats.MemoryStorage,
+				Replicas:    3,
+				Retention:   nats.LimitsPolicy,
+				MaxMsgs:     -1,
+				MaxBytes:    10485760,
+				Subjects:    []string{fmt.Sprintf("%s.>", DefaultJetStreamSubjectPrefix)},
+				AllowDirect: true,
+			},
+			wantError: false,
+		},
+		{
+			name: "Should set the duplicates window",
+			givenNATSConfig: env.NATSConfig{
+				JSStreamName:            DefaultStreamName,
+				JSSubjectPrefix:         DefaultJetStreamSubjectPrefix,
+				JSStreamStorageType:     StorageTypeMemory,
+				JSStreamDiscardPolicy:   DiscardPolicyNew,
+				JSStreamRetentionPolicy: RetentionPolicyLimits,
+				JSStreamReplicas:        3,
+				JSStreamMaxMessages:     -1,
+				JSStreamMaxBytes:        "-1",
+				JSStreamDuplicates:      5 * time.Minute,
+			},
+			wantStreamConfig: &nats.StreamConfig{
+				Name:        DefaultStreamName,
+				Discard:     nats.DiscardNew,
+				Storage:     nats.MemoryStorage,
+				Replicas:    3,
+				Retention:   nats.LimitsPolicy,
+				MaxMsgs:     -1,
+				MaxBytes:    -1,
+				Duplicates:  5 * time.Minute,
+				Subjects:    []string{fmt.Sprintf("%s.>", DefaultJetStreamSubjectPrefix)},
+				AllowDirect: true,
 			},
 			wantError: false,
 		},
@@ -212,6 +244,35 @@ func TestGetStreamConfig(t *testing.T) {
 	}
 }
 
+func TestGetDeadLetterStreamConfig(t *testing.T) {
+	t.Parallel()
+
+	// given
+	natsConfig := env.NATSConfig{JSStreamName: DefaultStreamName, JSSubjectPrefix: DefaultJetStreamSubjectPrefix}
+	streamConfig := &nats.StreamConfig{
+		Name:      DefaultStreamName,
+		Storage:   nats.FileStorage,
+		Replicas:  3,
+		Retention: nats.InterestPolicy,
+		MaxBytes:  10485760,
+		Subjects:  []string{fmt.Sprintf("%s.>", DefaultJetStreamSubjectPrefix)},
+	}
+
+	// when
+	deadLetterConfig := getDeadLetterStreamConfig(streamConfig, natsConfig)
+
+	// then
+	require.Equal(t, &nats.StreamConfig{
+		Name:      DefaultStreamName + "-deadletter",
+		Storage:   nats.FileStorage,
+		Replicas:  3,
+		Retention: nats.LimitsPolicy,
+		MaxBytes:  10485760,
+		Subjects:  []string{fmt.Sprintf("%s-deadletter.>", DefaultJetStreamSubjectPrefix)},
+	}, deadLetterConfig)
+	require.Equal(t, []string{fmt.Sprintf("%s.>", DefaultJetStreamSubjectPrefix)}, streamConfig.Subjects)
+}
+
 func TestCreateKeyPrefix(t *testing.T) {
 	// given
 	sub := eventingtesting.NewSubscription(subName, subNamespace)
@@ -244,10 +305,10 @@ func TestGetCleanEventTypes(t *testing.T) {
 	t.Parallel()
 	defaultLogger, err := logger.New(string(kymalogger.JSON), string(kymalogger.INFO))
 	require.NoError(t, err)
-	jscleaner := cleaner.NewJetStreamCleaner(defaultLogger)
 	testCases := []struct {
 		name              string
 		givenSubscription *eventingv1alpha2.Subscription
+		givenRewrites     []operatorv1alpha1.EventTypeRewrite
 		wantEventTypes    []eventingv1alpha2.EventType
 	}{
 		{
@@ -294,11 +355,61 @@ func TestGetCleanEventTypes(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Should rewrite eventTypes before cleaning them if the typeMatching is set to Standard",
+			givenSubscription: eventingtesting.NewSubscription("sub", "test",
+				eventingtesting.WithNotCleanEventSourceAndType(),
+				eventingtesting.WithTypeMatchingStandard(),
+			),
+			givenRewrites: []operatorv1alpha1.EventTypeRewrite{
+				{From: "order.*", To: "order.new-*"},
+			},
+			wantEventTypes: []eventingv1alpha2.EventType{
+				{
+					OriginalType: eventingtesting.OrderCreatedUncleanEvent,
+					CleanType:    "order.new-cre-ä+ted.v2",
+				},
+			},
+		},
+		{
+			name: "Should rewrite eventTypes if the typeMatching is set to Exact",
+			givenSubscription: eventingtesting.NewSubscription("sub", "test",
+				eventingtesting.WithEventType(eventingtesting.OrderCreatedV1Event),
+				eventingtesting.WithTypeMatchingExact(),
+			),
+			givenRewrites: []operatorv1alpha1.EventTypeRewrite{
+				{From: eventingtesting.OrderCreatedV1Event, To: "order.placed.v1"},
+			},
+			wantEventTypes: []eventingv1alpha2.EventType{
+				{
+					OriginalType: eventingtesting.OrderCreatedV1Event,
+					CleanType:    "order.placed.v1",
+				},
+			},
+		},
+		{
+			name: "Should keep the wildcard segments if the typeMatching is set to Wildcard",
+			givenSubscription: eventingtesting.NewSubscription("sub", "test",
+				eventingtesting.WithTypes([]string{"order.*.v1", "cus tomer.>"}),
+				eventingtesting.WithTypeMatching(eventingv1alpha2.TypeMatchingWildcard),
+			),
+			wantEventTypes: []eventingv1alpha2.EventType{
+				{
+					OriginalType: "order.*.v1",
+					CleanType:    "order.*.v1",
+				},
+				{
+					OriginalType: "cus tomer.>",
+					CleanType:    "customer.>",
+				},
+			},
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
+			jscleaner := cleaner.NewJetStreamCleaner(defaultLogger, tc.givenRewrites...)
 			eventTypes := GetCleanEventTypes(tc.givenSubscription, jscleaner)
 			require.Equal(t, tc.wantEventTypes, eventTypes)
 		})
@@ -316,6 +427,7 @@ func TestGetBackendJetStreamTypes(t *testing.T) {
 		name              string
 		givenSubscription *eventingv1alpha2.Subscription
 		givenJSSubjects   []string
+		givenConsolidated bool
 		wantJSTypes       []eventingv1alpha2.JetStreamTypes
 		wantError         bool
 	}{
@@ -380,6 +492,27 @@ func TestGetBackendJetStreamTypes(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "two types and two jsSubjects with consolidated consumers",
+			givenSubscription: eventingtesting.NewSubscription(subName, subNamespace,
+				eventingtesting.WithSource(eventingtesting.EventSourceUnclean),
+				eventingtesting.WithEventType(eventingtesting.OrderCreatedCleanEvent),
+				eventingtesting.WithEventType(eventingtesting.OrderCreatedV1Event)),
+			givenJSSubjects: js.GetJetStreamSubjects(eventingtesting.EventSourceUnclean,
+				[]string{eventingtesting.OrderCreatedCleanEvent, eventingtesting.OrderCreatedV1Event},
+				eventingv1alpha2.TypeMatchingStandard),
+			givenConsolidated: true,
+			wantJSTypes: []eventingv1alpha2.JetStreamTypes{
+				{
+					OriginalType: eventingtesting.OrderCreatedCleanEvent,
+					ConsumerName: computeConsumerName(defaultSub, consolidatedConsumerSubject),
+				},
+				{
+					OriginalType: eventingtesting.OrderCreatedV1Event,
+					ConsumerName: computeConsumerName(defaultSub, consolidatedConsumerSubject),
+				},
+			},
+		},
 		{
 			name: "should return error if length mismatch",
 			givenSubscription: eventingtesting.NewSubscription(subName, subNamespace,
@@ -396,7 +529,7 @@ func TestGetBackendJetStreamTypes(t *testing.T) {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
-			jsTypes, err := GetBackendJetStreamTypes(tc.givenSubscription, tc.givenJSSubjects)
+			jsTypes, err := GetBackendJetStreamTypes(tc.givenSubscription, tc.givenJSSubjects, tc.givenConsolidated)
 			if tc.wantError {
 				require.Error(t, err)
 			} else {
@@ -575,3 +708,63 @@ func TestSubscriptionSubjectIdentifierNamespacedName(t *testing.T) {
 		})
 	}
 }
+
+func TestSubjectMatches(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name         string
+		givenFilter  string
+		givenSubject string
+		wantMatch    bool
+	}{
+		{
+			name:         "equal subjects",
+			givenFilter:  "kyma.app.order.created.v1",
+			givenSubject: "kyma.app.order.created.v1",
+			wantMatch:    true,
+		},
+		{
+			name:         "different subjects",
+			givenFilter:  "kyma.app.order.created.v1",
+			givenSubject: "kyma.app.order.updated.v1",
+			wantMatch:    false,
+		},
+		{
+			name:         "shorter subject",
+			givenFilter:  "kyma.app.order.created.v1",
+			givenSubject: "kyma.app.order.created",
+			wantMatch:    false,
+		},
+		{
+			name:         "single token wildcard",
+			givenFilter:  "kyma.*.order.created.v1",
+			givenSubject: "kyma.app.order.created.v1",
+			wantMatch:    true,
+		},
+		{
+			name:         "single token wildcard with more tokens",
+			givenFilter:  "kyma.*",
+			givenSubject: "kyma.app.order.created.v1",
+			wantMatch:    false,
+		},
+		{
+			name:         "multiple tokens wildcard",
+			givenFilter:  "kyma.app.>",
+			givenSubject: "kyma.app.order.created.v1",
+			wantMatch:    true,
+		},
+		{
+			name:         "multiple tokens wildcard without tokens",
+			givenFilter:  "kyma.app.>",
+			givenSubject: "kyma.app",
+			wantMatch:    false,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tc.wantMatch, SubjectMatches(tc.givenFilter, tc.givenSubject))
+		})
+	}
+}
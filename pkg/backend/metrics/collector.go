@@ -35,6 +35,67 @@ const (
 	// subscriptionStatusMetricHelp help text for the subscription status metric.
 	subscriptionStatusMetricHelp = "The status of a subscription. `1` indicates the subscription is marked as ready"
 
+	// schemaValidationFailureMetricKey name of the schema validation failure metric.
+	schemaValidationFailureMetricKey = "eventing_ec_nats_schema_validation_failures_total"
+	// schemaValidationFailureMetricHelp help text for the schema validation failure metric.
+	schemaValidationFailureMetricHelp = "The total number of dispatched events not conforming to the schema registered for their type"
+
+	// transformationFailureMetricKey name of the transformation failure metric.
+	transformationFailureMetricKey = "eventing_ec_nats_transformation_failures_total"
+	// transformationFailureMetricHelp help text for the transformation failure metric.
+	transformationFailureMetricHelp = "The total number of events which could not be transformed and were dead-lettered"
+
+	// inFlightDeliveriesMetricKey name of the in-flight deliveries metric.
+	inFlightDeliveriesMetricKey = "eventing_ec_nats_in_flight_deliveries"
+	// inFlightDeliveriesMetricHelp help text for the in-flight deliveries metric.
+	inFlightDeliveriesMetricHelp = "The number of events being dispatched to the subscribers"
+
+	// abandonedDeliveriesMetricKey name of the abandoned deliveries metric.
+	abandonedDeliveriesMetricKey = "eventing_ec_nats_drain_abandoned_deliveries_total"
+	// abandonedDeliveriesMetricHelp help text for the abandoned deliveries metric.
+	abandonedDeliveriesMetricHelp = "The total number of in-flight deliveries abandoned because draining the dispatcher timed out"
+
+	// replyEventsMetricKey name of the reply events metric.
+	replyEventsMetricKey = "eventing_ec_nats_reply_events_total"
+	// replyEventsMetricHelp help text for the reply events metric.
+	replyEventsMetricHelp = "The total number of events replied by the subscribers and handled according to the result"
+
+	// consumerPendingMetricKey name of the consumer pending messages metric.
+	consumerPendingMetricKey = "eventing_ec_nats_consumer_pending_messages"
+	// consumerPendingMetricHelp help text for the consumer pending messages metric.
+	consumerPendingMetricHelp = "The number of messages in the stream not delivered to the consumer yet"
+
+	// consumerAckPendingMetricKey name of the consumer ack pending messages metric.
+	consumerAckPendingMetricKey = "eventing_ec_nats_consumer_ack_pending_messages"
+	// consumerAckPendingMetricHelp help text for the consumer ack pending messages metric.
+	consumerAckPendingMetricHelp = "The number of messages delivered to the consumer, but not acknowledged yet"
+
+	// consumerRedeliveredMetricKey name of the consumer redelivered messages metric.
+	consumerRedeliveredMetricKey = "eventing_ec_nats_consumer_redelivered_messages"
+	// consumerRedeliveredMetricHelp help text for the consumer redelivered messages metric.
+	consumerRedeliveredMetricHelp = "The number of messages being redelivered to the consumer"
+
+	// consumerOldestUnackedAgeMetricKey name of the consumer oldest unacknowledged message age metric.
+	consumerOldestUnackedAgeMetricKey = "eventing_ec_nats_consumer_oldest_unacked_message_age_seconds"
+	//nolint:lll // help text for metrics
+	// consumerOldestUnackedAgeMetricHelp help text for the consumer oldest unacknowledged message age metric.
+	consumerOldestUnackedAgeMetricHelp = "The age of the oldest message not acknowledged by the consumer. `0` indicates that the consumer has no backlog"
+
+	// streamMessagesMetricKey name of the stream messages metric.
+	streamMessagesMetricKey = "eventing_ec_nats_stream_messages"
+	// streamMessagesMetricHelp help text for the stream messages metric.
+	streamMessagesMetricHelp = "The number of messages stored in the stream"
+
+	// streamBytesMetricKey name of the stream bytes metric.
+	streamBytesMetricKey = "eventing_ec_nats_stream_bytes"
+	// streamBytesMetricHelp help text for the stream bytes metric.
+	streamBytesMetricHelp = "The number of bytes stored in the stream"
+
+	// streamLimitUsageMetricKey name of the stream limit usage metric.
+	streamLimitUsageMetricKey = "eventing_ec_nats_stream_limit_usage_ratio"
+	// streamLimitUsageMetricHelp help text for the stream limit usage metric.
+	streamLimitUsageMetricHelp = "The usage of the limits of the stream, by limit: `bytes` or `messages`. `1` indicates that the limit is reached"
+
 	subscriptionNameLabel      = "subscription_name"
 	eventTypeLabel             = "event_type"
 	sinkLabel                  = "sink"
@@ -43,6 +104,13 @@ const (
 	consumerNameLabel          = "consumer_name"
 	backendTypeLabel           = "eventing_backend"
 	streamNameLabel            = "stream_name"
+	validationPolicyLabel      = "validation_policy"
+	resultLabel                = "result"
+	limitLabel                 = "limit"
+
+	// the limits of the stream.
+	limitBytes    = "bytes"
+	limitMessages = "messages"
 )
 
 // Collector implements the prometheus.Collector interface.
@@ -52,6 +120,18 @@ type Collector struct {
 	latencyPerSubscriber    *prometheus.HistogramVec
 	health                  *prometheus.GaugeVec
 	subscriptionStatus      *prometheus.GaugeVec
+	schemaValidationFailure *prometheus.CounterVec
+	transformationFailure   *prometheus.CounterVec
+	inFlightDeliveries      *prometheus.GaugeVec
+	abandonedDeliveries     *prometheus.CounterVec
+	replyEvents             *prometheus.CounterVec
+	consumerPending         *prometheus.GaugeVec
+	consumerAckPending      *prometheus.GaugeVec
+	consumerRedelivered     *prometheus.GaugeVec
+	consumerOldestUnacked   *prometheus.GaugeVec
+	streamMessages          *prometheus.GaugeVec
+	streamBytes             *prometheus.GaugeVec
+	streamLimitUsage        *prometheus.GaugeVec
 }
 
 // NewCollector a new instance of Collector.
@@ -99,6 +179,90 @@ func NewCollector() *Collector {
 			},
 			[]string{subscriptionNameLabel, subscriptionNamespaceLabel, consumerNameLabel, backendTypeLabel, streamNameLabel},
 		),
+		schemaValidationFailure: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: schemaValidationFailureMetricKey,
+				Help: schemaValidationFailureMetricHelp,
+			},
+			[]string{subscriptionNameLabel, subscriptionNamespaceLabel, eventTypeLabel, consumerNameLabel, validationPolicyLabel},
+		),
+		transformationFailure: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: transformationFailureMetricKey,
+				Help: transformationFailureMetricHelp,
+			},
+			[]string{subscriptionNameLabel, subscriptionNamespaceLabel, eventTypeLabel, consumerNameLabel},
+		),
+		inFlightDeliveries: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: inFlightDeliveriesMetricKey,
+				Help: inFlightDeliveriesMetricHelp,
+			},
+			nil,
+		),
+		abandonedDeliveries: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: abandonedDeliveriesMetricKey,
+				Help: abandonedDeliveriesMetricHelp,
+			},
+			nil,
+		),
+		replyEvents: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: replyEventsMetricKey,
+				Help: replyEventsMetricHelp,
+			},
+			[]string{subscriptionNameLabel, subscriptionNamespaceLabel, eventTypeLabel, consumerNameLabel, resultLabel},
+		),
+		consumerPending: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: consumerPendingMetricKey,
+				Help: consumerPendingMetricHelp,
+			},
+			[]string{subscriptionNameLabel, subscriptionNamespaceLabel, eventTypeLabel, consumerNameLabel},
+		),
+		consumerAckPending: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: consumerAckPendingMetricKey,
+				Help: consumerAckPendingMetricHelp,
+			},
+			[]string{subscriptionNameLabel, subscriptionNamespaceLabel, eventTypeLabel, consumerNameLabel},
+		),
+		consumerRedelivered: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: consumerRedeliveredMetricKey,
+				Help: consumerRedeliveredMetricHelp,
+			},
+			[]string{subscriptionNameLabel, subscriptionNamespaceLabel, eventTypeLabel, consumerNameLabel},
+		),
+		consumerOldestUnacked: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: consumerOldestUnackedAgeMetricKey,
+				Help: consumerOldestUnackedAgeMetricHelp,
+			},
+			[]string{subscriptionNameLabel, subscriptionNamespaceLabel, eventTypeLabel, consumerNameLabel},
+		),
+		streamMessages: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: streamMessagesMetricKey,
+				Help: streamMessagesMetricHelp,
+			},
+			[]string{streamNameLabel},
+		),
+		streamBytes: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: streamBytesMetricKey,
+				Help: streamBytesMetricHelp,
+			},
+			[]string{streamNameLabel},
+		),
+		streamLimitUsage: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: streamLimitUsageMetricKey,
+				Help: streamLimitUsageMetricHelp,
+			},
+			[]string{streamNameLabel, limitLabel},
+		),
 	}
 }
 
@@ -109,6 +273,18 @@ func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
 	c.latencyPerSubscriber.Describe(ch)
 	c.health.Describe(ch)
 	c.subscriptionStatus.Describe(ch)
+	c.schemaValidationFailure.Describe(ch)
+	c.transformationFailure.Describe(ch)
+	c.inFlightDeliveries.Describe(ch)
+	c.abandonedDeliveries.Describe(ch)
+	c.replyEvents.Describe(ch)
+	c.consumerPending.Describe(ch)
+	c.consumerAckPending.Describe(ch)
+	c.consumerRedelivered.Describe(ch)
+	c.consumerOldestUnacked.Describe(ch)
+	c.streamMessages.Describe(ch)
+	c.streamBytes.Describe(ch)
+	c.streamLimitUsage.Describe(ch)
 }
 
 // Collect implements the prometheus.Collector interface Collect method.
@@ -118,6 +294,18 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric) {
 	c.latencyPerSubscriber.Collect(ch)
 	c.health.Collect(ch)
 	c.subscriptionStatus.Collect(ch)
+	c.schemaValidationFailure.Collect(ch)
+	c.transformationFailure.Collect(ch)
+	c.inFlightDeliveries.Collect(ch)
+	c.abandonedDeliveries.Collect(ch)
+	c.replyEvents.Collect(ch)
+	c.consumerPending.Collect(ch)
+	c.consumerAckPending.Collect(ch)
+	c.consumerRedelivered.Collect(ch)
+	c.consumerOldestUnacked.Collect(ch)
+	c.streamMessages.Collect(ch)
+	c.streamBytes.Collect(ch)
+	c.streamLimitUsage.Collect(ch)
 }
 
 // RegisterMetrics registers the metrics.
@@ -127,6 +315,18 @@ func (c *Collector) RegisterMetrics() {
 	metrics.Registry.MustRegister(c.latencyPerSubscriber)
 	metrics.Registry.MustRegister(c.health)
 	metrics.Registry.MustRegister(c.subscriptionStatus)
+	metrics.Registry.MustRegister(c.schemaValidationFailure)
+	metrics.Registry.MustRegister(c.transformationFailure)
+	metrics.Registry.MustRegister(c.inFlightDeliveries)
+	metrics.Registry.MustRegister(c.abandonedDeliveries)
+	metrics.Registry.MustRegister(c.replyEvents)
+	metrics.Registry.MustRegister(c.consumerPending)
+	metrics.Registry.MustRegister(c.consumerAckPending)
+	metrics.Registry.MustRegister(c.consumerRedelivered)
+	metrics.Registry.MustRegister(c.consumerOldestUnacked)
+	metrics.Registry.MustRegister(c.streamMessages)
+	metrics.Registry.MustRegister(c.streamBytes)
+	metrics.Registry.MustRegister(c.streamLimitUsage)
 
 	// set health metric to 1. With future updates this can be tied to other health indicators.
 	c.health.WithLabelValues().Set(1)
@@ -163,6 +363,52 @@ func (c *Collector) RecordEventTypes(subscriptionName, subscriptionNamespace, ev
 	c.eventTypes.WithLabelValues(subscriptionName, subscriptionNamespace, eventType, consumer).Inc()
 }
 
+// RecordSchemaValidationFailure records an eventing_ec_nats_schema_validation_failures_total metric.
+func (c *Collector) RecordSchemaValidationFailure(subscriptionName, subscriptionNamespace, eventType, consumerName,
+	validationPolicy string,
+) {
+	c.schemaValidationFailure.WithLabelValues(
+		subscriptionName,
+		subscriptionNamespace,
+		eventType,
+		consumerName,
+		validationPolicy).Inc()
+}
+
+// RecordTransformationFailure records an eventing_ec_nats_transformation_failures_total metric.
+func (c *Collector) RecordTransformationFailure(subscriptionName, subscriptionNamespace, eventType, consumerName string) {
+	c.transformationFailure.WithLabelValues(
+		subscriptionName,
+		subscriptionNamespace,
+		eventType,
+		consumerName).Inc()
+}
+
+// IncInFlightDeliveries increments the eventing_ec_nats_in_flight_deliveries metric.
+func (c *Collector) IncInFlightDeliveries() {
+	c.inFlightDeliveries.WithLabelValues().Inc()
+}
+
+// DecInFlightDeliveries decrements the eventing_ec_nats_in_flight_deliveries metric.
+func (c *Collector) DecInFlightDeliveries() {
+	c.inFlightDeliveries.WithLabelValues().Dec()
+}
+
+// RecordAbandonedDeliveries records an eventing_ec_nats_drain_abandoned_deliveries_total metric.
+func (c *Collector) RecordAbandonedDeliveries(count int) {
+	c.abandonedDeliveries.WithLabelValues().Add(float64(count))
+}
+
+// RecordReplyEvent records an eventing_ec_nats_reply_events_total metric.
+func (c *Collector) RecordReplyEvent(subscriptionName, subscriptionNamespace, eventType, consumerName, result string) {
+	c.replyEvents.WithLabelValues(
+		subscriptionName,
+		subscriptionNamespace,
+		eventType,
+		consumerName,
+		result).Inc()
+}
+
 // RecordSubscriptionStatus records an eventing_ec_subscription_status metric.
 func (c *Collector) RecordSubscriptionStatus(isActive bool, subscriptionName,
 	subscriptionNamespace, backendType, consumer, streamName string,
@@ -196,3 +442,49 @@ func (c *Collector) RemoveSubscriptionStatus(subscriptionName, subscriptionNames
 func (c *Collector) ResetSubscriptionStatus() {
 	c.subscriptionStatus.Reset()
 }
+
+// RecordConsumerLag records the eventing_ec_nats_consumer_* metrics of the backlog of the consumer. The event type is
+// empty if the consumer is consolidated.
+func (c *Collector) RecordConsumerLag(subscriptionName, subscriptionNamespace, eventType, consumerName string,
+	pending uint64, ackPending, redelivered int, oldestUnackedAge time.Duration,
+) {
+	labels := []string{subscriptionName, subscriptionNamespace, eventType, consumerName}
+	c.consumerPending.WithLabelValues(labels...).Set(float64(pending))
+	c.consumerAckPending.WithLabelValues(labels...).Set(float64(ackPending))
+	c.consumerRedelivered.WithLabelValues(labels...).Set(float64(redelivered))
+	c.consumerOldestUnacked.WithLabelValues(labels...).Set(oldestUnackedAge.Seconds())
+}
+
+// RemoveConsumerLag removes the eventing_ec_nats_consumer_* metrics of the consumer of an event type.
+func (c *Collector) RemoveConsumerLag(subscriptionName, subscriptionNamespace, eventType, consumerName string) {
+	labels := []string{subscriptionName, subscriptionNamespace, eventType, consumerName}
+	c.consumerPending.DeleteLabelValues(labels...)
+	c.consumerAckPending.DeleteLabelValues(labels...)
+	c.consumerRedelivered.DeleteLabelValues(labels...)
+	c.consumerOldestUnacked.DeleteLabelValues(labels...)
+}
+
+// RecordStreamUsage records the eventing_ec_nats_stream_* metrics. The usage of a limit is not recorded if the limit
+// is not positive, i.e. if the stream is unlimited.
+func (c *Collector) RecordStreamUsage(streamName string, messages, bytes uint64, maxMessages, maxBytes int64) {
+	c.streamMessages.WithLabelValues(streamName).Set(float64(messages))
+	c.streamBytes.WithLabelValues(streamName).Set(float64(bytes))
+	c.recordStreamLimitUsage(streamName, limitMessages, messages, maxMessages)
+	c.recordStreamLimitUsage(streamName, limitBytes, bytes, maxBytes)
+}
+
+// RemoveStreamUsage removes the eventing_ec_nats_stream_* metrics.
+func (c *Collector) RemoveStreamUsage(streamName string) {
+	c.streamMessages.DeleteLabelValues(streamName)
+	c.streamBytes.DeleteLabelValues(streamName)
+	c.streamLimitUsage.DeleteLabelValues(streamName, limitMessages)
+	c.streamLimitUsage.DeleteLabelValues(streamName, limitBytes)
+}
+
+func (c *Collector) recordStreamLimitUsage(streamName, limit string, usage uint64, maximum int64) {
+	if maximum <= 0 {
+		c.streamLimitUsage.DeleteLabelValues(streamName, limit)
+		return
+	}
+	c.streamLimitUsage.WithLabelValues(streamName, limit).Set(float64(usage) / float64(maximum))
+}
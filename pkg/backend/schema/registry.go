@@ -0,0 +1,146 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+
+	registryv1alpha1 "github.com/kyma-project/eventing-manager/api/registry/v1alpha1"
+)
+
+const versionSeparator = "."
+
+// Violation describes an event which does not conform to the schema registered for its type.
+type Violation struct {
+	// Policy is the validation policy of the EventType which registered the schema.
+	Policy registryv1alpha1.ValidationPolicy
+	// EventType is the key of the EventType which registered the schema.
+	EventType ktypes.NamespacedName
+	// Err is the validation error.
+	Err error
+}
+
+func (v *Violation) Error() string {
+	return fmt.Sprintf("event data does not conform to the schema of EventType %s: %v", v.EventType, v.Err)
+}
+
+// entry holds the compiled schemas of one EventType.
+type entry struct {
+	eventType string
+	source    string
+	policy    registryv1alpha1.ValidationPolicy
+	// validators maps the event type version to its schema validator.
+	validators map[string]*validate.SchemaValidator
+}
+
+// Registry holds the JSON Schemas registered by the EventType resources.
+// It is safe for concurrent use.
+type Registry struct {
+	mutex   sync.RWMutex
+	entries map[ktypes.NamespacedName]*entry
+}
+
+func NewRegistry() *Registry {
+	return &Registry{entries: make(map[ktypes.NamespacedName]*entry)}
+}
+
+// Set compiles and registers the schemas of the given EventType.
+// If any of the schemas is invalid, an error is returned and the previously registered schemas are kept.
+func (r *Registry) Set(eventType *registryv1alpha1.EventType) error {
+	e := &entry{
+		eventType:  eventType.Spec.Type,
+		source:     eventType.Spec.Source,
+		policy:     eventType.GetValidationPolicy(),
+		validators: make(map[string]*validate.SchemaValidator, len(eventType.Spec.Versions)),
+	}
+	for _, version := range eventType.Spec.Versions {
+		schema := &spec.Schema{}
+		if err := json.Unmarshal(version.Schema.Raw, schema); err != nil {
+			return fmt.Errorf("failed to parse the schema of version %s: %w", version.Name, err)
+		}
+		e.validators[version.Name] = validate.NewSchemaValidator(schema, nil, "", strfmt.Default)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.entries[ktypes.NamespacedName{Namespace: eventType.Namespace, Name: eventType.Name}] = e
+	return nil
+}
+
+// Delete removes the schemas registered by the EventType with the given key.
+func (r *Registry) Delete(key ktypes.NamespacedName) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	delete(r.entries, key)
+}
+
+// Validate validates the data of the given event against the schemas registered for its type and source.
+// It returns nil if the event conforms to all of them, or if there is no schema registered for it.
+// If the event violates the schemas of several EventTypes, the violation of the EventType with the lowest key
+// is returned, so that the applied policy does not depend on the order of the registration.
+// The event type is expected in the original form, e.g. order.created.v1.
+func (r *Registry) Validate(event *cloudevents.Event) *Violation {
+	index := strings.LastIndex(event.Type(), versionSeparator)
+	if index < 0 {
+		return nil
+	}
+	eventType, version := event.Type()[:index], event.Type()[index+1:]
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var data interface{}
+	var dataErr error
+	dataDecoded := false
+	for _, key := range r.matchingKeys(eventType, version, event.Source()) {
+		e := r.entries[key]
+		validator := e.validators[version]
+		// decode the data lazily and only once, since most of the events have no registered schema.
+		if !dataDecoded {
+			data, dataErr = decodeData(event)
+			dataDecoded = true
+		}
+		if dataErr != nil {
+			return &Violation{Policy: e.policy, EventType: key, Err: dataErr}
+		}
+		if result := validator.Validate(data); !result.IsValid() {
+			return &Violation{Policy: e.policy, EventType: key, Err: result.AsError()}
+		}
+	}
+	return nil
+}
+
+// matchingKeys returns the sorted keys of the EventTypes which registered a schema for the given event type, version,
+// and source. It must be called with the read lock held.
+func (r *Registry) matchingKeys(eventType, version, source string) []ktypes.NamespacedName {
+	var keys []ktypes.NamespacedName
+	for key, e := range r.entries {
+		if e.eventType != eventType || (e.source != "" && e.source != source) {
+			continue
+		}
+		if _, ok := e.validators[version]; ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+	return keys
+}
+
+func decodeData(event *cloudevents.Event) (interface{}, error) {
+	if len(event.Data()) == 0 {
+		return nil, nil //nolint:nilnil // an event without data is validated as null
+	}
+	var data interface{}
+	if err := json.Unmarshal(event.Data(), &data); err != nil {
+		return nil, fmt.Errorf("failed to decode the event data as JSON: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,197 @@
+package schema_test
+
+import (
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/stretchr/testify/require"
+	kapiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+
+	registryv1alpha1 "github.com/kyma-project/eventing-manager/api/registry/v1alpha1"
+	"github.com/kyma-project/eventing-manager/pkg/backend/schema"
+)
+
+const orderSchema = `{"type":"object","required":["orderId"],"properties":{"orderId":{"type":"string"}}}`
+
+func newEventType(source string, policy registryv1alpha1.ValidationPolicy, rawSchema string) *registryv1alpha1.EventType {
+	return &registryv1alpha1.EventType{
+		ObjectMeta: kmetav1.ObjectMeta{Name: "order-created", Namespace: "test"},
+		Spec: registryv1alpha1.EventTypeSpec{
+			Type:   "order.created",
+			Source: source,
+			Versions: []registryv1alpha1.EventTypeVersion{
+				{Name: "v1", Schema: kapiextensionsv1.JSON{Raw: []byte(rawSchema)}},
+			},
+			ValidationPolicy: policy,
+		},
+	}
+}
+
+func newEvent(t *testing.T, source, eventType, data string) *cloudevents.Event {
+	t.Helper()
+	event := cloudevents.NewEvent()
+	event.SetID("id")
+	event.SetSource(source)
+	event.SetType(eventType)
+	if data != "" {
+		require.NoError(t, event.SetData(cloudevents.ApplicationJSON, []byte(data)))
+	}
+	return &event
+}
+
+func TestRegistry_Validate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name            string
+		givenEventType  *registryv1alpha1.EventType
+		givenEvent      func(t *testing.T) *cloudevents.Event
+		wantViolation   bool
+		wantPolicy      registryv1alpha1.ValidationPolicy
+		wantErrContains string
+	}{
+		{
+			name:           "should accept a conforming event",
+			givenEventType: newEventType("", registryv1alpha1.ValidationPolicyDrop, orderSchema),
+			givenEvent: func(t *testing.T) *cloudevents.Event {
+				t.Helper()
+				return newEvent(t, "commerce", "order.created.v1", `{"orderId":"123"}`)
+			},
+		},
+		{
+			name:           "should reject an event missing a required property",
+			givenEventType: newEventType("", registryv1alpha1.ValidationPolicyDrop, orderSchema),
+			givenEvent: func(t *testing.T) *cloudevents.Event {
+				t.Helper()
+				return newEvent(t, "commerce", "order.created.v1", `{"amount":1}`)
+			},
+			wantViolation:   true,
+			wantPolicy:      registryv1alpha1.ValidationPolicyDrop,
+			wantErrContains: "orderId",
+		},
+		{
+			name:           "should reject an event with data which is not JSON",
+			givenEventType: newEventType("", registryv1alpha1.ValidationPolicyDeadLetter, orderSchema),
+			givenEvent: func(t *testing.T) *cloudevents.Event {
+				t.Helper()
+				return newEvent(t, "commerce", "order.created.v1", `not-json`)
+			},
+			wantViolation:   true,
+			wantPolicy:      registryv1alpha1.ValidationPolicyDeadLetter,
+			wantErrContains: "failed to decode the event data as JSON",
+		},
+		{
+			name:           "should use the default policy if it is not set",
+			givenEventType: newEventType("", "", orderSchema),
+			givenEvent: func(t *testing.T) *cloudevents.Event {
+				t.Helper()
+				return newEvent(t, "commerce", "order.created.v1", `{"orderId":1}`)
+			},
+			wantViolation: true,
+			wantPolicy:    registryv1alpha1.ValidationPolicyDeliverWithWarning,
+		},
+		{
+			name:           "should skip an event from another source",
+			givenEventType: newEventType("commerce", registryv1alpha1.ValidationPolicyDrop, orderSchema),
+			givenEvent: func(t *testing.T) *cloudevents.Event {
+				t.Helper()
+				return newEvent(t, "other", "order.created.v1", `{}`)
+			},
+		},
+		{
+			name:           "should skip an event with an unregistered version",
+			givenEventType: newEventType("", registryv1alpha1.ValidationPolicyDrop, orderSchema),
+			givenEvent: func(t *testing.T) *cloudevents.Event {
+				t.Helper()
+				return newEvent(t, "commerce", "order.created.v2", `{}`)
+			},
+		},
+		{
+			name:           "should skip an event with an unregistered type",
+			givenEventType: newEventType("", registryv1alpha1.ValidationPolicyDrop, orderSchema),
+			givenEvent: func(t *testing.T) *cloudevents.Event {
+				t.Helper()
+				return newEvent(t, "commerce", "order.deleted.v1", `{}`)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			registry := schema.NewRegistry()
+			require.NoError(t, registry.Set(tc.givenEventType))
+
+			// when
+			violation := registry.Validate(tc.givenEvent(t))
+
+			// then
+			if !tc.wantViolation {
+				require.Nil(t, violation)
+				return
+			}
+			require.NotNil(t, violation)
+			require.Equal(t, tc.wantPolicy, violation.Policy)
+			require.Equal(t, ktypes.NamespacedName{Namespace: "test", Name: "order-created"}, violation.EventType)
+			require.ErrorContains(t, violation, tc.wantErrContains)
+		})
+	}
+}
+
+func TestRegistry_SetAndDelete(t *testing.T) {
+	t.Parallel()
+
+	// given
+	registry := schema.NewRegistry()
+	eventType := newEventType("", registryv1alpha1.ValidationPolicyDrop, orderSchema)
+	event := newEvent(t, "commerce", "order.created.v1", `{}`)
+
+	// when
+	require.NoError(t, registry.Set(eventType))
+
+	// then
+	require.NotNil(t, registry.Validate(event))
+
+	// when
+	require.Error(t, registry.Set(newEventType("", registryv1alpha1.ValidationPolicyDrop, `not-json`)))
+
+	// then the previously registered schema is kept
+	require.NotNil(t, registry.Validate(event))
+
+	// when
+	registry.Delete(ktypes.NamespacedName{Namespace: eventType.Namespace, Name: eventType.Name})
+
+	// then
+	require.Nil(t, registry.Validate(event))
+}
+
+func TestRegistry_Validate_OverlappingEventTypes(t *testing.T) {
+	t.Parallel()
+
+	// given
+	registry := schema.NewRegistry()
+	for _, name := range []string{"order-created-c", "order-created-a", "order-created-b"} {
+		eventType := newEventType("", registryv1alpha1.ValidationPolicyDrop, orderSchema)
+		eventType.Name = name
+		if name == "order-created-a" {
+			eventType.Spec.ValidationPolicy = registryv1alpha1.ValidationPolicyDeadLetter
+		}
+		require.NoError(t, registry.Set(eventType))
+	}
+	event := newEvent(t, "commerce", "order.created.v1", `{}`)
+
+	for i := 0; i < 10; i++ {
+		// when
+		violation := registry.Validate(event)
+
+		// then the violation of the EventType with the lowest key is returned every time
+		require.NotNil(t, violation)
+		require.Equal(t, ktypes.NamespacedName{Namespace: "test", Name: "order-created-a"}, violation.EventType)
+		require.Equal(t, registryv1alpha1.ValidationPolicyDeadLetter, violation.Policy)
+	}
+}
@@ -0,0 +1,238 @@
+// Package sinkauth authenticates the requests dispatching the events to the sinks of the Subscriptions,
+// according to the webhook auth config of the Subscriptions.
+package sinkauth
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	kcorev1 "k8s.io/api/core/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	eventingv1alpha2 "github.com/kyma-project/eventing-manager/api/eventing/v1alpha2"
+	"github.com/kyma-project/eventing-manager/pkg/ems/api/events/types"
+)
+
+const (
+	// keys of the credentials in the webhook auth Secret.
+	SecretKeyToken    = "token"
+	SecretKeyUsername = "username"
+	SecretKeyPassword = "password"
+	SecretKeyHMAC     = "hmacKey"
+	SecretKeyCA       = "ca.crt"
+
+	// SignatureHeader is the header of the HMAC-SHA256 signature of the request, in the form sha256=<hex>.
+	// The signature is computed over the timestamp, a dot, and the request body.
+	SignatureHeader = "X-Eventing-Signature"
+	// SignatureTimestampHeader is the header of the Unix timestamp of the signature.
+	SignatureTimestampHeader = "X-Eventing-Signature-Timestamp"
+)
+
+var (
+	ErrMissingSecretKey   = errors.New("webhook auth Secret is missing a key")
+	ErrNoSecretReader     = errors.New("no reader for the webhook auth Secret")
+	ErrInvalidCertificate = errors.New("webhook auth Secret has an invalid certificate")
+)
+
+// Credentials are the webhook auth config of a Subscription, with the Secret holding its credentials if any.
+type Credentials struct {
+	authType types.AuthType
+	config   map[string]string
+	secret   *kcorev1.Secret
+}
+
+// GetCredentials returns the webhook auth credentials of the Subscription, reading its Secret with the given reader.
+// It returns nil if the Subscription has no webhook auth.
+func GetCredentials(ctx context.Context, reader client.Reader,
+	subscription *eventingv1alpha2.Subscription,
+) (*Credentials, error) {
+	authType, ok := subscription.Spec.Config[eventingv1alpha2.WebhookAuthType]
+	if !ok {
+		return nil, nil //nolint:nilnil // no webhook auth is configured.
+	}
+	credentials := &Credentials{authType: types.AuthType(authType), config: subscription.Spec.Config}
+	if !types.IsSecretAuthType(authType) {
+		return credentials, nil
+	}
+
+	if reader == nil {
+		return nil, ErrNoSecretReader
+	}
+	secret := &kcorev1.Secret{}
+	key := ktypes.NamespacedName{
+		Namespace: subscription.Namespace,
+		Name:      subscription.Spec.Config[eventingv1alpha2.WebhookAuthSecretName],
+	}
+	if err := reader.Get(ctx, key, secret); err != nil {
+		return nil, fmt.Errorf("failed to get the webhook auth Secret %s: %w", key, err)
+	}
+	credentials.secret = secret
+	return credentials, nil
+}
+
+// Version identifies the credentials, it changes whenever the webhook auth config or its Secret changes.
+func (c *Credentials) Version() string {
+	version := []string{string(c.authType)}
+	for _, key := range []string{
+		eventingv1alpha2.WebhookAuthClientID, eventingv1alpha2.WebhookAuthClientSecret,
+		eventingv1alpha2.WebhookAuthTokenURL, eventingv1alpha2.WebhookAuthScope,
+	} {
+		version = append(version, c.config[key])
+	}
+	if c.secret != nil {
+		version = append(version, string(c.secret.UID), c.secret.ResourceVersion)
+	}
+	return strings.Join(version, "/")
+}
+
+// Transport returns a RoundTripper which authenticates the requests with the credentials,
+// using the given transport to send them.
+func (c *Credentials) Transport(base *http.Transport) (http.RoundTripper, error) {
+	switch c.authType {
+	case types.AuthTypeBearer:
+		token, err := c.secretValue(SecretKeyToken)
+		if err != nil {
+			return nil, err
+		}
+		return &headerTransport{base: base, header: "Authorization", value: "Bearer " + string(token)}, nil
+	case types.AuthTypeBasic:
+		username, err := c.secretValue(SecretKeyUsername)
+		if err != nil {
+			return nil, err
+		}
+		password, err := c.secretValue(SecretKeyPassword)
+		if err != nil {
+			return nil, err
+		}
+		request := &http.Request{Header: http.Header{}}
+		request.SetBasicAuth(string(username), string(password))
+		return &headerTransport{base: base, header: "Authorization", value: request.Header.Get("Authorization")}, nil
+	case types.AuthTypeMTLS:
+		return c.mTLSTransport(base)
+	case types.AuthTypeHMAC:
+		key, err := c.secretValue(SecretKeyHMAC)
+		if err != nil {
+			return nil, err
+		}
+		return &signingTransport{base: base, key: key, now: time.Now}, nil
+	default:
+		return c.oauth2Transport(base), nil
+	}
+}
+
+// oauth2Transport returns a transport which fetches the tokens with the client credentials grant,
+// and caches them until they expire.
+func (c *Credentials) oauth2Transport(base *http.Transport) http.RoundTripper {
+	config := clientcredentials.Config{
+		ClientID:     c.config[eventingv1alpha2.WebhookAuthClientID],
+		ClientSecret: c.config[eventingv1alpha2.WebhookAuthClientSecret],
+		TokenURL:     c.config[eventingv1alpha2.WebhookAuthTokenURL],
+	}
+	if scope := c.config[eventingv1alpha2.WebhookAuthScope]; scope != "" {
+		config.Scopes = strings.Split(scope, ",")
+	}
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Transport: base})
+	return &oauth2.Transport{Source: config.TokenSource(ctx), Base: base}
+}
+
+// mTLSTransport returns a transport which presents the client certificate of the Secret,
+// and verifies the sink with the CA of the Secret if any.
+func (c *Credentials) mTLSTransport(base *http.Transport) (http.RoundTripper, error) {
+	certificate, err := c.secretValue(kcorev1.TLSCertKey)
+	if err != nil {
+		return nil, err
+	}
+	privateKey, err := c.secretValue(kcorev1.TLSPrivateKeyKey)
+	if err != nil {
+		return nil, err
+	}
+	keyPair, err := tls.X509KeyPair(certificate, privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCertificate, err)
+	}
+
+	transport := base.Clone()
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	transport.TLSClientConfig.Certificates = []tls.Certificate{keyPair}
+	if ca, ok := c.secret.Data[SecretKeyCA]; ok {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidCertificate, SecretKeyCA)
+		}
+		transport.TLSClientConfig.RootCAs = pool
+	}
+	return transport, nil
+}
+
+func (c *Credentials) secretValue(key string) ([]byte, error) {
+	value, ok := c.secret.Data[key]
+	if !ok || len(value) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrMissingSecretKey, key)
+	}
+	return value, nil
+}
+
+// headerTransport sets a static header on the requests.
+type headerTransport struct {
+	base   http.RoundTripper
+	header string
+	value  string
+}
+
+func (t *headerTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	request = request.Clone(request.Context())
+	request.Header.Set(t.header, t.value)
+	return t.base.RoundTrip(request)
+}
+
+// signingTransport signs the requests with HMAC-SHA256, so that the sinks can verify their origin.
+type signingTransport struct {
+	base http.RoundTripper
+	key  []byte
+	now  func() time.Time
+}
+
+func (t *signingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	var body []byte
+	if request.Body != nil {
+		var err error
+		if body, err = io.ReadAll(request.Body); err != nil {
+			return nil, err
+		}
+		if err = request.Body.Close(); err != nil {
+			return nil, err
+		}
+	}
+	request = request.Clone(request.Context())
+	request.Body = io.NopCloser(bytes.NewReader(body))
+
+	timestamp := strconv.FormatInt(t.now().Unix(), 10)
+	request.Header.Set(SignatureTimestampHeader, timestamp)
+	request.Header.Set(SignatureHeader, "sha256="+Sign(t.key, timestamp, body))
+	return t.base.RoundTrip(request)
+}
+
+// Sign returns the hex encoded HMAC-SHA256 signature of the request body with the given timestamp.
+func Sign(key []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
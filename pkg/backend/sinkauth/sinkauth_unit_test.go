@@ -0,0 +1,194 @@
+package sinkauth
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	kcorev1 "k8s.io/api/core/v1"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	eventingv1alpha2 "github.com/kyma-project/eventing-manager/api/eventing/v1alpha2"
+	eventingtesting "github.com/kyma-project/eventing-manager/testing"
+)
+
+const (
+	subNamespace = "test"
+	secretName   = "sink-credentials"
+)
+
+func Test_Transport(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		givenType  string
+		givenData  map[string][]byte
+		wantHeader func(t *testing.T, header http.Header, body string)
+		wantErr    error
+	}{
+		{
+			name:      "bearer auth should set the token",
+			givenType: "bearer",
+			givenData: map[string][]byte{SecretKeyToken: []byte("my-token")},
+			wantHeader: func(t *testing.T, header http.Header, _ string) {
+				t.Helper()
+				require.Equal(t, "Bearer my-token", header.Get("Authorization"))
+			},
+		},
+		{
+			name:      "basic auth should set the username and password",
+			givenType: "basic",
+			givenData: map[string][]byte{SecretKeyUsername: []byte("user"), SecretKeyPassword: []byte("pass")},
+			wantHeader: func(t *testing.T, header http.Header, _ string) {
+				t.Helper()
+				require.Equal(t, "Basic dXNlcjpwYXNz", header.Get("Authorization"))
+			},
+		},
+		{
+			name:      "hmac auth should sign the body",
+			givenType: "hmac",
+			givenData: map[string][]byte{SecretKeyHMAC: []byte("key")},
+			wantHeader: func(t *testing.T, header http.Header, body string) {
+				t.Helper()
+				timestamp := header.Get(SignatureTimestampHeader)
+				require.NotEmpty(t, timestamp)
+				require.Equal(t, "sha256="+Sign([]byte("key"), timestamp, []byte(body)), header.Get(SignatureHeader))
+			},
+		},
+		{
+			name:      "missing Secret key should return error",
+			givenType: "basic",
+			givenData: map[string][]byte{SecretKeyUsername: []byte("user")},
+			wantErr:   ErrMissingSecretKey,
+		},
+		{
+			name:      "invalid client certificate should return error",
+			givenType: "mtls",
+			givenData: map[string][]byte{
+				kcorev1.TLSCertKey:       []byte("invalid"),
+				kcorev1.TLSPrivateKeyKey: []byte("invalid"),
+			},
+			wantErr: ErrInvalidCertificate,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			var gotHeader http.Header
+			var gotBody string
+			sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				gotHeader, gotBody = r.Header, string(body)
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer sink.Close()
+
+			secret := &kcorev1.Secret{
+				ObjectMeta: kmetav1.ObjectMeta{Name: secretName, Namespace: subNamespace},
+				Data:       tc.givenData,
+			}
+			reader := fake.NewClientBuilder().WithObjects(secret).Build()
+			sub := eventingtesting.NewSubscription("sub", subNamespace,
+				eventingtesting.WithConfigValue(eventingv1alpha2.WebhookAuthType, tc.givenType),
+				eventingtesting.WithConfigValue(eventingv1alpha2.WebhookAuthSecretName, secretName),
+			)
+			credentials, err := GetCredentials(context.Background(), reader, sub)
+			require.NoError(t, err)
+
+			// when
+			transport, err := credentials.Transport(http.DefaultTransport.(*http.Transport).Clone())
+
+			// then
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			body := `{"foo":"bar"}`
+			response, err := (&http.Client{Transport: transport}).Post(sink.URL, "application/json",
+				strings.NewReader(body))
+			require.NoError(t, err)
+			require.NoError(t, response.Body.Close())
+			require.Equal(t, body, gotBody)
+			tc.wantHeader(t, gotHeader, gotBody)
+		})
+	}
+}
+
+func Test_OAuth2Transport(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var tokenRequests atomic.Int32
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"bearer","expires_in":3600}`,
+			tokenRequests.Add(1))
+	}))
+	defer tokenServer.Close()
+
+	var gotAuthorization []string
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuthorization = append(gotAuthorization, r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer sink.Close()
+
+	sub := eventingtesting.NewSubscription("sub", subNamespace,
+		eventingtesting.WithConfigValue(eventingv1alpha2.WebhookAuthType, "oauth2"),
+		eventingtesting.WithConfigValue(eventingv1alpha2.WebhookAuthClientID, "id"),
+		eventingtesting.WithConfigValue(eventingv1alpha2.WebhookAuthClientSecret, "secret"),
+		eventingtesting.WithConfigValue(eventingv1alpha2.WebhookAuthTokenURL, tokenServer.URL),
+	)
+	credentials, err := GetCredentials(context.Background(), nil, sub)
+	require.NoError(t, err)
+	transport, err := credentials.Transport(http.DefaultTransport.(*http.Transport).Clone())
+	require.NoError(t, err)
+
+	// when
+	for i := 0; i < 2; i++ {
+		response, err := (&http.Client{Transport: transport}).Get(sink.URL)
+		require.NoError(t, err)
+		require.NoError(t, response.Body.Close())
+	}
+
+	// then the token is fetched once and cached
+	require.Equal(t, []string{"Bearer token-1", "Bearer token-1"}, gotAuthorization)
+	require.Equal(t, int32(1), tokenRequests.Load())
+}
+
+func Test_GetCredentials(t *testing.T) {
+	t.Parallel()
+
+	// given
+	sub := eventingtesting.NewSubscription("sub", subNamespace)
+
+	// when
+	credentials, err := GetCredentials(context.Background(), nil, sub)
+
+	// then the subscription without webhook auth has no credentials
+	require.NoError(t, err)
+	require.Nil(t, credentials)
+
+	// when the Secret does not exist
+	sub = eventingtesting.NewSubscription("sub", subNamespace,
+		eventingtesting.WithConfigValue(eventingv1alpha2.WebhookAuthType, "bearer"),
+		eventingtesting.WithConfigValue(eventingv1alpha2.WebhookAuthSecretName, secretName),
+	)
+	_, err = GetCredentials(context.Background(), fake.NewClientBuilder().Build(), sub)
+
+	// then
+	require.Error(t, err)
+}
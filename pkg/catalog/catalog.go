@@ -0,0 +1,210 @@
+package catalog
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	eventingv1alpha2 "github.com/kyma-project/eventing-manager/api/eventing/v1alpha2"
+	backendjetstream "github.com/kyma-project/eventing-manager/pkg/backend/jetstream"
+	"github.com/kyma-project/eventing-manager/pkg/logger"
+)
+
+const (
+	catalogName = "event-catalog"
+
+	// subjectStatsTTL is how long the stream subject stats are cached, so that the requests to the catalog do not
+	// query the stream each time.
+	subjectStatsTTL = 30 * time.Second
+)
+
+// StreamInspector provides the subjects observed in the stream of the active backend.
+type StreamInspector interface {
+	// GetJetStreamSubject returns the stream subject of the given source and event type.
+	GetJetStreamSubject(source, subject string, typeMatching eventingv1alpha2.TypeMatching) string
+	// GetSubjectStats returns the stats of all the subjects stored in the stream.
+	GetSubjectStats() (map[string]backendjetstream.SubjectStats, error)
+}
+
+// EventTypeCatalog lists the known event types.
+type EventTypeCatalog struct {
+	EventTypes []EventType `json:"eventTypes"`
+}
+
+// EventType describes an event type known either from the Subscriptions or from the stream.
+type EventType struct {
+	// Source is the publisher of the event type, as specified in the Subscriptions.
+	Source string `json:"source,omitempty"`
+	// Type is the event type as specified in the Subscriptions.
+	// For event types observed only in the stream, it is the stream subject.
+	Type string `json:"type"`
+	// Subject is the event type as used on the backend.
+	Subject string `json:"subject,omitempty"`
+	// Subscribers lists the Subscriptions subscribing to the event type.
+	Subscribers []Subscriber `json:"subscribers,omitempty"`
+	// Messages is the number of messages stored in the stream for the event type.
+	Messages uint64 `json:"messages,omitempty"`
+	// LastSeen is the time the last message stored in the stream for the event type was published.
+	LastSeen *time.Time `json:"lastSeen,omitempty"`
+	// LastSeenSkipped is true if the time the last message was published was not looked up, since the stream stores
+	// too many subjects.
+	LastSeenSkipped bool `json:"lastSeenSkipped,omitempty"`
+}
+
+// Subscriber describes a Subscription subscribing to an event type.
+type Subscriber struct {
+	Namespace    string `json:"namespace"`
+	Name         string `json:"name"`
+	Sink         string `json:"sink"`
+	ConsumerName string `json:"consumerName,omitempty"`
+}
+
+// Catalog aggregates the event types from all Subscriptions and from the stream of the active backend.
+// It is safe for concurrent use.
+type Catalog struct {
+	client    client.Reader
+	logger    *logger.Logger
+	mutex     sync.RWMutex
+	inspector StreamInspector
+
+	// statsMutex guards the cached subject stats, and is held while they are fetched, so that concurrent requests
+	// query the stream once.
+	statsMutex   sync.Mutex
+	stats        map[string]backendjetstream.SubjectStats
+	statsFetched time.Time
+	now          func() time.Time
+}
+
+func New(client client.Reader, logger *logger.Logger) *Catalog {
+	return &Catalog{client: client, logger: logger, now: time.Now}
+}
+
+// SetStreamInspector sets the inspector of the active backend stream, or removes it if nil is given.
+func (c *Catalog) SetStreamInspector(inspector StreamInspector) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.inspector = inspector
+
+	c.statsMutex.Lock()
+	defer c.statsMutex.Unlock()
+	c.stats = nil
+}
+
+func (c *Catalog) getStreamInspector() StreamInspector {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.inspector
+}
+
+// List returns the event types sorted by source and type.
+func (c *Catalog) List(ctx context.Context) (*EventTypeCatalog, error) {
+	subscriptions := &eventingv1alpha2.SubscriptionList{}
+	if err := c.client.List(ctx, subscriptions); err != nil {
+		return nil, err
+	}
+
+	inspector := c.getStreamInspector()
+	entries := map[string]*EventType{}
+	for _, sub := range subscriptions.Items {
+		consumers := make(map[string]string, len(sub.Status.Backend.Types))
+		for _, jsType := range sub.Status.Backend.Types {
+			consumers[jsType.OriginalType] = jsType.ConsumerName
+		}
+		eventMeshTypes := make(map[string]string, len(sub.Status.Backend.EmsTypes))
+		for _, emsType := range sub.Status.Backend.EmsTypes {
+			eventMeshTypes[emsType.OriginalType] = emsType.EventMeshType
+		}
+
+		for _, eventType := range sub.Status.Types {
+			key := sub.Spec.Source + "/" + eventType.OriginalType
+			entry, ok := entries[key]
+			if !ok {
+				entry = &EventType{Source: sub.Spec.Source, Type: eventType.OriginalType}
+				entries[key] = entry
+			}
+			if inspector != nil {
+				entry.Subject = inspector.GetJetStreamSubject(sub.Spec.Source, eventType.CleanType, sub.Spec.TypeMatching)
+			} else if eventMeshType, found := eventMeshTypes[eventType.OriginalType]; found {
+				entry.Subject = eventMeshType
+			}
+			entry.Subscribers = append(entry.Subscribers, Subscriber{
+				Namespace:    sub.Namespace,
+				Name:         sub.Name,
+				Sink:         sub.Spec.Sink,
+				ConsumerName: consumers[eventType.OriginalType],
+			})
+		}
+	}
+
+	if inspector != nil {
+		c.addSubjectStats(inspector, entries)
+	}
+
+	result := &EventTypeCatalog{EventTypes: make([]EventType, 0, len(entries))}
+	for _, entry := range entries {
+		result.EventTypes = append(result.EventTypes, *entry)
+	}
+	sort.Slice(result.EventTypes, func(i, j int) bool {
+		if result.EventTypes[i].Source != result.EventTypes[j].Source {
+			return result.EventTypes[i].Source < result.EventTypes[j].Source
+		}
+		return result.EventTypes[i].Type < result.EventTypes[j].Type
+	})
+	return result, nil
+}
+
+// addSubjectStats adds the stream stats to the entries with a matching subject,
+// and adds an entry for each subject which is not subscribed.
+func (c *Catalog) addSubjectStats(inspector StreamInspector, entries map[string]*EventType) {
+	stats, err := c.getSubjectStats(inspector)
+	if err != nil {
+		// the event types from the Subscriptions are still listed.
+		c.namedLogger().Errorw("Failed to get the stream subject stats", "error", err)
+		return
+	}
+
+	bySubject := make(map[string][]*EventType, len(entries))
+	for _, entry := range entries {
+		bySubject[entry.Subject] = append(bySubject[entry.Subject], entry)
+	}
+	for subject, subjectStats := range stats {
+		subjectStats := subjectStats
+		subscribed, ok := bySubject[subject]
+		if !ok {
+			entry := &EventType{Type: subject, Subject: subject}
+			entries["/"+subject] = entry
+			subscribed = []*EventType{entry}
+		}
+		for _, entry := range subscribed {
+			entry.Messages = subjectStats.Messages
+			if !subjectStats.LastSeen.IsZero() {
+				entry.LastSeen = &subjectStats.LastSeen
+			}
+			entry.LastSeenSkipped = subjectStats.LastSeenSkipped
+		}
+	}
+}
+
+// getSubjectStats returns the subject stats of the stream, which are cached for subjectStatsTTL.
+func (c *Catalog) getSubjectStats(inspector StreamInspector) (map[string]backendjetstream.SubjectStats, error) {
+	c.statsMutex.Lock()
+	defer c.statsMutex.Unlock()
+
+	if c.stats != nil && c.now().Sub(c.statsFetched) < subjectStatsTTL {
+		return c.stats, nil
+	}
+	stats, err := inspector.GetSubjectStats()
+	if err != nil {
+		return nil, err
+	}
+	c.stats, c.statsFetched = stats, c.now()
+	return stats, nil
+}
+
+func (c *Catalog) namedLogger() *zap.SugaredLogger {
+	return c.logger.WithContext().Named(catalogName)
+}
@@ -0,0 +1,277 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	kymalogger "github.com/kyma-project/kyma/common/logging/logger"
+	"github.com/stretchr/testify/require"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	eventingv1alpha2 "github.com/kyma-project/eventing-manager/api/eventing/v1alpha2"
+	backendjetstream "github.com/kyma-project/eventing-manager/pkg/backend/jetstream"
+	"github.com/kyma-project/eventing-manager/pkg/eventstore"
+	"github.com/kyma-project/eventing-manager/pkg/logger"
+)
+
+type stubInspector struct {
+	stats map[string]backendjetstream.SubjectStats
+	err   error
+}
+
+func (s stubInspector) GetJetStreamSubject(source, subject string, typeMatching eventingv1alpha2.TypeMatching) string {
+	if typeMatching == eventingv1alpha2.TypeMatchingExact {
+		return "kyma." + subject
+	}
+	return "kyma." + source + "." + subject
+}
+
+func (s stubInspector) GetSubjectStats() (map[string]backendjetstream.SubjectStats, error) {
+	return s.stats, s.err
+}
+
+// countingInspector counts the calls of GetSubjectStats.
+type countingInspector struct {
+	stubInspector
+	calls int
+}
+
+func (c *countingInspector) GetSubjectStats() (map[string]backendjetstream.SubjectStats, error) {
+	c.calls++
+	return c.stubInspector.GetSubjectStats()
+}
+
+type stubAuthorizer struct {
+	err error
+}
+
+func (s stubAuthorizer) Authorize(_ context.Context, _, namespace, name string) error {
+	if namespace != "" || name != "" {
+		return errors.New("the catalog must be authorized for all the namespaces")
+	}
+	return s.err
+}
+
+func newSubscription(name, source string, types ...string) *eventingv1alpha2.Subscription {
+	sub := &eventingv1alpha2.Subscription{
+		ObjectMeta: kmetav1.ObjectMeta{Name: name, Namespace: "test"},
+		Spec: eventingv1alpha2.SubscriptionSpec{
+			Sink:   "http://" + name + ".test.svc.cluster.local",
+			Source: source,
+			Types:  types,
+		},
+	}
+	for _, eventType := range types {
+		sub.Status.Types = append(sub.Status.Types, eventingv1alpha2.EventType{OriginalType: eventType, CleanType: eventType})
+		sub.Status.Backend.Types = append(sub.Status.Backend.Types,
+			eventingv1alpha2.JetStreamTypes{OriginalType: eventType, ConsumerName: name + "-consumer"})
+	}
+	return sub
+}
+
+func newCatalog(t *testing.T, subs ...*eventingv1alpha2.Subscription) *Catalog {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, eventingv1alpha2.AddToScheme(scheme))
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	for _, sub := range subs {
+		builder = builder.WithObjects(sub)
+	}
+	defaultLogger, err := logger.New(string(kymalogger.JSON), string(kymalogger.INFO))
+	require.NoError(t, err)
+	return New(builder.Build(), defaultLogger)
+}
+
+func TestCatalog_List(t *testing.T) {
+	t.Parallel()
+
+	lastSeen := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		givenInspector StreamInspector
+		wantEventTypes []EventType
+	}{
+		{
+			name: "should list the event types from the Subscriptions only if there is no stream",
+			wantEventTypes: []EventType{
+				{
+					Source: "commerce", Type: "order.created.v1",
+					Subscribers: []Subscriber{
+						{Namespace: "test", Name: "sub1", Sink: "http://sub1.test.svc.cluster.local", ConsumerName: "sub1-consumer"},
+						{Namespace: "test", Name: "sub2", Sink: "http://sub2.test.svc.cluster.local", ConsumerName: "sub2-consumer"},
+					},
+				},
+				{
+					Source: "commerce", Type: "order.deleted.v1",
+					Subscribers: []Subscriber{
+						{Namespace: "test", Name: "sub2", Sink: "http://sub2.test.svc.cluster.local", ConsumerName: "sub2-consumer"},
+					},
+				},
+			},
+		},
+		{
+			name: "should add the stream stats and the subjects which are not subscribed",
+			givenInspector: stubInspector{stats: map[string]backendjetstream.SubjectStats{
+				"kyma.commerce.order.created.v1": {Messages: 3, LastSeen: lastSeen},
+				"kyma.noapp.order.paid.v1":       {Messages: 1, LastSeenSkipped: true},
+			}},
+			wantEventTypes: []EventType{
+				{Type: "kyma.noapp.order.paid.v1", Subject: "kyma.noapp.order.paid.v1", Messages: 1, LastSeenSkipped: true},
+				{
+					Source: "commerce", Type: "order.created.v1", Subject: "kyma.commerce.order.created.v1",
+					Subscribers: []Subscriber{
+						{Namespace: "test", Name: "sub1", Sink: "http://sub1.test.svc.cluster.local", ConsumerName: "sub1-consumer"},
+						{Namespace: "test", Name: "sub2", Sink: "http://sub2.test.svc.cluster.local", ConsumerName: "sub2-consumer"},
+					},
+					Messages: 3,
+					LastSeen: &lastSeen,
+				},
+				{
+					Source: "commerce", Type: "order.deleted.v1", Subject: "kyma.commerce.order.deleted.v1",
+					Subscribers: []Subscriber{
+						{Namespace: "test", Name: "sub2", Sink: "http://sub2.test.svc.cluster.local", ConsumerName: "sub2-consumer"},
+					},
+				},
+			},
+		},
+		{
+			name:           "should list the event types from the Subscriptions if the stream stats are not available",
+			givenInspector: stubInspector{err: errors.New("not connected")},
+			wantEventTypes: []EventType{
+				{
+					Source: "commerce", Type: "order.created.v1", Subject: "kyma.commerce.order.created.v1",
+					Subscribers: []Subscriber{
+						{Namespace: "test", Name: "sub1", Sink: "http://sub1.test.svc.cluster.local", ConsumerName: "sub1-consumer"},
+						{Namespace: "test", Name: "sub2", Sink: "http://sub2.test.svc.cluster.local", ConsumerName: "sub2-consumer"},
+					},
+				},
+				{
+					Source: "commerce", Type: "order.deleted.v1", Subject: "kyma.commerce.order.deleted.v1",
+					Subscribers: []Subscriber{
+						{Namespace: "test", Name: "sub2", Sink: "http://sub2.test.svc.cluster.local", ConsumerName: "sub2-consumer"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			catalog := newCatalog(t,
+				newSubscription("sub1", "commerce", "order.created.v1"),
+				newSubscription("sub2", "commerce", "order.created.v1", "order.deleted.v1"),
+			)
+			catalog.SetStreamInspector(tc.givenInspector)
+
+			// when
+			got, err := catalog.List(context.Background())
+
+			// then
+			require.NoError(t, err)
+			require.Equal(t, tc.wantEventTypes, got.EventTypes)
+		})
+	}
+}
+
+func TestCatalog_List_CachesSubjectStats(t *testing.T) {
+	t.Parallel()
+
+	// given
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	catalog := newCatalog(t, newSubscription("sub1", "commerce", "order.created.v1"))
+	catalog.now = func() time.Time { return now }
+	inspector := &countingInspector{stubInspector: stubInspector{
+		stats: map[string]backendjetstream.SubjectStats{"kyma.commerce.order.created.v1": {Messages: 1}},
+	}}
+	catalog.SetStreamInspector(inspector)
+
+	// when
+	_, err := catalog.List(context.Background())
+	require.NoError(t, err)
+	_, err = catalog.List(context.Background())
+	require.NoError(t, err)
+
+	// then the stream is queried once
+	require.Equal(t, 1, inspector.calls)
+
+	// when the cached stats expire
+	now = now.Add(subjectStatsTTL)
+	_, err = catalog.List(context.Background())
+	require.NoError(t, err)
+
+	// then the stream is queried again
+	require.Equal(t, 2, inspector.calls)
+}
+
+func TestServer_handleEventTypes(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name            string
+		givenMethod     string
+		givenAuthorizer stubAuthorizer
+		wantStatus      int
+	}{
+		{
+			name:        "should list the event types for an authorized user",
+			givenMethod: http.MethodGet,
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:            "should reject an unauthenticated user",
+			givenMethod:     http.MethodGet,
+			givenAuthorizer: stubAuthorizer{err: eventstore.ErrUnauthenticated},
+			wantStatus:      http.StatusUnauthorized,
+		},
+		{
+			name:            "should reject an unauthorized user",
+			givenMethod:     http.MethodGet,
+			givenAuthorizer: stubAuthorizer{err: eventstore.ErrForbidden},
+			wantStatus:      http.StatusForbidden,
+		},
+		{
+			name:        "should reject other methods",
+			givenMethod: http.MethodPost,
+			wantStatus:  http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			server := NewServer(":0", newCatalog(t, newSubscription("sub1", "commerce", "order.created.v1")),
+				tc.givenAuthorizer)
+			request := httptest.NewRequest(tc.givenMethod, EventTypesPath, nil)
+			request.Header.Set("Authorization", "Bearer token")
+
+			// when
+			recorder := httptest.NewRecorder()
+			server.handleEventTypes(recorder, request)
+
+			// then
+			require.Equal(t, tc.wantStatus, recorder.Code)
+			if tc.wantStatus != http.StatusOK {
+				return
+			}
+			got := EventTypeCatalog{}
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+			require.Len(t, got.EventTypes, 1)
+			require.Equal(t, "order.created.v1", got.EventTypes[0].Type)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/kyma-project/eventing-manager/pkg/eventstore"
+)
+
+const (
+	// EventTypesPath is the path of the endpoint listing the event types.
+	EventTypesPath = "/v1/eventtypes"
+
+	readHeaderTimeout = 5 * time.Second
+	shutdownTimeout   = 5 * time.Second
+)
+
+// Perform a compile-time check.
+var _ manager.Runnable = &Server{}
+
+// Server serves the read-only HTTP endpoints of the Catalog.
+// The catalog lists the Subscriptions of all the namespaces, so the users must be allowed to browse the events of
+// all the Subscriptions, see eventstore.Authorizer.
+type Server struct {
+	addr       string
+	catalog    *Catalog
+	authorizer eventstore.Authorizer
+}
+
+func NewServer(addr string, catalog *Catalog, authorizer eventstore.Authorizer) *Server {
+	return &Server{addr: addr, catalog: catalog, authorizer: authorizer}
+}
+
+// Start starts the server and blocks until the given context is done.
+func (s *Server) Start(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc(EventTypesPath, s.handleEventTypes)
+	server := &http.Server{Addr: s.addr, Handler: mux, ReadHeaderTimeout: readHeaderTimeout}
+
+	errChan := make(chan error, 1)
+	go func() {
+		s.catalog.namedLogger().Infow("Starting the event catalog server", "address", s.addr)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errChan <- err
+		}
+		close(errChan)
+	}()
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
+
+// NeedLeaderElection implements the manager.LeaderElectionRunnable interface.
+// The catalog is read-only, so it is served by all the replicas.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+func (s *Server) handleEventTypes(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	// an empty namespace and name authorize the access to the Subscriptions of all the namespaces.
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if err := s.authorizer.Authorize(r.Context(), token, "", ""); err != nil {
+		switch {
+		case errors.Is(err, eventstore.ErrUnauthenticated):
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+		case errors.Is(err, eventstore.ErrForbidden):
+			http.Error(w, err.Error(), http.StatusForbidden)
+		default:
+			s.catalog.namedLogger().Errorw("Failed to authorize the user", "error", err)
+			http.Error(w, "failed to authorize the user", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	eventTypes, err := s.catalog.List(r.Context())
+	if err != nil {
+		s.catalog.namedLogger().Errorw("Failed to list the event types", "error", err)
+		http.Error(w, "failed to list the event types", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(eventTypes); err != nil {
+		s.catalog.namedLogger().Errorw("Failed to write the event types", "error", err)
+	}
+}
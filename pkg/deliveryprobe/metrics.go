@@ -0,0 +1,70 @@
+package deliveryprobe
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// probesMetricKey name of the delivery probes metric.
+	probesMetricKey = "eventing_ec_delivery_probe_total"
+	// probesMetricHelp help text for the delivery probes metric.
+	probesMetricHelp = "The total number of canary events published by the delivery probe per result"
+
+	// latencyMetricKey name of the delivery probe latency metric.
+	latencyMetricKey = "eventing_ec_delivery_probe_latency_seconds"
+	// latencyMetricHelp help text for the delivery probe latency metric.
+	latencyMetricHelp = "The end-to-end latency of the delivered canary events, from publishing to receiving them"
+
+	backendLabel = "eventing_backend"
+	resultLabel  = "result"
+
+	resultSuccess = "success"
+	resultFailure = "failure"
+)
+
+// collector exposes the results of the delivery probes.
+type collector struct {
+	probes  *prometheus.CounterVec
+	latency *prometheus.HistogramVec
+}
+
+func newCollector() *collector {
+	const (
+		// the latency buckets start at 5ms and end at about 40s.
+		bucketMin    = 0.005
+		bucketFactor = 2
+		bucketCount  = 14
+	)
+	return &collector{
+		probes: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: probesMetricKey,
+				Help: probesMetricHelp,
+			},
+			[]string{backendLabel, resultLabel},
+		),
+		latency: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    latencyMetricKey,
+				Help:    latencyMetricHelp,
+				Buckets: prometheus.ExponentialBuckets(bucketMin, bucketFactor, bucketCount),
+			},
+			[]string{backendLabel},
+		),
+	}
+}
+
+func (c *collector) register() {
+	metrics.Registry.MustRegister(c.probes, c.latency)
+}
+
+// record records the result of a delivery probe of the given backend.
+func (c *collector) record(backend string, result Result) {
+	if result.Err != nil {
+		c.probes.WithLabelValues(backend, resultFailure).Inc()
+		return
+	}
+	c.probes.WithLabelValues(backend, resultSuccess).Inc()
+	c.latency.WithLabelValues(backend).Observe(result.Latency.Seconds())
+}
@@ -0,0 +1,294 @@
+// Package deliveryprobe probes the end-to-end event delivery. It periodically publishes a canary event through
+// Eventing Publisher Proxy to a Subscription served by the Prober itself, and measures whether and how fast the event
+// is delivered by the active backend.
+package deliveryprobe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/kyma-project/eventing-manager/pkg/cloudevent"
+	"github.com/kyma-project/eventing-manager/pkg/logger"
+)
+
+const (
+	proberName = "delivery-probe"
+
+	// EventSource is the source of the canary events.
+	EventSource = "eventing-manager"
+	// EventType is the type of the canary events.
+	EventType = "delivery.probe.v1"
+
+	// idleInterval is the interval at which a disabled Prober checks whether it got enabled.
+	idleInterval = 10 * time.Second
+
+	readHeaderTimeout = 5 * time.Second
+	shutdownTimeout   = 5 * time.Second
+)
+
+var (
+	ErrPublishFailed = errors.New("failed to publish the canary event")
+	ErrNotDelivered  = errors.New("the canary event was not delivered")
+)
+
+// Perform a compile-time check.
+var (
+	_ manager.Runnable               = &Prober{}
+	_ manager.LeaderElectionRunnable = &Prober{}
+)
+
+// Target is the configuration the Prober probes the event delivery with.
+type Target struct {
+	// PublishURL is the URL of Eventing Publisher Proxy the canary events are published to.
+	PublishURL string
+	// Backend is the active backend, which labels the metrics.
+	Backend  string
+	Interval time.Duration
+	Timeout  time.Duration
+}
+
+// Result is the result of a delivery probe, Err is nil if the canary event was delivered within the timeout.
+type Result struct {
+	Time    time.Time
+	Latency time.Duration
+	Err     error
+}
+
+// Prober publishes the canary events and serves the sink of their Subscription. It is disabled until it is
+// configured with a Target by the Eventing reconciler, which also creates the Subscription of the canary events.
+//
+// Only the leader runs the Prober, so the Endpoints of the sink Service point to the leader Pod only.
+type Prober struct {
+	addr    string
+	client  cloudevent.Client
+	logger  *logger.Logger
+	metrics *collector
+
+	mutex      sync.Mutex
+	target     *Target
+	notify     func()
+	lastResult *Result
+	// pending holds the channels receiving the delivery time of the canary events being probed, by their ID.
+	pending map[string]chan time.Time
+}
+
+func NewProber(addr string, logger *logger.Logger) (*Prober, error) {
+	client, err := cloudevent.ClientFactory{}.NewHTTP()
+	if err != nil {
+		return nil, err
+	}
+	return &Prober{
+		addr:    addr,
+		client:  client,
+		logger:  logger,
+		metrics: newCollector(),
+		pending: make(map[string]chan time.Time),
+	}, nil
+}
+
+// RegisterMetrics registers the metrics of the delivery probes.
+func (p *Prober) RegisterMetrics() {
+	p.metrics.register()
+}
+
+// Port returns the port the sink of the canary events is served on.
+func (p *Prober) Port() (int32, error) {
+	_, port, err := net.SplitHostPort(p.addr)
+	if err != nil {
+		return 0, err
+	}
+	portNum, err := strconv.ParseInt(port, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return int32(portNum), nil
+}
+
+// Configure enables the Prober with the given Target. The notify func is called whenever a probe result differs from
+// the previous one in its success.
+func (p *Prober) Configure(target Target, notify func()) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.target = &target
+	p.notify = notify
+}
+
+// Disable disables the Prober and forgets its last result.
+func (p *Prober) Disable() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.target = nil
+	p.notify = nil
+	p.lastResult = nil
+}
+
+// LastResult returns the result of the last delivery probe, if any.
+func (p *Prober) LastResult() (Result, bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.lastResult == nil {
+		return Result{}, false
+	}
+	return *p.lastResult, true
+}
+
+// Start serves the sink of the canary events and probes the event delivery periodically until the given context is
+// done.
+func (p *Prober) Start(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", p.handleEvent)
+	server := &http.Server{Addr: p.addr, Handler: mux, ReadHeaderTimeout: readHeaderTimeout}
+
+	errChan := make(chan error, 1)
+	go func() {
+		p.namedLogger().Infow("Starting the delivery probe sink", "address", p.addr)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errChan <- err
+		}
+		close(errChan)
+	}()
+
+	for {
+		interval := idleInterval
+		if target, found := p.Target(); found {
+			p.probe(ctx, target)
+			interval = target.Interval
+		}
+
+		select {
+		case err := <-errChan:
+			return err
+		case <-ctx.Done():
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+			defer cancel()
+			return server.Shutdown(shutdownCtx)
+		case <-time.After(interval):
+		}
+	}
+}
+
+// NeedLeaderElection implements the manager.LeaderElectionRunnable interface.
+func (p *Prober) NeedLeaderElection() bool {
+	return true
+}
+
+// probe publishes a canary event to the given Target and waits until it is delivered or the timeout is exceeded.
+func (p *Prober) probe(ctx context.Context, target Target) {
+	id := uuid.NewString()
+	delivered := p.expect(id)
+	defer p.forget(id)
+
+	probeCtx, cancel := context.WithTimeout(ctx, target.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	result := Result{Time: start}
+	if err := p.publish(probeCtx, target.PublishURL, id); err != nil {
+		result.Err = err
+	} else {
+		select {
+		case deliveredAt := <-delivered:
+			result.Latency = deliveredAt.Sub(start)
+		case <-probeCtx.Done():
+			result.Err = fmt.Errorf("%w within %v", ErrNotDelivered, target.Timeout)
+		}
+	}
+
+	// the probe is not conclusive if the Prober is stopped.
+	if ctx.Err() != nil {
+		return
+	}
+	if result.Err != nil {
+		p.namedLogger().Warnw("Delivery probe failed", "id", id, "error", result.Err)
+	}
+	p.metrics.record(target.Backend, result)
+	p.setLastResult(result)
+}
+
+func (p *Prober) publish(ctx context.Context, url, id string) error {
+	event := cloudevents.NewEvent()
+	event.SetID(id)
+	event.SetSource(EventSource)
+	event.SetType(EventType)
+	if err := event.SetData(cloudevents.ApplicationJSON, map[string]string{"probe": id}); err != nil {
+		return err
+	}
+
+	if result := p.client.Send(cloudevents.ContextWithTarget(ctx, url), event); !cloudevents.IsACK(result) {
+		return fmt.Errorf("%w: %w", ErrPublishFailed, result)
+	}
+	return nil
+}
+
+// handleEvent receives the canary events. It acknowledges all the events, even the unexpected ones, so that they are
+// not redelivered.
+func (p *Prober) handleEvent(w http.ResponseWriter, r *http.Request) {
+	event, err := binding.ToEvent(r.Context(), cehttp.NewMessageFromHttpRequest(r))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	p.mutex.Lock()
+	if delivered, found := p.pending[event.ID()]; found {
+		delivered <- time.Now()
+		delete(p.pending, event.ID())
+	}
+	p.mutex.Unlock()
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (p *Prober) expect(id string) <-chan time.Time {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	delivered := make(chan time.Time, 1)
+	p.pending[id] = delivered
+	return delivered
+}
+
+func (p *Prober) forget(id string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	delete(p.pending, id)
+}
+
+// Target returns the Target the Prober is configured with, if it is enabled.
+func (p *Prober) Target() (Target, bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.target == nil {
+		return Target{}, false
+	}
+	return *p.target, true
+}
+
+// setLastResult sets the last result, and notifies if it differs from the previous one in its success.
+func (p *Prober) setLastResult(result Result) {
+	p.mutex.Lock()
+	changed := p.lastResult == nil || (p.lastResult.Err == nil) != (result.Err == nil)
+	p.lastResult = &result
+	notify := p.notify
+	p.mutex.Unlock()
+
+	if changed && notify != nil {
+		notify()
+	}
+}
+
+func (p *Prober) namedLogger() *zap.SugaredLogger {
+	return p.logger.WithContext().Named(proberName)
+}
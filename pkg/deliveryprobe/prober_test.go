@@ -0,0 +1,153 @@
+package deliveryprobe
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	kymalogger "github.com/kyma-project/kyma/common/logging/logger"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/eventing-manager/pkg/logger"
+)
+
+func newProber(t *testing.T) *Prober {
+	t.Helper()
+	defaultLogger, err := logger.New(string(kymalogger.JSON), string(kymalogger.INFO))
+	require.NoError(t, err)
+	prober, err := NewProber(":8083", defaultLogger)
+	require.NoError(t, err)
+	return prober
+}
+
+// newPublisher returns a fake Eventing Publisher Proxy, which responds with the given status code, and delivers the
+// accepted events to the sink of the given Prober if deliver is true.
+func newPublisher(t *testing.T, prober *Prober, statusCode int, deliver bool) *httptest.Server {
+	t.Helper()
+	sink := httptest.NewServer(http.HandlerFunc(prober.handleEvent))
+	t.Cleanup(sink.Close)
+
+	publisher := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(statusCode)
+		if !deliver || statusCode >= http.StatusMultipleChoices {
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return
+		}
+		request, err := http.NewRequest(http.MethodPost, sink.URL, bytes.NewReader(body))
+		if err != nil {
+			return
+		}
+		request.Header = r.Header.Clone()
+		if response, err := http.DefaultClient.Do(request); err == nil {
+			_ = response.Body.Close()
+		}
+	}))
+	t.Cleanup(publisher.Close)
+	return publisher
+}
+
+func Test_probe(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name             string
+		givenStatusCode  int
+		givenDeliver     bool
+		wantError        error
+		wantResultMetric string
+	}{
+		{
+			name:             "should succeed if the canary event is delivered",
+			givenStatusCode:  http.StatusNoContent,
+			givenDeliver:     true,
+			wantResultMetric: resultSuccess,
+		},
+		{
+			name:             "should fail if the canary event cannot be published",
+			givenStatusCode:  http.StatusInternalServerError,
+			wantError:        ErrPublishFailed,
+			wantResultMetric: resultFailure,
+		},
+		{
+			name:             "should fail if the canary event is not delivered",
+			givenStatusCode:  http.StatusNoContent,
+			givenDeliver:     false,
+			wantError:        ErrNotDelivered,
+			wantResultMetric: resultFailure,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			prober := newProber(t)
+			publisher := newPublisher(t, prober, tc.givenStatusCode, tc.givenDeliver)
+			notified := 0
+			target := Target{
+				PublishURL: publisher.URL,
+				Backend:    "NATS",
+				Interval:   time.Minute,
+				Timeout:    200 * time.Millisecond,
+			}
+			prober.Configure(target, func() { notified++ })
+
+			// when
+			prober.probe(context.Background(), target)
+			prober.probe(context.Background(), target)
+
+			// then
+			result, found := prober.LastResult()
+			require.True(t, found)
+			require.ErrorIs(t, result.Err, tc.wantError)
+			require.Equal(t, 1, notified, "only the first result must be notified")
+			require.Equal(t, float64(2), testutil.ToFloat64(
+				prober.metrics.probes.WithLabelValues(target.Backend, tc.wantResultMetric)))
+			require.Empty(t, prober.pending)
+		})
+	}
+}
+
+func Test_Disable(t *testing.T) {
+	t.Parallel()
+
+	// given
+	prober := newProber(t)
+	publisher := newPublisher(t, prober, http.StatusNoContent, true)
+	target := Target{PublishURL: publisher.URL, Interval: time.Minute, Timeout: time.Second}
+	prober.Configure(target, nil)
+	prober.probe(context.Background(), target)
+
+	// when
+	prober.Disable()
+
+	// then
+	_, found := prober.LastResult()
+	require.False(t, found)
+	_, found = prober.Target()
+	require.False(t, found)
+}
+
+func Test_Port(t *testing.T) {
+	t.Parallel()
+
+	// given
+	prober := newProber(t)
+
+	// when
+	port, err := prober.Port()
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, int32(8083), port)
+}
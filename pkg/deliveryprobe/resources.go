@@ -0,0 +1,116 @@
+package deliveryprobe
+
+import (
+	"fmt"
+
+	kcorev1 "k8s.io/api/core/v1"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	eventingv1alpha2 "github.com/kyma-project/eventing-manager/api/eventing/v1alpha2"
+	"github.com/kyma-project/eventing-manager/internal/label"
+)
+
+const (
+	nameSuffix  = "delivery-probe"
+	portName    = "http-probe"
+	servicePort = 80
+)
+
+// ResourceName returns the name of the Service, the Endpoints and the Subscription of the delivery probe of the
+// Eventing CR with the given name.
+func ResourceName(eventingName string) string {
+	return fmt.Sprintf("%s-%s", eventingName, nameSuffix)
+}
+
+// SinkURL returns the cluster-local URL of the Service of the delivery probe.
+func SinkURL(name, namespace string) string {
+	return fmt.Sprintf("http://%s.%s.svc.cluster.local", name, namespace)
+}
+
+// NewService returns the Service of the delivery probe. It has no selector, because its Endpoints point to the
+// eventing-manager replica running the Prober only, see NewEndpoints.
+func NewService(name, namespace string, port int32) *kcorev1.Service {
+	// setting `TypeMeta` is important for patch apply to work.
+	return &kcorev1.Service{
+		TypeMeta: kmetav1.TypeMeta{
+			Kind:       "Service",
+			APIVersion: "v1",
+		},
+		ObjectMeta: kmetav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels(),
+		},
+		Spec: kcorev1.ServiceSpec{
+			Ports: []kcorev1.ServicePort{
+				{
+					Name:       portName,
+					Protocol:   kcorev1.ProtocolTCP,
+					Port:       servicePort,
+					TargetPort: intstr.FromInt32(port),
+				},
+			},
+		},
+	}
+}
+
+// NewEndpoints returns the Endpoints of the Service of the delivery probe, which point to the Pod with the given IP.
+func NewEndpoints(name, namespace, podIP string, port int32) *kcorev1.Endpoints {
+	// setting `TypeMeta` is important for patch apply to work.
+	return &kcorev1.Endpoints{
+		TypeMeta: kmetav1.TypeMeta{
+			Kind:       "Endpoints",
+			APIVersion: "v1",
+		},
+		ObjectMeta: kmetav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels(),
+		},
+		Subsets: []kcorev1.EndpointSubset{
+			{
+				Addresses: []kcorev1.EndpointAddress{{IP: podIP}},
+				Ports: []kcorev1.EndpointPort{
+					{
+						Name:     portName,
+						Protocol: kcorev1.ProtocolTCP,
+						Port:     port,
+					},
+				},
+			},
+		},
+	}
+}
+
+// NewSubscription returns the Subscription of the canary events, which are delivered to the given sink.
+func NewSubscription(name, namespace, sink string) *eventingv1alpha2.Subscription {
+	// setting `TypeMeta` is important for patch apply to work.
+	return &eventingv1alpha2.Subscription{
+		TypeMeta: kmetav1.TypeMeta{
+			Kind:       "Subscription",
+			APIVersion: eventingv1alpha2.GroupVersion.String(),
+		},
+		ObjectMeta: kmetav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels(),
+		},
+		Spec: eventingv1alpha2.SubscriptionSpec{
+			Sink:         sink,
+			TypeMatching: eventingv1alpha2.TypeMatchingStandard,
+			Source:       EventSource,
+			Types:        []string{EventType},
+		},
+	}
+}
+
+func labels() map[string]string {
+	return map[string]string{
+		label.KeyName:      nameSuffix,
+		label.KeyCreatedBy: label.ValueEventingManager,
+		label.KeyManagedBy: label.ValueEventingManager,
+		label.KeyPartOf:    label.ValueEventingManager,
+		label.KeyComponent: nameSuffix,
+	}
+}
@@ -0,0 +1,202 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	operatorv1alpha1 "github.com/kyma-project/eventing-manager/api/operator/v1alpha1"
+)
+
+var (
+	ErrStrategyNotSupported = errors.New("domain discovery strategy not supported")
+	ErrResourceInvalid      = errors.New("invalid namespaced name. It must be in the format of 'namespace/name'")
+	ErrDomainNotFound       = errors.New("no domain found")
+)
+
+// Source identifies the resource the domain is discovered from.
+type Source struct {
+	GVR       schema.GroupVersionResource
+	Namespace string
+	Name      string
+}
+
+// String returns the source in a human-readable format, e.g. for logs and error messages.
+func (s Source) String() string {
+	return fmt.Sprintf("%s %s/%s", s.GVR.GroupResource(), s.Namespace, s.Name)
+}
+
+// strategy reads the hosts the domain is derived from out of a resource.
+type strategy struct {
+	gvr   schema.GroupVersionResource
+	hosts func(obj *unstructured.Unstructured, discovery *operatorv1alpha1.DomainDiscovery) ([]string, error)
+}
+
+//nolint:gochecknoglobals // the strategies are constant.
+var strategies = map[operatorv1alpha1.DomainDiscoveryStrategy]strategy{
+	operatorv1alpha1.DomainDiscoveryStrategyIstioGateway: {
+		gvr:   schema.GroupVersionResource{Group: "networking.istio.io", Version: "v1beta1", Resource: "gateways"},
+		hosts: istioGatewayHosts,
+	},
+	operatorv1alpha1.DomainDiscoveryStrategyGateway: {
+		gvr:   schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "gateways"},
+		hosts: gatewayHosts,
+	},
+	operatorv1alpha1.DomainDiscoveryStrategyIngress: {
+		gvr:   schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"},
+		hosts: ingressHosts,
+	},
+	operatorv1alpha1.DomainDiscoveryStrategyService: {
+		gvr:   schema.GroupVersionResource{Version: "v1", Resource: "services"},
+		hosts: serviceHosts,
+	},
+}
+
+// Discoverer discovers the cluster public domain from the resource selected by a DomainDiscovery.
+// The ShootInfo strategy is not handled by the Discoverer, because it reads a well-known ConfigMap.
+type Discoverer struct {
+	client dynamic.Interface
+}
+
+func NewDiscoverer(client dynamic.Interface) *Discoverer {
+	return &Discoverer{client: client}
+}
+
+// SourceOf returns the resource the domain is discovered from by the given DomainDiscovery.
+func SourceOf(discovery *operatorv1alpha1.DomainDiscovery) (Source, error) {
+	s, found := strategies[discovery.Strategy]
+	if !found {
+		return Source{}, fmt.Errorf("%w: %s", ErrStrategyNotSupported, discovery.Strategy)
+	}
+
+	namespace, name, found := strings.Cut(discovery.Resource, "/")
+	if !found || namespace == "" || name == "" {
+		return Source{}, fmt.Errorf("%w: %s", ErrResourceInvalid, discovery.Resource)
+	}
+
+	return Source{GVR: s.gvr, Namespace: namespace, Name: name}, nil
+}
+
+// Discover returns the domain discovered by the given DomainDiscovery.
+// The first wildcard host, e.g. "*.example.com", takes precedence over the other hosts.
+func (d *Discoverer) Discover(ctx context.Context, discovery *operatorv1alpha1.DomainDiscovery) (string, error) {
+	source, err := SourceOf(discovery)
+	if err != nil {
+		return "", err
+	}
+
+	obj, err := d.client.Resource(source.GVR).Namespace(source.Namespace).Get(ctx, source.Name, kmetav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	hosts, err := strategies[discovery.Strategy].hosts(obj, discovery)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the hosts of %s: %w", source, err)
+	}
+
+	domain := ""
+	for _, host := range hosts {
+		if wildcardDomain, found := strings.CutPrefix(host, "*."); found {
+			return wildcardDomain, nil
+		}
+		if domain == "" && host != "*" {
+			domain = host
+		}
+	}
+	if domain == "" {
+		return "", fmt.Errorf("%w in %s", ErrDomainNotFound, source)
+	}
+	return domain, nil
+}
+
+// istioGatewayHosts returns the hosts of the servers of an Istio Gateway. The hosts have the format
+// "[namespace/]host", see https://istio.io/latest/docs/reference/config/networking/gateway/#Server.
+func istioGatewayHosts(obj *unstructured.Unstructured, _ *operatorv1alpha1.DomainDiscovery) ([]string, error) {
+	servers, _, err := unstructured.NestedSlice(obj.Object, "spec", "servers")
+	if err != nil {
+		return nil, err
+	}
+
+	var hosts []string
+	for _, server := range servers {
+		serverMap, ok := server.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		serverHosts, _, err := unstructured.NestedStringSlice(serverMap, "hosts")
+		if err != nil {
+			return nil, err
+		}
+		for _, host := range serverHosts {
+			if _, h, found := strings.Cut(host, "/"); found {
+				host = h
+			}
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts, nil
+}
+
+// gatewayHosts returns the hostnames of the listeners of a Kubernetes Gateway API Gateway.
+func gatewayHosts(obj *unstructured.Unstructured, _ *operatorv1alpha1.DomainDiscovery) ([]string, error) {
+	listeners, _, err := unstructured.NestedSlice(obj.Object, "spec", "listeners")
+	if err != nil {
+		return nil, err
+	}
+
+	var hosts []string
+	for _, listener := range listeners {
+		listenerMap, ok := listener.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if hostname, found, _ := unstructured.NestedString(listenerMap, "hostname"); found {
+			hosts = append(hosts, hostname)
+		}
+	}
+	return hosts, nil
+}
+
+// ingressHosts returns the hosts of the rules of an Ingress.
+func ingressHosts(obj *unstructured.Unstructured, _ *operatorv1alpha1.DomainDiscovery) ([]string, error) {
+	rules, _, err := unstructured.NestedSlice(obj.Object, "spec", "rules")
+	if err != nil {
+		return nil, err
+	}
+
+	var hosts []string
+	for _, rule := range rules {
+		ruleMap, ok := rule.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if host, found, _ := unstructured.NestedString(ruleMap, "host"); found {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts, nil
+}
+
+// serviceHosts returns the DNS names in the label, or the annotation, of a Service with the key of the
+// DomainDiscovery. Several DNS names are separated by commas, e.g. in the annotations of external-dns.
+func serviceHosts(obj *unstructured.Unstructured, discovery *operatorv1alpha1.DomainDiscovery) ([]string, error) {
+	value, found := obj.GetLabels()[discovery.Key]
+	if !found {
+		value = obj.GetAnnotations()[discovery.Key]
+	}
+
+	var hosts []string
+	for _, host := range strings.Split(value, ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts, nil
+}
@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	kdynamicfake "k8s.io/client-go/dynamic/fake"
+
+	operatorv1alpha1 "github.com/kyma-project/eventing-manager/api/operator/v1alpha1"
+)
+
+func newObject(apiVersion, kind, name string, spec map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata":   map[string]interface{}{"namespace": "test-namespace", "name": name},
+	}}
+	if spec != nil {
+		obj.Object["spec"] = spec
+	}
+	return obj
+}
+
+func Test_Discover(t *testing.T) {
+	t.Parallel()
+
+	istioGateway := newObject("networking.istio.io/v1beta1", "Gateway", "kyma-gateway", map[string]interface{}{
+		"servers": []interface{}{
+			map[string]interface{}{"hosts": []interface{}{"istio-system/api.istio.example.com"}},
+			map[string]interface{}{"hosts": []interface{}{"*.istio.example.com"}},
+		},
+	})
+	gateway := newObject("gateway.networking.k8s.io/v1", "Gateway", "gateway", map[string]interface{}{
+		"listeners": []interface{}{
+			map[string]interface{}{"name": "http"},
+			map[string]interface{}{"name": "https", "hostname": "*.gateway.example.com"},
+		},
+	})
+	ingress := newObject("networking.k8s.io/v1", "Ingress", "ingress", map[string]interface{}{
+		"rules": []interface{}{map[string]interface{}{"host": "ingress.example.com"}},
+	})
+	emptyIngress := newObject("networking.k8s.io/v1", "Ingress", "empty-ingress", nil)
+	service := newObject("v1", "Service", "service", nil)
+	service.SetAnnotations(map[string]string{
+		"external-dns.alpha.kubernetes.io/hostname": "service.example.com, other.example.com",
+	})
+
+	testCases := []struct {
+		name           string
+		givenDiscovery *operatorv1alpha1.DomainDiscovery
+		wantDomain     string
+		wantError      error
+	}{
+		{
+			name: "should discover the domain from the wildcard host of an Istio Gateway",
+			givenDiscovery: &operatorv1alpha1.DomainDiscovery{
+				Strategy: operatorv1alpha1.DomainDiscoveryStrategyIstioGateway,
+				Resource: "test-namespace/kyma-gateway",
+			},
+			wantDomain: "istio.example.com",
+		},
+		{
+			name: "should discover the domain from the listener hostname of a Gateway",
+			givenDiscovery: &operatorv1alpha1.DomainDiscovery{
+				Strategy: operatorv1alpha1.DomainDiscoveryStrategyGateway,
+				Resource: "test-namespace/gateway",
+			},
+			wantDomain: "gateway.example.com",
+		},
+		{
+			name: "should discover the domain from the rule host of an Ingress",
+			givenDiscovery: &operatorv1alpha1.DomainDiscovery{
+				Strategy: operatorv1alpha1.DomainDiscoveryStrategyIngress,
+				Resource: "test-namespace/ingress",
+			},
+			wantDomain: "ingress.example.com",
+		},
+		{
+			name: "should discover the domain from the annotation of a Service",
+			givenDiscovery: &operatorv1alpha1.DomainDiscovery{
+				Strategy: operatorv1alpha1.DomainDiscoveryStrategyService,
+				Resource: "test-namespace/service",
+				Key:      "external-dns.alpha.kubernetes.io/hostname",
+			},
+			wantDomain: "service.example.com",
+		},
+		{
+			name: "should return an error if the resource has no host",
+			givenDiscovery: &operatorv1alpha1.DomainDiscovery{
+				Strategy: operatorv1alpha1.DomainDiscoveryStrategyIngress,
+				Resource: "test-namespace/empty-ingress",
+			},
+			wantError: ErrDomainNotFound,
+		},
+		{
+			name: "should return an error if the resource is invalid",
+			givenDiscovery: &operatorv1alpha1.DomainDiscovery{
+				Strategy: operatorv1alpha1.DomainDiscoveryStrategyIngress,
+				Resource: "ingress",
+			},
+			wantError: ErrResourceInvalid,
+		},
+		{
+			name: "should return an error if the strategy does not read a resource",
+			givenDiscovery: &operatorv1alpha1.DomainDiscovery{
+				Strategy: operatorv1alpha1.DomainDiscoveryStrategyShootInfo,
+			},
+			wantError: ErrStrategyNotSupported,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			// the objects are created by their resource, because the fake client guesses "gatewaies" for Gateways.
+			client := kdynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+			givenObjects := map[operatorv1alpha1.DomainDiscoveryStrategy][]*unstructured.Unstructured{
+				operatorv1alpha1.DomainDiscoveryStrategyIstioGateway: {istioGateway},
+				operatorv1alpha1.DomainDiscoveryStrategyGateway:      {gateway},
+				operatorv1alpha1.DomainDiscoveryStrategyIngress:      {ingress, emptyIngress},
+				operatorv1alpha1.DomainDiscoveryStrategyService:      {service},
+			}
+			for strategy, objs := range givenObjects {
+				for _, obj := range objs {
+					_, err := client.Resource(strategies[strategy].gvr).Namespace(obj.GetNamespace()).
+						Create(context.Background(), obj.DeepCopy(), kmetav1.CreateOptions{})
+					require.NoError(t, err)
+				}
+			}
+
+			// when
+			gotDomain, err := NewDiscoverer(client).Discover(context.Background(), tc.givenDiscovery)
+
+			// then
+			require.ErrorIs(t, err, tc.wantError)
+			require.Equal(t, tc.wantDomain, gotDomain)
+		})
+	}
+}
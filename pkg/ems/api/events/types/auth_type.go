@@ -4,10 +4,25 @@ type AuthType string
 
 const (
 	AuthTypeClientCredentials AuthType = "oauth2"
+	// The following auth types are supported by the JetStream backend only, with credentials from a Secret.
+	AuthTypeBearer AuthType = "bearer"
+	AuthTypeBasic  AuthType = "basic"
+	AuthTypeMTLS   AuthType = "mtls"
+	AuthTypeHMAC   AuthType = "hmac"
 )
 
 func IsInvalidAuthType(value string) bool {
-	return value != string(AuthTypeClientCredentials)
+	switch AuthType(value) {
+	case AuthTypeClientCredentials, AuthTypeBearer, AuthTypeBasic, AuthTypeMTLS, AuthTypeHMAC:
+		return false
+	default:
+		return true
+	}
+}
+
+// IsSecretAuthType returns true if the credentials of the auth type are read from a Secret.
+func IsSecretAuthType(value string) bool {
+	return !IsInvalidAuthType(value) && AuthType(value) != AuthTypeClientCredentials
 }
 
 func GetAuthType(_ string) AuthType {
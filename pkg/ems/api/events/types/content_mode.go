@@ -3,4 +3,16 @@ package types
 const (
 	ContentModeBinary     = "BINARY"
 	ContentModeStructured = "STRUCTURED"
+	// ContentModeRaw delivers the data of the events without the CloudEvent attributes,
+	// it is supported by the JetStream backend only.
+	ContentModeRaw = "RAW"
 )
+
+func IsInvalidContentMode(value string) bool {
+	switch value {
+	case ContentModeBinary, ContentModeStructured, ContentModeRaw:
+		return false
+	default:
+		return true
+	}
+}
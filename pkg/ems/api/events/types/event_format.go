@@ -0,0 +1,16 @@
+package types
+
+// The event formats of the structured content mode. The Protobuf format is supported by the JetStream backend only.
+const (
+	EventFormatJSON     = "JSON"
+	EventFormatProtobuf = "PROTOBUF"
+)
+
+func IsInvalidEventFormat(value string) bool {
+	switch value {
+	case EventFormatJSON, EventFormatProtobuf:
+		return false
+	default:
+		return true
+	}
+}
@@ -6,17 +6,26 @@ import (
 	"golang.org/x/oauth2"
 
 	"github.com/kyma-project/eventing-manager/pkg/env"
-	"github.com/kyma-project/eventing-manager/pkg/signals"
 )
 
 func NewAuthenticatedClient(cfg env.Config) *http.Client {
-	ctx := signals.NewReusableContext()
-	config := getDefaultOauth2Config(cfg)
-	// create and configure oauth2 client
-	client := config.Client(ctx)
+	client, _ := NewRotatableAuthenticatedClient(cfg)
+	return client
+}
 
+// NewRotatableAuthenticatedClient returns an oauth2 client and its token source, which rotates the client credentials
+// of the returned client in place.
+func NewRotatableAuthenticatedClient(cfg env.Config) (*http.Client, *RotatableTokenSource) {
+	tokenSource := NewRotatableTokenSource(cfg)
+
+	// create and configure oauth2 client
 	base := http.DefaultTransport.(*http.Transport).Clone()
-	client.Transport.(*oauth2.Transport).Base = base
+	client := &http.Client{
+		Transport: &oauth2.Transport{
+			Source: tokenSource,
+			Base:   base,
+		},
+	}
 
-	return client
+	return client, tokenSource
 }
@@ -1,7 +1,9 @@
 package auth
 
 import (
+	"encoding/json"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"golang.org/x/oauth2"
@@ -39,3 +41,41 @@ func TestAuthenticator(t *testing.T) {
 		t.Errorf("HTTP Client Transport MaxIdleConnsPerHost is misconfigured want: %d but got: %d", maxIdleConnsPerHost, httpTransport.MaxIdleConnsPerHost)
 	}
 }
+
+func TestRotatableTokenSource(t *testing.T) {
+	// given a token endpoint issuing a token per client ID
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientID, _, _ := r.BasicAuth()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "token-" + clientID,
+			"token_type":   "bearer",
+			"expires_in":   3600,
+		})
+	}))
+	defer server.Close()
+
+	cfg := env.Config{ClientID: "foo", ClientSecret: "foo", TokenEndpoint: server.URL}
+	tokenSource := NewRotatableTokenSource(cfg)
+
+	token, err := tokenSource.Token()
+	if err != nil {
+		t.Fatalf("get token failed: %v", err)
+	}
+	if token.AccessToken != "token-foo" {
+		t.Errorf("token is invalid want: %s but got: %s", "token-foo", token.AccessToken)
+	}
+
+	// when the client credentials are rotated
+	cfg.ClientID = "bar"
+	tokenSource.SetCredentials(cfg)
+
+	// then the cached token is not reused
+	token, err = tokenSource.Token()
+	if err != nil {
+		t.Fatalf("get token failed: %v", err)
+	}
+	if token.AccessToken != "token-bar" {
+		t.Errorf("token is invalid want: %s but got: %s", "token-bar", token.AccessToken)
+	}
+}
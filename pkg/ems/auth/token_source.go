@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/oauth2"
+
+	"github.com/kyma-project/eventing-manager/pkg/env"
+	"github.com/kyma-project/eventing-manager/pkg/signals"
+)
+
+// Perform a compile-time check.
+var _ oauth2.TokenSource = &RotatableTokenSource{}
+
+// RotatableTokenSource is an oauth2 token source for client credentials, which can be rotated without recreating
+// the HTTP clients using it.
+type RotatableTokenSource struct {
+	ctx    context.Context
+	mutex  sync.RWMutex
+	source oauth2.TokenSource
+}
+
+func NewRotatableTokenSource(cfg env.Config) *RotatableTokenSource {
+	tokenSource := &RotatableTokenSource{ctx: signals.NewReusableContext()}
+	tokenSource.SetCredentials(cfg)
+	return tokenSource
+}
+
+// Token returns a token of the current client credentials, it is reused until it expires.
+func (s *RotatableTokenSource) Token() (*oauth2.Token, error) {
+	s.mutex.RLock()
+	source := s.source
+	s.mutex.RUnlock()
+	return source.Token()
+}
+
+// SetCredentials swaps the client credentials, the token of the previous credentials is not reused.
+func (s *RotatableTokenSource) SetCredentials(cfg env.Config) {
+	config := getDefaultOauth2Config(cfg)
+	source := config.TokenSource(s.ctx)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.source = source
+}
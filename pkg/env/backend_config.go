@@ -12,11 +12,14 @@ type BackendConfig struct {
 	PublisherConfig PublisherConfig
 
 	// namespace where eventing-manager is deployed.
-	Namespace           string `default:"kyma-system" envconfig:"NAMESPACE"`
+	Namespace string `default:"kyma-system" envconfig:"NAMESPACE"`
+	// IP of the eventing-manager Pod, which serves the canary events of the delivery probe.
+	PodIP               string `envconfig:"POD_IP"`
 	EventingCRName      string `default:"eventing"    envconfig:"EVENTING_CR_NAME"`
 	EventingCRNamespace string `default:"kyma-system" envconfig:"EVENTING_CR_NAMESPACE"`
 
 	WebhookSecretName   string `default:"eventing-manager-webhook-server-cert"        envconfig:"WEBHOOK_SECRET_NAME"`
+	WebhookServiceName  string `default:"eventing-manager-webhook-service"            envconfig:"WEBHOOK_SERVICE_NAME"`
 	MutatingWebhookName string `default:"subscription-mutating-webhook-configuration" envconfig:"MUTATING_WEBHOOK_NAME"`
 	//nolint:lll
 	ValidatingWebhookName string `default:"subscription-validating-webhook-configuration" envconfig:"VALIDATING_WEBHOOK_NAME"`
@@ -58,6 +58,9 @@ type Config struct {
 	// note: eventType format is <prefix>.<application>.<event>.<version>
 	EventTypePrefix string `envconfig:"EVENT_TYPE_PREFIX" required:"true"`
 
+	// EventTypeRewrites are the rules to rewrite the event types of the Subscriptions, encoded as JSON.
+	EventTypeRewrites EventTypeRewrites `envconfig:"EVENT_TYPE_REWRITES" required:"false"`
+
 	// EventingWebhookAuthEnabled enable/disable the Eventing webhook auth feature flag.
 	EventingWebhookAuthEnabled bool `default:"false" envconfig:"EVENTING_WEBHOOK_AUTH_ENABLED" required:"false"`
 
@@ -19,6 +19,7 @@ func Test_GetConfig(t *testing.T) {
 		"BEB_API_URL":                "BEB_API_URL",
 		"BEB_NAMESPACE":              "/test",
 		"WEBHOOK_ACTIVATION_TIMEOUT": "60s",
+		"EVENT_TYPE_REWRITES":        `[{"from":"sap.kyma.custom.oldapp.*","to":"sap.kyma.custom.newapp.*"}]`,
 	}
 
 	for k, v := range envs {
@@ -38,4 +39,7 @@ func Test_GetConfig(t *testing.T) {
 	g.Expect(err).ShouldNot(HaveOccurred())
 	g.Expect(config.WebhookActivationTimeout).To(Equal(webhookActivationTimeout))
 	g.Expect(config.NATSProvisioningEnabled).To(BeTrue())
+	g.Expect(config.EventTypeRewrites).To(Equal(EventTypeRewrites{
+		{From: "sap.kyma.custom.oldapp.*", To: "sap.kyma.custom.newapp.*"},
+	}))
 }
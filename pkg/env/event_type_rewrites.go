@@ -0,0 +1,32 @@
+package env
+
+import (
+	"encoding/json"
+
+	"github.com/kyma-project/eventing-manager/api/operator/v1alpha1"
+)
+
+// EventTypeRewrites are the rules to rewrite the event types of the Subscriptions.
+// They are decoded from JSON when read from the environment.
+type EventTypeRewrites []v1alpha1.EventTypeRewrite
+
+// Decode implements the envconfig.Decoder interface.
+func (r *EventTypeRewrites) Decode(value string) error {
+	if len(value) == 0 {
+		*r = nil
+		return nil
+	}
+	return json.Unmarshal([]byte(value), r)
+}
+
+// Encode returns the JSON representation of the rules, which can be decoded by Decode.
+func (r EventTypeRewrites) Encode() (string, error) {
+	if len(r) == 0 {
+		return "", nil
+	}
+	value, err := json.Marshal(r)
+	if err != nil {
+		return "", err
+	}
+	return string(value), nil
+}
@@ -0,0 +1,59 @@
+package env
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/eventing-manager/api/operator/v1alpha1"
+)
+
+func Test_EventTypeRewrites(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name              string
+		givenRewrites     EventTypeRewrites
+		wantEncodedValue  string
+		wantDecodedResult EventTypeRewrites
+	}{
+		{
+			name:              "should encode no rules to an empty value",
+			givenRewrites:     nil,
+			wantEncodedValue:  "",
+			wantDecodedResult: nil,
+		},
+		{
+			name: "should encode the rules to JSON",
+			givenRewrites: EventTypeRewrites{
+				{From: "sap.kyma.custom.oldapp.*", To: "sap.kyma.custom.newapp.*"},
+			},
+			wantEncodedValue: `[{"from":"sap.kyma.custom.oldapp.*","to":"sap.kyma.custom.newapp.*"}]`,
+			wantDecodedResult: EventTypeRewrites{
+				v1alpha1.EventTypeRewrite{From: "sap.kyma.custom.oldapp.*", To: "sap.kyma.custom.newapp.*"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			value, err := tc.givenRewrites.Encode()
+
+			// then
+			require.NoError(t, err)
+			require.Equal(t, tc.wantEncodedValue, value)
+
+			// when
+			var result EventTypeRewrites
+			err = result.Decode(value)
+
+			// then
+			require.NoError(t, err)
+			require.Equal(t, tc.wantDecodedResult, result)
+		})
+	}
+}
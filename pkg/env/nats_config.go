@@ -15,10 +15,17 @@ type NATSConfig struct {
 	URL           string
 	MaxReconnects int
 	ReconnectWait time.Duration
+	// Credentials of the NATS user of the eventing-controller, it connects anonymously if they are empty.
+	// UserCredentials is the content of a NATS credentials file with the user JWT and nkey seed.
+	UserCredentials string
+	User            string
+	Password        string
 
 	// EventTypePrefix prefix for the EventType
 	// note: eventType format is <prefix>.<application>.<event>.<version>
 	EventTypePrefix string
+	// EventTypeRewrites are the rules to rewrite the event types of the Subscriptions.
+	EventTypeRewrites EventTypeRewrites
 
 	// HTTP Transport config for the message dispatcher
 	MaxIdleConns        int           `default:"50"  envconfig:"MAX_IDLE_CONNS"`
@@ -43,6 +50,8 @@ type NATSConfig struct {
 	JSStreamMaxMessages     int64  `default:"-1"       envconfig:"JS_STREAM_MAX_MSGS"`
 	JSStreamMaxBytes        string
 	JSStreamMaxMsgsPerTopic int64
+	// JSStreamDuplicates is the time window in which the stream drops messages with an already stored Nats-Msg-Id.
+	JSStreamDuplicates time.Duration
 	// JSStreamDiscardPolicy specifies which events to discard from the stream in case limits are reached
 	//  new: reject new messages for the stream
 	//  old: discard old messages from the stream to make room for new messages
@@ -56,6 +65,31 @@ type NATSConfig struct {
 	// - new: When first consuming messages, the consumer starts receiving messages that were created
 	//   after the consumer was created.
 	JSConsumerDeliverPolicy string `default:"new" envconfig:"JS_CONSUMER_DELIVER_POLICY"`
+
+	// JSShardedDispatch splits the dispatching from the consumer management. The leader manages the consumers only,
+	// and the consumers are dispatched by all replicas, sharing them by consistent hashing.
+	JSShardedDispatch bool `default:"false" envconfig:"JS_SHARDED_DISPATCH"`
+
+	// JSConsolidatedConsumers creates one consumer per subscription filtering all its event types, instead of one
+	// consumer per event type. The existing consumers are migrated when the value is changed.
+	JSConsolidatedConsumers bool `default:"false" envconfig:"JS_CONSOLIDATED_CONSUMERS"`
+
+	// JSDrainTimeout is the time given to the in-flight deliveries to be ACKed or NAKed on shutdown,
+	// before the connection is closed and they are redelivered after the ACK wait.
+	JSDrainTimeout time.Duration `default:"20s" envconfig:"JS_DRAIN_TIMEOUT"`
+
+	// JSReplyMaxHops is the maximum number of times an event can be replied to by the subscribers with reply enabled,
+	// which protects from reply loops. The replies exceeding it are dropped.
+	JSReplyMaxHops int `default:"3" envconfig:"JS_REPLY_MAX_HOPS"`
+
+	// JSLagCollectionInterval is the interval at which the backlog of the consumers and the usage of the stream are
+	// recorded in the metrics.
+	JSLagCollectionInterval time.Duration `default:"30s" envconfig:"JS_LAG_COLLECTION_INTERVAL"`
+
+	// Idempotency cache of the dispatcher, which skips the redelivery of already dispatched events.
+	// The cache is disabled if the size is 0.
+	JSIdempotencyCacheSize int
+	JSIdempotencyCacheTTL  time.Duration
 }
 
 // GetNewNATSConfig returns NATSConfig with values based on Eventing CR.
@@ -65,6 +99,9 @@ func (nc NATSConfig) GetNewNATSConfig(eventingCR v1alpha1.Eventing) NATSConfig {
 		URL:                     nc.URL,
 		MaxReconnects:           nc.MaxReconnects,
 		ReconnectWait:           nc.ReconnectWait,
+		UserCredentials:         nc.UserCredentials,
+		User:                    nc.User,
+		Password:                nc.Password,
 		MaxIdleConns:            nc.MaxIdleConns,
 		MaxConnsPerHost:         nc.MaxConnsPerHost,
 		MaxIdleConnsPerHost:     nc.MaxIdleConnsPerHost,
@@ -75,12 +112,21 @@ func (nc NATSConfig) GetNewNATSConfig(eventingCR v1alpha1.Eventing) NATSConfig {
 		JSStreamMaxMessages:     nc.JSStreamMaxMessages,
 		JSStreamDiscardPolicy:   nc.JSStreamDiscardPolicy,
 		JSConsumerDeliverPolicy: nc.JSConsumerDeliverPolicy,
+		JSShardedDispatch:       nc.JSShardedDispatch,
+		JSConsolidatedConsumers: nc.JSConsolidatedConsumers,
+		JSDrainTimeout:          nc.JSDrainTimeout,
+		JSReplyMaxHops:          nc.JSReplyMaxHops,
+		JSLagCollectionInterval: nc.JSLagCollectionInterval,
 		// values from Eventing CR.
 		EventTypePrefix:         eventingCR.Spec.Backend.Config.EventTypePrefix,
+		EventTypeRewrites:       eventingCR.Spec.Backend.Config.EventTypeRewrites,
 		JSStreamStorageType:     strings.ToLower(eventingCR.Spec.Backend.Config.NATSStreamStorageType),
 		JSStreamReplicas:        eventingCR.Spec.Backend.Config.NATSStreamReplicas,
 		JSStreamMaxBytes:        eventingCR.Spec.Backend.Config.NATSStreamMaxSize.String(),
 		JSStreamMaxMsgsPerTopic: int64(eventingCR.Spec.Backend.Config.NATSMaxMsgsPerTopic),
+		JSStreamDuplicates:      eventingCR.Spec.Backend.Config.NATSStreamDuplicatesWindow.Duration,
+		JSIdempotencyCacheSize:  eventingCR.Spec.Backend.Config.NATSIdempotencyCacheSize,
+		JSIdempotencyCacheTTL:   eventingCR.Spec.Backend.Config.NATSIdempotencyCacheTTL.Duration,
 	}
 }
 
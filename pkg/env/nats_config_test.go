@@ -20,6 +20,8 @@ func Test_GetNewNATSConfig(t *testing.T) {
 		URL:                     "http://eventing-nats.svc.cluster.local",
 		MaxReconnects:           10,
 		ReconnectWait:           100,
+		User:                    "user",
+		Password:                "password",
 		MaxIdleConns:            5,
 		MaxConnsPerHost:         10,
 		MaxIdleConnsPerHost:     10,
@@ -30,6 +32,11 @@ func Test_GetNewNATSConfig(t *testing.T) {
 		JSStreamMaxMessages:     100000,
 		JSStreamDiscardPolicy:   "DiscardNew",
 		JSConsumerDeliverPolicy: "DeliverNew",
+		JSShardedDispatch:       true,
+		JSConsolidatedConsumers: true,
+		JSDrainTimeout:          30 * time.Second,
+		JSReplyMaxHops:          5,
+		JSLagCollectionInterval: time.Minute,
 	}
 
 	givenEventing := &v1alpha1.Eventing{
@@ -46,11 +53,17 @@ func Test_GetNewNATSConfig(t *testing.T) {
 			Backend: &v1alpha1.Backend{
 				Type: v1alpha1.NatsBackendType,
 				Config: v1alpha1.BackendConfig{
-					EventTypePrefix:       "sap.kyma.custom",
-					NATSStreamStorageType: "Memory",
-					NATSStreamMaxSize:     resource.MustParse("650M"),
-					NATSStreamReplicas:    5,
-					NATSMaxMsgsPerTopic:   5000,
+					EventTypePrefix:            "sap.kyma.custom",
+					NATSStreamStorageType:      "Memory",
+					NATSStreamMaxSize:          resource.MustParse("650M"),
+					NATSStreamReplicas:         5,
+					NATSMaxMsgsPerTopic:        5000,
+					NATSStreamDuplicatesWindow: kmetav1.Duration{Duration: time.Minute},
+					NATSIdempotencyCacheSize:   1000,
+					NATSIdempotencyCacheTTL:    kmetav1.Duration{Duration: 5 * time.Minute},
+					EventTypeRewrites: []v1alpha1.EventTypeRewrite{
+						{From: "sap.kyma.custom.oldapp.*", To: "sap.kyma.custom.newapp.*"},
+					},
 				},
 			},
 		},
@@ -64,6 +77,9 @@ func Test_GetNewNATSConfig(t *testing.T) {
 	require.Equal(t, givenConfig.URL, result.URL)
 	require.Equal(t, givenConfig.MaxReconnects, result.MaxReconnects)
 	require.Equal(t, givenConfig.ReconnectWait, result.ReconnectWait)
+	require.Equal(t, givenConfig.UserCredentials, result.UserCredentials)
+	require.Equal(t, givenConfig.User, result.User)
+	require.Equal(t, givenConfig.Password, result.Password)
 	require.Equal(t, givenConfig.MaxIdleConns, result.MaxIdleConns)
 	require.Equal(t, givenConfig.MaxConnsPerHost, result.MaxConnsPerHost)
 	require.Equal(t, givenConfig.MaxIdleConnsPerHost, result.MaxIdleConnsPerHost)
@@ -74,13 +90,22 @@ func Test_GetNewNATSConfig(t *testing.T) {
 	require.Equal(t, givenConfig.JSStreamMaxMessages, result.JSStreamMaxMessages)
 	require.Equal(t, givenConfig.JSStreamDiscardPolicy, result.JSStreamDiscardPolicy)
 	require.Equal(t, givenConfig.JSConsumerDeliverPolicy, result.JSConsumerDeliverPolicy)
+	require.Equal(t, givenConfig.JSShardedDispatch, result.JSShardedDispatch)
+	require.Equal(t, givenConfig.JSConsolidatedConsumers, result.JSConsolidatedConsumers)
+	require.Equal(t, givenConfig.JSDrainTimeout, result.JSDrainTimeout)
+	require.Equal(t, givenConfig.JSReplyMaxHops, result.JSReplyMaxHops)
+	require.Equal(t, givenConfig.JSLagCollectionInterval, result.JSLagCollectionInterval)
 
 	// check values from eventing CR.
 	require.Equal(t, givenEventing.Spec.Backend.Config.EventTypePrefix, result.EventTypePrefix)
+	require.Equal(t, EventTypeRewrites(givenEventing.Spec.Backend.Config.EventTypeRewrites), result.EventTypeRewrites)
 	require.Equal(t, strings.ToLower(givenEventing.Spec.Backend.Config.NATSStreamStorageType), result.JSStreamStorageType)
 	require.Equal(t, givenEventing.Spec.Backend.Config.NATSStreamReplicas, result.JSStreamReplicas)
 	require.Equal(t, givenEventing.Spec.Backend.Config.NATSStreamMaxSize.String(), result.JSStreamMaxBytes)
 	require.Equal(t, int64(givenEventing.Spec.Backend.Config.NATSMaxMsgsPerTopic), result.JSStreamMaxMsgsPerTopic)
+	require.Equal(t, givenEventing.Spec.Backend.Config.NATSStreamDuplicatesWindow.Duration, result.JSStreamDuplicates)
+	require.Equal(t, givenEventing.Spec.Backend.Config.NATSIdempotencyCacheSize, result.JSIdempotencyCacheSize)
+	require.Equal(t, givenEventing.Spec.Backend.Config.NATSIdempotencyCacheTTL.Duration, result.JSIdempotencyCacheTTL)
 }
 
 func Test_GetNATSConfig(t *testing.T) {
@@ -118,6 +143,9 @@ func Test_GetNATSConfig(t *testing.T) {
 				JSStreamRetentionPolicy: "interest",
 				JSStreamMaxMessages:     -1,
 				JSConsumerDeliverPolicy: "new",
+				JSDrainTimeout:          20 * time.Second,
+				JSReplyMaxHops:          3,
+				JSLagCollectionInterval: 30 * time.Second,
 				JSStreamDiscardPolicy:   "new",
 			},
 			wantErr: false,
@@ -150,6 +178,9 @@ func Test_GetNATSConfig(t *testing.T) {
 				JSStreamRetentionPolicy: "jsrp",
 				JSStreamMaxMessages:     5,
 				JSConsumerDeliverPolicy: "jcdp",
+				JSDrainTimeout:          20 * time.Second,
+				JSReplyMaxHops:          3,
+				JSLagCollectionInterval: 30 * time.Second,
 				JSStreamDiscardPolicy:   "jsdp",
 			},
 			wantErr: false,
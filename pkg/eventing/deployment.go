@@ -10,6 +10,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 
 	"github.com/kyma-project/eventing-manager/api/operator/v1alpha1"
 	"github.com/kyma-project/eventing-manager/internal/label"
@@ -35,6 +36,13 @@ const (
 	PublisherSecretEMSURLKey       = "ems-publish-url"
 	PublisherSecretBEBNamespaceKey = "beb-namespace"
 
+	NATSCredentialsManagerCredsKey      = "manager.creds"
+	NATSCredentialsManagerUserKey       = "manager.user"
+	NATSCredentialsManagerPasswordKey   = "manager.password"
+	NATSCredentialsPublisherCredsKey    = "publisher.creds"
+	NATSCredentialsPublisherUserKey     = "publisher.user"
+	NATSCredentialsPublisherPasswordKey = "publisher.password"
+
 	PriorityClassName             = "eventing-manager-priority-class"
 	TerminationGracePeriodSeconds = int64(30)
 )
@@ -229,7 +237,7 @@ func WithNATSEnvVars(natsConfig env.NATSConfig, publisherConfig env.PublisherCon
 func getNATSEnvVars(natsConfig env.NATSConfig, publisherConfig env.PublisherConfig,
 	eventing *v1alpha1.Eventing,
 ) []kcorev1.EnvVar {
-	return []kcorev1.EnvVar{
+	envVars := []kcorev1.EnvVar{
 		{Name: "BACKEND", Value: "nats"},
 		{Name: "PORT", Value: strconv.Itoa(int(publisherPortNum))},
 		{Name: "NATS_URL", Value: natsConfig.URL},
@@ -239,6 +247,31 @@ func getNATSEnvVars(natsConfig env.NATSConfig, publisherConfig env.PublisherConf
 		{Name: "APPLICATION_CRD_ENABLED", Value: strconv.FormatBool(publisherConfig.ApplicationCRDEnabled)},
 		// JetStream-specific config
 		{Name: "JS_STREAM_NAME", Value: natsConfig.JSStreamName},
+		// the publisher sets the Nats-Msg-Id header to the CloudEvent id, so that the stream drops the events
+		// published again within its duplicates window, e.g. when a publisher retries after a timeout.
+		{Name: "JS_MSG_ID_FROM_EVENT_ID", Value: "true"},
+	}
+	if secretName := eventing.Spec.Backend.Config.NATSCredentialsSecret; secretName != "" {
+		// the publisher connects as the publish-only user.
+		envVars = append(envVars,
+			getOptionalSecretEnvVar("NATS_CREDENTIALS", secretName, NATSCredentialsPublisherCredsKey),
+			getOptionalSecretEnvVar("NATS_USER", secretName, NATSCredentialsPublisherUserKey),
+			getOptionalSecretEnvVar("NATS_PASSWORD", secretName, NATSCredentialsPublisherPasswordKey),
+		)
+	}
+	return envVars
+}
+
+func getOptionalSecretEnvVar(name, secretName, key string) kcorev1.EnvVar {
+	return kcorev1.EnvVar{
+		Name: name,
+		ValueFrom: &kcorev1.EnvVarSource{
+			SecretKeyRef: &kcorev1.SecretKeySelector{
+				LocalObjectReference: kcorev1.LocalObjectReference{Name: secretName},
+				Key:                  key,
+				Optional:             ptr.To(true),
+			},
+		},
 	}
 }
 
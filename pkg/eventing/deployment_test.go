@@ -138,6 +138,7 @@ func Test_GetNATSEnvVars(t *testing.T) {
 				{Name: "EVENT_TYPE_PREFIX", Value: ""},
 				{Name: "APPLICATION_CRD_ENABLED", Value: "false"},
 				{Name: "JS_STREAM_NAME", Value: ""},
+				{Name: "JS_MSG_ID_FROM_EVENT_ID", Value: "true"},
 			},
 		},
 		{
@@ -159,6 +160,32 @@ func Test_GetNATSEnvVars(t *testing.T) {
 				{Name: "EVENT_TYPE_PREFIX", Value: ""},
 				{Name: "APPLICATION_CRD_ENABLED", Value: "false"},
 				{Name: "JS_STREAM_NAME", Value: "sap"},
+				{Name: "JS_MSG_ID_FROM_EVENT_ID", Value: "true"},
+			},
+		},
+		{
+			name: "Test the NATS credentials of the publisher",
+			givenEnvs: map[string]string{
+				"PUBLISHER_REQUEST_TIMEOUT": "10s",
+			},
+			givenNATSConfig: env.NATSConfig{
+				JSStreamName: "sap",
+				URL:          "test-url",
+			},
+			givenEventing: testutils.NewEventingCR(testutils.WithEventingNATSCredentialsSecret("nats-credentials")),
+			wantEnvs: []kcorev1.EnvVar{
+				{Name: "BACKEND", Value: "nats"},
+				{Name: "PORT", Value: "8080"},
+				{Name: "NATS_URL", Value: "test-url"},
+				{Name: "REQUEST_TIMEOUT", Value: "10s"},
+				{Name: "LEGACY_NAMESPACE", Value: "kyma"},
+				{Name: "EVENT_TYPE_PREFIX", Value: ""},
+				{Name: "APPLICATION_CRD_ENABLED", Value: "false"},
+				{Name: "JS_STREAM_NAME", Value: "sap"},
+				{Name: "JS_MSG_ID_FROM_EVENT_ID", Value: "true"},
+				getOptionalSecretEnvVar("NATS_CREDENTIALS", "nats-credentials", "publisher.creds"),
+				getOptionalSecretEnvVar("NATS_USER", "nats-credentials", "publisher.user"),
+				getOptionalSecretEnvVar("NATS_PASSWORD", "nats-credentials", "publisher.password"),
 			},
 		},
 	}
@@ -0,0 +1,134 @@
+package eventstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"sync"
+	"time"
+
+	kauthenticationv1 "k8s.io/api/authentication/v1"
+	kauthorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	eventingv1alpha2 "github.com/kyma-project/eventing-manager/api/eventing/v1alpha2"
+)
+
+const (
+	// Subresource is the subresource of the Subscriptions which the users must be allowed to get, to browse the events
+	// of the Subscriptions.
+	Subresource = "eventstore"
+
+	subscriptionsResource = "subscriptions"
+
+	// tokenReviewTTL is the duration for which the result of a TokenReview is reused, so that the repeated requests
+	// with the same token do not create a TokenReview each.
+	tokenReviewTTL = time.Minute
+)
+
+var (
+	ErrUnauthenticated = errors.New("the token is not authenticated")
+	ErrForbidden       = errors.New("the user is not allowed to browse the events of the Subscription")
+)
+
+// Authorizer authorizes the users browsing the events of the Subscriptions.
+type Authorizer interface {
+	// Authorize returns nil if the user of the bearer token is allowed to browse the events of the Subscription.
+	Authorize(ctx context.Context, token, namespace, name string) error
+}
+
+// Perform a compile-time check.
+var _ Authorizer = &ReviewAuthorizer{}
+
+// ReviewAuthorizer authenticates the tokens with TokenReviews, and authorizes the users with SubjectAccessReviews
+// to get the eventstore subresource of the Subscription.
+type ReviewAuthorizer struct {
+	client client.Client
+
+	// tokenReviews are the results of the recent TokenReviews by the hash of their token.
+	tokenReviews map[[sha256.Size]byte]reviewedToken
+	mutex        sync.Mutex
+}
+
+// reviewedToken is the result of a TokenReview which is reused until it expires.
+type reviewedToken struct {
+	status  kauthenticationv1.TokenReviewStatus
+	expires time.Time
+}
+
+func NewReviewAuthorizer(client client.Client) *ReviewAuthorizer {
+	return &ReviewAuthorizer{client: client, tokenReviews: make(map[[sha256.Size]byte]reviewedToken)}
+}
+
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+func (a *ReviewAuthorizer) Authorize(ctx context.Context, token, namespace, name string) error {
+	if token == "" {
+		return ErrUnauthenticated
+	}
+	status, err := a.reviewToken(ctx, token)
+	if err != nil {
+		return err
+	}
+	if !status.Authenticated {
+		return ErrUnauthenticated
+	}
+
+	user := status.User
+	extra := make(map[string]kauthorizationv1.ExtraValue, len(user.Extra))
+	for key, value := range user.Extra {
+		extra[key] = kauthorizationv1.ExtraValue(value)
+	}
+	accessReview := &kauthorizationv1.SubjectAccessReview{
+		Spec: kauthorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &kauthorizationv1.ResourceAttributes{
+				Namespace:   namespace,
+				Verb:        "get",
+				Group:       eventingv1alpha2.GroupVersion.Group,
+				Resource:    subscriptionsResource,
+				Subresource: Subresource,
+				Name:        name,
+			},
+			User:   user.Username,
+			Groups: user.Groups,
+			UID:    user.UID,
+			Extra:  extra,
+		},
+	}
+	if err := a.client.Create(ctx, accessReview); err != nil {
+		return err
+	}
+	if !accessReview.Status.Allowed {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// reviewToken returns the status of the TokenReview of the given token, which is reused for the tokenReviewTTL.
+func (a *ReviewAuthorizer) reviewToken(ctx context.Context, token string) (kauthenticationv1.TokenReviewStatus, error) {
+	key := sha256.Sum256([]byte(token))
+	now := time.Now()
+	a.mutex.Lock()
+	review, found := a.tokenReviews[key]
+	a.mutex.Unlock()
+	if found && now.Before(review.expires) {
+		return review.status, nil
+	}
+
+	tokenReview := &kauthenticationv1.TokenReview{Spec: kauthenticationv1.TokenReviewSpec{Token: token}}
+	if err := a.client.Create(ctx, tokenReview); err != nil {
+		return kauthenticationv1.TokenReviewStatus{}, err
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	// drop the expired results, so that the results of the tokens which are not used anymore are not kept.
+	for key, review := range a.tokenReviews {
+		if !now.Before(review.expires) {
+			delete(a.tokenReviews, key)
+		}
+	}
+	a.tokenReviews[key] = reviewedToken{status: tokenReview.Status, expires: now.Add(tokenReviewTTL)}
+	return tokenReview.Status, nil
+}
@@ -0,0 +1,248 @@
+// Package eventstore provides read-only access to the messages and the consumers of the Subscriptions in the stream
+// of the active backend, for debugging the delivery of the events.
+package eventstore
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	ceevent "github.com/cloudevents/sdk-go/v2/event"
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	eventingv1alpha2 "github.com/kyma-project/eventing-manager/api/eventing/v1alpha2"
+	backendjetstream "github.com/kyma-project/eventing-manager/pkg/backend/jetstream"
+	backendutils "github.com/kyma-project/eventing-manager/pkg/backend/utils"
+	"github.com/kyma-project/eventing-manager/pkg/logger"
+)
+
+const (
+	browserName = "event-store"
+
+	// DefaultLimit is the default number of the listed messages.
+	DefaultLimit = 10
+	// MaxLimit is the maximum number of the listed messages.
+	MaxLimit = 100
+)
+
+var (
+	ErrNoStream        = errors.New("the active backend has no stream")
+	ErrUnknownSubject  = errors.New("the subject is not subscribed by the Subscription")
+	ErrMessageNotFound = errors.New("the message was not found in the stream")
+)
+
+// StreamReader reads the messages and the consumers of the stream of the active backend.
+type StreamReader interface {
+	// GetJetStreamSubject returns the stream subject of the given source and event type.
+	GetJetStreamSubject(source, subject string, typeMatching eventingv1alpha2.TypeMatching) string
+	// GetSubjectMessages returns up to limit of the most recent messages stored for the subject.
+	GetSubjectMessages(subject string, limit int) ([]*nats.RawStreamMsg, error)
+	// GetStreamMessage returns the message stored with the sequence.
+	GetStreamMessage(seq uint64) (*nats.RawStreamMsg, error)
+	// GetConsumerInfo returns the state of the consumer.
+	GetConsumerInfo(consumerName string) (*nats.ConsumerInfo, error)
+}
+
+// Message is a message stored in the stream.
+type Message struct {
+	Sequence uint64    `json:"sequence"`
+	Subject  string    `json:"subject"`
+	Time     time.Time `json:"time"`
+	// Event is the message decoded as a CloudEvent.
+	Event *ceevent.Event `json:"event,omitempty"`
+	// Data is the data of the message which cannot be decoded as a CloudEvent.
+	Data string `json:"data,omitempty"`
+}
+
+// Consumer describes the state of a consumer of a Subscription.
+type Consumer struct {
+	Name string `json:"name"`
+	// EventTypes are the event types of the Subscription consumed by the consumer.
+	EventTypes []string `json:"eventTypes"`
+	// Pending is the number of the messages which were not delivered yet.
+	Pending uint64 `json:"pending"`
+	// AckPending is the number of the messages which were delivered and are waiting to be acknowledged.
+	AckPending int `json:"ackPending"`
+	// Redelivered is the number of the messages which were redelivered and are waiting to be acknowledged.
+	Redelivered int `json:"redelivered"`
+	// Delivered is the last delivered message.
+	Delivered SequenceInfo `json:"delivered"`
+	// AckFloor is the last message below which all the messages were acknowledged.
+	AckFloor SequenceInfo `json:"ackFloor"`
+	// Error is set if the state of the consumer cannot be read.
+	Error string `json:"error,omitempty"`
+}
+
+// SequenceInfo is a position of a consumer in the stream.
+type SequenceInfo struct {
+	Stream uint64     `json:"stream"`
+	Last   *time.Time `json:"last,omitempty"`
+}
+
+// Browser reads the messages and the consumers of the Subscriptions in the stream of the active backend.
+// It is safe for concurrent use.
+type Browser struct {
+	client client.Reader
+	logger *logger.Logger
+	mutex  sync.RWMutex
+	reader StreamReader
+}
+
+func New(client client.Reader, logger *logger.Logger) *Browser {
+	return &Browser{client: client, logger: logger}
+}
+
+// SetStreamReader sets the reader of the active backend stream, or removes it if nil is given.
+func (b *Browser) SetStreamReader(reader StreamReader) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.reader = reader
+}
+
+func (b *Browser) getStreamReader() (StreamReader, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	if b.reader == nil {
+		return nil, ErrNoStream
+	}
+	return b.reader, nil
+}
+
+// GetSubscription returns the Subscription by its namespace and name.
+func (b *Browser) GetSubscription(ctx context.Context, namespace, name string) (*eventingv1alpha2.Subscription, error) {
+	subscription := &eventingv1alpha2.Subscription{}
+	if err := b.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// ListMessages returns up to limit of the most recent messages of the subjects of the Subscription, starting with
+// the most recent one. If subject is given, only the messages of the subject are returned.
+func (b *Browser) ListMessages(subscription *eventingv1alpha2.Subscription, subject string,
+	limit int) ([]Message, error) {
+	reader, err := b.getStreamReader()
+	if err != nil {
+		return nil, err
+	}
+	subjects := getSubjects(reader, subscription)
+	if subject != "" {
+		if _, ok := subjects[subject]; !ok {
+			return nil, ErrUnknownSubject
+		}
+		subjects = map[string][]string{subject: subjects[subject]}
+	}
+
+	var messages []Message
+	for subj := range subjects {
+		msgs, err := reader.GetSubjectMessages(subj, limit)
+		if err != nil {
+			return nil, err
+		}
+		for _, msg := range msgs {
+			messages = append(messages, b.toMessage(msg))
+		}
+	}
+	sort.Slice(messages, func(i, j int) bool { return messages[i].Sequence > messages[j].Sequence })
+	if len(messages) > limit {
+		messages = messages[:limit]
+	}
+	return messages, nil
+}
+
+// GetMessage returns the message of the Subscription stored with the sequence.
+func (b *Browser) GetMessage(subscription *eventingv1alpha2.Subscription, seq uint64) (*Message, error) {
+	reader, err := b.getStreamReader()
+	if err != nil {
+		return nil, err
+	}
+	msg, err := reader.GetStreamMessage(seq)
+	if errors.Is(err, nats.ErrMsgNotFound) {
+		return nil, ErrMessageNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	// the messages of the subjects which are not subscribed by the Subscription are not disclosed
+	if !matchesAny(getSubjects(reader, subscription), msg.Subject) {
+		return nil, ErrMessageNotFound
+	}
+	message := b.toMessage(msg)
+	return &message, nil
+}
+
+// GetConsumers returns the state of the consumers of the Subscription.
+func (b *Browser) GetConsumers(subscription *eventingv1alpha2.Subscription) ([]Consumer, error) {
+	reader, err := b.getStreamReader()
+	if err != nil {
+		return nil, err
+	}
+
+	// the consolidated consumers consume multiple event types of the Subscription
+	var names []string
+	eventTypes := map[string][]string{}
+	for _, jsType := range subscription.Status.Backend.Types {
+		if _, ok := eventTypes[jsType.ConsumerName]; !ok {
+			names = append(names, jsType.ConsumerName)
+		}
+		eventTypes[jsType.ConsumerName] = append(eventTypes[jsType.ConsumerName], jsType.OriginalType)
+	}
+
+	consumers := make([]Consumer, 0, len(names))
+	for _, name := range names {
+		consumer := Consumer{Name: name, EventTypes: eventTypes[name]}
+		info, err := reader.GetConsumerInfo(name)
+		if err != nil {
+			consumer.Error = err.Error()
+			consumers = append(consumers, consumer)
+			continue
+		}
+		consumer.Pending = info.NumPending
+		consumer.AckPending = info.NumAckPending
+		consumer.Redelivered = info.NumRedelivered
+		consumer.Delivered = SequenceInfo{Stream: info.Delivered.Stream, Last: info.Delivered.Last}
+		consumer.AckFloor = SequenceInfo{Stream: info.AckFloor.Stream, Last: info.AckFloor.Last}
+		consumers = append(consumers, consumer)
+	}
+	return consumers, nil
+}
+
+func (b *Browser) toMessage(msg *nats.RawStreamMsg) Message {
+	message := Message{Sequence: msg.Sequence, Subject: msg.Subject, Time: msg.Time}
+	event, err := backendutils.ConvertMsgToCE(&nats.Msg{Subject: msg.Subject, Header: msg.Header, Data: msg.Data})
+	if err != nil {
+		b.namedLogger().Debugw("Failed to decode the message as a CloudEvent", "sequence", msg.Sequence, "error", err)
+		message.Data = string(msg.Data)
+		return message
+	}
+	message.Event = event
+	return message
+}
+
+func (b *Browser) namedLogger() *zap.SugaredLogger {
+	return b.logger.WithContext().Named(browserName)
+}
+
+// getSubjects returns the stream subjects of the Subscription with their event types.
+func getSubjects(reader StreamReader, subscription *eventingv1alpha2.Subscription) map[string][]string {
+	subjects := make(map[string][]string, len(subscription.Status.Types))
+	for _, eventType := range subscription.Status.Types {
+		subject := reader.GetJetStreamSubject(subscription.Spec.Source, eventType.CleanType,
+			subscription.Spec.TypeMatching)
+		subjects[subject] = append(subjects[subject], eventType.OriginalType)
+	}
+	return subjects
+}
+
+func matchesAny(subjects map[string][]string, subject string) bool {
+	for filter := range subjects {
+		if backendjetstream.SubjectMatches(filter, subject) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,393 @@
+package eventstore
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	kymalogger "github.com/kyma-project/kyma/common/logging/logger"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/require"
+	kauthenticationv1 "k8s.io/api/authentication/v1"
+	kauthorizationv1 "k8s.io/api/authorization/v1"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	eventingv1alpha2 "github.com/kyma-project/eventing-manager/api/eventing/v1alpha2"
+	backendjetstream "github.com/kyma-project/eventing-manager/pkg/backend/jetstream"
+	"github.com/kyma-project/eventing-manager/pkg/logger"
+)
+
+const testToken = "test-token"
+
+type stubAuthorizer struct {
+	err error
+}
+
+func (s stubAuthorizer) Authorize(_ context.Context, _, _, _ string) error {
+	return s.err
+}
+
+type stubStreamReader struct {
+	messages  []*nats.RawStreamMsg
+	consumers map[string]*nats.ConsumerInfo
+}
+
+func (s stubStreamReader) GetJetStreamSubject(source, subject string, _ eventingv1alpha2.TypeMatching) string {
+	return "kyma." + source + "." + subject
+}
+
+func (s stubStreamReader) GetSubjectMessages(subject string, limit int) ([]*nats.RawStreamMsg, error) {
+	var messages []*nats.RawStreamMsg
+	for i := len(s.messages) - 1; i >= 0 && len(messages) < limit; i-- {
+		if backendjetstream.SubjectMatches(subject, s.messages[i].Subject) {
+			messages = append(messages, s.messages[i])
+		}
+	}
+	return messages, nil
+}
+
+func (s stubStreamReader) GetStreamMessage(seq uint64) (*nats.RawStreamMsg, error) {
+	for _, msg := range s.messages {
+		if msg.Sequence == seq {
+			return msg, nil
+		}
+	}
+	return nil, nats.ErrMsgNotFound
+}
+
+func (s stubStreamReader) GetConsumerInfo(consumerName string) (*nats.ConsumerInfo, error) {
+	info, ok := s.consumers[consumerName]
+	if !ok {
+		return nil, nats.ErrConsumerNotFound
+	}
+	return info, nil
+}
+
+func newMsg(seq uint64, subject, eventType string) *nats.RawStreamMsg {
+	return &nats.RawStreamMsg{
+		Sequence: seq,
+		Subject:  subject,
+		Data: []byte(`{"specversion":"1.0","id":"id","source":"app","type":"` + eventType +
+			`","datacontenttype":"application/json","data":{"key":"value"}}`),
+	}
+}
+
+func newSubscription(name string, types ...string) *eventingv1alpha2.Subscription {
+	sub := &eventingv1alpha2.Subscription{
+		ObjectMeta: kmetav1.ObjectMeta{Name: name, Namespace: "test"},
+		Spec: eventingv1alpha2.SubscriptionSpec{
+			Sink:   "http://" + name + ".test.svc.cluster.local",
+			Source: "app",
+			Types:  types,
+		},
+	}
+	for _, eventType := range types {
+		sub.Status.Types = append(sub.Status.Types, eventingv1alpha2.EventType{OriginalType: eventType, CleanType: eventType})
+		sub.Status.Backend.Types = append(sub.Status.Backend.Types,
+			eventingv1alpha2.JetStreamTypes{OriginalType: eventType, ConsumerName: name + "-consumer"})
+	}
+	return sub
+}
+
+func newBrowser(t *testing.T, reader StreamReader, subs ...*eventingv1alpha2.Subscription) *Browser {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, eventingv1alpha2.AddToScheme(scheme))
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	for _, sub := range subs {
+		builder = builder.WithObjects(sub)
+	}
+	defaultLogger, err := logger.New(string(kymalogger.JSON), string(kymalogger.INFO))
+	require.NoError(t, err)
+	browser := New(builder.Build(), defaultLogger)
+	if reader != nil {
+		browser.SetStreamReader(reader)
+	}
+	return browser
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	reader := stubStreamReader{
+		messages: []*nats.RawStreamMsg{
+			newMsg(1, "kyma.app.order.created.v1", "order.created.v1"),
+			newMsg(2, "kyma.app.customer.created.v1", "customer.created.v1"),
+			newMsg(3, "kyma.app.order.updated.v1", "order.updated.v1"),
+			newMsg(4, "kyma.app.order.created.v1", "order.created.v1"),
+		},
+		consumers: map[string]*nats.ConsumerInfo{
+			"orders-consumer": {NumPending: 3, NumAckPending: 2, NumRedelivered: 1, AckFloor: nats.SequenceInfo{Stream: 1}},
+		},
+	}
+	orders := newSubscription("orders", "order.created.v1", "order.updated.v1")
+
+	testCases := []struct {
+		name          string
+		givenPath     string
+		givenAuthErr  error
+		givenReader   StreamReader
+		wantStatus    int
+		wantSequences []uint64
+		wantConsumers []Consumer
+		wantSingleSeq uint64
+	}{
+		{
+			name:         "should reject the unauthenticated requests",
+			givenPath:    "namespaces/test/subscriptions/orders/messages",
+			givenAuthErr: ErrUnauthenticated,
+			givenReader:  reader,
+			wantStatus:   http.StatusUnauthorized,
+		},
+		{
+			name:         "should reject the unauthorized requests",
+			givenPath:    "namespaces/test/subscriptions/orders/messages",
+			givenAuthErr: ErrForbidden,
+			givenReader:  reader,
+			wantStatus:   http.StatusForbidden,
+		},
+		{
+			name:        "should return not found for an unknown Subscription",
+			givenPath:   "namespaces/test/subscriptions/unknown/messages",
+			givenReader: reader,
+			wantStatus:  http.StatusNotFound,
+		},
+		{
+			name:        "should return not found for an unknown resource",
+			givenPath:   "namespaces/test/subscriptions/orders/unknown",
+			givenReader: reader,
+			wantStatus:  http.StatusNotFound,
+		},
+		{
+			name:        "should return service unavailable if there is no stream",
+			givenPath:   "namespaces/test/subscriptions/orders/messages",
+			givenReader: nil,
+			wantStatus:  http.StatusServiceUnavailable,
+		},
+		{
+			name:          "should list the recent messages of the Subscription",
+			givenPath:     "namespaces/test/subscriptions/orders/messages",
+			givenReader:   reader,
+			wantStatus:    http.StatusOK,
+			wantSequences: []uint64{4, 3, 1},
+		},
+		{
+			name:          "should list the recent messages of the Subscription up to the limit",
+			givenPath:     "namespaces/test/subscriptions/orders/messages?limit=2",
+			givenReader:   reader,
+			wantStatus:    http.StatusOK,
+			wantSequences: []uint64{4, 3},
+		},
+		{
+			name:          "should list the recent messages of a subject of the Subscription",
+			givenPath:     "namespaces/test/subscriptions/orders/messages?subject=kyma.app.order.created.v1",
+			givenReader:   reader,
+			wantStatus:    http.StatusOK,
+			wantSequences: []uint64{4, 1},
+		},
+		{
+			name:        "should reject a subject which is not subscribed by the Subscription",
+			givenPath:   "namespaces/test/subscriptions/orders/messages?subject=kyma.app.customer.created.v1",
+			givenReader: reader,
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "should reject an invalid limit",
+			givenPath:   "namespaces/test/subscriptions/orders/messages?limit=0",
+			givenReader: reader,
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:          "should show a message of the Subscription decoded as a CloudEvent",
+			givenPath:     "namespaces/test/subscriptions/orders/messages/3",
+			givenReader:   reader,
+			wantStatus:    http.StatusOK,
+			wantSingleSeq: 3,
+		},
+		{
+			name:        "should not show a message of another subject",
+			givenPath:   "namespaces/test/subscriptions/orders/messages/2",
+			givenReader: reader,
+			wantStatus:  http.StatusNotFound,
+		},
+		{
+			name:        "should return not found for an unknown sequence",
+			givenPath:   "namespaces/test/subscriptions/orders/messages/10",
+			givenReader: reader,
+			wantStatus:  http.StatusNotFound,
+		},
+		{
+			name:        "should show the state of the consumers of the Subscription",
+			givenPath:   "namespaces/test/subscriptions/orders/consumers",
+			givenReader: reader,
+			wantStatus:  http.StatusOK,
+			wantConsumers: []Consumer{
+				{
+					Name:        "orders-consumer",
+					EventTypes:  []string{"order.created.v1", "order.updated.v1"},
+					Pending:     3,
+					AckPending:  2,
+					Redelivered: 1,
+					AckFloor:    SequenceInfo{Stream: 1},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			handler := NewHandler(newBrowser(t, tc.givenReader, orders), stubAuthorizer{err: tc.givenAuthErr})
+			request := httptest.NewRequest(http.MethodGet, PathPrefix+tc.givenPath, nil)
+			request.Header.Set("Authorization", "Bearer "+testToken)
+			recorder := httptest.NewRecorder()
+
+			// when
+			handler.ServeHTTP(recorder, request)
+
+			// then
+			require.Equal(t, tc.wantStatus, recorder.Code)
+			switch {
+			case tc.wantSequences != nil:
+				var messages []Message
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &messages))
+				sequences := make([]uint64, 0, len(messages))
+				for _, message := range messages {
+					require.NotNil(t, message.Event)
+					sequences = append(sequences, message.Sequence)
+				}
+				require.Equal(t, tc.wantSequences, sequences)
+			case tc.wantSingleSeq != 0:
+				var message Message
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &message))
+				require.Equal(t, tc.wantSingleSeq, message.Sequence)
+				require.NotNil(t, message.Event)
+				require.Equal(t, "order.updated.v1", message.Event.Type())
+			case tc.wantConsumers != nil:
+				var consumers []Consumer
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &consumers))
+				require.Equal(t, tc.wantConsumers, consumers)
+			}
+		})
+	}
+}
+
+func TestReviewAuthorizer_Authorize(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name               string
+		givenToken         string
+		givenAuthenticated bool
+		givenAllowed       bool
+		wantErr            error
+	}{
+		{
+			name:       "should reject a missing token",
+			givenToken: "",
+			wantErr:    ErrUnauthenticated,
+		},
+		{
+			name:               "should reject an unauthenticated token",
+			givenToken:         testToken,
+			givenAuthenticated: false,
+			wantErr:            ErrUnauthenticated,
+		},
+		{
+			name:               "should reject an unauthorized user",
+			givenToken:         testToken,
+			givenAuthenticated: true,
+			givenAllowed:       false,
+			wantErr:            ErrForbidden,
+		},
+		{
+			name:               "should authorize an authorized user",
+			givenToken:         testToken,
+			givenAuthenticated: true,
+			givenAllowed:       true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			var gotAttributes *kauthorizationv1.ResourceAttributes
+			fakeClient := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(_ context.Context, _ client.WithWatch, obj client.Object, _ ...client.CreateOption) error {
+					switch review := obj.(type) {
+					case *kauthenticationv1.TokenReview:
+						require.Equal(t, tc.givenToken, review.Spec.Token)
+						review.Status.Authenticated = tc.givenAuthenticated
+						review.Status.User = kauthenticationv1.UserInfo{Username: "user", Groups: []string{"group"}}
+					case *kauthorizationv1.SubjectAccessReview:
+						require.Equal(t, "user", review.Spec.User)
+						gotAttributes = review.Spec.ResourceAttributes
+						review.Status.Allowed = tc.givenAllowed
+					}
+					return nil
+				},
+			}).Build()
+			authorizer := NewReviewAuthorizer(fakeClient)
+
+			// when
+			err := authorizer.Authorize(context.Background(), tc.givenToken, "test", "orders")
+
+			// then
+			require.ErrorIs(t, err, tc.wantErr)
+			if tc.givenAuthenticated {
+				require.Equal(t, &kauthorizationv1.ResourceAttributes{
+					Namespace:   "test",
+					Verb:        "get",
+					Group:       eventingv1alpha2.GroupVersion.Group,
+					Resource:    "subscriptions",
+					Subresource: Subresource,
+					Name:        "orders",
+				}, gotAttributes)
+			}
+		})
+	}
+}
+
+func TestReviewAuthorizer_Authorize_ReusesTokenReviews(t *testing.T) {
+	t.Parallel()
+
+	// given
+	tokenReviews := 0
+	fakeClient := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(_ context.Context, _ client.WithWatch, obj client.Object, _ ...client.CreateOption) error {
+			if _, ok := obj.(*kauthenticationv1.TokenReview); ok {
+				tokenReviews++
+			}
+			return nil
+		},
+	}).Build()
+	authorizer := NewReviewAuthorizer(fakeClient)
+
+	// when the same unauthenticated token is used repeatedly
+	for i := 0; i < 3; i++ {
+		err := authorizer.Authorize(context.Background(), testToken, "test", "orders")
+		require.ErrorIs(t, err, ErrUnauthenticated)
+	}
+
+	// then
+	require.Equal(t, 1, tokenReviews)
+
+	// when another token is used
+	err := authorizer.Authorize(context.Background(), "other-token", "test", "orders")
+
+	// then
+	require.ErrorIs(t, err, ErrUnauthenticated)
+	require.Equal(t, 2, tokenReviews)
+}
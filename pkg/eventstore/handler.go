@@ -0,0 +1,123 @@
+package eventstore
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const (
+	// PathPrefix is the path of the endpoints of the event store, followed by
+	// namespaces/{namespace}/subscriptions/{name}/ and either messages, messages/{sequence}, or consumers.
+	PathPrefix = "/debug/eventstore/"
+
+	messagesPath  = "messages"
+	consumersPath = "consumers"
+
+	limitParam   = "limit"
+	subjectParam = "subject"
+)
+
+// Handler serves the read-only HTTP endpoints of the event store.
+type Handler struct {
+	browser    *Browser
+	authorizer Authorizer
+}
+
+func NewHandler(browser *Browser, authorizer Authorizer) *Handler {
+	return &Handler{browser: browser, authorizer: authorizer}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	// namespaces/{namespace}/subscriptions/{name}/{resource}[/{sequence}]
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, PathPrefix), "/"), "/")
+	if len(parts) < 5 || len(parts) > 6 || parts[0] != "namespaces" || parts[2] != subscriptionsResource {
+		http.NotFound(w, r)
+		return
+	}
+	namespace, name, resource := parts[1], parts[3], parts[4]
+
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if err := h.authorizer.Authorize(r.Context(), token, namespace, name); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	subscription, err := h.browser.GetSubscription(r.Context(), namespace, name)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	var result interface{}
+	switch {
+	case resource == messagesPath && len(parts) == 5:
+		limit, parseErr := parseLimit(r.URL.Query().Get(limitParam))
+		if parseErr != nil {
+			http.Error(w, "limit must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		result, err = h.browser.ListMessages(subscription, r.URL.Query().Get(subjectParam), limit)
+	case resource == messagesPath:
+		seq, parseErr := strconv.ParseUint(parts[5], 10, 64)
+		if parseErr != nil {
+			http.Error(w, "sequence must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		result, err = h.browser.GetMessage(subscription, seq)
+	case resource == consumersPath && len(parts) == 5:
+		result, err = h.browser.GetConsumers(subscription)
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		h.browser.namedLogger().Errorw("Failed to write the response", "error", err)
+	}
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrUnauthenticated):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, ErrForbidden):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, ErrMessageNotFound), kerrors.IsNotFound(err):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrUnknownSubject):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, ErrNoStream):
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+	default:
+		h.browser.namedLogger().Errorw("Failed to browse the event store", "error", err)
+		http.Error(w, "failed to browse the event store", http.StatusInternalServerError)
+	}
+}
+
+func parseLimit(value string) (int, error) {
+	if value == "" {
+		return DefaultLimit, nil
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 1 {
+		return 0, errors.New("invalid limit")
+	}
+	if limit > MaxLimit {
+		return MaxLimit, nil
+	}
+	return limit, nil
+}
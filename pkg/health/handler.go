@@ -0,0 +1,71 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+)
+
+// PathBackendHealth is the path of the endpoint serving the results of the checks.
+const PathBackendHealth = "/backend-health"
+
+// Perform a compile-time check.
+var _ http.Handler = &Registry{}
+
+// checkResult is the result of a check served by the endpoint.
+type checkResult struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	Error   string `json:"error,omitempty"`
+}
+
+// instanceResults are the results of the checks of a backend instance served by the endpoint.
+type instanceResults struct {
+	Instance string        `json:"instance"`
+	Checks   []checkResult `json:"checks"`
+}
+
+// ServeHTTP serves the results of the last run of the checks of all the backend instances as JSON. It responds with
+// the status code 503 if any check failed, and with 200 otherwise. It does not run the checks, so that serving the
+// results never calls the backends. The results are empty on the replicas which are not the leader, since the checks
+// are set by the Eventing reconciler.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	results, healthy := r.allResults()
+	w.Header().Set("Content-Type", "application/json")
+	if !healthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(results)
+}
+
+// allResults returns the results of the last run of the checks of all the backend instances sorted by instance,
+// and false if any check failed.
+func (r *Registry) allResults() ([]instanceResults, bool) {
+	all := []instanceResults{}
+	if r == nil {
+		return all, true
+	}
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	healthy := true
+	for instance, results := range r.results {
+		checks := make([]checkResult, 0, len(results))
+		for _, result := range results {
+			check := checkResult{Name: result.Name, Healthy: result.Err == nil}
+			if result.Err != nil {
+				check.Error = result.Err.Error()
+				healthy = false
+			}
+			checks = append(checks, check)
+		}
+		all = append(all, instanceResults{Instance: instance, Checks: checks})
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Instance < all[j].Instance })
+	return all, healthy
+}
@@ -0,0 +1,212 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// The names of the checks of the messaging backends.
+const (
+	CheckJetStreamAccount = "jetstream-account"
+	CheckJetStreamStream  = "jetstream-stream"
+	CheckEventMeshToken   = "eventmesh-token"
+	CheckEventMeshAPI     = "eventmesh-api"
+)
+
+var ErrCheckTimeout = errors.New("health check timed out")
+
+// Perform a compile-time check.
+var _ manager.Runnable = &Registry{}
+
+// Check is a check of a messaging backend.
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// Result is the result of a check, Err is nil if the check succeeded.
+type Result struct {
+	Name string
+	Err  error
+}
+
+// Registry holds the checks of the active messaging backends. It runs them periodically in the background,
+// each with a timeout, and caches their results, so that reading the results never calls the backends.
+// A nil Registry holds no checks.
+type Registry struct {
+	timeout  time.Duration
+	interval time.Duration
+	// refresh triggers running the checks before the next interval, e.g. once the checks of a new instance are set.
+	refresh chan struct{}
+
+	mutex sync.RWMutex
+	// checks holds the checks per backend instance, e.g. per NATS server.
+	checks map[string][]Check
+	// results holds the results of the last run of the checks per backend instance.
+	results map[string][]Result
+	// handler is called when the results of a backend instance changed.
+	handler func(instance string)
+}
+
+func NewRegistry(timeout, interval time.Duration) *Registry {
+	return &Registry{
+		timeout:  timeout,
+		interval: interval,
+		refresh:  make(chan struct{}, 1),
+		checks:   make(map[string][]Check),
+		results:  make(map[string][]Result),
+	}
+}
+
+// SetHandler sets the handler called when the results of a backend instance changed.
+func (r *Registry) SetHandler(handler func(instance string)) {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.handler = handler
+}
+
+// Set replaces the checks of the given backend instance. The checks of a new instance are run immediately.
+func (r *Registry) Set(instance string, checks []Check) {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	_, found := r.checks[instance]
+	r.checks[instance] = checks
+	r.mutex.Unlock()
+
+	if !found {
+		select {
+		case r.refresh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Remove removes the checks of the given backend instance and their results, e.g. once its subscription manager
+// is stopped.
+func (r *Registry) Remove(instance string) {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	delete(r.checks, instance)
+	delete(r.results, instance)
+}
+
+// Results returns the results of the last run of the checks of the given backend instance. It returns no results
+// if the checks did not run yet.
+func (r *Registry) Results(instance string) []Result {
+	if r == nil {
+		return nil
+	}
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return append([]Result(nil), r.results[instance]...)
+}
+
+// Start runs the checks every interval and whenever the checks of a new instance are set, until the given
+// context is done.
+func (r *Registry) Start(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		r.Refresh(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		case <-r.refresh:
+		}
+	}
+}
+
+// NeedLeaderElection implements the manager.LeaderElectionRunnable interface.
+// The checks are set by the Eventing reconciler, which runs on the leader only.
+func (r *Registry) NeedLeaderElection() bool {
+	return true
+}
+
+// Refresh runs the checks of all the backend instances and caches their results. It calls the handler for each
+// instance whose results changed.
+func (r *Registry) Refresh(ctx context.Context) {
+	if r == nil {
+		return
+	}
+	r.mutex.RLock()
+	instances := make([]string, 0, len(r.checks))
+	checks := make(map[string][]Check, len(r.checks))
+	for instance, instanceChecks := range r.checks {
+		instances = append(instances, instance)
+		checks[instance] = instanceChecks
+	}
+	r.mutex.RUnlock()
+	sort.Strings(instances)
+
+	for _, instance := range instances {
+		results := make([]Result, 0, len(checks[instance]))
+		for _, check := range checks[instance] {
+			results = append(results, Result{Name: check.Name, Err: r.run(ctx, check)})
+		}
+
+		r.mutex.Lock()
+		if _, found := r.checks[instance]; !found {
+			// the instance was removed while its checks were running.
+			r.mutex.Unlock()
+			continue
+		}
+		changed := !equalResults(r.results[instance], results)
+		r.results[instance] = results
+		handler := r.handler
+		r.mutex.Unlock()
+
+		if changed && handler != nil {
+			handler(instance)
+		}
+	}
+}
+
+// run runs the given check, and gives up on it once the timeout is exceeded, even if the check does not stop.
+func (r *Registry) run(ctx context.Context, check Check) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- check.Run(ctx)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("%w: %s after %v", ErrCheckTimeout, check.Name, r.timeout)
+	}
+}
+
+// equalResults returns true if the given results have the same checks with the same errors.
+func equalResults(a, b []Result) bool {
+	return reflect.DeepEqual(resultMessages(a), resultMessages(b))
+}
+
+func resultMessages(results []Result) map[string]string {
+	messages := make(map[string]string, len(results))
+	for _, result := range results {
+		messages[result.Name] = ""
+		if result.Err != nil {
+			messages[result.Name] = result.Err.Error()
+		}
+	}
+	return messages
+}
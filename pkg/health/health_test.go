@@ -0,0 +1,163 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errCheckFailed = errors.New("check failed")
+
+func Test_Refresh(t *testing.T) {
+	t.Parallel()
+
+	// given
+	registry := NewRegistry(50*time.Millisecond, time.Hour)
+	registry.Set("nats", []Check{
+		{Name: CheckJetStreamAccount, Run: func(context.Context) error { return nil }},
+		{Name: CheckJetStreamStream, Run: func(context.Context) error { return errCheckFailed }},
+	})
+	registry.Set("eventmesh", []Check{
+		{Name: CheckEventMeshAPI, Run: func(ctx context.Context) error {
+			// ignores the context, so that the registry has to give up on it.
+			time.Sleep(time.Second)
+			return nil
+		}},
+	})
+	require.Empty(t, registry.Results("nats"))
+
+	// when
+	registry.Refresh(context.Background())
+
+	// then
+	require.Equal(t, []Result{
+		{Name: CheckJetStreamAccount, Err: nil},
+		{Name: CheckJetStreamStream, Err: errCheckFailed},
+	}, registry.Results("nats"))
+	eventMeshResults := registry.Results("eventmesh")
+	require.Len(t, eventMeshResults, 1)
+	require.ErrorIs(t, eventMeshResults[0].Err, ErrCheckTimeout)
+	require.Empty(t, registry.Results("unknown"))
+
+	// when the checks of an instance are removed
+	registry.Remove("nats")
+
+	// then
+	require.Empty(t, registry.Results("nats"))
+}
+
+func Test_Refresh_Handler(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var failing atomic.Bool
+	registry := NewRegistry(time.Second, time.Hour)
+	registry.Set("nats", []Check{{Name: CheckJetStreamStream, Run: func(context.Context) error {
+		if failing.Load() {
+			return errCheckFailed
+		}
+		return nil
+	}}})
+	var changes []string
+	registry.SetHandler(func(instance string) { changes = append(changes, instance) })
+
+	// when the checks run for the first time, again with the same results, and again with changed results
+	registry.Refresh(context.Background())
+	registry.Refresh(context.Background())
+	failing.Store(true)
+	registry.Refresh(context.Background())
+
+	// then the handler is called for the first and the changed results only
+	require.Equal(t, []string{"nats", "nats"}, changes)
+}
+
+func Test_Start(t *testing.T) {
+	t.Parallel()
+
+	// given
+	registry := NewRegistry(time.Second, time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error)
+	go func() { done <- registry.Start(ctx) }()
+
+	// when the checks of a new instance are set
+	registry.Set("nats", []Check{{Name: CheckJetStreamStream, Run: func(context.Context) error { return nil }}})
+
+	// then they run before the next interval
+	require.Eventually(t, func() bool {
+		return len(registry.Results("nats")) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func Test_NilRegistry(t *testing.T) {
+	t.Parallel()
+
+	// given
+	var registry *Registry
+
+	// when
+	registry.Set("nats", []Check{{Name: CheckJetStreamStream, Run: func(context.Context) error { return errCheckFailed }}})
+	registry.Refresh(context.Background())
+
+	// then
+	require.Empty(t, registry.Results("nats"))
+	results, healthy := registry.allResults()
+	require.Empty(t, results)
+	require.True(t, healthy)
+}
+
+func Test_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		givenErr       error
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:           "should serve the results with status code 200 if all the checks succeeded",
+			givenErr:       nil,
+			wantStatusCode: http.StatusOK,
+			wantBody:       `[{"instance":"nats","checks":[{"name":"jetstream-stream","healthy":true}]}]`,
+		},
+		{
+			name:           "should serve the results with status code 503 if a check failed",
+			givenErr:       errCheckFailed,
+			wantStatusCode: http.StatusServiceUnavailable,
+			wantBody: `[{"instance":"nats","checks":[` +
+				`{"name":"jetstream-stream","healthy":false,"error":"check failed"}]}]`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			registry := NewRegistry(time.Second, time.Hour)
+			registry.Set("nats", []Check{{Name: CheckJetStreamStream, Run: func(context.Context) error {
+				return tc.givenErr
+			}}})
+			registry.Refresh(context.Background())
+			recorder := httptest.NewRecorder()
+
+			// when
+			registry.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, PathBackendHealth, nil))
+
+			// then
+			require.Equal(t, tc.wantStatusCode, recorder.Code)
+			require.JSONEq(t, tc.wantBody, recorder.Body.String())
+		})
+	}
+}
@@ -12,4 +12,6 @@ const (
 	APIRuleCrdName string = "apirules.gateway.kyma-project.io"
 	// PeerAuthenticationCRDName is the name of the Istio peer authentication CRD.
 	PeerAuthenticationCRDName = "peerauthentications.security.istio.io"
+	// SubscriptionCrdName is the name of the Subscription CRD, which uses a conversion webhook.
+	SubscriptionCrdName = "subscriptions.eventing.kyma-project.io"
 )
@@ -0,0 +1,234 @@
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	kcoordinationv1 "k8s.io/api/coordination/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/kyma-project/eventing-manager/internal/label"
+	"github.com/kyma-project/eventing-manager/pkg/logger"
+)
+
+const (
+	membershipName = "shard-membership"
+
+	defaultLeaseDuration = 15 * time.Second
+	defaultRenewInterval = 5 * time.Second
+)
+
+// Perform a compile-time check.
+var _ manager.Runnable = &LeaseMembership{}
+
+// Owner decides which keys are owned by this replica.
+type Owner interface {
+	// Owns returns true if the given key is owned by this replica.
+	Owns(key string) bool
+	// Changes returns a channel which receives a value whenever the ownership changes.
+	Changes() <-chan struct{}
+}
+
+// LeaseMembership tracks the live replicas of a shard group by a Lease per replica, and assigns the keys
+// to the replicas by consistent hashing. A replica holds its shard Lease by renewing it, and leaves the group
+// by deleting it or by not renewing it within the lease duration, e.g. when it crashed.
+// The keys are rebalanced automatically whenever replicas join or leave.
+// It is safe for concurrent use.
+type LeaseMembership struct {
+	client        client.Client
+	reader        client.Reader
+	namespace     string
+	group         string
+	identity      string
+	leaseDuration time.Duration
+	renewInterval time.Duration
+	logger        *logger.Logger
+	now           func() time.Time
+
+	mutex   sync.RWMutex
+	ring    *Ring
+	changes chan struct{}
+}
+
+// NewLeaseMembership returns the membership of the given replica identity in the given shard group.
+// The reader should not be cached, since only the Leases in the given namespace are listed.
+func NewLeaseMembership(client client.Client, reader client.Reader, namespace, group, identity string,
+	logger *logger.Logger,
+) *LeaseMembership {
+	return &LeaseMembership{
+		client:        client,
+		reader:        reader,
+		namespace:     namespace,
+		group:         group,
+		identity:      identity,
+		leaseDuration: defaultLeaseDuration,
+		renewInterval: defaultRenewInterval,
+		logger:        logger,
+		now:           time.Now,
+		ring:          NewRing(nil, defaultVirtualNodes),
+		changes:       make(chan struct{}, 1),
+	}
+}
+
+// +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;create;update;delete
+
+// Start renews the shard Lease until the given context is done, and then deletes it to leave the group.
+func (m *LeaseMembership) Start(ctx context.Context) error {
+	m.namedLogger().Infow("Joining the shard group", "group", m.group, "identity", m.identity)
+
+	ticker := time.NewTicker(m.renewInterval)
+	defer ticker.Stop()
+	for {
+		if err := m.renew(ctx); err != nil {
+			m.namedLogger().Errorw("Failed to renew the shard membership", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			m.leave()
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection implements the manager.LeaderElectionRunnable interface.
+// All the replicas are members of the shard group.
+func (m *LeaseMembership) NeedLeaderElection() bool {
+	return false
+}
+
+// Owns returns true if the given key is owned by this replica.
+func (m *LeaseMembership) Owns(key string) bool {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.ring.Owner(key) == m.identity
+}
+
+// Changes returns a channel which receives a value whenever the members of the group change.
+func (m *LeaseMembership) Changes() <-chan struct{} {
+	return m.changes
+}
+
+// Members returns the sorted live members of the group.
+func (m *LeaseMembership) Members() []string {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.ring.Members()
+}
+
+// renew renews the shard Lease of this replica and updates the ring with the live members.
+func (m *LeaseMembership) renew(ctx context.Context) error {
+	if err := m.renewLease(ctx); err != nil {
+		return err
+	}
+
+	leases := &kcoordinationv1.LeaseList{}
+	if err := m.reader.List(ctx, leases,
+		client.InNamespace(m.namespace),
+		client.MatchingLabels{label.KeyShard: m.group},
+	); err != nil {
+		return fmt.Errorf("failed to list the shard leases: %w", err)
+	}
+
+	members := liveMembers(leases.Items, m.now())
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if reflect.DeepEqual(members, m.ring.Members()) {
+		return nil
+	}
+	m.namedLogger().Infow("Rebalancing the shards", "group", m.group, "members", members)
+	m.ring = NewRing(members, defaultVirtualNodes)
+	select {
+	case m.changes <- struct{}{}:
+	default:
+		// a change is already pending.
+	}
+	return nil
+}
+
+func (m *LeaseMembership) renewLease(ctx context.Context) error {
+	now := kmetav1.NewMicroTime(m.now())
+	lease := &kcoordinationv1.Lease{}
+	err := m.reader.Get(ctx, client.ObjectKey{Namespace: m.namespace, Name: m.leaseName()}, lease)
+	if kerrors.IsNotFound(err) {
+		lease = &kcoordinationv1.Lease{
+			ObjectMeta: kmetav1.ObjectMeta{
+				Name:      m.leaseName(),
+				Namespace: m.namespace,
+				Labels: map[string]string{
+					label.KeyShard:     m.group,
+					label.KeyCreatedBy: label.ValueEventingManager,
+				},
+			},
+			Spec: kcoordinationv1.LeaseSpec{
+				HolderIdentity:       ptr.To(m.identity),
+				LeaseDurationSeconds: ptr.To(int32(m.leaseDuration.Seconds())),
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}
+		if err = m.client.Create(ctx, lease); err != nil {
+			return fmt.Errorf("failed to create the shard lease: %w", err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get the shard lease: %w", err)
+	}
+
+	lease.Spec.HolderIdentity = ptr.To(m.identity)
+	lease.Spec.LeaseDurationSeconds = ptr.To(int32(m.leaseDuration.Seconds()))
+	lease.Spec.RenewTime = &now
+	if err = m.client.Update(ctx, lease); err != nil {
+		return fmt.Errorf("failed to update the shard lease: %w", err)
+	}
+	return nil
+}
+
+// leave deletes the shard Lease, so that the other replicas take over the keys without waiting for it to expire.
+func (m *LeaseMembership) leave() {
+	ctx, cancel := context.WithTimeout(context.Background(), m.renewInterval)
+	defer cancel()
+
+	lease := &kcoordinationv1.Lease{ObjectMeta: kmetav1.ObjectMeta{Name: m.leaseName(), Namespace: m.namespace}}
+	if err := m.client.Delete(ctx, lease); err != nil && !kerrors.IsNotFound(err) {
+		m.namedLogger().Errorw("Failed to delete the shard lease", "error", err)
+		return
+	}
+	m.namedLogger().Infow("Left the shard group", "group", m.group, "identity", m.identity)
+}
+
+func (m *LeaseMembership) leaseName() string {
+	return m.group + "-" + m.identity
+}
+
+func (m *LeaseMembership) namedLogger() *zap.SugaredLogger {
+	return m.logger.WithContext().Named(membershipName)
+}
+
+// liveMembers returns the sorted holders of the leases which were renewed within their lease duration.
+func liveMembers(leases []kcoordinationv1.Lease, now time.Time) []string {
+	members := make([]string, 0, len(leases))
+	for _, lease := range leases {
+		spec := lease.Spec
+		if spec.HolderIdentity == nil || spec.RenewTime == nil || spec.LeaseDurationSeconds == nil {
+			continue
+		}
+		expiry := spec.RenewTime.Add(time.Duration(*spec.LeaseDurationSeconds) * time.Second)
+		if now.After(expiry) {
+			continue
+		}
+		members = append(members, *spec.HolderIdentity)
+	}
+	sort.Strings(members)
+	return members
+}
@@ -0,0 +1,80 @@
+package sharding
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kymalogger "github.com/kyma-project/kyma/common/logging/logger"
+	"github.com/stretchr/testify/require"
+	kcoordinationv1 "k8s.io/api/coordination/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kyma-project/eventing-manager/internal/label"
+	"github.com/kyma-project/eventing-manager/pkg/logger"
+)
+
+func newLease(identity string, renewTime time.Time) *kcoordinationv1.Lease {
+	return &kcoordinationv1.Lease{
+		ObjectMeta: kmetav1.ObjectMeta{
+			Name:      "dispatcher-" + identity,
+			Namespace: "kyma-system",
+			Labels:    map[string]string{label.KeyShard: "dispatcher"},
+		},
+		Spec: kcoordinationv1.LeaseSpec{
+			HolderIdentity:       ptr.To(identity),
+			LeaseDurationSeconds: ptr.To(int32(15)),
+			RenewTime:            ptr.To(kmetav1.NewMicroTime(renewTime)),
+		},
+	}
+}
+
+func TestLeaseMembership_renew(t *testing.T) {
+	t.Parallel()
+
+	// given
+	now := time.Now()
+	scheme := runtime.NewScheme()
+	require.NoError(t, kcoordinationv1.AddToScheme(scheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newLease("replica-b", now),
+		newLease("replica-c", now.Add(-time.Minute)), // expired
+	).Build()
+	defaultLogger, err := logger.New(string(kymalogger.JSON), string(kymalogger.INFO))
+	require.NoError(t, err)
+	membership := NewLeaseMembership(fakeClient, fakeClient, "kyma-system", "dispatcher", "replica-a", defaultLogger)
+	membership.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	// when
+	require.NoError(t, membership.renew(ctx))
+
+	// then the own lease is created and the expired member is left out
+	lease := &kcoordinationv1.Lease{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: "kyma-system", Name: "dispatcher-replica-a"}, lease))
+	require.Equal(t, "replica-a", *lease.Spec.HolderIdentity)
+	require.Equal(t, []string{"replica-a", "replica-b"}, membership.Members())
+	require.Len(t, membership.Changes(), 1)
+	<-membership.Changes()
+
+	// when renewing without changes
+	now = now.Add(time.Second)
+	require.NoError(t, membership.renew(ctx))
+
+	// then
+	require.Empty(t, membership.Changes())
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: "kyma-system", Name: "dispatcher-replica-a"}, lease))
+	require.True(t, lease.Spec.RenewTime.Time.Equal(now.Truncate(time.Microsecond)))
+
+	// when leaving the group
+	membership.leave()
+
+	// then
+	err = fakeClient.Get(ctx, client.ObjectKey{Namespace: "kyma-system", Name: "dispatcher-replica-a"}, lease)
+	require.True(t, kerrors.IsNotFound(err))
+}
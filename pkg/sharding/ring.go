@@ -0,0 +1,68 @@
+package sharding
+
+import (
+	"hash/fnv"
+	"sort"
+	"strconv"
+)
+
+// defaultVirtualNodes is the number of points per member on the ring, which spreads the keys evenly.
+const defaultVirtualNodes = 100
+
+// Ring assigns keys to members by consistent hashing, so that only the keys of a joining or leaving member move.
+// It is immutable and safe for concurrent use.
+type Ring struct {
+	members []string
+	hashes  []uint32
+	owners  map[uint32]string
+}
+
+// NewRing returns a ring of the given members with the given number of virtual nodes per member.
+func NewRing(members []string, virtualNodes int) *Ring {
+	if virtualNodes <= 0 {
+		virtualNodes = defaultVirtualNodes
+	}
+	ring := &Ring{
+		members: append([]string{}, members...),
+		hashes:  make([]uint32, 0, len(members)*virtualNodes),
+		owners:  make(map[uint32]string, len(members)*virtualNodes),
+	}
+	sort.Strings(ring.members)
+	for _, member := range ring.members {
+		for i := 0; i < virtualNodes; i++ {
+			hash := hashOf(member + "#" + strconv.Itoa(i))
+			if _, ok := ring.owners[hash]; ok {
+				// keep the first member on a hash collision, the members are sorted so that it is deterministic.
+				continue
+			}
+			ring.owners[hash] = member
+			ring.hashes = append(ring.hashes, hash)
+		}
+	}
+	sort.Slice(ring.hashes, func(i, j int) bool { return ring.hashes[i] < ring.hashes[j] })
+	return ring
+}
+
+// Members returns the sorted members of the ring.
+func (r *Ring) Members() []string {
+	return append([]string{}, r.members...)
+}
+
+// Owner returns the member owning the given key, or an empty string if the ring has no members.
+func (r *Ring) Owner(key string) string {
+	if len(r.hashes) == 0 {
+		return ""
+	}
+	hash := hashOf(key)
+	i := sort.Search(len(r.hashes), func(i int) bool { return r.hashes[i] >= hash })
+	if i == len(r.hashes) {
+		i = 0
+	}
+	return r.owners[r.hashes[i]]
+}
+
+func hashOf(key string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return h.Sum32()
+}
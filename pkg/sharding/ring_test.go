@@ -0,0 +1,49 @@
+package sharding_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-project/eventing-manager/pkg/sharding"
+)
+
+func TestRing_Owner(t *testing.T) {
+	t.Parallel()
+
+	// given
+	keys := make([]string, 1000)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("namespace/subscription-%d/kyma.noapp.order.created.v1", i)
+	}
+
+	// when
+	empty := sharding.NewRing(nil, 0)
+
+	// then
+	require.Empty(t, empty.Owner(keys[0]))
+
+	// when
+	ring := sharding.NewRing([]string{"replica-b", "replica-a"}, 0)
+
+	// then every key has an owner and the keys are spread
+	require.Equal(t, []string{"replica-a", "replica-b"}, ring.Members())
+	owned := map[string]int{}
+	for _, key := range keys {
+		owned[ring.Owner(key)]++
+	}
+	require.Len(t, owned, 2)
+	require.Greater(t, owned["replica-a"], len(keys)/4)
+	require.Greater(t, owned["replica-b"], len(keys)/4)
+
+	// when a replica joins
+	scaled := sharding.NewRing([]string{"replica-a", "replica-b", "replica-c"}, 0)
+
+	// then only keys moving to the new replica change their owner
+	for _, key := range keys {
+		if owner := scaled.Owner(key); owner != "replica-c" {
+			require.Equal(t, ring.Owner(key), owner)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// Package subscriptiondefaults defaults the Subscriptions with the EventingConfig of their namespace.
+package subscriptiondefaults
+
+import (
+	"context"
+	"fmt"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	eventingv1alpha2 "github.com/kyma-project/eventing-manager/api/eventing/v1alpha2"
+	operatorv1alpha1 "github.com/kyma-project/eventing-manager/api/operator/v1alpha1"
+	"github.com/kyma-project/eventing-manager/pkg/tenancy"
+)
+
+// +kubebuilder:rbac:groups=eventing.kyma-project.io,resources=eventingconfigs,verbs=get;list;watch
+
+var _ admission.CustomDefaulter = &Defaulter{}
+
+// Defaulter defaults the Subscriptions with the defaults of the EventingConfig of their namespace, capped by the
+// limits of the Eventing instance owning the namespace, and then with the built-in defaults.
+type Defaulter struct {
+	client   client.Reader
+	resolver *tenancy.Resolver
+}
+
+func NewDefaulter(client client.Reader, resolver *tenancy.Resolver) *Defaulter {
+	return &Defaulter{client: client, resolver: resolver}
+}
+
+// Default implements admission.CustomDefaulter.
+func (d *Defaulter) Default(ctx context.Context, obj runtime.Object) error {
+	subscription, ok := obj.(*eventingv1alpha2.Subscription)
+	if !ok {
+		return fmt.Errorf("expected a Subscription but got %T", obj)
+	}
+
+	defaults, err := d.getDefaults(ctx, subscription.Namespace)
+	if err != nil {
+		return err
+	}
+	subscription.DefaultWith(defaults)
+	subscription.Default()
+	return nil
+}
+
+// getDefaults returns the Subscription defaults of the namespace, or nil if the namespace has no EventingConfig.
+func (d *Defaulter) getDefaults(ctx context.Context, namespace string) (*eventingv1alpha2.SubscriptionDefaults, error) {
+	config := &eventingv1alpha2.EventingConfig{}
+	key := ktypes.NamespacedName{Namespace: namespace, Name: eventingv1alpha2.EventingConfigName}
+	if err := d.client.Get(ctx, key, config); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get the EventingConfig of the namespace %s: %w", namespace, err)
+	}
+	defaults := config.Spec.SubscriptionDefaults
+	if defaults == nil || defaults.MaxInFlightMessages == nil {
+		return defaults, nil
+	}
+
+	limits, err := d.getLimits(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if limits != nil && limits.MaxInFlightMessages != nil && *defaults.MaxInFlightMessages > *limits.MaxInFlightMessages {
+		defaults = defaults.DeepCopy()
+		defaults.MaxInFlightMessages = limits.MaxInFlightMessages
+	}
+	return defaults, nil
+}
+
+// getLimits returns the Subscription limits of the Eventing instance owning the namespace, or nil if no instance
+// owns it.
+func (d *Defaulter) getLimits(ctx context.Context, namespace string) (*operatorv1alpha1.SubscriptionLimits, error) {
+	owner, found, err := d.resolver.Owner(ctx, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve the Eventing instance of the namespace %s: %w", namespace, err)
+	}
+	if !found {
+		return nil, nil
+	}
+	eventing := &operatorv1alpha1.Eventing{}
+	if err = d.client.Get(ctx, owner, eventing); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	return eventing.Spec.SubscriptionLimits, nil
+}
@@ -0,0 +1,159 @@
+package subscriptiondefaults
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	kcorev1 "k8s.io/api/core/v1"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	eventingv1alpha2 "github.com/kyma-project/eventing-manager/api/eventing/v1alpha2"
+	operatorv1alpha1 "github.com/kyma-project/eventing-manager/api/operator/v1alpha1"
+	"github.com/kyma-project/eventing-manager/pkg/tenancy"
+	eventingtesting "github.com/kyma-project/eventing-manager/testing"
+)
+
+const namespace = "test"
+
+var defaultInstance = ktypes.NamespacedName{Namespace: "kyma-system", Name: "eventing"}
+
+func newEventingConfig(defaults *eventingv1alpha2.SubscriptionDefaults) *eventingv1alpha2.EventingConfig {
+	return &eventingv1alpha2.EventingConfig{
+		ObjectMeta: kmetav1.ObjectMeta{Namespace: namespace, Name: eventingv1alpha2.EventingConfigName},
+		Spec:       eventingv1alpha2.EventingConfigSpec{SubscriptionDefaults: defaults},
+	}
+}
+
+func newEventing(limits *operatorv1alpha1.SubscriptionLimits) *operatorv1alpha1.Eventing {
+	return &operatorv1alpha1.Eventing{
+		ObjectMeta: kmetav1.ObjectMeta{Namespace: defaultInstance.Namespace, Name: defaultInstance.Name},
+		Spec:       operatorv1alpha1.EventingSpec{SubscriptionLimits: limits},
+	}
+}
+
+func newDefaulter(t *testing.T, objs ...client.Object) *Defaulter {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, kcorev1.AddToScheme(scheme))
+	require.NoError(t, eventingv1alpha2.AddToScheme(scheme))
+	require.NoError(t, operatorv1alpha1.AddToScheme(scheme))
+	objs = append(objs, &kcorev1.Namespace{ObjectMeta: kmetav1.ObjectMeta{Name: namespace}})
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	return NewDefaulter(fakeClient, tenancy.NewResolver(fakeClient, defaultInstance))
+}
+
+func Test_Default(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		givenObjs  []client.Object
+		givenSub   *eventingv1alpha2.Subscription
+		wantConfig map[string]string
+	}{
+		{
+			name:     "should set the built-in defaults if the namespace has no EventingConfig",
+			givenSub: eventingtesting.NewSubscription("sub", namespace),
+			wantConfig: map[string]string{
+				eventingv1alpha2.MaxInFlightMessages: eventingv1alpha2.DefaultMaxInFlightMessages,
+			},
+		},
+		{
+			name: "should set the defaults of the EventingConfig",
+			givenObjs: []client.Object{
+				newEventingConfig(&eventingv1alpha2.SubscriptionDefaults{
+					MaxInFlightMessages: ptr.To(20),
+					Qos:                 "AT_MOST_ONCE",
+					ContentMode:         "STRUCTURED",
+					WebhookAuth: &eventingv1alpha2.DefaultWebhookAuth{
+						Type:       "bearer",
+						SecretName: "sink-credentials",
+					},
+				}),
+			},
+			givenSub: eventingtesting.NewSubscription("sub", namespace),
+			wantConfig: map[string]string{
+				eventingv1alpha2.MaxInFlightMessages:         "20",
+				eventingv1alpha2.ProtocolSettingsQos:         "AT_MOST_ONCE",
+				eventingv1alpha2.ProtocolSettingsContentMode: "STRUCTURED",
+				eventingv1alpha2.WebhookAuthType:             "bearer",
+				eventingv1alpha2.WebhookAuthSecretName:       "sink-credentials",
+			},
+		},
+		{
+			name: "should not override the config of the Subscription",
+			givenObjs: []client.Object{
+				newEventingConfig(&eventingv1alpha2.SubscriptionDefaults{
+					MaxInFlightMessages: ptr.To(20),
+					Qos:                 "AT_MOST_ONCE",
+				}),
+			},
+			givenSub: eventingtesting.NewSubscription("sub", namespace,
+				eventingtesting.WithMaxInFlightMessages("5"),
+			),
+			wantConfig: map[string]string{
+				eventingv1alpha2.MaxInFlightMessages: "5",
+				eventingv1alpha2.ProtocolSettingsQos: "AT_MOST_ONCE",
+			},
+		},
+		{
+			name: "should cap the default maxInFlightMessages by the limit of the owning Eventing CR",
+			givenObjs: []client.Object{
+				newEventingConfig(&eventingv1alpha2.SubscriptionDefaults{MaxInFlightMessages: ptr.To(100)}),
+				newEventing(&operatorv1alpha1.SubscriptionLimits{MaxInFlightMessages: ptr.To(50)}),
+			},
+			givenSub: eventingtesting.NewSubscription("sub", namespace),
+			wantConfig: map[string]string{
+				eventingv1alpha2.MaxInFlightMessages: "50",
+			},
+		},
+		{
+			name: "should not cap the default maxInFlightMessages below the limit of the owning Eventing CR",
+			givenObjs: []client.Object{
+				newEventingConfig(&eventingv1alpha2.SubscriptionDefaults{MaxInFlightMessages: ptr.To(20)}),
+				newEventing(&operatorv1alpha1.SubscriptionLimits{MaxInFlightMessages: ptr.To(50)}),
+			},
+			givenSub: eventingtesting.NewSubscription("sub", namespace),
+			wantConfig: map[string]string{
+				eventingv1alpha2.MaxInFlightMessages: "20",
+			},
+		},
+		{
+			name: "should not cap the maxInFlightMessages of the Subscription",
+			givenObjs: []client.Object{
+				newEventingConfig(&eventingv1alpha2.SubscriptionDefaults{MaxInFlightMessages: ptr.To(100)}),
+				newEventing(&operatorv1alpha1.SubscriptionLimits{MaxInFlightMessages: ptr.To(50)}),
+			},
+			givenSub: eventingtesting.NewSubscription("sub", namespace,
+				eventingtesting.WithMaxInFlightMessages("80"),
+			),
+			wantConfig: map[string]string{
+				eventingv1alpha2.MaxInFlightMessages: "80",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			defaulter := newDefaulter(t, tc.givenObjs...)
+
+			// when
+			err := defaulter.Default(context.Background(), tc.givenSub)
+
+			// then
+			require.NoError(t, err)
+			require.Equal(t, tc.wantConfig, tc.givenSub.Spec.Config)
+			require.Equal(t, eventingv1alpha2.TypeMatchingStandard, tc.givenSub.Spec.TypeMatching)
+		})
+	}
+}
@@ -28,8 +28,10 @@ import (
 	"github.com/kyma-project/eventing-manager/pkg/backend/sink"
 	backendutils "github.com/kyma-project/eventing-manager/pkg/backend/utils"
 	"github.com/kyma-project/eventing-manager/pkg/env"
+	"github.com/kyma-project/eventing-manager/pkg/health"
 	"github.com/kyma-project/eventing-manager/pkg/logger"
 	submgrmanager "github.com/kyma-project/eventing-manager/pkg/subscriptionmanager/manager"
+	"github.com/kyma-project/eventing-manager/pkg/tenancy"
 )
 
 const (
@@ -39,6 +41,13 @@ const (
 var (
 	ErrDecodingOauthCredentialFailed = errors.New("in")
 	ErrDomainEmpty                   = errors.New("domain must be a non-empty value")
+	ErrNotStarted                    = errors.New("EventMesh subscription manager is not started")
+)
+
+// Perform a compile-time check.
+var (
+	_ submgrmanager.Manager            = &SubscriptionManager{}
+	_ submgrmanager.CredentialsRotator = &SubscriptionManager{}
 )
 
 // AddToScheme adds the own schemes to the runtime scheme.
@@ -72,9 +81,15 @@ type SubscriptionManager struct {
 	resyncPeriod     time.Duration
 	mgr              manager.Manager
 	eventMeshBackend backendeventmesh.Backend
-	logger           *logger.Logger
-	collector        *metrics.Collector
-	domain           string
+	// eventMeshHandler and eventMeshReconciler are set once the manager is started, the credentials are rotated
+	// through them.
+	eventMeshHandler    *backendeventmesh.EventMesh
+	eventMeshReconciler *eventmesh.Reconciler
+	oauth2credential    *backendeventmesh.OAuth2ClientCredentials
+	logger              *logger.Logger
+	collector           *metrics.Collector
+	domain              string
+	filter              *tenancy.Filter
 }
 
 // NewSubscriptionManager creates the SubscriptionManager for BEB and initializes it as far as it
@@ -93,6 +108,11 @@ func NewSubscriptionManager(restCfg *rest.Config, metricsAddr string, resyncPeri
 	}
 }
 
+// SetFilter sets the filter of the Subscriptions owned by the Eventing instance of the subscription manager.
+func (c *SubscriptionManager) SetFilter(filter *tenancy.Filter) {
+	c.filter = filter
+}
+
 // Init implements the subscriptionmanager.Manager interface.
 func (c *SubscriptionManager) Init(mgr manager.Manager) error {
 	if len(c.domain) == 0 {
@@ -127,7 +147,7 @@ func (c *SubscriptionManager) Start(_ env.DefaultSubscriptionConfig, params subm
 
 	// Initialize v1alpha2 handler for EventMesh
 	eventMeshHandler := backendeventmesh.NewEventMesh(oauth2credential, nameMapper, c.logger)
-	eventMeshcleaner := cleaner.NewEventMeshCleaner(c.logger)
+	eventMeshcleaner := cleaner.NewEventMeshCleaner(c.logger, c.envCfg.EventTypeRewrites...)
 	eventMeshReconciler := eventmesh.NewReconciler(
 		client,
 		c.logger,
@@ -141,15 +161,76 @@ func (c *SubscriptionManager) Start(_ env.DefaultSubscriptionConfig, params subm
 		c.collector,
 		c.domain,
 	)
+	eventMeshReconciler.SetFilter(c.filter)
 	c.eventMeshBackend = eventMeshReconciler.Backend
 	if err := eventMeshReconciler.SetupUnmanaged(ctx, c.mgr); err != nil {
 		return xerrors.Errorf("setup EventMesh subscription controller failed: %v", err)
 	}
+	c.eventMeshHandler = eventMeshHandler
+	c.eventMeshReconciler = eventMeshReconciler
+	c.oauth2credential = oauth2credential
 	c.namedLogger().Info("Started v1alpha2 EventMesh subscription manager")
 
 	return nil
 }
 
+// RotateCredentials implements the subscriptionmanager.CredentialsRotator interface. It swaps the EventMesh client
+// credentials from the environment into the EventMesh HTTP client, and the given webhook auth credentials into
+// the EventMesh handler. The subscriptions are then reconciled to update their webhook auth on EventMesh, without
+// recreating them.
+func (c *SubscriptionManager) RotateCredentials(params submgrmanager.Params) (bool, error) {
+	if c.eventMeshHandler == nil || c.eventMeshReconciler == nil {
+		return false, ErrNotStarted
+	}
+	rotated := false
+
+	// Need to read env to read the rotated BEB related secrets
+	envCfg := env.GetConfig()
+	if envCfg.ClientID != c.envCfg.ClientID || envCfg.ClientSecret != c.envCfg.ClientSecret ||
+		envCfg.TokenEndpoint != c.envCfg.TokenEndpoint {
+		c.eventMeshHandler.SetClientCredentials(envCfg)
+		c.envCfg.ClientID = envCfg.ClientID
+		c.envCfg.ClientSecret = envCfg.ClientSecret
+		c.envCfg.TokenEndpoint = envCfg.TokenEndpoint
+		c.namedLogger().Info("Rotated the EventMesh client credentials")
+		rotated = true
+	}
+
+	oauth2credential := getOAuth2ClientCredentials(params)
+	if *oauth2credential != *c.oauth2credential {
+		c.eventMeshHandler.SetCredentials(oauth2credential)
+		c.eventMeshReconciler.SetCredentials(oauth2credential)
+		if err := c.eventMeshReconciler.EnqueueReconciliationForAllSubscriptions(context.Background()); err != nil {
+			return rotated, xerrors.Errorf("enqueue subscriptions after rotating the credentials failed: %v", err)
+		}
+		// save the credentials only once the subscriptions are enqueued, so that a failed rotation is retried
+		c.oauth2credential = oauth2credential
+		c.namedLogger().Info("Rotated the webhook auth credentials")
+		rotated = true
+	}
+
+	return rotated, nil
+}
+
+// HealthChecks implements the subscriptionmanager.HealthChecker interface. The checks verify that a token of the
+// EventMesh client credentials can be fetched and that the EventMesh API responds.
+func (c *SubscriptionManager) HealthChecks() []health.Check {
+	return []health.Check{
+		{Name: health.CheckEventMeshToken, Run: func(context.Context) error {
+			if c.eventMeshHandler == nil {
+				return ErrNotStarted
+			}
+			return c.eventMeshHandler.CheckToken()
+		}},
+		{Name: health.CheckEventMeshAPI, Run: func(context.Context) error {
+			if c.eventMeshHandler == nil {
+				return ErrNotStarted
+			}
+			return c.eventMeshHandler.CheckAPI()
+		}},
+	}
+}
+
 // Stop implements the subscriptionmanager.Manager interface and stops the EventMesh subscription manager.
 // If runCleanup is false, it will only mark the subscriptions as not ready. If it is true, it will
 // clean up subscriptions on EventMesh.
@@ -165,13 +246,15 @@ func (c *SubscriptionManager) Stop(runCleanup bool) error {
 func (c *SubscriptionManager) stopEventMeshBackend(runCleanup bool) error {
 	dynamicClient := dynamic.NewForConfigOrDie(c.restCfg)
 	if !runCleanup {
-		return markAllV1Alpha2SubscriptionsAsNotReady(dynamicClient, c.namedLogger())
+		return markAllV1Alpha2SubscriptionsAsNotReady(dynamicClient, c.filter, c.namedLogger())
 	}
 
-	return cleanupEventMesh(c.eventMeshBackend, dynamicClient, c.namedLogger())
+	return cleanupEventMesh(c.eventMeshBackend, dynamicClient, c.filter, c.namedLogger())
 }
 
-func markAllV1Alpha2SubscriptionsAsNotReady(dynamicClient dynamic.Interface, logger *zap.SugaredLogger) error {
+func markAllV1Alpha2SubscriptionsAsNotReady(dynamicClient dynamic.Interface, filter *tenancy.Filter,
+	logger *zap.SugaredLogger,
+) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	// Fetch all subscriptions.
@@ -183,6 +266,10 @@ func markAllV1Alpha2SubscriptionsAsNotReady(dynamicClient dynamic.Interface, log
 	if err != nil {
 		return errors.Wrapf(err, "convert subscriptionList from unstructured list failed")
 	}
+	// skip the subscriptions owned by another Eventing instance.
+	if subs.Items, err = filter.OwnedSubscriptions(ctx, subs.Items); err != nil {
+		return errors.Wrapf(err, "filter owned subscriptions failed")
+	}
 	// Mark all as not ready
 	for _, sub := range subs.Items {
 		if !sub.Status.Ready {
@@ -200,7 +287,9 @@ func markAllV1Alpha2SubscriptionsAsNotReady(dynamicClient dynamic.Interface, log
 }
 
 // cleanupEventMesh removes all created EventMesh artifacts (based on Subscription v1alpha2).
-func cleanupEventMesh(backend backendeventmesh.Backend, dynamicClient dynamic.Interface, logger *zap.SugaredLogger) error {
+func cleanupEventMesh(backend backendeventmesh.Backend, dynamicClient dynamic.Interface, filter *tenancy.Filter,
+	logger *zap.SugaredLogger,
+) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -219,6 +308,10 @@ func cleanupEventMesh(backend backendeventmesh.Backend, dynamicClient dynamic.In
 	if err != nil {
 		return errors.Wrapf(err, "convert subscriptionList from unstructured list failed")
 	}
+	// skip the subscriptions owned by another Eventing instance.
+	if subs.Items, err = filter.OwnedSubscriptions(ctx, subs.Items); err != nil {
+		return errors.Wrapf(err, "filter owned subscriptions failed")
+	}
 
 	// Clean APIRules.
 	isCleanupSuccessful := true
@@ -9,10 +9,13 @@ import (
 	kymalogger "github.com/kyma-project/kyma/common/logging/logger"
 	"github.com/stretchr/testify/require"
 	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	eventingv1alpha2 "github.com/kyma-project/eventing-manager/api/eventing/v1alpha2"
+	"github.com/kyma-project/eventing-manager/internal/controller/eventing/subscription/eventmesh"
 	"github.com/kyma-project/eventing-manager/pkg/backend/cleaner"
 	backendeventmesh "github.com/kyma-project/eventing-manager/pkg/backend/eventmesh"
 	"github.com/kyma-project/eventing-manager/pkg/backend/utils"
@@ -132,7 +135,7 @@ func Test_cleanupEventMesh(t *testing.T) {
 	require.NotNil(t, unstructuredAPIRuleBeforeCleanup)
 
 	// when
-	err = cleanupEventMesh(bebSubMgr.eventMeshBackend, bebSubMgr.Client, defaultLogger.WithContext())
+	err = cleanupEventMesh(bebSubMgr.eventMeshBackend, bebSubMgr.Client, nil, defaultLogger.WithContext())
 	require.NoError(t, err)
 
 	// then
@@ -202,7 +205,7 @@ func Test_markAllV1Alpha2SubscriptionsAsNotReady(t *testing.T) {
 	require.True(t, gotSub.Status.Ready)
 
 	// when
-	err = markAllV1Alpha2SubscriptionsAsNotReady(fakeClient, defaultLogger.WithContext())
+	err = markAllV1Alpha2SubscriptionsAsNotReady(fakeClient, nil, defaultLogger.WithContext())
 	require.NoError(t, err)
 
 	// then
@@ -220,6 +223,69 @@ func Test_markAllV1Alpha2SubscriptionsAsNotReady(t *testing.T) {
 	require.Equal(t, eventMeshLocalHash, gotSub.Status.Backend.EventMeshLocalHash)
 }
 
+func Test_RotateCredentials(t *testing.T) {
+	// given
+	t.Setenv("CLIENT_ID", "client-id")
+	t.Setenv("CLIENT_SECRET", "client-secret")
+	t.Setenv("TOKEN_ENDPOINT", "https://token.local")
+	t.Setenv("EVENT_TYPE_PREFIX", eventingtesting.EventTypePrefix)
+
+	defaultLogger, err := logger.New(string(kymalogger.JSON), string(kymalogger.INFO))
+	require.NoError(t, err)
+
+	params := submgrmanager.Params{
+		submgrmanager.ParamNameClientID:     []byte("webhook_client_id"),
+		submgrmanager.ParamNameClientSecret: []byte("webhook_client_secret"),
+		submgrmanager.ParamNameTokenURL:     []byte("https://webhook-token.local"),
+		submgrmanager.ParamNameCertsURL:     []byte("https://webhook-certs.local"),
+	}
+
+	subMgr := NewSubscriptionManager(nil, "", 0, defaultLogger, nil, "mydomain.com")
+
+	// when the subscription manager is not started
+	_, err = subMgr.RotateCredentials(params)
+
+	// then
+	require.ErrorIs(t, err, ErrNotStarted)
+
+	// given a started subscription manager
+	scheme := runtime.NewScheme()
+	require.NoError(t, eventingv1alpha2.AddToScheme(scheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	credentials := getOAuth2ClientCredentials(params)
+	nameMapper := utils.NewBEBSubscriptionNameMapper("mydomain.com", backendeventmesh.MaxSubscriptionNameLength)
+	subMgr.eventMeshHandler = backendeventmesh.NewEventMesh(credentials, nameMapper, defaultLogger)
+	subMgr.eventMeshReconciler = eventmesh.NewReconciler(fakeClient, defaultLogger, nil, subMgr.envCfg,
+		cleaner.NewEventMeshCleaner(defaultLogger), subMgr.eventMeshHandler, credentials, nameMapper, nil, nil,
+		"mydomain.com")
+	subMgr.oauth2credential = credentials
+
+	// when the credentials did not change
+	rotated, err := subMgr.RotateCredentials(params)
+
+	// then
+	require.NoError(t, err)
+	require.False(t, rotated)
+
+	// when the EventMesh client credentials changed
+	t.Setenv("CLIENT_SECRET", "new-client-secret")
+	rotated, err = subMgr.RotateCredentials(params)
+
+	// then
+	require.NoError(t, err)
+	require.True(t, rotated)
+	require.Equal(t, "new-client-secret", subMgr.envCfg.ClientSecret)
+
+	// when the webhook auth credentials changed
+	params[submgrmanager.ParamNameClientSecret] = []byte("new_webhook_client_secret")
+	rotated, err = subMgr.RotateCredentials(params)
+
+	// then
+	require.NoError(t, err)
+	require.True(t, rotated)
+	require.Equal(t, "new_webhook_client_secret", subMgr.oauth2credential.ClientSecret)
+}
+
 func startBEBMock() *eventingtesting.EventMeshMock {
 	b := eventingtesting.NewEventMeshMock()
 	b.Start()
@@ -7,11 +7,14 @@ import (
 
 	"github.com/kyma-project/eventing-manager/api/operator/v1alpha1"
 	"github.com/kyma-project/eventing-manager/pkg/backend/metrics"
+	"github.com/kyma-project/eventing-manager/pkg/catalog"
 	"github.com/kyma-project/eventing-manager/pkg/env"
+	"github.com/kyma-project/eventing-manager/pkg/eventstore"
 	"github.com/kyma-project/eventing-manager/pkg/logger"
 	"github.com/kyma-project/eventing-manager/pkg/subscriptionmanager/eventmesh"
 	"github.com/kyma-project/eventing-manager/pkg/subscriptionmanager/jetstream"
 	"github.com/kyma-project/eventing-manager/pkg/subscriptionmanager/manager"
+	"github.com/kyma-project/eventing-manager/pkg/tenancy"
 )
 
 // Perform a compile-time check.
@@ -20,7 +23,7 @@ var _ ManagerFactory = &Factory{}
 //go:generate go run github.com/vektra/mockery/v2 --name=ManagerFactory --outpkg=mocks --case=underscore
 type ManagerFactory interface {
 	NewJetStreamManager(v1alpha1.Eventing, env.NATSConfig) manager.Manager
-	NewEventMeshManager(domain string) (manager.Manager, error)
+	NewEventMeshManager(eventing v1alpha1.Eventing, domain string) (manager.Manager, error)
 }
 
 type Factory struct {
@@ -29,6 +32,10 @@ type Factory struct {
 	metricsCollector *metrics.Collector
 	resyncPeriod     time.Duration
 	logger           *logger.Logger
+	catalog          *catalog.Catalog
+	eventStore       *eventstore.Browser
+	resolver         *tenancy.Resolver
+	drainer          *jetstream.Drainer
 }
 
 func NewFactory(
@@ -37,6 +44,10 @@ func NewFactory(
 	metricsCollector *metrics.Collector,
 	resyncPeriod time.Duration,
 	logger *logger.Logger,
+	catalog *catalog.Catalog,
+	eventStore *eventstore.Browser,
+	resolver *tenancy.Resolver,
+	drainer *jetstream.Drainer,
 ) *Factory {
 	return &Factory{
 		k8sRestCfg:       k8sRestCfg,
@@ -44,16 +55,39 @@ func NewFactory(
 		metricsCollector: metricsCollector,
 		resyncPeriod:     resyncPeriod,
 		logger:           logger,
+		catalog:          catalog,
+		eventStore:       eventStore,
+		resolver:         resolver,
+		drainer:          drainer,
 	}
 }
 
 func (f Factory) NewJetStreamManager(eventing v1alpha1.Eventing, natsConfig env.NATSConfig) manager.Manager {
-	return jetstream.NewSubscriptionManager(f.k8sRestCfg, natsConfig.GetNewNATSConfig(eventing),
-		f.metricsAddress, f.metricsCollector, f.logger)
+	// the event catalog and the event store expose the stream of the default Eventing instance only.
+	eventCatalog, eventStore := f.catalog, f.eventStore
+	if f.resolver != nil && !f.resolver.IsDefault(&eventing) {
+		eventCatalog, eventStore = nil, nil
+	}
+	subManager := jetstream.NewSubscriptionManager(f.k8sRestCfg, natsConfig.GetNewNATSConfig(eventing),
+		f.metricsAddress, f.metricsCollector, f.logger, eventCatalog, eventStore)
+	subManager.SetFilter(f.getFilter(eventing))
+	subManager.SetDrainer(f.drainer)
+	return subManager
 }
 
-func (f Factory) NewEventMeshManager(domain string) (manager.Manager, error) {
-	return eventmesh.NewSubscriptionManager(
+func (f Factory) NewEventMeshManager(eventing v1alpha1.Eventing, domain string) (manager.Manager, error) {
+	subManager := eventmesh.NewSubscriptionManager(
 		f.k8sRestCfg, f.metricsAddress, f.resyncPeriod, f.logger, f.metricsCollector, domain,
-	), nil
+	)
+	subManager.SetFilter(f.getFilter(eventing))
+	return subManager, nil
+}
+
+// getFilter returns the filter of the Subscriptions owned by the given Eventing instance,
+// or nil if the Subscriptions are not shared by several Eventing instances.
+func (f Factory) getFilter(eventing v1alpha1.Eventing) *tenancy.Filter {
+	if f.resolver == nil {
+		return nil
+	}
+	return f.resolver.Filter(&eventing)
 }
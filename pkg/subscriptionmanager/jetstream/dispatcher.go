@@ -0,0 +1,261 @@
+package jetstream
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	eventingv1alpha2 "github.com/kyma-project/eventing-manager/api/eventing/v1alpha2"
+	registryv1alpha1 "github.com/kyma-project/eventing-manager/api/registry/v1alpha1"
+	"github.com/kyma-project/eventing-manager/internal/controller/events"
+	"github.com/kyma-project/eventing-manager/pkg/backend/cleaner"
+	backendjetstream "github.com/kyma-project/eventing-manager/pkg/backend/jetstream"
+	backendmetrics "github.com/kyma-project/eventing-manager/pkg/backend/metrics"
+	"github.com/kyma-project/eventing-manager/pkg/backend/schema"
+	"github.com/kyma-project/eventing-manager/pkg/env"
+	"github.com/kyma-project/eventing-manager/pkg/logger"
+	"github.com/kyma-project/eventing-manager/pkg/sharding"
+	"github.com/kyma-project/eventing-manager/pkg/tenancy"
+)
+
+const (
+	dispatcherName = "jetstream-dispatcher"
+
+	defaultDispatcherResyncPeriod = 10 * time.Second
+)
+
+// Perform a compile-time check.
+var _ manager.Runnable = &Dispatcher{}
+
+// NATSConfigProvider returns the config of the NATS backend, or nil if NATS is not the active backend.
+type NATSConfigProvider func(ctx context.Context) (*env.NATSConfig, error)
+
+// Dispatcher dispatches the events of the JetStream consumers owned by this replica, if the sharded dispatch
+// is enabled. It runs on all the replicas, while the consumers are managed by the subscription manager
+// of the leader. The consumers are rebalanced whenever the owner changes.
+type Dispatcher struct {
+	client           client.Client
+	secretReader     client.Reader
+	recorder         record.EventRecorder
+	owner            sharding.Owner
+	configProvider   NATSConfigProvider
+	subsConfig       env.DefaultSubscriptionConfig
+	metricsCollector *backendmetrics.Collector
+	logger           *logger.Logger
+	resyncPeriod     time.Duration
+	filter           *tenancy.Filter
+
+	config     *env.NATSConfig
+	handler    *backendjetstream.JetStream
+	registry   *schema.Registry
+	eventTypes map[ktypes.NamespacedName]bool
+}
+
+func NewDispatcher(client client.Client, secretReader client.Reader, recorder record.EventRecorder,
+	owner sharding.Owner, configProvider NATSConfigProvider, subsConfig env.DefaultSubscriptionConfig,
+	metricsCollector *backendmetrics.Collector, logger *logger.Logger,
+) *Dispatcher {
+	return &Dispatcher{
+		client:           client,
+		secretReader:     secretReader,
+		recorder:         recorder,
+		owner:            owner,
+		configProvider:   configProvider,
+		subsConfig:       subsConfig,
+		metricsCollector: metricsCollector,
+		logger:           logger,
+		resyncPeriod:     defaultDispatcherResyncPeriod,
+		registry:         schema.NewRegistry(),
+		eventTypes:       map[ktypes.NamespacedName]bool{},
+	}
+}
+
+// SetFilter sets the filter of the Subscriptions owned by the Eventing instance of the dispatcher.
+func (d *Dispatcher) SetFilter(filter *tenancy.Filter) {
+	d.filter = filter
+}
+
+// Start syncs the owned consumers periodically and whenever the owner changes, until the given context is done.
+func (d *Dispatcher) Start(ctx context.Context) error {
+	ticker := time.NewTicker(d.resyncPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			d.stop()
+			return nil
+		case <-ticker.C:
+		case <-d.owner.Changes():
+		}
+		if err := d.sync(ctx); err != nil {
+			d.namedLogger().Errorw("Failed to sync the dispatched consumers", "error", err)
+		}
+	}
+}
+
+// NeedLeaderElection implements the manager.LeaderElectionRunnable interface.
+// All the replicas dispatch their owned consumers.
+func (d *Dispatcher) NeedLeaderElection() bool {
+	return false
+}
+
+func (d *Dispatcher) sync(ctx context.Context) error {
+	config, err := d.configProvider(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the NATS config: %w", err)
+	}
+	if config == nil || !config.JSShardedDispatch {
+		d.stop()
+		return nil
+	}
+
+	if d.handler == nil || !reflect.DeepEqual(*config, *d.config) {
+		d.stop()
+		if err = d.start(*config); err != nil {
+			return err
+		}
+	}
+
+	d.syncSchemas(ctx)
+
+	subscriptions, err := d.listOwnedSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+	return d.handler.SyncDispatch(subscriptions, d.owner.Owns)
+}
+
+// listOwnedSubscriptions returns the Subscriptions owned by the Eventing instance of the dispatcher.
+func (d *Dispatcher) listOwnedSubscriptions(ctx context.Context) ([]eventingv1alpha2.Subscription, error) {
+	subscriptions := &eventingv1alpha2.SubscriptionList{}
+	if err := d.client.List(ctx, subscriptions); err != nil {
+		return nil, fmt.Errorf("failed to list the subscriptions: %w", err)
+	}
+	owned, err := d.filter.OwnedSubscriptions(ctx, subscriptions.Items)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter the owned subscriptions: %w", err)
+	}
+	return owned, nil
+}
+
+func (d *Dispatcher) start(config env.NATSConfig) error {
+	// the subscription reconciler of the leader does not dispatch the consumers, so the dispatcher reports the health
+	// of their deliveries itself.
+	deliveryHealth := backendjetstream.NewDeliveryHealthTracker()
+	deliveryHealth.SetHandler(func(key ktypes.NamespacedName, failure *backendjetstream.DeliveryFailure,
+		healthChanged bool,
+	) {
+		d.handleDeliveryHealth(deliveryHealth, key, failure, healthChanged)
+	})
+	jsCleaner := cleaner.NewJetStreamCleaner(d.logger, config.EventTypeRewrites...)
+	handler := newJetStreamHandler(config, d.subsConfig, jsCleaner, d.metricsCollector, d.logger,
+		handlerDependencies{
+			schemaRegistry: d.registry,
+			secretReader:   d.secretReader,
+			deliveryHealth: deliveryHealth,
+		})
+	connClosedHandler := func(_ *nats.Conn) {
+		// the connection is initialized again by the next sync.
+		d.namedLogger().Info("JetStream connection is closed and reconnect attempts are exceeded!")
+	}
+	if err := handler.Initialize(connClosedHandler); err != nil {
+		return fmt.Errorf("failed to initialize the JetStream dispatcher: %w", err)
+	}
+	d.config = &config
+	d.handler = handler
+	d.namedLogger().Info("Started the JetStream dispatcher")
+	return nil
+}
+
+func (d *Dispatcher) stop() {
+	if d.handler == nil {
+		return
+	}
+	// drains the in-flight deliveries and closes the connection, the consumers are kept.
+	d.handler.Shutdown()
+	d.handler = nil
+	d.config = nil
+	d.namedLogger().Info("Stopped the JetStream dispatcher")
+}
+
+// syncSchemas keeps the schema registry in sync with the EventTypes, since the event type controller
+// runs on the leader only.
+func (d *Dispatcher) syncSchemas(ctx context.Context) {
+	eventTypes := &registryv1alpha1.EventTypeList{}
+	if err := d.client.List(ctx, eventTypes); err != nil {
+		d.namedLogger().Errorw("Failed to list the event types", "error", err)
+		return
+	}
+
+	current := make(map[ktypes.NamespacedName]bool, len(eventTypes.Items))
+	for i := range eventTypes.Items {
+		eventType := &eventTypes.Items[i]
+		key := ktypes.NamespacedName{Namespace: eventType.Namespace, Name: eventType.Name}
+		current[key] = true
+		if err := d.registry.Set(eventType); err != nil {
+			d.namedLogger().Debugw("Skipping the invalid event type", "eventType", key.String(), "error", err)
+		}
+	}
+	for key := range d.eventTypes {
+		if !current[key] {
+			d.registry.Delete(key)
+		}
+	}
+	d.eventTypes = current
+}
+
+// handleDeliveryHealth is called by the delivery health tracker when the deliveries of a subscription fail or when
+// their health changes. It records a warning event on the subscription for the failure, and updates its
+// DeliveryHealthy condition if the health changed, which the subscription reconciler keeps.
+func (d *Dispatcher) handleDeliveryHealth(tracker *backendjetstream.DeliveryHealthTracker, key ktypes.NamespacedName,
+	failure *backendjetstream.DeliveryFailure, healthChanged bool,
+) {
+	ctx := context.Background()
+	subscription := &eventingv1alpha2.Subscription{}
+	if err := d.client.Get(ctx, key, subscription); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			d.namedLogger().Errorw("Failed to get the subscription to report the delivery health",
+				"namespace", key.Namespace, "name", key.Name, "error", err)
+		}
+		return
+	}
+
+	if failure != nil {
+		events.Warn(d.recorder, subscription, events.ReasonDeliveryFailed,
+			"Failed to deliver events to the sink %s with status code %d: %s",
+			subscription.Spec.Sink, failure.StatusCode, failure.Error)
+	}
+	if !healthChanged {
+		return
+	}
+	health, found := tracker.Get(key)
+	if !found {
+		return
+	}
+	desiredSubscription := subscription.DeepCopy()
+	condition := eventingv1alpha2.GetDeliveryHealthyCondition(subscription, health.Healthy(), health.Message())
+	conditions := make([]eventingv1alpha2.Condition, 0, len(subscription.Status.Conditions)+1)
+	for _, c := range subscription.Status.Conditions {
+		if c.Type != eventingv1alpha2.ConditionDeliveryHealthy {
+			conditions = append(conditions, c)
+		}
+	}
+	desiredSubscription.Status.Conditions = append(conditions, condition)
+	patch := client.MergeFromWithOptions(subscription, client.MergeFromWithOptimisticLock{})
+	if err := d.client.Status().Patch(ctx, desiredSubscription, patch); err != nil {
+		d.namedLogger().Errorw("Failed to update the delivery health of the subscription",
+			"namespace", key.Namespace, "name", key.Name, "error", err)
+	}
+}
+
+func (d *Dispatcher) namedLogger() *zap.SugaredLogger {
+	return d.logger.WithContext().Named(dispatcherName)
+}
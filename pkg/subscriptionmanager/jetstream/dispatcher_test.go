@@ -0,0 +1,111 @@
+package jetstream
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	kymalogger "github.com/kyma-project/kyma/common/logging/logger"
+	"github.com/stretchr/testify/require"
+	kcorev1 "k8s.io/api/core/v1"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	eventingv1alpha2 "github.com/kyma-project/eventing-manager/api/eventing/v1alpha2"
+	operatorv1alpha1 "github.com/kyma-project/eventing-manager/api/operator/v1alpha1"
+	"github.com/kyma-project/eventing-manager/pkg/backend/jetstream"
+	"github.com/kyma-project/eventing-manager/pkg/backend/metrics"
+	"github.com/kyma-project/eventing-manager/pkg/env"
+	"github.com/kyma-project/eventing-manager/pkg/logger"
+	"github.com/kyma-project/eventing-manager/pkg/tenancy"
+	eventingtesting "github.com/kyma-project/eventing-manager/testing"
+)
+
+var errSinkUnavailable = errors.New("sink unavailable")
+
+func Test_Dispatcher_handleDeliveryHealth(t *testing.T) {
+	t.Parallel()
+
+	// given
+	subscription := eventingtesting.NewSubscription(subscriptionName, subscriptionNamespace,
+		eventingtesting.WithSinkURL("https://sink.test"),
+	)
+	scheme := runtime.NewScheme()
+	require.NoError(t, eventingv1alpha2.AddToScheme(scheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(subscription).WithStatusSubresource(subscription).Build()
+	recorder := record.NewFakeRecorder(10)
+	defaultLogger, err := logger.New(string(kymalogger.JSON), string(kymalogger.INFO))
+	require.NoError(t, err)
+	dispatcher := NewDispatcher(fakeClient, fakeClient, recorder, nil, nil, env.DefaultSubscriptionConfig{},
+		metrics.NewCollector(), defaultLogger)
+
+	key := ktypes.NamespacedName{Namespace: subscriptionNamespace, Name: subscriptionName}
+	tracker := jetstream.NewDeliveryHealthTracker()
+	for i := 0; i < 20; i++ {
+		tracker.RecordFailure(key, http.StatusServiceUnavailable, errSinkUnavailable)
+	}
+
+	// when
+	dispatcher.handleDeliveryHealth(tracker, key,
+		&jetstream.DeliveryFailure{StatusCode: http.StatusServiceUnavailable, Error: errSinkUnavailable.Error()}, true)
+
+	// then
+	require.Len(t, recorder.Events, 1)
+	require.Contains(t, <-recorder.Events, "status code 503")
+	got := &eventingv1alpha2.Subscription{}
+	require.NoError(t, fakeClient.Get(context.Background(), key, got))
+	condition := got.Status.FindCondition(eventingv1alpha2.ConditionDeliveryHealthy)
+	require.NotNil(t, condition)
+	require.Equal(t, kcorev1.ConditionFalse, condition.Status)
+	require.Equal(t, eventingv1alpha2.ConditionReasonDeliveryFailing, condition.Reason)
+}
+
+func Test_Dispatcher_listOwnedSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	// given the default Eventing instance and a tenant instance owning the namespace tenant-apps
+	defaultEventing := &operatorv1alpha1.Eventing{
+		ObjectMeta: kmetav1.ObjectMeta{Namespace: "kyma-system", Name: "eventing", CreationTimestamp: kmetav1.Now()},
+	}
+	tenantEventing := &operatorv1alpha1.Eventing{
+		ObjectMeta: kmetav1.ObjectMeta{
+			Namespace: "tenant-system", Name: "tenant",
+			CreationTimestamp: kmetav1.NewTime(time.Now().Add(time.Minute)),
+		},
+		Spec: operatorv1alpha1.EventingSpec{
+			NamespaceSelector: &kmetav1.LabelSelector{MatchLabels: map[string]string{"tenant": "a"}},
+		},
+	}
+	scheme := runtime.NewScheme()
+	require.NoError(t, kcorev1.AddToScheme(scheme))
+	require.NoError(t, operatorv1alpha1.AddToScheme(scheme))
+	require.NoError(t, eventingv1alpha2.AddToScheme(scheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		defaultEventing, tenantEventing,
+		&kcorev1.Namespace{ObjectMeta: kmetav1.ObjectMeta{Name: "apps"}},
+		&kcorev1.Namespace{ObjectMeta: kmetav1.ObjectMeta{Name: "tenant-apps", Labels: map[string]string{"tenant": "a"}}},
+		eventingtesting.NewSubscription("sub", "apps"),
+		eventingtesting.NewSubscription("sub", "tenant-apps"),
+	).Build()
+	resolver := tenancy.NewResolver(fakeClient,
+		ktypes.NamespacedName{Namespace: defaultEventing.Namespace, Name: defaultEventing.Name})
+	defaultLogger, err := logger.New(string(kymalogger.JSON), string(kymalogger.INFO))
+	require.NoError(t, err)
+	dispatcher := NewDispatcher(fakeClient, fakeClient, record.NewFakeRecorder(10), nil, nil,
+		env.DefaultSubscriptionConfig{}, metrics.NewCollector(), defaultLogger)
+	dispatcher.SetFilter(resolver.Filter(defaultEventing))
+
+	// when
+	got, err := dispatcher.listOwnedSubscriptions(context.Background())
+
+	// then only the Subscriptions of the default instance are dispatched
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Equal(t, "apps", got[0].Namespace)
+}
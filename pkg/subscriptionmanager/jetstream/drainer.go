@@ -0,0 +1,72 @@
+package jetstream
+
+import (
+	"context"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// Perform a compile-time check.
+var _ manager.Runnable = &Drainer{}
+
+// Drainer drains the in-flight deliveries of the started subscription managers when the manager stops, e.g. during
+// a rollout. It is added to the manager once, while the subscription managers are started and stopped repeatedly.
+type Drainer struct {
+	mutex  sync.Mutex
+	drains map[*SubscriptionManager]func()
+}
+
+func NewDrainer() *Drainer {
+	return &Drainer{drains: make(map[*SubscriptionManager]func())}
+}
+
+// Start waits until the given context is done, then it drains the started subscription managers.
+func (d *Drainer) Start(ctx context.Context) error {
+	<-ctx.Done()
+
+	d.mutex.Lock()
+	drains := make([]func(), 0, len(d.drains))
+	for _, drain := range d.drains {
+		drains = append(drains, drain)
+	}
+	d.drains = make(map[*SubscriptionManager]func())
+	d.mutex.Unlock()
+
+	var wg sync.WaitGroup
+	for _, drain := range drains {
+		wg.Add(1)
+		go func(drain func()) {
+			defer wg.Done()
+			drain()
+		}(drain)
+	}
+	wg.Wait()
+	return nil
+}
+
+// NeedLeaderElection implements the manager.LeaderElectionRunnable interface.
+// The subscription managers are started on the leader only.
+func (d *Drainer) NeedLeaderElection() bool {
+	return true
+}
+
+// add registers the drain of the given subscription manager, it replaces the drain of its previous start.
+func (d *Drainer) add(sm *SubscriptionManager, drain func()) {
+	if d == nil {
+		return
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.drains[sm] = drain
+}
+
+// remove unregisters the drain of the given subscription manager, e.g. because it was stopped.
+func (d *Drainer) remove(sm *SubscriptionManager) {
+	if d == nil {
+		return
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	delete(d.drains, sm)
+}
@@ -0,0 +1,34 @@
+package jetstream
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Drainer_Start(t *testing.T) {
+	t.Parallel()
+
+	// given
+	drainer := NewDrainer()
+	started, restarted, stopped := &SubscriptionManager{}, &SubscriptionManager{}, &SubscriptionManager{}
+	var startedDrains, restartedDrains, stoppedDrains atomic.Int32
+	drainer.add(started, func() { startedDrains.Add(1) })
+	drainer.add(restarted, func() { restartedDrains.Add(100) })
+	drainer.add(restarted, func() { restartedDrains.Add(1) })
+	drainer.add(stopped, func() { stoppedDrains.Add(1) })
+	drainer.remove(stopped)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// when
+	require.NoError(t, drainer.Start(ctx))
+
+	// then the started subscription managers are drained once, with the drain of their latest start
+	require.Equal(t, int32(1), startedDrains.Load())
+	require.Equal(t, int32(1), restartedDrains.Load())
+	require.Equal(t, int32(0), stoppedDrains.Load())
+}
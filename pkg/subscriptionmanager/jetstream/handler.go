@@ -0,0 +1,35 @@
+package jetstream
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/eventing-manager/pkg/backend/cleaner"
+	backendjetstream "github.com/kyma-project/eventing-manager/pkg/backend/jetstream"
+	backendmetrics "github.com/kyma-project/eventing-manager/pkg/backend/metrics"
+	"github.com/kyma-project/eventing-manager/pkg/backend/schema"
+	"github.com/kyma-project/eventing-manager/pkg/env"
+	"github.com/kyma-project/eventing-manager/pkg/logger"
+)
+
+// handlerDependencies are the optional dependencies of the JetStream handlers, which are shared by the subscription
+// manager and the sharded dispatcher, so that both dispatch the events the same way.
+type handlerDependencies struct {
+	// schemaRegistry validates the data of the dispatched events.
+	schemaRegistry *schema.Registry
+	// secretReader reads the Secrets of the sink authentication. It reads them directly, to not cache all the
+	// Secrets of the cluster.
+	secretReader client.Reader
+	// deliveryHealth tracks the results of the deliveries to the sinks.
+	deliveryHealth *backendjetstream.DeliveryHealthTracker
+}
+
+// newJetStreamHandler returns the JetStream handler of the given config with the given dependencies.
+func newJetStreamHandler(config env.NATSConfig, subsConfig env.DefaultSubscriptionConfig, jsCleaner cleaner.Cleaner,
+	metricsCollector *backendmetrics.Collector, logger *logger.Logger, dependencies handlerDependencies,
+) *backendjetstream.JetStream {
+	handler := backendjetstream.NewJetStream(config, metricsCollector, jsCleaner, subsConfig, logger)
+	handler.SetSchemaRegistry(dependencies.schemaRegistry)
+	handler.SetSecretReader(dependencies.secretReader)
+	handler.SetDeliveryHealthTracker(dependencies.deliveryHealth)
+	return handler
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/nats-io/nats.go"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"golang.org/x/xerrors"
@@ -18,22 +19,30 @@ import (
 
 	eventingv1alpha1 "github.com/kyma-project/eventing-manager/api/eventing/v1alpha1"
 	eventingv1alpha2 "github.com/kyma-project/eventing-manager/api/eventing/v1alpha2"
+	eventtypecontroller "github.com/kyma-project/eventing-manager/internal/controller/eventing/eventtype"
 	subscriptioncontrollerjetstream "github.com/kyma-project/eventing-manager/internal/controller/eventing/subscription/jetstream"
 	"github.com/kyma-project/eventing-manager/pkg/backend/cleaner"
 	"github.com/kyma-project/eventing-manager/pkg/backend/eventtype"
 	backendjetstream "github.com/kyma-project/eventing-manager/pkg/backend/jetstream"
 	backendmetrics "github.com/kyma-project/eventing-manager/pkg/backend/metrics"
+	"github.com/kyma-project/eventing-manager/pkg/backend/schema"
 	"github.com/kyma-project/eventing-manager/pkg/backend/sink"
 	backendutils "github.com/kyma-project/eventing-manager/pkg/backend/utils"
+	"github.com/kyma-project/eventing-manager/pkg/catalog"
 	"github.com/kyma-project/eventing-manager/pkg/env"
+	"github.com/kyma-project/eventing-manager/pkg/eventstore"
+	"github.com/kyma-project/eventing-manager/pkg/health"
 	"github.com/kyma-project/eventing-manager/pkg/logger"
 	submgrmanager "github.com/kyma-project/eventing-manager/pkg/subscriptionmanager/manager"
+	"github.com/kyma-project/eventing-manager/pkg/tenancy"
 )
 
 const (
 	subscriptionManagerName = "jetstream-subscription-manager"
 )
 
+var ErrNotStarted = errors.New("JetStream subscription manager is not started")
+
 // AddToScheme adds all types of clientset and eventing into the given scheme.
 func AddToScheme(scheme *runtime.Scheme) error {
 	if err := kkubernetesscheme.AddToScheme(scheme); err != nil {
@@ -62,11 +71,16 @@ type SubscriptionManager struct {
 	mgr              manager.Manager
 	backendv2        backendjetstream.Backend
 	logger           *logger.Logger
+	catalog          *catalog.Catalog
+	eventStore       *eventstore.Browser
+	filter           *tenancy.Filter
+	drainer          *Drainer
 }
 
 // NewSubscriptionManager creates the subscription manager for JetStream.
 func NewSubscriptionManager(restCfg *rest.Config, natsConfig env.NATSConfig, metricsAddr string,
-	metricsCollector *backendmetrics.Collector, logger *logger.Logger,
+	metricsCollector *backendmetrics.Collector, logger *logger.Logger, catalog *catalog.Catalog,
+	eventStore *eventstore.Browser,
 ) *SubscriptionManager {
 	return &SubscriptionManager{
 		envCfg:           natsConfig,
@@ -74,9 +88,22 @@ func NewSubscriptionManager(restCfg *rest.Config, natsConfig env.NATSConfig, met
 		metricsAddr:      metricsAddr,
 		metricsCollector: metricsCollector,
 		logger:           logger,
+		catalog:          catalog,
+		eventStore:       eventStore,
 	}
 }
 
+// SetFilter sets the filter of the Subscriptions owned by the Eventing instance of the subscription manager.
+func (sm *SubscriptionManager) SetFilter(filter *tenancy.Filter) {
+	sm.filter = filter
+}
+
+// SetDrainer sets the drainer of the in-flight deliveries when the manager stops. They are not drained on shutdown
+// if it is not set.
+func (sm *SubscriptionManager) SetDrainer(drainer *Drainer) {
+	sm.drainer = drainer
+}
+
 // Init initialize the JetStream subscription manager.
 func (sm *SubscriptionManager) Init(mgr manager.Manager) error {
 	if len(sm.envCfg.URL) == 0 {
@@ -101,9 +128,15 @@ func (sm *SubscriptionManager) Start(defaultSubsConfig env.DefaultSubscriptionCo
 	eventingv1alpha1.InitializeEventTypeCleaner(simpleCleaner)
 
 	// Initialize v1alpha2 event type cleaner
-	jsCleaner := cleaner.NewJetStreamCleaner(sm.logger)
-	jetStreamHandler := backendjetstream.NewJetStream(sm.envCfg,
-		sm.metricsCollector, jsCleaner, defaultSubsConfig, sm.logger)
+	jsCleaner := cleaner.NewJetStreamCleaner(sm.logger, sm.envCfg.EventTypeRewrites...)
+	schemaRegistry := schema.NewRegistry()
+	deliveryHealth := backendjetstream.NewDeliveryHealthTracker()
+	jetStreamHandler := newJetStreamHandler(sm.envCfg, defaultSubsConfig, jsCleaner, sm.metricsCollector, sm.logger,
+		handlerDependencies{
+			schemaRegistry: schemaRegistry,
+			secretReader:   sm.mgr.GetAPIReader(),
+			deliveryHealth: deliveryHealth,
+		})
 	jetStreamReconciler := subscriptioncontrollerjetstream.NewReconciler(
 		client,
 		jetStreamHandler,
@@ -113,6 +146,10 @@ func (sm *SubscriptionManager) Start(defaultSubsConfig env.DefaultSubscriptionCo
 		sink.NewValidator(client, recorder),
 		sm.metricsCollector,
 	)
+	jetStreamReconciler.SetFilter(sm.filter)
+	// report the failed deliveries on the Subscriptions, and reflect their health in the DeliveryHealthy condition.
+	deliveryHealth.SetHandler(jetStreamReconciler.HandleDeliveryHealth)
+	jetStreamReconciler.SetDeliveryHealthTracker(deliveryHealth)
 	sm.backendv2 = jetStreamReconciler.Backend
 
 	if err := jetStreamHandler.Initialize(jetStreamReconciler.HandleNatsConnClose); err != nil {
@@ -132,27 +169,98 @@ func (sm *SubscriptionManager) Start(defaultSubsConfig env.DefaultSubscriptionCo
 	if err := jetStreamReconciler.SetupUnmanaged(ctx, sm.mgr); err != nil {
 		return xerrors.Errorf("unable to setup the NATS subscription controller: %v", err)
 	}
+
+	// start the event type controller to keep the schema registry in sync
+	eventTypeReconciler := eventtypecontroller.NewReconciler(client, schemaRegistry, sm.logger)
+	if err := eventTypeReconciler.SetupUnmanaged(ctx, sm.mgr); err != nil {
+		return xerrors.Errorf("unable to setup the event type controller: %v", err)
+	}
+
+	// expose the stream subjects in the event catalog, and browse the stream messages and consumers in the event store.
+	// They are not set for the Eventing instances other than the default one.
+	if sm.catalog != nil {
+		sm.catalog.SetStreamInspector(jetStreamHandler)
+	}
+	if sm.eventStore != nil {
+		sm.eventStore.SetStreamReader(jetStreamHandler)
+	}
+
+	// record the backlog of the consumers and the usage of the stream until the subscription manager is stopped.
+	if sm.envCfg.JSLagCollectionInterval > 0 {
+		lagCollector := newLagCollector(client, jetStreamHandler, sm.metricsCollector, sm.filter, sm.namedLogger(),
+			sm.envCfg.JSConsolidatedConsumers)
+		go lagCollector.run(ctx, sm.envCfg.JSLagCollectionInterval)
+	}
+
+	// drain the in-flight deliveries when the manager stops, e.g. during a rollout.
+	sm.drainer.add(sm, func() {
+		sm.cancel()
+		jetStreamHandler.Shutdown()
+	})
+
 	sm.namedLogger().Info("Started v1alpha2 JetStream subscription manager")
 
 	return nil
 }
 
+// HealthChecks implements the subscriptionmanager.HealthChecker interface. The checks verify that the JetStream
+// account info can be read and that the stream exists.
+func (sm *SubscriptionManager) HealthChecks() []health.Check {
+	return []health.Check{
+		{Name: health.CheckJetStreamAccount, Run: func(ctx context.Context) error {
+			jsCtx, err := sm.getJetStreamContext()
+			if err != nil {
+				return err
+			}
+			_, err = jsCtx.AccountInfo(nats.Context(ctx))
+			return err
+		}},
+		{Name: health.CheckJetStreamStream, Run: func(ctx context.Context) error {
+			jsCtx, err := sm.getJetStreamContext()
+			if err != nil {
+				return err
+			}
+			_, err = jsCtx.StreamInfo(sm.envCfg.JSStreamName, nats.Context(ctx))
+			return err
+		}},
+	}
+}
+
+func (sm *SubscriptionManager) getJetStreamContext() (nats.JetStreamContext, error) {
+	if sm.backendv2 == nil || sm.backendv2.GetJetStreamContext() == nil {
+		return nil, ErrNotStarted
+	}
+	return sm.backendv2.GetJetStreamContext(), nil
+}
+
+// Stop stops the controllers and drains the in-flight deliveries, before the JetStream artifacts are cleaned up
+// if runCleanup is true, e.g. when switching the backend.
 func (sm *SubscriptionManager) Stop(runCleanup bool) error {
+	sm.drainer.remove(sm)
+	if sm.catalog != nil {
+		sm.catalog.SetStreamInspector(nil)
+	}
+	if sm.eventStore != nil {
+		sm.eventStore.SetStreamReader(nil)
+	}
+	// stop the controllers first, so that the consumers are not bound again while draining.
+	sm.cancel()
 	if sm.backendv2 != nil {
 		sm.backendv2.Shutdown()
 	}
-	sm.cancel()
 
 	if !runCleanup {
 		return nil
 	}
 	dynamicClient := dynamic.NewForConfigOrDie(sm.restCfg)
 
-	return cleanupv2(sm.backendv2, dynamicClient, sm.namedLogger())
+	return cleanupv2(sm.backendv2, dynamicClient, sm.filter, sm.namedLogger())
 }
 
 // clean removes all JetStream artifacts.
-func cleanupv2(backend backendjetstream.Backend, dynamicClient dynamic.Interface, logger *zap.SugaredLogger) error {
+func cleanupv2(backend backendjetstream.Backend, dynamicClient dynamic.Interface, filter *tenancy.Filter,
+	logger *zap.SugaredLogger,
+) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -175,6 +283,11 @@ func cleanupv2(backend backendjetstream.Backend, dynamicClient dynamic.Interface
 		return errors.Wrapf(err, "convert subscriptionList from unstructured list failed")
 	}
 
+	// skip the subscriptions owned by another Eventing instance.
+	if subs.Items, err = filter.OwnedSubscriptions(ctx, subs.Items); err != nil {
+		return errors.Wrapf(err, "filter owned subscriptions failed")
+	}
+
 	// clean all status.
 	isCleanupSuccessful := true
 	for _, v := range subs.Items {
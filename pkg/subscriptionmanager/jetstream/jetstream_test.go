@@ -34,7 +34,7 @@ func TestCleanup(t *testing.T) {
 	testEnv.consumersEquals(t, 1)
 
 	// when
-	err := cleanupv2(testEnv.jsBackend, testEnv.dynamicClient, testEnv.defaultLogger.WithContext())
+	err := cleanupv2(testEnv.jsBackend, testEnv.dynamicClient, nil, testEnv.defaultLogger.WithContext())
 
 	// then
 	require.NoError(t, err)
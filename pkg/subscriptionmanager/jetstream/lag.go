@@ -0,0 +1,153 @@
+package jetstream
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	eventingv1alpha2 "github.com/kyma-project/eventing-manager/api/eventing/v1alpha2"
+	backendjetstream "github.com/kyma-project/eventing-manager/pkg/backend/jetstream"
+	backendmetrics "github.com/kyma-project/eventing-manager/pkg/backend/metrics"
+	"github.com/kyma-project/eventing-manager/pkg/tenancy"
+)
+
+// lagReader reads the backlog of the consumers and the usage of the stream.
+type lagReader interface {
+	GetConsumerLag(consumerName string) (backendjetstream.ConsumerLag, error)
+	GetStreamUsage() (backendjetstream.StreamUsage, error)
+}
+
+// consumerKey identifies the consumer of a Subscription in the metrics. The event type is empty if the consumer is
+// consolidated, i.e. if it filters all the event types of the Subscription.
+type consumerKey struct {
+	subscriptionName      string
+	subscriptionNamespace string
+	eventType             string
+	consumerName          string
+}
+
+// lagCollector periodically records the backlog of the consumers of the owned Subscriptions, and the usage of the
+// stream in the metrics. Only the leader runs it, since only the leader starts the subscription managers.
+type lagCollector struct {
+	client  client.Reader
+	reader  lagReader
+	metrics *backendmetrics.Collector
+	filter  *tenancy.Filter
+	logger  *zap.SugaredLogger
+	// consolidated is true if the Subscriptions have a consolidated consumer instead of a consumer per event type.
+	consolidated bool
+
+	// consumers holds the consumers whose backlog is recorded, to remove the metrics of the deleted ones.
+	consumers map[consumerKey]struct{}
+	// streamName is the name of the stream whose usage is recorded.
+	streamName string
+}
+
+func newLagCollector(client client.Reader, reader lagReader, metrics *backendmetrics.Collector,
+	filter *tenancy.Filter, logger *zap.SugaredLogger, consolidated bool,
+) *lagCollector {
+	return &lagCollector{
+		client:       client,
+		reader:       reader,
+		metrics:      metrics,
+		filter:       filter,
+		logger:       logger,
+		consolidated: consolidated,
+		consumers:    make(map[consumerKey]struct{}),
+	}
+}
+
+// run records the metrics at the given interval until the given context is done, then it removes them.
+func (c *lagCollector) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			c.removeAll()
+			return
+		case <-ticker.C:
+			c.collect(ctx)
+		}
+	}
+}
+
+// collect records the backlog of the consumers in Status.Backend.Types of the owned Subscriptions and the usage of
+// the stream. Each consumer is read once, even if it is listed for several event types. The consumers which cannot
+// be read are skipped, so that a missing consumer does not hide the others.
+func (c *lagCollector) collect(ctx context.Context) {
+	c.collectStreamUsage()
+
+	var subscriptions eventingv1alpha2.SubscriptionList
+	if err := c.client.List(ctx, &subscriptions); err != nil {
+		c.logger.Errorw("Failed to list the Subscriptions to collect the consumer lag", "error", err)
+		return
+	}
+	owned, err := c.filter.OwnedSubscriptions(ctx, subscriptions.Items)
+	if err != nil {
+		c.logger.Errorw("Failed to filter the owned Subscriptions to collect the consumer lag", "error", err)
+		return
+	}
+
+	collected := make(map[consumerKey]struct{})
+	read := make(map[string]struct{})
+	for _, subscription := range owned {
+		for _, eventType := range subscription.Status.Backend.Types {
+			if eventType.ConsumerName == "" {
+				continue
+			}
+			if _, found := read[eventType.ConsumerName]; found {
+				continue
+			}
+			read[eventType.ConsumerName] = struct{}{}
+			key := consumerKey{
+				subscriptionName:      subscription.Name,
+				subscriptionNamespace: subscription.Namespace,
+				eventType:             eventType.OriginalType,
+				consumerName:          eventType.ConsumerName,
+			}
+			if c.consolidated {
+				key.eventType = ""
+			}
+			lag, err := c.reader.GetConsumerLag(eventType.ConsumerName)
+			if err != nil {
+				c.logger.Debugw("Failed to read the consumer lag", "consumer", eventType.ConsumerName, "error", err)
+				continue
+			}
+			c.metrics.RecordConsumerLag(key.subscriptionName, key.subscriptionNamespace, key.eventType,
+				key.consumerName, lag.Pending, lag.AckPending, lag.Redelivered, lag.OldestUnackedAge)
+			collected[key] = struct{}{}
+		}
+	}
+
+	// remove the metrics of the consumers which were deleted, or could not be read.
+	for key := range c.consumers {
+		if _, found := collected[key]; !found {
+			c.metrics.RemoveConsumerLag(key.subscriptionName, key.subscriptionNamespace, key.eventType, key.consumerName)
+		}
+	}
+	c.consumers = collected
+}
+
+func (c *lagCollector) collectStreamUsage() {
+	usage, err := c.reader.GetStreamUsage()
+	if err != nil {
+		c.logger.Errorw("Failed to read the stream usage", "error", err)
+		return
+	}
+	c.metrics.RecordStreamUsage(usage.Name, usage.Messages, usage.Bytes, usage.MaxMessages, usage.MaxBytes)
+	c.streamName = usage.Name
+}
+
+func (c *lagCollector) removeAll() {
+	for key := range c.consumers {
+		c.metrics.RemoveConsumerLag(key.subscriptionName, key.subscriptionNamespace, key.eventType, key.consumerName)
+	}
+	c.consumers = make(map[consumerKey]struct{})
+	if c.streamName != "" {
+		c.metrics.RemoveStreamUsage(c.streamName)
+		c.streamName = ""
+	}
+}
@@ -0,0 +1,185 @@
+package jetstream
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	kymalogger "github.com/kyma-project/kyma/common/logging/logger"
+	"github.com/nats-io/nats.go"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	eventingv1alpha2 "github.com/kyma-project/eventing-manager/api/eventing/v1alpha2"
+	"github.com/kyma-project/eventing-manager/pkg/backend/jetstream"
+	"github.com/kyma-project/eventing-manager/pkg/backend/metrics"
+	"github.com/kyma-project/eventing-manager/pkg/logger"
+	eventingtesting "github.com/kyma-project/eventing-manager/testing"
+)
+
+var errStreamUnavailable = errors.New("stream unavailable")
+
+// stubLagReader returns the lag of the known consumers.
+type stubLagReader struct {
+	lags  map[string]jetstream.ConsumerLag
+	usage jetstream.StreamUsage
+}
+
+func (r stubLagReader) GetConsumerLag(consumerName string) (jetstream.ConsumerLag, error) {
+	lag, found := r.lags[consumerName]
+	if !found {
+		return jetstream.ConsumerLag{}, nats.ErrConsumerNotFound
+	}
+	return lag, nil
+}
+
+func (r stubLagReader) GetStreamUsage() (jetstream.StreamUsage, error) {
+	if r.usage.Name == "" {
+		return jetstream.StreamUsage{}, errStreamUnavailable
+	}
+	return r.usage, nil
+}
+
+// countingLagReader counts the reads of the consumer lag.
+type countingLagReader struct {
+	stubLagReader
+	calls int
+}
+
+func (r *countingLagReader) GetConsumerLag(consumerName string) (jetstream.ConsumerLag, error) {
+	r.calls++
+	return r.stubLagReader.GetConsumerLag(consumerName)
+}
+
+func newTestLagCollector(t *testing.T, reader lagReader, consolidated bool,
+	subscriptions ...*eventingv1alpha2.Subscription,
+) (*lagCollector, *metrics.Collector) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, eventingv1alpha2.AddToScheme(scheme))
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	for _, subscription := range subscriptions {
+		builder = builder.WithObjects(subscription)
+	}
+	defaultLogger, err := logger.New(string(kymalogger.JSON), string(kymalogger.INFO))
+	require.NoError(t, err)
+	collector := metrics.NewCollector()
+	return newLagCollector(builder.Build(), reader, collector, nil, defaultLogger.WithContext(), consolidated), collector
+}
+
+func Test_lagCollector_collect(t *testing.T) {
+	t.Parallel()
+
+	// given
+	subscription := eventingtesting.NewSubscription(subscriptionName, subscriptionNamespace,
+		eventingtesting.WithStatusJSBackendTypes([]eventingv1alpha2.JetStreamTypes{
+			{OriginalType: "order.created.v1", ConsumerName: "consumer-created"},
+			{OriginalType: "order.updated.v1", ConsumerName: "consumer-updated"},
+			{OriginalType: "order.deleted.v1", ConsumerName: "consumer-unknown"},
+		}),
+	)
+	reader := stubLagReader{
+		lags: map[string]jetstream.ConsumerLag{
+			"consumer-created": {Pending: 10, AckPending: 2, Redelivered: 1, OldestUnackedAge: 90 * time.Second},
+			"consumer-updated": {},
+		},
+		usage: jetstream.StreamUsage{Name: "sap", Messages: 12, Bytes: 512, MaxMessages: -1, MaxBytes: 1024},
+	}
+	lagCollector, collector := newTestLagCollector(t, reader, false, subscription)
+
+	// when
+	lagCollector.collect(context.Background())
+
+	// then
+	expected := `
+# HELP eventing_ec_nats_consumer_pending_messages The number of messages in the stream not delivered to the consumer yet
+# TYPE eventing_ec_nats_consumer_pending_messages gauge
+eventing_ec_nats_consumer_pending_messages{consumer_name="consumer-created",event_type="order.created.v1",subscription_name="test",subscription_namespace="test"} 10
+eventing_ec_nats_consumer_pending_messages{consumer_name="consumer-updated",event_type="order.updated.v1",subscription_name="test",subscription_namespace="test"} 0
+# HELP eventing_ec_nats_consumer_oldest_unacked_message_age_seconds The age of the oldest message not acknowledged by the consumer. ` + "`0`" + ` indicates that the consumer has no backlog
+# TYPE eventing_ec_nats_consumer_oldest_unacked_message_age_seconds gauge
+eventing_ec_nats_consumer_oldest_unacked_message_age_seconds{consumer_name="consumer-created",event_type="order.created.v1",subscription_name="test",subscription_namespace="test"} 90
+eventing_ec_nats_consumer_oldest_unacked_message_age_seconds{consumer_name="consumer-updated",event_type="order.updated.v1",subscription_name="test",subscription_namespace="test"} 0
+# HELP eventing_ec_nats_stream_limit_usage_ratio The usage of the limits of the stream, by limit: ` + "`bytes`" + ` or ` + "`messages`" + `. ` + "`1`" + ` indicates that the limit is reached
+# TYPE eventing_ec_nats_stream_limit_usage_ratio gauge
+eventing_ec_nats_stream_limit_usage_ratio{limit="bytes",stream_name="sap"} 0.5
+# HELP eventing_ec_nats_stream_messages The number of messages stored in the stream
+# TYPE eventing_ec_nats_stream_messages gauge
+eventing_ec_nats_stream_messages{stream_name="sap"} 12
+`
+	require.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected),
+		"eventing_ec_nats_consumer_pending_messages",
+		"eventing_ec_nats_consumer_oldest_unacked_message_age_seconds",
+		"eventing_ec_nats_stream_limit_usage_ratio",
+		"eventing_ec_nats_stream_messages",
+	))
+}
+
+func Test_lagCollector_collect_ConsolidatedConsumers(t *testing.T) {
+	t.Parallel()
+
+	// given
+	subscription := eventingtesting.NewSubscription(subscriptionName, subscriptionNamespace,
+		eventingtesting.WithStatusJSBackendTypes([]eventingv1alpha2.JetStreamTypes{
+			{OriginalType: "order.created.v1", ConsumerName: "consumer"},
+			{OriginalType: "order.updated.v1", ConsumerName: "consumer"},
+		}),
+	)
+	reader := &countingLagReader{stubLagReader: stubLagReader{
+		lags:  map[string]jetstream.ConsumerLag{"consumer": {Pending: 10}},
+		usage: jetstream.StreamUsage{Name: "sap", Messages: 10},
+	}}
+	lagCollector, collector := newTestLagCollector(t, reader, true, subscription)
+
+	// when
+	lagCollector.collect(context.Background())
+
+	// then the consumer is read once, and its metrics are not labeled by event type
+	require.Equal(t, 1, reader.calls)
+	expected := `
+# HELP eventing_ec_nats_consumer_pending_messages The number of messages in the stream not delivered to the consumer yet
+# TYPE eventing_ec_nats_consumer_pending_messages gauge
+eventing_ec_nats_consumer_pending_messages{consumer_name="consumer",event_type="",subscription_name="test",subscription_namespace="test"} 10
+`
+	require.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected),
+		"eventing_ec_nats_consumer_pending_messages",
+	))
+}
+
+func Test_lagCollector_removesStaleMetrics(t *testing.T) {
+	t.Parallel()
+
+	// given
+	subscription := eventingtesting.NewSubscription(subscriptionName, subscriptionNamespace,
+		eventingtesting.WithStatusJSBackendTypes([]eventingv1alpha2.JetStreamTypes{
+			{OriginalType: "order.created.v1", ConsumerName: "consumer-created"},
+		}),
+	)
+	reader := stubLagReader{
+		lags:  map[string]jetstream.ConsumerLag{"consumer-created": {Pending: 10}},
+		usage: jetstream.StreamUsage{Name: "sap", Messages: 10},
+	}
+	lagCollector, collector := newTestLagCollector(t, reader, false, subscription)
+	lagCollector.collect(context.Background())
+	require.Equal(t, 1, testutil.CollectAndCount(collector, "eventing_ec_nats_consumer_pending_messages"))
+
+	// when the consumer is deleted
+	delete(reader.lags, "consumer-created")
+	lagCollector.collect(context.Background())
+
+	// then
+	require.Equal(t, 0, testutil.CollectAndCount(collector, "eventing_ec_nats_consumer_pending_messages"))
+
+	// when the collector is stopped
+	reader.lags["consumer-created"] = jetstream.ConsumerLag{Pending: 10}
+	lagCollector.collect(context.Background())
+	lagCollector.removeAll()
+
+	// then
+	require.Equal(t, 0, testutil.CollectAndCount(collector, "eventing_ec_nats_consumer_pending_messages"))
+	require.Equal(t, 0, testutil.CollectAndCount(collector, "eventing_ec_nats_stream_messages"))
+}
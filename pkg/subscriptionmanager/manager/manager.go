@@ -4,6 +4,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	"github.com/kyma-project/eventing-manager/pkg/env"
+	"github.com/kyma-project/eventing-manager/pkg/health"
 )
 
 const (
@@ -28,3 +29,22 @@ type Manager interface {
 	// Stop tells the subscription manager instance to shut down and clean-up.
 	Stop(runCleanup bool) error
 }
+
+// CredentialsRotator defines the interface that subscription managers should implement, if they can rotate the
+// credentials of their messaging backend in place, without recreating the subscriptions on the backend.
+//
+//go:generate go run github.com/vektra/mockery/v2 --name=CredentialsRotator --outpkg=mocks --output=mocks --case=underscore
+type CredentialsRotator interface {
+	// RotateCredentials swaps the given credentials into the started subscription manager instance.
+	// It returns true if any of the credentials were rotated.
+	RotateCredentials(params Params) (bool, error)
+}
+
+// HealthChecker defines the interface that subscription managers should implement, if they can check whether their
+// messaging backend is ready.
+//
+//go:generate go run github.com/vektra/mockery/v2 --name=HealthChecker --outpkg=mocks --output=mocks --case=underscore
+type HealthChecker interface {
+	// HealthChecks returns the health checks of the messaging backend of the started subscription manager instance.
+	HealthChecks() []health.Check
+}
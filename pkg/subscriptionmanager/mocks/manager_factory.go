@@ -23,9 +23,9 @@ func (_m *ManagerFactory) EXPECT() *ManagerFactory_Expecter {
 	return &ManagerFactory_Expecter{mock: &_m.Mock}
 }
 
-// NewEventMeshManager provides a mock function with given fields: domain
-func (_m *ManagerFactory) NewEventMeshManager(domain string) (manager.Manager, error) {
-	ret := _m.Called(domain)
+// NewEventMeshManager provides a mock function with given fields: eventing, domain
+func (_m *ManagerFactory) NewEventMeshManager(eventing v1alpha1.Eventing, domain string) (manager.Manager, error) {
+	ret := _m.Called(eventing, domain)
 
 	if len(ret) == 0 {
 		panic("no return value specified for NewEventMeshManager")
@@ -33,19 +33,19 @@ func (_m *ManagerFactory) NewEventMeshManager(domain string) (manager.Manager, e
 
 	var r0 manager.Manager
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (manager.Manager, error)); ok {
-		return rf(domain)
+	if rf, ok := ret.Get(0).(func(v1alpha1.Eventing, string) (manager.Manager, error)); ok {
+		return rf(eventing, domain)
 	}
-	if rf, ok := ret.Get(0).(func(string) manager.Manager); ok {
-		r0 = rf(domain)
+	if rf, ok := ret.Get(0).(func(v1alpha1.Eventing, string) manager.Manager); ok {
+		r0 = rf(eventing, domain)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(manager.Manager)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(domain)
+	if rf, ok := ret.Get(1).(func(v1alpha1.Eventing, string) error); ok {
+		r1 = rf(eventing, domain)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -59,14 +59,15 @@ type ManagerFactory_NewEventMeshManager_Call struct {
 }
 
 // NewEventMeshManager is a helper method to define mock.On call
+//   - eventing v1alpha1.Eventing
 //   - domain string
-func (_e *ManagerFactory_Expecter) NewEventMeshManager(domain interface{}) *ManagerFactory_NewEventMeshManager_Call {
-	return &ManagerFactory_NewEventMeshManager_Call{Call: _e.mock.On("NewEventMeshManager", domain)}
+func (_e *ManagerFactory_Expecter) NewEventMeshManager(eventing interface{}, domain interface{}) *ManagerFactory_NewEventMeshManager_Call {
+	return &ManagerFactory_NewEventMeshManager_Call{Call: _e.mock.On("NewEventMeshManager", eventing, domain)}
 }
 
-func (_c *ManagerFactory_NewEventMeshManager_Call) Run(run func(domain string)) *ManagerFactory_NewEventMeshManager_Call {
+func (_c *ManagerFactory_NewEventMeshManager_Call) Run(run func(eventing v1alpha1.Eventing, domain string)) *ManagerFactory_NewEventMeshManager_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(v1alpha1.Eventing), args[1].(string))
 	})
 	return _c
 }
@@ -76,7 +77,7 @@ func (_c *ManagerFactory_NewEventMeshManager_Call) Return(_a0 manager.Manager, _
 	return _c
 }
 
-func (_c *ManagerFactory_NewEventMeshManager_Call) RunAndReturn(run func(string) (manager.Manager, error)) *ManagerFactory_NewEventMeshManager_Call {
+func (_c *ManagerFactory_NewEventMeshManager_Call) RunAndReturn(run func(v1alpha1.Eventing, string) (manager.Manager, error)) *ManagerFactory_NewEventMeshManager_Call {
 	_c.Call.Return(run)
 	return _c
 }
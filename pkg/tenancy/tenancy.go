@@ -0,0 +1,235 @@
+package tenancy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	kcorev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	eventingv1alpha2 "github.com/kyma-project/eventing-manager/api/eventing/v1alpha2"
+	operatorv1alpha1 "github.com/kyma-project/eventing-manager/api/operator/v1alpha1"
+)
+
+// Resolver resolves which Eventing instance owns the Subscriptions of a namespace.
+//
+// The instances with a namespace selector own the Subscriptions of the selected namespaces. The default instance
+// owns the Subscriptions of all the other namespaces, unless it has a namespace selector itself. If several
+// instances select the same namespace, the preceding one owns it, see precedes.
+type Resolver struct {
+	client          client.Reader
+	defaultInstance ktypes.NamespacedName
+}
+
+func NewResolver(client client.Reader, defaultInstance ktypes.NamespacedName) *Resolver {
+	return &Resolver{client: client, defaultInstance: defaultInstance}
+}
+
+// IsDefault returns true if the given Eventing CR is the default instance.
+func (r *Resolver) IsDefault(eventing *operatorv1alpha1.Eventing) bool {
+	return keyOf(eventing) == r.defaultInstance
+}
+
+// Filter returns the filter of the Subscriptions owned by the given Eventing instance.
+func (r *Resolver) Filter(eventing *operatorv1alpha1.Eventing) *Filter {
+	return &Filter{resolver: r, instance: keyOf(eventing)}
+}
+
+// Owner returns the Eventing instance owning the Subscriptions of the given namespace.
+// It returns false if no instance owns them.
+func (r *Resolver) Owner(ctx context.Context, namespace string) (ktypes.NamespacedName, bool, error) {
+	instances, err := r.listInstances(ctx)
+	if err != nil {
+		return ktypes.NamespacedName{}, false, err
+	}
+
+	namespaceLabels, err := r.getNamespaceLabels(ctx, namespace)
+	if err != nil {
+		return ktypes.NamespacedName{}, false, err
+	}
+
+	for i := range instances {
+		selector, err := getNamespaceSelector(&instances[i])
+		if err != nil || selector == nil {
+			// the instances with an invalid selector are not reconciled.
+			continue
+		}
+		if selector.Matches(namespaceLabels) {
+			return keyOf(&instances[i]), true, nil
+		}
+	}
+
+	for i := range instances {
+		if r.IsDefault(&instances[i]) && instances[i].Spec.NamespaceSelector == nil {
+			return r.defaultInstance, true, nil
+		}
+	}
+
+	return ktypes.NamespacedName{}, false, nil
+}
+
+// Conflicts returns the conflicts of the given Eventing instance with the instances preceding it.
+// The preceding instances keep running, hence the conflicts have to be resolved in the given instance.
+func (r *Resolver) Conflicts(ctx context.Context, eventing *operatorv1alpha1.Eventing) ([]string, error) {
+	instances, err := r.listInstances(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := &kcorev1.NamespaceList{}
+	if err = r.client.List(ctx, namespaces); err != nil {
+		return nil, err
+	}
+
+	var conflicts []string
+	for i := range instances {
+		other := &instances[i]
+		if keyOf(other) == keyOf(eventing) || !r.precedes(other, eventing) {
+			continue
+		}
+		conflicts = append(conflicts, r.getConflicts(eventing, other, namespaces.Items)...)
+	}
+	return conflicts, nil
+}
+
+func (r *Resolver) getConflicts(eventing, other *operatorv1alpha1.Eventing, namespaces []kcorev1.Namespace) []string {
+	var conflicts []string
+
+	// the cluster-scoped resources of the publisher proxy are named after the Eventing CR.
+	if eventing.Name == other.Name {
+		conflicts = append(conflicts, fmt.Sprintf("the cluster-scoped publisher proxy resources are named "+
+			"the same as those of Eventing CR %s", keyOf(other)))
+	}
+
+	if eventing.Spec.Backend != nil && other.Spec.Backend != nil && eventing.Spec.Backend.Type == other.Spec.Backend.Type {
+		switch eventing.Spec.Backend.Type {
+		case operatorv1alpha1.NatsBackendType:
+			// the NATS server is resolved by the namespace of the Eventing CR, and the instances would share its stream.
+			if eventing.Namespace == other.Namespace {
+				conflicts = append(conflicts, fmt.Sprintf("the NATS server of namespace %s is used by Eventing CR %s",
+					eventing.Namespace, keyOf(other)))
+			}
+		case operatorv1alpha1.EventMeshBackendType:
+			// the EventMesh credentials configure the eventing-manager process.
+			conflicts = append(conflicts, fmt.Sprintf("the EventMesh backend is used by Eventing CR %s, "+
+				"only one EventMesh instance is supported per cluster", keyOf(other)))
+		}
+	}
+
+	selector, err := getNamespaceSelector(eventing)
+	if err != nil || selector == nil {
+		return conflicts
+	}
+	otherSelector, err := getNamespaceSelector(other)
+	if err != nil || otherSelector == nil {
+		// the default instance without a namespace selector owns only the namespaces not selected by another instance.
+		return conflicts
+	}
+	for _, namespace := range namespaces {
+		if selector.Matches(labels.Set(namespace.Labels)) && otherSelector.Matches(labels.Set(namespace.Labels)) {
+			conflicts = append(conflicts, fmt.Sprintf("namespace %s is selected by Eventing CR %s",
+				namespace.Name, keyOf(other)))
+		}
+	}
+
+	return conflicts
+}
+
+// listInstances returns the Eventing instances sorted by their precedence.
+func (r *Resolver) listInstances(ctx context.Context) ([]operatorv1alpha1.Eventing, error) {
+	eventingList := &operatorv1alpha1.EventingList{}
+	if err := r.client.List(ctx, eventingList); err != nil {
+		return nil, err
+	}
+
+	instances := eventingList.Items
+	sort.SliceStable(instances, func(i, j int) bool {
+		return r.precedes(&instances[i], &instances[j])
+	})
+	return instances, nil
+}
+
+// precedes returns true if the Eventing instance a precedes the instance b. The default instance precedes all
+// the others, which precede each other by their creation time, and by their namespaced name if created at once.
+func (r *Resolver) precedes(a, b *operatorv1alpha1.Eventing) bool {
+	if r.IsDefault(a) != r.IsDefault(b) {
+		return r.IsDefault(a)
+	}
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return keyOf(a).String() < keyOf(b).String()
+}
+
+func (r *Resolver) getNamespaceLabels(ctx context.Context, namespace string) (labels.Set, error) {
+	ns := &kcorev1.Namespace{}
+	if err := r.client.Get(ctx, ktypes.NamespacedName{Name: namespace}, ns); err != nil {
+		if kerrors.IsNotFound(err) {
+			return labels.Set{}, nil
+		}
+		return nil, err
+	}
+	return ns.Labels, nil
+}
+
+// ValidateNamespaceSelector returns an error if the namespace selector of the given Eventing CR is invalid.
+func ValidateNamespaceSelector(eventing *operatorv1alpha1.Eventing) error {
+	_, err := getNamespaceSelector(eventing)
+	return err
+}
+
+// getNamespaceSelector returns the namespace selector of the given Eventing CR, or nil if it is not set.
+func getNamespaceSelector(eventing *operatorv1alpha1.Eventing) (labels.Selector, error) {
+	if eventing.Spec.NamespaceSelector == nil {
+		return nil, nil //nolint:nilnil // the namespace selector is optional.
+	}
+	return kmetav1.LabelSelectorAsSelector(eventing.Spec.NamespaceSelector)
+}
+
+func keyOf(eventing *operatorv1alpha1.Eventing) ktypes.NamespacedName {
+	return ktypes.NamespacedName{Namespace: eventing.Namespace, Name: eventing.Name}
+}
+
+// Filter decides which Subscriptions are owned by an Eventing instance.
+// A nil Filter owns all the Subscriptions, e.g. if a single Eventing instance is supported.
+type Filter struct {
+	resolver *Resolver
+	instance ktypes.NamespacedName
+}
+
+// Owns returns true if the Subscriptions of the given namespace are owned by the Eventing instance.
+func (f *Filter) Owns(ctx context.Context, namespace string) (bool, error) {
+	if f == nil {
+		return true, nil
+	}
+	owner, found, err := f.resolver.Owner(ctx, namespace)
+	if err != nil {
+		return false, err
+	}
+	return found && owner == f.instance, nil
+}
+
+// OwnedSubscriptions returns the given Subscriptions which are owned by the Eventing instance.
+func (f *Filter) OwnedSubscriptions(ctx context.Context,
+	subscriptions []eventingv1alpha2.Subscription,
+) ([]eventingv1alpha2.Subscription, error) {
+	if f == nil {
+		return subscriptions, nil
+	}
+	owned := make([]eventingv1alpha2.Subscription, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		ok, err := f.Owns(ctx, subscription.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			owned = append(owned, subscription)
+		}
+	}
+	return owned, nil
+}
@@ -0,0 +1,195 @@
+package tenancy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	kcorev1 "k8s.io/api/core/v1"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	eventingv1alpha2 "github.com/kyma-project/eventing-manager/api/eventing/v1alpha2"
+	operatorv1alpha1 "github.com/kyma-project/eventing-manager/api/operator/v1alpha1"
+)
+
+var defaultInstance = ktypes.NamespacedName{Namespace: "kyma-system", Name: "eventing"}
+
+func newEventing(namespace, name string, created time.Time, matchLabels map[string]string) *operatorv1alpha1.Eventing {
+	eventing := &operatorv1alpha1.Eventing{
+		ObjectMeta: kmetav1.ObjectMeta{
+			Namespace:         namespace,
+			Name:              name,
+			CreationTimestamp: kmetav1.NewTime(created),
+		},
+		Spec: operatorv1alpha1.EventingSpec{
+			Backend: &operatorv1alpha1.Backend{Type: operatorv1alpha1.NatsBackendType},
+		},
+	}
+	if matchLabels != nil {
+		eventing.Spec.NamespaceSelector = &kmetav1.LabelSelector{MatchLabels: matchLabels}
+	}
+	return eventing
+}
+
+func newNamespace(name string, labels map[string]string) *kcorev1.Namespace {
+	return &kcorev1.Namespace{ObjectMeta: kmetav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func newResolver(t *testing.T, objs ...client.Object) *Resolver {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, kcorev1.AddToScheme(scheme))
+	require.NoError(t, operatorv1alpha1.AddToScheme(scheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	return NewResolver(fakeClient, defaultInstance)
+}
+
+func Test_Owner(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	tenantA := ktypes.NamespacedName{Namespace: "tenant-a-system", Name: "tenant-a"}
+	tenantB := ktypes.NamespacedName{Namespace: "tenant-b-system", Name: "tenant-b"}
+
+	testCases := []struct {
+		name           string
+		givenObjects   []client.Object
+		givenNamespace string
+		wantFound      bool
+		wantOwner      ktypes.NamespacedName
+	}{
+		{
+			name: "should resolve the default instance if the namespace is not selected",
+			givenObjects: []client.Object{
+				newNamespace("apps", nil),
+				newEventing(defaultInstance.Namespace, defaultInstance.Name, now, nil),
+				newEventing(tenantA.Namespace, tenantA.Name, now, map[string]string{"tenant": "a"}),
+			},
+			givenNamespace: "apps",
+			wantFound:      true,
+			wantOwner:      defaultInstance,
+		},
+		{
+			name: "should resolve the instance selecting the namespace",
+			givenObjects: []client.Object{
+				newNamespace("apps", map[string]string{"tenant": "a"}),
+				newEventing(defaultInstance.Namespace, defaultInstance.Name, now, nil),
+				newEventing(tenantA.Namespace, tenantA.Name, now, map[string]string{"tenant": "a"}),
+			},
+			givenNamespace: "apps",
+			wantFound:      true,
+			wantOwner:      tenantA,
+		},
+		{
+			name: "should resolve the preceding instance if several instances select the namespace",
+			givenObjects: []client.Object{
+				newNamespace("apps", map[string]string{"tenant": "a"}),
+				newEventing(tenantA.Namespace, tenantA.Name, now, map[string]string{"tenant": "a"}),
+				newEventing(tenantB.Namespace, tenantB.Name, now.Add(-time.Hour), map[string]string{"tenant": "a"}),
+			},
+			givenNamespace: "apps",
+			wantFound:      true,
+			wantOwner:      tenantB,
+		},
+		{
+			name: "should resolve no instance if the default instance has a namespace selector",
+			givenObjects: []client.Object{
+				newNamespace("apps", nil),
+				newEventing(defaultInstance.Namespace, defaultInstance.Name, now, map[string]string{"tenant": "default"}),
+			},
+			givenNamespace: "apps",
+			wantFound:      false,
+		},
+		{
+			name: "should resolve no instance if the default instance does not exist",
+			givenObjects: []client.Object{
+				newNamespace("apps", nil),
+				newEventing(tenantA.Namespace, tenantA.Name, now, map[string]string{"tenant": "a"}),
+			},
+			givenNamespace: "apps",
+			wantFound:      false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			resolver := newResolver(t, tc.givenObjects...)
+
+			// when
+			owner, found, err := resolver.Owner(context.Background(), tc.givenNamespace)
+
+			// then
+			require.NoError(t, err)
+			require.Equal(t, tc.wantFound, found)
+			if tc.wantFound {
+				require.Equal(t, tc.wantOwner, owner)
+			}
+		})
+	}
+}
+
+func Test_Conflicts(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	// given
+	defaultEventing := newEventing(defaultInstance.Namespace, defaultInstance.Name, now, nil)
+	tenantA := newEventing("tenant-a-system", "tenant-a", now.Add(-time.Hour), map[string]string{"tenant": "a"})
+	tenantB := newEventing("tenant-b-system", "tenant-b", now, map[string]string{"team": "b"})
+	resolver := newResolver(t,
+		newNamespace("apps", map[string]string{"tenant": "a", "team": "b"}),
+		defaultEventing, tenantA, tenantB,
+	)
+
+	// when
+	defaultConflicts, err := resolver.Conflicts(context.Background(), defaultEventing)
+	require.NoError(t, err)
+	tenantAConflicts, err := resolver.Conflicts(context.Background(), tenantA)
+	require.NoError(t, err)
+	tenantBConflicts, err := resolver.Conflicts(context.Background(), tenantB)
+	require.NoError(t, err)
+
+	// then
+	require.Empty(t, defaultConflicts)
+	require.Empty(t, tenantAConflicts)
+	require.Equal(t, []string{"namespace apps is selected by Eventing CR tenant-a-system/tenant-a"}, tenantBConflicts)
+}
+
+func Test_Filter_OwnedSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	// given
+	tenantA := newEventing("tenant-a-system", "tenant-a", time.Now(), map[string]string{"tenant": "a"})
+	resolver := newResolver(t,
+		newNamespace("apps", nil),
+		newNamespace("tenant-a-apps", map[string]string{"tenant": "a"}),
+		newEventing(defaultInstance.Namespace, defaultInstance.Name, time.Now(), nil),
+		tenantA,
+	)
+	subscriptions := []eventingv1alpha2.Subscription{
+		{ObjectMeta: kmetav1.ObjectMeta{Namespace: "apps", Name: "sub"}},
+		{ObjectMeta: kmetav1.ObjectMeta{Namespace: "tenant-a-apps", Name: "sub"}},
+	}
+
+	// when
+	tenantOwned, err := resolver.Filter(tenantA).OwnedSubscriptions(context.Background(), subscriptions)
+	require.NoError(t, err)
+	var nilFilter *Filter
+	allOwned, err := nilFilter.OwnedSubscriptions(context.Background(), subscriptions)
+	require.NoError(t, err)
+
+	// then
+	require.Len(t, tenantOwned, 1)
+	require.Equal(t, "tenant-a-apps", tenantOwned[0].Namespace)
+	require.Len(t, allOwned, 2)
+}
@@ -0,0 +1,219 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/embedded"
+	"go.opentelemetry.io/otel/trace/noop"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	// tracerName is the name of the tracer of eventing-manager, which is also the instrumentation scope of its spans.
+	tracerName  = "github.com/kyma-project/eventing-manager"
+	serviceName = "eventing-manager"
+
+	shutdownTimeout = 5 * time.Second
+
+	defaultEndpointResyncPeriod = 10 * time.Second
+)
+
+var ErrInvalidEndpoint = errors.New("the OTLP endpoint must be an absolute http or https URL")
+
+// Perform a compile-time check.
+var (
+	_ trace.TracerProvider           = &Provider{}
+	_ manager.Runnable               = &Provider{}
+	_ manager.LeaderElectionRunnable = &Provider{}
+)
+
+// EndpointSource returns the OTLP endpoint the spans are exported to, it is empty if the spans are dropped.
+type EndpointSource func(ctx context.Context) (string, error)
+
+// exporterFactory creates the exporter of the spans to the given endpoint.
+type exporterFactory func(ctx context.Context, endpoint string) (sdktrace.SpanExporter, error)
+
+// Provider is the TracerProvider of eventing-manager. It exports the spans to the OTLP endpoint of its endpoint
+// source, and drops them while no endpoint is configured. The tracers of the Provider stay valid if it is
+// reconfigured, so that they can be created once by the dispatchers.
+type Provider struct {
+	embedded.TracerProvider
+
+	newExporter    exporterFactory
+	endpointSource EndpointSource
+	resyncPeriod   time.Duration
+
+	mutex       sync.RWMutex
+	endpoint    string
+	sdkProvider *sdktrace.TracerProvider
+}
+
+func NewProvider() *Provider {
+	return &Provider{newExporter: newOTLPExporter, resyncPeriod: defaultEndpointResyncPeriod}
+}
+
+// SetEndpointSource sets the source of the OTLP endpoint, which the Provider syncs periodically once it is started.
+func (p *Provider) SetEndpointSource(source EndpointSource) {
+	p.endpointSource = source
+}
+
+// Tracer returns the tracer of eventing-manager, which creates the spans by the TracerProvider registered globally.
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// Tracer implements the trace.TracerProvider interface.
+func (p *Provider) Tracer(name string, options ...trace.TracerOption) trace.Tracer {
+	return &tracer{provider: p, name: name, options: options}
+}
+
+// Configure exports the spans to the given OTLP/HTTP endpoint, or drops them if the endpoint is empty. The spans
+// recorded for the previous endpoint are flushed to it.
+func (p *Provider) Configure(ctx context.Context, endpoint string) error {
+	p.mutex.Lock()
+	if endpoint == p.endpoint {
+		p.mutex.Unlock()
+		return nil
+	}
+
+	var sdkProvider *sdktrace.TracerProvider
+	if endpoint != "" {
+		exporter, err := p.newExporter(ctx, endpoint)
+		if err != nil {
+			p.mutex.Unlock()
+			return err
+		}
+		sdkProvider = sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(exporter),
+			sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName))),
+			// the publisher decides whether an event is sampled, the events published without a trace are sampled.
+			sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.AlwaysSample())),
+		)
+	}
+	previous := p.sdkProvider
+	p.endpoint, p.sdkProvider = endpoint, sdkProvider
+	p.mutex.Unlock()
+
+	return shutdown(previous)
+}
+
+// Endpoint returns the OTLP endpoint the Provider exports the spans to, it is empty if the spans are dropped.
+func (p *Provider) Endpoint() string {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return p.endpoint
+}
+
+// Start syncs the endpoint of the endpoint source periodically, and flushes the recorded spans once the given context
+// is done. It runs on all the replicas, so that all of them export the spans of their dispatching.
+func (p *Provider) Start(ctx context.Context) error {
+	if p.endpointSource != nil {
+		p.syncPeriodically(ctx)
+	}
+
+	<-ctx.Done()
+	p.mutex.Lock()
+	sdkProvider := p.sdkProvider
+	p.endpoint, p.sdkProvider = "", nil
+	p.mutex.Unlock()
+	return shutdown(sdkProvider)
+}
+
+// NeedLeaderElection implements the manager.LeaderElectionRunnable interface. The events are dispatched by all the
+// replicas of eventing-manager.
+func (p *Provider) NeedLeaderElection() bool {
+	return false
+}
+
+// syncPeriodically syncs the endpoint of the endpoint source until the given context is done. The errors are reported
+// to the error handler of OpenTelemetry.
+func (p *Provider) syncPeriodically(ctx context.Context) {
+	ticker := time.NewTicker(p.resyncPeriod)
+	defer ticker.Stop()
+	for {
+		if err := p.sync(ctx); err != nil {
+			otel.Handle(err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sync configures the Provider with the endpoint of its endpoint source.
+func (p *Provider) sync(ctx context.Context) error {
+	endpoint, err := p.endpointSource(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the OTLP endpoint: %w", err)
+	}
+	if err = p.Configure(ctx, endpoint); err != nil {
+		return fmt.Errorf("failed to export the spans to %s: %w", endpoint, err)
+	}
+	return nil
+}
+
+// current returns the TracerProvider the spans are created by.
+func (p *Provider) current() trace.TracerProvider {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	if p.sdkProvider == nil {
+		return noop.NewTracerProvider()
+	}
+	return p.sdkProvider
+}
+
+// tracer creates the spans by the TracerProvider the Provider is currently configured with.
+type tracer struct {
+	embedded.Tracer
+
+	provider *Provider
+	name     string
+	options  []trace.TracerOption
+}
+
+func (t *tracer) Start(ctx context.Context, spanName string,
+	options ...trace.SpanStartOption,
+) (context.Context, trace.Span) {
+	return t.provider.current().Tracer(t.name, t.options...).Start(ctx, spanName, options...)
+}
+
+func newOTLPExporter(ctx context.Context, endpoint string) (sdktrace.SpanExporter, error) {
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidEndpoint, err)
+	}
+	if (endpointURL.Scheme != "http" && endpointURL.Scheme != "https") || endpointURL.Host == "" {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidEndpoint, endpoint)
+	}
+
+	options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(endpointURL.Host)}
+	if endpointURL.Scheme == "http" {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+	if endpointURL.Path != "" && endpointURL.Path != "/" {
+		options = append(options, otlptracehttp.WithURLPath(endpointURL.Path))
+	}
+	return otlptracehttp.New(ctx, options...)
+}
+
+func shutdown(sdkProvider *sdktrace.TracerProvider) error {
+	if sdkProvider == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return sdkProvider.Shutdown(ctx)
+}
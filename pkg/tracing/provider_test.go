@@ -0,0 +1,165 @@
+package tracing
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// keepingExporter is an in-memory exporter, which keeps the exported spans on shutdown.
+type keepingExporter struct {
+	*tracetest.InMemoryExporter
+}
+
+func (e keepingExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+// newTestProvider returns a Provider, which exports the spans to in-memory exporters by their endpoint.
+func newTestProvider(t *testing.T) (*Provider, map[string]keepingExporter) {
+	t.Helper()
+	exporters := make(map[string]keepingExporter)
+	provider := NewProvider()
+	provider.newExporter = func(_ context.Context, endpoint string) (sdktrace.SpanExporter, error) {
+		exporter := keepingExporter{InMemoryExporter: tracetest.NewInMemoryExporter()}
+		exporters[endpoint] = exporter
+		return exporter, nil
+	}
+	return provider, exporters
+}
+
+func Test_Configure(t *testing.T) {
+	t.Parallel()
+
+	// given
+	provider, exporters := newTestProvider(t)
+	tracer := provider.Tracer(tracerName)
+
+	// when
+	_, span := tracer.Start(context.Background(), "dropped")
+	span.End()
+	require.NoError(t, provider.Configure(context.Background(), "http://collector-a:4318"))
+	_, span = tracer.Start(context.Background(), "exported-to-a")
+	span.End()
+	require.NoError(t, provider.Configure(context.Background(), "http://collector-b:4318"))
+	_, span = tracer.Start(context.Background(), "exported-to-b")
+	span.End()
+	require.NoError(t, provider.Configure(context.Background(), ""))
+	_, span = tracer.Start(context.Background(), "dropped")
+	span.End()
+
+	// then
+	require.Empty(t, provider.Endpoint())
+	require.Len(t, exporters, 2)
+	// the spans are flushed to the previous endpoint once the provider is reconfigured.
+	require.Len(t, exporters["http://collector-a:4318"].GetSpans(), 1)
+	require.Equal(t, "exported-to-a", exporters["http://collector-a:4318"].GetSpans()[0].Name)
+	require.Len(t, exporters["http://collector-b:4318"].GetSpans(), 1)
+	require.Equal(t, "exported-to-b", exporters["http://collector-b:4318"].GetSpans()[0].Name)
+}
+
+func Test_Start(t *testing.T) {
+	t.Parallel()
+
+	// given
+	provider, exporters := newTestProvider(t)
+	require.NoError(t, provider.Configure(context.Background(), "http://collector:4318"))
+	_, span := provider.Tracer(tracerName).Start(context.Background(), "exported")
+	span.End()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// when
+	err := provider.Start(ctx)
+
+	// then
+	require.NoError(t, err)
+	require.Empty(t, provider.Endpoint())
+	require.Len(t, exporters["http://collector:4318"].GetSpans(), 1)
+}
+
+func Test_Start_EndpointSource(t *testing.T) {
+	t.Parallel()
+
+	// given
+	provider, _ := newTestProvider(t)
+	provider.resyncPeriod = 10 * time.Millisecond
+	var endpoint atomic.Value
+	endpoint.Store("http://collector-a:4318")
+	provider.SetEndpointSource(func(context.Context) (string, error) {
+		return endpoint.Load().(string), nil
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error)
+
+	// when
+	go func() { done <- provider.Start(ctx) }()
+
+	// then the provider is configured with the endpoint of the source
+	require.Eventually(t, func() bool {
+		return provider.Endpoint() == "http://collector-a:4318"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// when the endpoint of the source changes
+	endpoint.Store("http://collector-b:4318")
+
+	// then the provider is reconfigured
+	require.Eventually(t, func() bool {
+		return provider.Endpoint() == "http://collector-b:4318"
+	}, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+	require.Empty(t, provider.Endpoint())
+}
+
+func Test_newOTLPExporter(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		givenEndpoint string
+		wantError     error
+	}{
+		{
+			name:          "should create the exporter for an http endpoint",
+			givenEndpoint: "http://telemetry-otlp-traces.kyma-system:4318",
+		},
+		{
+			name:          "should create the exporter for an https endpoint with a path",
+			givenEndpoint: "https://collector.example.com/otlp/v1/traces",
+		},
+		{
+			name:          "should fail if the endpoint is not an http URL",
+			givenEndpoint: "grpc://collector:4317",
+			wantError:     ErrInvalidEndpoint,
+		},
+		{
+			name:          "should fail if the endpoint has no host",
+			givenEndpoint: "collector:4318",
+			wantError:     ErrInvalidEndpoint,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			exporter, err := newOTLPExporter(context.Background(), tc.givenEndpoint)
+
+			// then
+			require.ErrorIs(t, err, tc.wantError)
+			if tc.wantError == nil {
+				require.NotNil(t, exporter)
+				require.NoError(t, exporter.Shutdown(context.Background()))
+			}
+		})
+	}
+}
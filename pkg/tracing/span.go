@@ -0,0 +1,96 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ceevent "github.com/cloudevents/sdk-go/v2/event"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// attributes of the spans, which are not covered by the semantic conventions.
+const (
+	AttributeSubscriptionName      = attribute.Key("eventing.subscription.name")
+	AttributeSubscriptionNamespace = attribute.Key("eventing.subscription.namespace")
+	AttributeConsumerName          = attribute.Key("eventing.consumer.name")
+	AttributeDeliveryAttempt       = attribute.Key("eventing.delivery.attempt")
+	AttributeStreamTimestamp       = attribute.Key("eventing.stream.timestamp")
+	AttributeQueueDuration         = attribute.Key("eventing.stream.queue_duration_ms")
+)
+
+// DeliveryAttempt describes an attempt to deliver an event to the sink of a Subscription.
+type DeliveryAttempt struct {
+	// System is the messaging system the event is consumed from, e.g. nats.
+	System                string
+	SubscriptionName      string
+	SubscriptionNamespace string
+	// ConsumerName is the name of the consumer the event is consumed by.
+	ConsumerName string
+	// Attempt is the number of the delivery attempt of the event, starting at 1.
+	Attempt uint64
+	// StoredAt is the time the event was stored in the stream, it is zero if unknown.
+	StoredAt time.Time
+}
+
+// StartDeliverySpan starts the consumer span of the given delivery attempt of the given CloudEvent by the given tracer.
+// The publish span is resolved from the traceparent header put into the given context by AddTracingHeadersToContext.
+// According to the semantic conventions of messaging, the consumer span is a child of the publish span and links to
+// it. If the consumer span is recorded, it replaces the publish span in the traceparent header, so that the sink
+// continues the trace from the delivery attempt. If the time the event was stored in the stream is known, the span
+// records it and the duration the event was queued in the stream before the delivery attempt.
+func StartDeliverySpan(ctx context.Context, tracer trace.Tracer, ce *ceevent.Event,
+	attempt DeliveryAttempt,
+) (context.Context, trace.Span) {
+	propagator := propagation.TraceContext{}
+	header := cehttp.HeaderFrom(ctx)
+	ctx = propagator.Extract(ctx, propagation.HeaderCarrier(header))
+
+	options := []trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingSystem(attempt.System),
+			semconv.MessagingOperationProcess,
+			semconv.MessagingMessageID(ce.ID()),
+			semconv.MessagingDestinationName(ce.Type()),
+			AttributeSubscriptionName.String(attempt.SubscriptionName),
+			AttributeSubscriptionNamespace.String(attempt.SubscriptionNamespace),
+			AttributeConsumerName.String(attempt.ConsumerName),
+			AttributeDeliveryAttempt.Int64(int64(attempt.Attempt)),
+		),
+	}
+	if !attempt.StoredAt.IsZero() {
+		now := time.Now()
+		options = append(options, trace.WithAttributes(
+			AttributeStreamTimestamp.String(attempt.StoredAt.UTC().Format(time.RFC3339Nano)),
+			AttributeQueueDuration.Int64(now.Sub(attempt.StoredAt).Milliseconds()),
+		))
+	}
+	if publishSpan := trace.SpanContextFromContext(ctx); publishSpan.IsValid() {
+		options = append(options, trace.WithLinks(trace.Link{SpanContext: publishSpan}))
+	}
+
+	ctx, span := tracer.Start(ctx, fmt.Sprintf("%s process", ce.Type()), options...)
+	if span.IsRecording() {
+		propagatedHeader := header.Clone()
+		propagator.Inject(ctx, propagation.HeaderCarrier(propagatedHeader))
+		ctx = cehttp.WithCustomHeader(ctx, propagatedHeader)
+	}
+	return ctx, span
+}
+
+// EndDeliverySpan records the status code responded by the sink and the error of the delivery attempt, if any, and
+// ends the given span.
+func EndDeliverySpan(span trace.Span, statusCode int, err error) {
+	span.SetAttributes(semconv.HTTPStatusCode(statusCode))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
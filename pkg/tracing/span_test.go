@@ -0,0 +1,158 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const (
+	publishTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	publishTraceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+	publishSpanID      = "00f067aa0ba902b7"
+)
+
+var errDeliveryFailed = errors.New("delivery failed")
+
+func Test_StartDeliverySpan(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name             string
+		givenTraceParent string
+		givenError       error
+		wantLinked       bool
+		wantStatusCode   codes.Code
+	}{
+		{
+			name:             "should continue the trace of the publish span",
+			givenTraceParent: publishTraceParent,
+			wantLinked:       true,
+			wantStatusCode:   codes.Unset,
+		},
+		{
+			name:           "should start a new trace if the event was published without a trace",
+			wantLinked:     false,
+			wantStatusCode: codes.Unset,
+		},
+		{
+			name:             "should record the error of the delivery attempt",
+			givenTraceParent: publishTraceParent,
+			givenError:       errDeliveryFailed,
+			wantLinked:       true,
+			wantStatusCode:   codes.Error,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			recorder := tracetest.NewSpanRecorder()
+			tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer(tracerName)
+			extensions := map[string]string{}
+			if tc.givenTraceParent != "" {
+				extensions[traceParentCEExtensionsKey] = tc.givenTraceParent
+			}
+			event := NewEventWithExtensions(extensions)
+			ctx := AddTracingHeadersToContext(context.Background(), event)
+
+			// when
+			spanCtx, span := StartDeliverySpan(ctx, tracer, event, DeliveryAttempt{
+				System:                "nats",
+				SubscriptionName:      "sub",
+				SubscriptionNamespace: "test",
+				ConsumerName:          "consumer",
+				Attempt:               2,
+			})
+			EndDeliverySpan(span, http.StatusOK, tc.givenError)
+
+			// then
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			gotSpan := spans[0]
+			require.Equal(t, trace.SpanKindConsumer, gotSpan.SpanKind())
+			require.Equal(t, tc.wantStatusCode, gotSpan.Status().Code)
+			require.Subset(t, gotSpan.Attributes(), []any{
+				semconv.MessagingSystem("nats"),
+				AttributeSubscriptionName.String("sub"),
+				AttributeSubscriptionNamespace.String("test"),
+				AttributeConsumerName.String("consumer"),
+				AttributeDeliveryAttempt.Int64(2),
+				semconv.HTTPStatusCode(http.StatusOK),
+			})
+
+			for _, kv := range gotSpan.Attributes() {
+				require.NotEqual(t, AttributeStreamTimestamp, kv.Key)
+			}
+
+			if tc.wantLinked {
+				require.Equal(t, publishTraceID, gotSpan.SpanContext().TraceID().String())
+				require.Equal(t, publishSpanID, gotSpan.Parent().SpanID().String())
+				require.Len(t, gotSpan.Links(), 1)
+				require.Equal(t, publishSpanID, gotSpan.Links()[0].SpanContext.SpanID().String())
+			} else {
+				require.False(t, gotSpan.Parent().IsValid())
+				require.Empty(t, gotSpan.Links())
+			}
+
+			// the sink continues the trace from the delivery attempt.
+			wantTraceParent := "00-" + gotSpan.SpanContext().TraceID().String() + "-" +
+				gotSpan.SpanContext().SpanID().String() + "-01"
+			require.Equal(t, wantTraceParent, cehttp.HeaderFrom(spanCtx).Get(traceParentKey))
+		})
+	}
+}
+
+func Test_StartDeliverySpan_StoredAt(t *testing.T) {
+	t.Parallel()
+
+	// given
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer(tracerName)
+	event := NewEventWithExtensions(map[string]string{})
+	storedAt := time.Now().Add(-time.Minute)
+
+	// when
+	_, span := StartDeliverySpan(context.Background(), tracer, event, DeliveryAttempt{StoredAt: storedAt})
+	EndDeliverySpan(span, http.StatusOK, nil)
+
+	// then
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	attributes := map[attribute.Key]attribute.Value{}
+	for _, kv := range spans[0].Attributes() {
+		attributes[kv.Key] = kv.Value
+	}
+	require.Equal(t, storedAt.UTC().Format(time.RFC3339Nano), attributes[AttributeStreamTimestamp].AsString())
+	require.GreaterOrEqual(t, attributes[AttributeQueueDuration].AsInt64(), time.Minute.Milliseconds())
+}
+
+func Test_StartDeliverySpan_NotRecording(t *testing.T) {
+	t.Parallel()
+
+	// given
+	event := NewEventWithExtensions(map[string]string{traceParentCEExtensionsKey: publishTraceParent})
+	ctx := AddTracingHeadersToContext(context.Background(), event)
+
+	// when
+	spanCtx, span := StartDeliverySpan(ctx, noop.NewTracerProvider().Tracer(tracerName), event, DeliveryAttempt{})
+	EndDeliverySpan(span, http.StatusOK, nil)
+
+	// then
+	require.Equal(t, http.Header{"Traceparent": {publishTraceParent}}, cehttp.HeaderFrom(spanCtx))
+}
@@ -0,0 +1,177 @@
+package webhookcert
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+const (
+	// CACertField is the field of the webhook Secret containing the CA bundle injected into the webhooks.
+	CACertField = "ca.crt"
+	// CAKeyField is the field of the webhook Secret containing the key of the current CA.
+	CAKeyField = "ca.key"
+	// TLSCertField is the field of the webhook Secret containing the serving certificate.
+	TLSCertField = "tls.crt"
+	// TLSKeyField is the field of the webhook Secret containing the key of the serving certificate.
+	TLSKeyField = "tls.key"
+
+	caCommonName   = "eventing-manager-webhook-ca"
+	certPEMType    = "CERTIFICATE"
+	keyPEMType     = "EC PRIVATE KEY"
+	serialNumBits  = 128
+	clockSkewSlack = 5 * time.Minute
+)
+
+var ErrInvalidPEM = errors.New("invalid PEM data")
+
+// keyPair is a certificate with its private key.
+type keyPair struct {
+	cert    *x509.Certificate
+	key     crypto.Signer
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// newCA returns a new self-signed CA valid from now for the validity.
+func newCA(now time.Time, validity time.Duration) (*keyPair, error) {
+	template := &x509.Certificate{
+		Subject:               pkix.Name{CommonName: caCommonName},
+		NotBefore:             now.Add(-clockSkewSlack),
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	return newKeyPair(template, nil)
+}
+
+// newServingCert returns a new serving certificate for the DNS names signed by the CA, valid from now for the
+// validity.
+func newServingCert(ca *keyPair, dnsNames []string, now time.Time, validity time.Duration) (*keyPair, error) {
+	template := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: dnsNames[0]},
+		DNSNames:    dnsNames,
+		NotBefore:   now.Add(-clockSkewSlack),
+		NotAfter:    now.Add(validity),
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	return newKeyPair(template, ca)
+}
+
+// newKeyPair creates a certificate from the template signed by the parent, or a self-signed one if parent is nil.
+func newKeyPair(template *x509.Certificate, parent *keyPair) (*keyPair, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate the key: %w", err)
+	}
+	template.SerialNumber, err = rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), serialNumBits))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate the serial number: %w", err)
+	}
+
+	parentCert, parentKey := template, crypto.Signer(key)
+	if parent != nil {
+		parentCert, parentKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parentCert, key.Public(), parentKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the certificate: %w", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return &keyPair{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: certPEMType, Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: keyPEMType, Bytes: keyDER}),
+	}, nil
+}
+
+// parseKeyPair parses the first certificate of certPEM and its private key.
+func parseKeyPair(certPEM, keyPEM []byte) (*keyPair, error) {
+	certs, err := parseCertificates(certPEM)
+	if err != nil {
+		return nil, err
+	}
+	keyBlock, _ := pem.Decode(keyPEM)
+	if keyBlock == nil {
+		return nil, ErrInvalidPEM
+	}
+	key, err := x509.ParseECPrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	if !key.PublicKey.Equal(certs[0].PublicKey) {
+		return nil, errors.New("the key does not match the certificate")
+	}
+	return &keyPair{
+		cert:    certs[0],
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: certPEMType, Bytes: certs[0].Raw}),
+		keyPEM:  keyPEM,
+	}, nil
+}
+
+// parseCertificates parses all the certificates of the PEM data.
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != certPEMType {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, ErrInvalidPEM
+	}
+	return certs, nil
+}
+
+// encodeCertificates encodes the certificates as PEM data.
+func encodeCertificates(certs []*x509.Certificate) []byte {
+	var buffer bytes.Buffer
+	for _, cert := range certs {
+		_ = pem.Encode(&buffer, &pem.Block{Type: certPEMType, Bytes: cert.Raw})
+	}
+	return buffer.Bytes()
+}
+
+// serviceDNSNames returns the DNS names of the Service, starting with the fully qualified one.
+func serviceDNSNames(service, namespace string) []string {
+	return []string{
+		fmt.Sprintf("%s.%s.svc.cluster.local", service, namespace),
+		fmt.Sprintf("%s.%s.svc", service, namespace),
+		fmt.Sprintf("%s.%s", service, namespace),
+		service,
+	}
+}
+
+// CABundle returns the CA bundle of the webhook Secret data. The Secrets created before the CA was stored separately
+// contain a self-signed serving certificate only, which is used as the CA bundle.
+func CABundle(data map[string][]byte) []byte {
+	if caBundle := data[CACertField]; len(caBundle) > 0 {
+		return caBundle
+	}
+	return data[TLSCertField]
+}
@@ -0,0 +1,48 @@
+package webhookcert
+
+import (
+	"bytes"
+
+	kadmissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	kapiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// InjectIntoMutatingWebhooks sets the CA bundle of all the webhooks of the configuration.
+// It returns true if any of the webhooks was changed.
+func InjectIntoMutatingWebhooks(config *kadmissionregistrationv1.MutatingWebhookConfiguration, caBundle []byte) bool {
+	changed := false
+	for i := range config.Webhooks {
+		changed = injectInto(&config.Webhooks[i].ClientConfig.CABundle, caBundle) || changed
+	}
+	return changed
+}
+
+// InjectIntoValidatingWebhooks sets the CA bundle of all the webhooks of the configuration.
+// It returns true if any of the webhooks was changed.
+func InjectIntoValidatingWebhooks(config *kadmissionregistrationv1.ValidatingWebhookConfiguration,
+	caBundle []byte) bool {
+	changed := false
+	for i := range config.Webhooks {
+		changed = injectInto(&config.Webhooks[i].ClientConfig.CABundle, caBundle) || changed
+	}
+	return changed
+}
+
+// InjectIntoConversionWebhook sets the CA bundle of the conversion webhook of the CRD, if it uses one.
+// It returns true if the conversion webhook was changed.
+func InjectIntoConversionWebhook(crd *kapiextensionsv1.CustomResourceDefinition, caBundle []byte) bool {
+	conversion := crd.Spec.Conversion
+	if conversion == nil || conversion.Strategy != kapiextensionsv1.WebhookConverter ||
+		conversion.Webhook == nil || conversion.Webhook.ClientConfig == nil {
+		return false
+	}
+	return injectInto(&conversion.Webhook.ClientConfig.CABundle, caBundle)
+}
+
+func injectInto(target *[]byte, caBundle []byte) bool {
+	if bytes.Equal(*target, caBundle) {
+		return false
+	}
+	*target = caBundle
+	return true
+}
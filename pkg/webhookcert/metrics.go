@@ -0,0 +1,46 @@
+package webhookcert
+
+import (
+	"crypto/x509"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// expiryMetricKey name of the webhook certificate expiry metric.
+	expiryMetricKey = "eventing_ec_webhook_certificate_expiry_timestamp_seconds"
+	// expiryMetricHelp help text for the webhook certificate expiry metric.
+	expiryMetricHelp = "The expiry time of the webhook certificates in seconds since the epoch"
+
+	certificateLabel = "certificate"
+
+	caCertificate      = "ca"
+	servingCertificate = "serving"
+)
+
+// expiryCollector exposes the expiry time of the webhook certificates.
+type expiryCollector struct {
+	expiry *prometheus.GaugeVec
+}
+
+func newExpiryCollector() *expiryCollector {
+	return &expiryCollector{
+		expiry: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: expiryMetricKey,
+				Help: expiryMetricHelp,
+			},
+			[]string{certificateLabel},
+		),
+	}
+}
+
+func (c *expiryCollector) register() {
+	metrics.Registry.MustRegister(c.expiry)
+}
+
+// set sets the expiry time of the certificate.
+func (c *expiryCollector) set(certificate string, cert *x509.Certificate) {
+	c.expiry.WithLabelValues(certificate).Set(float64(cert.NotAfter.Unix()))
+}
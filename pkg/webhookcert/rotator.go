@@ -0,0 +1,260 @@
+// Package webhookcert bootstraps and rotates the self-signed CA and serving certificate of the webhook server.
+package webhookcert
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	kadmissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	kcorev1 "k8s.io/api/core/v1"
+	kapiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/kyma-project/eventing-manager/pkg/env"
+	"github.com/kyma-project/eventing-manager/pkg/k8s"
+	"github.com/kyma-project/eventing-manager/pkg/logger"
+)
+
+const (
+	rotatorName = "webhook-cert-rotator"
+
+	// caValidity is the validity of the CA.
+	caValidity = 365 * 24 * time.Hour
+	// certValidity is the validity of the serving certificate.
+	certValidity = 90 * 24 * time.Hour
+	// renewBefore is the duration before the expiry at which the CA and the serving certificate are renewed.
+	renewBefore = 30 * 24 * time.Hour
+	// checkInterval is the interval at which the certificates are checked.
+	checkInterval = time.Hour
+	// retryInterval is the interval at which a failed check is retried.
+	retryInterval = 10 * time.Second
+)
+
+var ErrNoCertificate = errors.New("the webhook serving certificate is not loaded yet")
+
+// Perform a compile-time check.
+var (
+	_ manager.Runnable               = &Rotator{}
+	_ manager.LeaderElectionRunnable = &Rotator{}
+)
+
+// Rotator bootstraps the CA and the serving certificate of the webhook server in the webhook Secret, renews them
+// before they expire, and injects the CA bundle into the webhook configurations and the conversion webhook of the
+// Subscription CRD. The webhook server gets the serving certificate from the Rotator, so the renewed certificates
+// are served without a restart.
+//
+// All the replicas run the Rotator, since they all serve the webhooks. The concurrent renewals are resolved by the
+// optimistic concurrency of the Secret updates.
+type Rotator struct {
+	client        client.Client
+	backendConfig env.BackendConfig
+	logger        *logger.Logger
+	certificate   atomic.Pointer[tls.Certificate]
+	expiry        *expiryCollector
+	now           func() time.Time
+}
+
+func NewRotator(client client.Client, backendConfig env.BackendConfig, logger *logger.Logger) *Rotator {
+	return &Rotator{
+		client:        client,
+		backendConfig: backendConfig,
+		logger:        logger,
+		expiry:        newExpiryCollector(),
+		now:           time.Now,
+	}
+}
+
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=update;patch
+
+// Start checks the certificates periodically until the given context is done.
+func (r *Rotator) Start(ctx context.Context) error {
+	for {
+		interval := checkInterval
+		if err := r.rotate(ctx); err != nil {
+			r.namedLogger().Errorw("Failed to rotate the webhook certificates", "error", err)
+			interval = retryInterval
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// NeedLeaderElection implements the manager.LeaderElectionRunnable interface.
+func (r *Rotator) NeedLeaderElection() bool {
+	return false
+}
+
+// GetCertificate returns the current serving certificate, it is meant to be set in the TLS config of the webhook
+// server.
+func (r *Rotator) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	certificate := r.certificate.Load()
+	if certificate == nil {
+		return nil, ErrNoCertificate
+	}
+	return certificate, nil
+}
+
+// TLSOpts returns the option of the webhook server serving the certificate of the Rotator.
+func (r *Rotator) TLSOpts() []func(*tls.Config) {
+	return []func(*tls.Config){
+		func(config *tls.Config) {
+			config.GetCertificate = r.GetCertificate
+		},
+	}
+}
+
+// RegisterMetrics registers the expiry metric of the certificates.
+func (r *Rotator) RegisterMetrics() {
+	r.expiry.register()
+}
+
+// rotate renews the certificates of the webhook Secret if needed, loads the serving certificate, and injects the CA
+// bundle into the webhooks.
+func (r *Rotator) rotate(ctx context.Context) error {
+	secret := &kcorev1.Secret{}
+	key := client.ObjectKey{Namespace: r.backendConfig.Namespace, Name: r.backendConfig.WebhookSecretName}
+	err := r.client.Get(ctx, key, secret)
+	notFound := kerrors.IsNotFound(err)
+	if err != nil && !notFound {
+		return err
+	}
+	if notFound {
+		secret = &kcorev1.Secret{
+			ObjectMeta: kmetav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+			Type:       kcorev1.SecretTypeOpaque,
+		}
+	}
+
+	data, renewed, err := r.renew(secret.Data)
+	if err != nil {
+		return err
+	}
+	if renewed {
+		secret.Data = data
+		if notFound {
+			err = r.client.Create(ctx, secret)
+		} else {
+			// a conflict means that another replica renewed the certificates, they are loaded at the next check
+			err = r.client.Update(ctx, secret)
+		}
+		if err != nil {
+			return err
+		}
+		r.namedLogger().Infow("Renewed the webhook certificates", "secret", key.String())
+	}
+
+	certificate, err := tls.X509KeyPair(data[TLSCertField], data[TLSKeyField])
+	if err != nil {
+		return err
+	}
+	r.certificate.Store(&certificate)
+	if serving, err := parseKeyPair(data[TLSCertField], data[TLSKeyField]); err == nil {
+		r.expiry.set(servingCertificate, serving.cert)
+	}
+	if ca, err := parseKeyPair(data[CACertField], data[CAKeyField]); err == nil {
+		r.expiry.set(caCertificate, ca.cert)
+	}
+
+	return r.injectCABundle(ctx, data[CACertField])
+}
+
+// renew returns the Secret data with the expired, expiring, or missing certificates renewed, and whether any of them
+// was renewed.
+func (r *Rotator) renew(data map[string][]byte) (map[string][]byte, bool, error) {
+	now := r.now()
+	renewAt := now.Add(renewBefore)
+	renewed := false
+
+	// the previous CAs stay in the bundle until they expire, since the other replicas may still serve certificates
+	// signed by them
+	var caBundle []*x509.Certificate
+	if certs, err := parseCertificates(data[CACertField]); err == nil {
+		for _, cert := range certs {
+			if cert.NotAfter.After(now) {
+				caBundle = append(caBundle, cert)
+			}
+		}
+		renewed = len(caBundle) != len(certs)
+	}
+
+	ca, err := parseKeyPair(data[CACertField], data[CAKeyField])
+	caRenewed := err != nil || ca.cert.NotAfter.Before(renewAt)
+	if caRenewed {
+		if ca, err = newCA(now, caValidity); err != nil {
+			return nil, false, err
+		}
+		caBundle = append([]*x509.Certificate{ca.cert}, caBundle...)
+		renewed = true
+	}
+
+	dnsNames := serviceDNSNames(r.backendConfig.WebhookServiceName, r.backendConfig.Namespace)
+	serving, err := parseKeyPair(data[TLSCertField], data[TLSKeyField])
+	if caRenewed || err != nil || serving.cert.NotAfter.Before(renewAt) ||
+		serving.cert.CheckSignatureFrom(ca.cert) != nil || !slices.Equal(serving.cert.DNSNames, dnsNames) {
+		if serving, err = newServingCert(ca, dnsNames, now, certValidity); err != nil {
+			return nil, false, err
+		}
+		renewed = true
+	}
+
+	if !renewed {
+		return data, false, nil
+	}
+	return map[string][]byte{
+		CACertField:  encodeCertificates(caBundle),
+		CAKeyField:   ca.keyPEM,
+		TLSCertField: serving.certPEM,
+		TLSKeyField:  serving.keyPEM,
+	}, true, nil
+}
+
+// injectCABundle injects the CA bundle into the webhook configurations and the conversion webhook of the
+// Subscription CRD. The missing resources are skipped, they are reported by the Eventing reconciler.
+func (r *Rotator) injectCABundle(ctx context.Context, caBundle []byte) error {
+	mutatingWH := &kadmissionregistrationv1.MutatingWebhookConfiguration{}
+	if err := r.client.Get(ctx, client.ObjectKey{Name: r.backendConfig.MutatingWebhookName}, mutatingWH); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return err
+		}
+	} else if InjectIntoMutatingWebhooks(mutatingWH, caBundle) {
+		if err := r.client.Update(ctx, mutatingWH); err != nil {
+			return err
+		}
+	}
+
+	validatingWH := &kadmissionregistrationv1.ValidatingWebhookConfiguration{}
+	if err := r.client.Get(ctx, client.ObjectKey{Name: r.backendConfig.ValidatingWebhookName}, validatingWH); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return err
+		}
+	} else if InjectIntoValidatingWebhooks(validatingWH, caBundle) {
+		if err := r.client.Update(ctx, validatingWH); err != nil {
+			return err
+		}
+	}
+
+	crd := &kapiextensionsv1.CustomResourceDefinition{}
+	if err := r.client.Get(ctx, client.ObjectKey{Name: k8s.SubscriptionCrdName}, crd); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if InjectIntoConversionWebhook(crd, caBundle) {
+		return r.client.Update(ctx, crd)
+	}
+	return nil
+}
+
+func (r *Rotator) namedLogger() *zap.SugaredLogger {
+	return r.logger.WithContext().Named(rotatorName)
+}
@@ -0,0 +1,257 @@
+package webhookcert
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	kymalogger "github.com/kyma-project/kyma/common/logging/logger"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	kadmissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	kcorev1 "k8s.io/api/core/v1"
+	kapiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kyma-project/eventing-manager/pkg/env"
+	"github.com/kyma-project/eventing-manager/pkg/k8s"
+	"github.com/kyma-project/eventing-manager/pkg/logger"
+)
+
+func newTestBackendConfig() env.BackendConfig {
+	return env.BackendConfig{
+		Namespace:             "kyma-system",
+		WebhookSecretName:     "webhook-cert",
+		WebhookServiceName:    "webhook-service",
+		MutatingWebhookName:   "mutating-webhook",
+		ValidatingWebhookName: "validating-webhook",
+	}
+}
+
+func newRotator(t *testing.T, objs ...client.Object) (*Rotator, client.Client) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, kcorev1.AddToScheme(scheme))
+	require.NoError(t, kadmissionregistrationv1.AddToScheme(scheme))
+	require.NoError(t, kapiextensionsv1.AddToScheme(scheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	defaultLogger, err := logger.New(string(kymalogger.JSON), string(kymalogger.INFO))
+	require.NoError(t, err)
+	return NewRotator(fakeClient, newTestBackendConfig(), defaultLogger), fakeClient
+}
+
+func newWebhookObjects() []client.Object {
+	config := newTestBackendConfig()
+	return []client.Object{
+		&kadmissionregistrationv1.MutatingWebhookConfiguration{
+			ObjectMeta: kmetav1.ObjectMeta{Name: config.MutatingWebhookName},
+			Webhooks:   []kadmissionregistrationv1.MutatingWebhook{{Name: "first"}, {Name: "second"}},
+		},
+		&kadmissionregistrationv1.ValidatingWebhookConfiguration{
+			ObjectMeta: kmetav1.ObjectMeta{Name: config.ValidatingWebhookName},
+			Webhooks:   []kadmissionregistrationv1.ValidatingWebhook{{Name: "first"}},
+		},
+		&kapiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: kmetav1.ObjectMeta{Name: k8s.SubscriptionCrdName},
+			Spec: kapiextensionsv1.CustomResourceDefinitionSpec{
+				Conversion: &kapiextensionsv1.CustomResourceConversion{
+					Strategy: kapiextensionsv1.WebhookConverter,
+					Webhook: &kapiextensionsv1.WebhookConversion{
+						ClientConfig: &kapiextensionsv1.WebhookClientConfig{},
+					},
+				},
+			},
+		},
+	}
+}
+
+func getSecret(t *testing.T, fakeClient client.Client) *kcorev1.Secret {
+	t.Helper()
+	config := newTestBackendConfig()
+	secret := &kcorev1.Secret{}
+	require.NoError(t, fakeClient.Get(context.Background(),
+		client.ObjectKey{Namespace: config.Namespace, Name: config.WebhookSecretName}, secret))
+	return secret
+}
+
+// requireCABundleInjected checks that the CA bundle is injected into all the webhooks.
+func requireCABundleInjected(t *testing.T, fakeClient client.Client, caBundle []byte) {
+	t.Helper()
+	ctx := context.Background()
+	config := newTestBackendConfig()
+
+	mutatingWH := &kadmissionregistrationv1.MutatingWebhookConfiguration{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: config.MutatingWebhookName}, mutatingWH))
+	for _, webhook := range mutatingWH.Webhooks {
+		require.Equal(t, caBundle, webhook.ClientConfig.CABundle)
+	}
+	validatingWH := &kadmissionregistrationv1.ValidatingWebhookConfiguration{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: config.ValidatingWebhookName}, validatingWH))
+	for _, webhook := range validatingWH.Webhooks {
+		require.Equal(t, caBundle, webhook.ClientConfig.CABundle)
+	}
+	crd := &kapiextensionsv1.CustomResourceDefinition{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: k8s.SubscriptionCrdName}, crd))
+	require.Equal(t, caBundle, crd.Spec.Conversion.Webhook.ClientConfig.CABundle)
+}
+
+// requireServingCertVerified checks that the served certificate is verified by the CA bundle of the Secret.
+func requireServingCertVerified(t *testing.T, rotator *Rotator, secret *kcorev1.Secret, now time.Time) {
+	t.Helper()
+	certificate, err := rotator.GetCertificate(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(certificate.Certificate[0])
+	require.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	require.True(t, roots.AppendCertsFromPEM(secret.Data[CACertField]))
+	_, err = leaf.Verify(x509.VerifyOptions{
+		DNSName:     "webhook-service.kyma-system.svc",
+		Roots:       roots,
+		CurrentTime: now,
+	})
+	require.NoError(t, err)
+}
+
+func TestRotator_Bootstrap(t *testing.T) {
+	t.Parallel()
+
+	// given
+	rotator, fakeClient := newRotator(t, newWebhookObjects()...)
+	_, err := rotator.GetCertificate(&tls.ClientHelloInfo{})
+	require.ErrorIs(t, err, ErrNoCertificate)
+
+	// when
+	require.NoError(t, rotator.rotate(context.Background()))
+
+	// then
+	secret := getSecret(t, fakeClient)
+	for _, field := range []string{CACertField, CAKeyField, TLSCertField, TLSKeyField} {
+		require.NotEmpty(t, secret.Data[field])
+	}
+	requireCABundleInjected(t, fakeClient, secret.Data[CACertField])
+	requireServingCertVerified(t, rotator, secret, time.Now())
+
+	serving, err := parseKeyPair(secret.Data[TLSCertField], secret.Data[TLSKeyField])
+	require.NoError(t, err)
+	require.InDelta(t, float64(serving.cert.NotAfter.Unix()),
+		testutil.ToFloat64(rotator.expiry.expiry.WithLabelValues(servingCertificate)), 0)
+
+	// when the certificates are still valid
+	resourceVersion := secret.ResourceVersion
+	require.NoError(t, rotator.rotate(context.Background()))
+
+	// then they are not renewed
+	require.Equal(t, resourceVersion, getSecret(t, fakeClient).ResourceVersion)
+}
+
+func TestRotator_Rotate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name             string
+		givenElapsed     time.Duration
+		wantCARenewed    bool
+		wantCertRenewed  bool
+		wantCABundleSize int
+	}{
+		{
+			name:             "should keep the valid certificates",
+			givenElapsed:     certValidity - renewBefore - time.Hour,
+			wantCARenewed:    false,
+			wantCertRenewed:  false,
+			wantCABundleSize: 1,
+		},
+		{
+			name:             "should renew the expiring serving certificate only",
+			givenElapsed:     certValidity - renewBefore + time.Hour,
+			wantCARenewed:    false,
+			wantCertRenewed:  true,
+			wantCABundleSize: 1,
+		},
+		{
+			name:             "should renew the expiring CA and keep trusting the previous one",
+			givenElapsed:     caValidity - renewBefore + time.Hour,
+			wantCARenewed:    true,
+			wantCertRenewed:  true,
+			wantCABundleSize: 2,
+		},
+		{
+			name:             "should renew the expired CA and drop the previous one",
+			givenElapsed:     caValidity + time.Hour,
+			wantCARenewed:    true,
+			wantCertRenewed:  true,
+			wantCABundleSize: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			rotator, fakeClient := newRotator(t, newWebhookObjects()...)
+			require.NoError(t, rotator.rotate(context.Background()))
+			before := getSecret(t, fakeClient)
+			now := time.Now().Add(tc.givenElapsed)
+			rotator.now = func() time.Time { return now }
+
+			// when
+			require.NoError(t, rotator.rotate(context.Background()))
+
+			// then
+			after := getSecret(t, fakeClient)
+			require.Equal(t, tc.wantCARenewed, string(before.Data[CAKeyField]) != string(after.Data[CAKeyField]))
+			require.Equal(t, tc.wantCertRenewed, string(before.Data[TLSKeyField]) != string(after.Data[TLSKeyField]))
+			caBundle, err := parseCertificates(after.Data[CACertField])
+			require.NoError(t, err)
+			require.Len(t, caBundle, tc.wantCABundleSize)
+			requireCABundleInjected(t, fakeClient, after.Data[CACertField])
+			requireServingCertVerified(t, rotator, after, now)
+		})
+	}
+}
+
+func TestRotator_ReplaceLegacySecret(t *testing.T) {
+	t.Parallel()
+
+	// given a Secret with a serving certificate only
+	ca, err := newCA(time.Now(), caValidity)
+	require.NoError(t, err)
+	config := newTestBackendConfig()
+	legacySecret := &kcorev1.Secret{
+		ObjectMeta: kmetav1.ObjectMeta{Namespace: config.Namespace, Name: config.WebhookSecretName},
+		Data:       map[string][]byte{TLSCertField: ca.certPEM, TLSKeyField: ca.keyPEM},
+	}
+	rotator, fakeClient := newRotator(t, append(newWebhookObjects(), legacySecret)...)
+
+	// when
+	require.NoError(t, rotator.rotate(context.Background()))
+
+	// then
+	secret := getSecret(t, fakeClient)
+	require.NotEmpty(t, secret.Data[CAKeyField])
+	require.NotEqual(t, ca.certPEM, secret.Data[TLSCertField])
+	requireCABundleInjected(t, fakeClient, secret.Data[CACertField])
+	requireServingCertVerified(t, rotator, secret, time.Now())
+}
+
+func TestRotator_MissingWebhooks(t *testing.T) {
+	t.Parallel()
+
+	// given
+	rotator, fakeClient := newRotator(t)
+
+	// when
+	err := rotator.rotate(context.Background())
+
+	// then the certificates are bootstrapped without the webhooks
+	require.NoError(t, err)
+	require.NotEmpty(t, getSecret(t, fakeClient).Data[TLSCertField])
+}
@@ -191,7 +191,7 @@ func NewTestEnvironment(config TestEnvironmentConfig, connMock *natsconnectionmo
 	// define subscription manager factory mock.
 	subManagerFactoryMock := new(submgrmocks.ManagerFactory)
 	subManagerFactoryMock.On("NewJetStreamManager", mock.Anything, mock.Anything).Return(jetStreamSubManagerMock)
-	subManagerFactoryMock.On("NewEventMeshManager", mock.Anything).Return(eventMeshSubManagerMock, nil)
+	subManagerFactoryMock.On("NewEventMeshManager", mock.Anything, mock.Anything).Return(eventMeshSubManagerMock, nil)
 
 	// setup default mock
 	if connMock == nil {
@@ -217,6 +217,8 @@ func NewTestEnvironment(config TestEnvironmentConfig, connMock *natsconnectionmo
 		opts,
 		config.AllowedEventingCR,
 		natsconnectionmocks.NewBuilder(connMock),
+		nil,
+		nil,
 	)
 
 	if err = (eventingReconciler).SetupWithManager(ctrlMgr); err != nil {
@@ -41,6 +41,13 @@ func WithEventingCRNamespace(namespace string) EventingOption {
 	}
 }
 
+func WithEventingNamespaceSelector(matchLabels map[string]string) EventingOption {
+	return func(e *v1alpha1.Eventing) error {
+		e.Spec.NamespaceSelector = &kmetav1.LabelSelector{MatchLabels: matchLabels}
+		return nil
+	}
+}
+
 func WithEventingCRFinalizer(finalizer string) EventingOption {
 	return func(e *v1alpha1.Eventing) error {
 		controllerutil.AddFinalizer(e, finalizer)
@@ -107,6 +114,24 @@ func WithEventingDomain(domain string) EventingOption {
 	}
 }
 
+func WithEventingDomainDiscovery(strategy v1alpha1.DomainDiscoveryStrategy, resource, key string) EventingOption {
+	return func(e *v1alpha1.Eventing) error {
+		e.Spec.Backend.Config.DomainDiscovery = &v1alpha1.DomainDiscovery{
+			Strategy: strategy,
+			Resource: resource,
+			Key:      key,
+		}
+		return nil
+	}
+}
+
+func WithEventingNATSCredentialsSecret(name string) EventingOption {
+	return func(e *v1alpha1.Eventing) error {
+		e.Spec.Backend.Config.NATSCredentialsSecret = name
+		return nil
+	}
+}
+
 func WithEventingLogLevel(logLevel string) EventingOption {
 	return func(e *v1alpha1.Eventing) error {
 		e.Spec.LogLevel = logLevel
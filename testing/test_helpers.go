@@ -804,6 +804,12 @@ func WithTypeMatchingExact() SubscriptionOpt {
 }
 
 // WithMaxInFlight is a SubscriptionOpt that sets the status with the maxInFlightMessages int value.
+func WithTransformation(transformation *eventingv1alpha2.Transformation) SubscriptionOpt {
+	return func(subscription *eventingv1alpha2.Subscription) {
+		subscription.Spec.Transformation = transformation
+	}
+}
+
 func WithMaxInFlight(maxInFlight int) SubscriptionOpt {
 	return func(subscription *eventingv1alpha2.Subscription) {
 		subscription.Spec.Config = map[string]string{